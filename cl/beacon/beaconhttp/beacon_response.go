@@ -26,6 +26,10 @@ func NewBeaconResponse(data any) *BeaconResponse {
 func (r *BeaconResponse) With(key string, value any) (out *BeaconResponse) {
 	out = new(BeaconResponse)
 	*out = *r
+	out.Extra = make(map[string]any, len(r.Extra)+1)
+	for k, v := range r.Extra {
+		out.Extra[k] = v
+	}
 	out.Extra[key] = value
 	return out
 }
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"fmt"
+	"math/bits"
+	"net/http"
+
+	"github.com/Giulio2002/bls"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cl/beacon/beaconhttp"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+// canAggregateBits returns whether two aggregation bitlists have the same length and disjoint participants.
+func canAggregateBits(a, b []byte) bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return false
+	}
+	// The highest set bit of a bitlist marks its length, it is shared by both lists and is not a participant.
+	last := len(a) - 1
+	if a[last] == 0 || bits.Len8(a[last]) != bits.Len8(b[last]) {
+		return false
+	}
+	lengthBit := byte(1) << (bits.Len8(a[last]) - 1)
+	for i := 0; i < last; i++ {
+		if a[i]&b[i] != 0 {
+			return false
+		}
+	}
+	return a[last]&b[last]&^lengthBit == 0
+}
+
+func (a *ApiHandler) GetEthV1ValidatorAggregateAttestation(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	attestationDataRoot, err := beaconhttp.HashFromQueryParams(r, "attestation_data_root")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	slot, err := beaconhttp.Uint64FromQueryParams(r, "slot")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	if attestationDataRoot == nil || slot == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("attestation_data_root and slot url params are required"))
+	}
+
+	var aggregate *solid.Attestation
+	for _, attestation := range a.operationsPool.AttestationsPool.Raw() {
+		data := attestation.AttestantionData()
+		if data.Slot() != *slot {
+			continue
+		}
+		root, err := data.HashSSZ()
+		if err != nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+		}
+		if root != *attestationDataRoot {
+			continue
+		}
+		if aggregate == nil {
+			aggregate = solid.NewAttestionFromParameters(libcommon.Copy(attestation.AggregationBits()), data, attestation.Signature())
+			continue
+		}
+		// Only aggregate attestations with disjoint participants, otherwise some signatures would be counted twice.
+		if !canAggregateBits(aggregate.AggregationBits(), attestation.AggregationBits()) {
+			continue
+		}
+		aggregateSignature, signature := aggregate.Signature(), attestation.Signature()
+		aggregatedSignature, err := bls.AggregateSignatures([][]byte{aggregateSignature[:], signature[:]})
+		if err != nil {
+			// Skip attestations with malformed signatures.
+			continue
+		}
+		bits := aggregate.AggregationBits()
+		for i, b := range attestation.AggregationBits() {
+			bits[i] |= b
+		}
+		var newSignature libcommon.Bytes96
+		copy(newSignature[:], aggregatedSignature)
+		aggregate.SetSignature(newSignature)
+	}
+	if aggregate == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("no matching attestation found"))
+	}
+	return newBeaconResponse(aggregate), nil
+}
+
+func (a *ApiHandler) GetEthV1ValidatorSyncCommitteeContribution(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	slot, err := beaconhttp.Uint64FromQueryParams(r, "slot")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	subcommitteeIndex, err := beaconhttp.Uint64FromQueryParams(r, "subcommittee_index")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	beaconBlockRoot, err := beaconhttp.HashFromQueryParams(r, "beacon_block_root")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	if slot == nil || subcommitteeIndex == nil || beaconBlockRoot == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("slot, subcommittee_index and beacon_block_root url params are required"))
+	}
+	if *subcommitteeIndex >= a.beaconChainCfg.SyncCommitteeSubnetCount {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid subcommittee index %d", *subcommitteeIndex))
+	}
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, fmt.Errorf("beacon node is still syncing"))
+	}
+	if headState.Version() < clparams.AltairVersion {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("sync committees are not active yet"))
+	}
+
+	// Messages of the slot are signed by the committee of the period of the next slot (see get_sync_subcommittee_pubkeys)
+	var committee []libcommon.Bytes48
+	switch requestPeriod, statePeriod := a.beaconChainCfg.SyncCommitteePeriod(*slot+1), a.beaconChainCfg.SyncCommitteePeriod(headState.Slot()); requestPeriod {
+	case statePeriod:
+		committee = headState.CurrentSyncCommittee().GetCommittee()
+	case statePeriod + 1:
+		committee = headState.NextSyncCommittee().GetCommittee()
+	default:
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("slot %d is outside the sync committee periods of the head state", *slot))
+	}
+	subcommitteeSize := a.beaconChainCfg.SyncCommitteeSize / a.beaconChainCfg.SyncCommitteeSubnetCount
+	members := committee[*subcommitteeIndex*subcommitteeSize : (*subcommitteeIndex+1)*subcommitteeSize]
+
+	contribution := &cltypes.Contribution{
+		Slot:              *slot,
+		BeaconBlockRoot:   *beaconBlockRoot,
+		SubcommitteeIndex: *subcommitteeIndex,
+		AggregationBits:   make([]byte, cltypes.SyncCommitteeAggregationBitsSize),
+	}
+	signatures := [][]byte{}
+	for _, msg := range a.operationsPool.SyncCommitteeMessagesPool.Raw() {
+		if msg.Slot != *slot || msg.BeaconBlockRoot != *beaconBlockRoot {
+			continue
+		}
+		validator, err := headState.ValidatorForValidatorIndex(int(msg.ValidatorIndex))
+		if err != nil {
+			continue
+		}
+		pk := validator.PublicKey()
+		// A validator may sit more than once in the subcommittee, each position carries its own signature.
+		for i, member := range members {
+			if member != pk || contribution.AggregationBits[i/8]&(1<<(i%8)) != 0 {
+				continue
+			}
+			contribution.AggregationBits[i/8] |= 1 << (i % 8)
+			signatures = append(signatures, libcommon.Copy(msg.Signature[:]))
+		}
+	}
+	if len(signatures) == 0 {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("no matching sync committee messages found"))
+	}
+	aggregatedSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	copy(contribution.Signature[:], aggregatedSignature)
+	return newBeaconResponse(contribution), nil
+}
@@ -1,10 +1,27 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
 
+	"github.com/Giulio2002/bls"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon/cl/beacon/beaconhttp"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	"github.com/ledgerwatch/erigon/cl/phase1/core/state"
+	"github.com/ledgerwatch/erigon/cl/transition"
+	"github.com/ledgerwatch/erigon/cl/transition/impl/eth2"
+	"github.com/ledgerwatch/erigon/cl/transition/machine"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
 )
 
 func (a *ApiHandler) GetEthV1ValidatorAttestationData(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
@@ -30,3 +47,356 @@ func (a *ApiHandler) GetEthV1ValidatorAttestationData(w http.ResponseWriter, r *
 	}
 	return newBeaconResponse(attestationData), nil
 }
+
+// blockContents is the deneb flavour of a produced block, which carries the blobs along with the block.
+type blockContents struct {
+	Block     *cltypes.BeaconBlock `json:"block"`
+	KzgProofs []hexutility.Bytes   `json:"kzg_proofs"`
+	Blobs     []hexutility.Bytes   `json:"blobs"`
+}
+
+func (a *ApiHandler) GetEthV2ValidatorBlocks(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	block, blobsBundle, executionValue, consensusValue, err := a.produceBeaconBlock(r)
+	if err != nil {
+		return nil, err
+	}
+	a.cacheProducedPayload(block, blobsBundle)
+	var data any = block
+	if block.Version() >= clparams.DenebVersion {
+		contents := blockContents{Block: block, KzgProofs: []hexutility.Bytes{}, Blobs: []hexutility.Bytes{}}
+		if blobsBundle != nil {
+			contents.KzgProofs = blobsBundle.Proofs
+			contents.Blobs = blobsBundle.Blobs
+		}
+		data = contents
+	}
+	w.Header().Set("Eth-Consensus-Version", clparams.ClVersionToString(block.Version()))
+	return newBeaconResponse(data).
+		WithVersion(block.Version()).
+		With("execution_payload_blinded", false).
+		With("execution_payload_value", executionValue.String()).
+		With("consensus_block_value", consensusValue.String()), nil
+}
+
+func (a *ApiHandler) GetEthV1ValidatorBlindedBlock(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	block, blobsBundle, _, _, err := a.produceBeaconBlock(r)
+	if err != nil {
+		return nil, err
+	}
+	a.cacheProducedPayload(block, blobsBundle)
+	blindedBlock, err := block.Blinded()
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	w.Header().Set("Eth-Consensus-Version", clparams.ClVersionToString(block.Version()))
+	return newBeaconResponse(blindedBlock).WithVersion(block.Version()), nil
+}
+
+// produceBeaconBlock builds an unsigned block on top of the head for the slot in the request. It returns the block,
+// the blobs of its payload and the execution and consensus values of the block in wei.
+func (a *ApiHandler) produceBeaconBlock(r *http.Request) (*cltypes.BeaconBlock, *engine_types.BlobsBundleV1, *big.Int, *big.Int, error) {
+	slotStr, err := beaconhttp.StringFromRequest(r, "slot")
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid slot %q", slotStr))
+	}
+	randaoReveal := hexutility.FromHex(r.URL.Query().Get("randao_reveal"))
+	if len(randaoReveal) != length.Bytes96 {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("randao_reveal url param must be a 96 bytes hex string"))
+	}
+	graffiti, err := beaconhttp.HashFromQueryParams(r, "graffiti")
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, fmt.Errorf("beacon node is still syncing"))
+	}
+	if slot <= headState.Slot() {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("cannot produce a block at slot %d, head is at slot %d", slot, headState.Slot()))
+	}
+	targetState, err := headState.Copy()
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	if err := transition.DefaultMachine.ProcessSlots(targetState, slot); err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	// Deposits require the eth1 deposit tree, which is not tracked, so we cannot produce a valid block while some are pending.
+	if targetState.Eth1Data().DepositCount > targetState.Eth1DepositIndex() {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, fmt.Errorf("cannot produce a block with pending deposits"))
+	}
+	proposerIndex, err := targetState.GetBeaconProposerIndex()
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	latestBlockHeader := targetState.LatestBlockHeader()
+	parentRoot, err := latestBlockHeader.HashSSZ()
+	if err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+
+	block := cltypes.NewBeaconBlock(a.beaconChainCfg)
+	block.Slot = slot
+	block.ProposerIndex = proposerIndex
+	block.ParentRoot = parentRoot
+	block.Body.Version = targetState.Version()
+	copy(block.Body.RandaoReveal[:], randaoReveal)
+	if graffiti != nil {
+		block.Body.Graffiti = *graffiti
+	}
+	eth1Data := *targetState.Eth1Data()
+	block.Body.Eth1Data = &eth1Data
+	block.Body.SyncAggregate = a.produceSyncAggregate(slot, parentRoot)
+	block.Body.Deposits = solid.NewStaticListSSZ[*cltypes.Deposit](int(a.beaconChainCfg.MaxDeposits), 1240)
+
+	executionValue := new(big.Int)
+	var blobsBundle *engine_types.BlobsBundleV1
+	if block.Body.ExecutionPayload, blobsBundle, executionValue, err = a.produceExecutionPayload(r.Context(), targetState, parentRoot, proposerIndex); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	block.Body.BlobKzgCommitments = solid.NewStaticListSSZ[*cltypes.KZGCommitment](int(a.beaconChainCfg.MaxBlobsPerBlock), length.Bytes48)
+	if blobsBundle != nil {
+		for _, c := range blobsBundle.Commitments {
+			commitment := new(cltypes.KZGCommitment)
+			copy(commitment[:], c)
+			block.Body.BlobKzgCommitments.Append(commitment)
+		}
+	}
+
+	// Pool operations are not fully validated, fall back to an empty block body if they do not apply on top of the state.
+	a.includeOperations(targetState, block.Body)
+	collector := &eth2.BlockRewardsCollector{}
+	postState, err := a.processProducedBlock(targetState, block, collector)
+	if err != nil {
+		a.logger.Debug("[Beacon API] produced block has invalid operations, dropping them", "slot", slot, "err", err)
+		a.clearOperations(block.Body)
+		collector = &eth2.BlockRewardsCollector{}
+		if postState, err = a.processProducedBlock(targetState, block, collector); err != nil {
+			return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+		}
+	}
+	if block.StateRoot, err = postState.HashSSZ(); err != nil {
+		return nil, nil, nil, nil, beaconhttp.NewEndpointError(http.StatusInternalServerError, err)
+	}
+	consensusGwei := collector.Attestations + collector.AttesterSlashings + collector.ProposerSlashings + collector.SyncAggregate
+	consensusValue := new(big.Int).Mul(new(big.Int).SetUint64(consensusGwei), big.NewInt(1_000_000_000))
+	return block, blobsBundle, executionValue, consensusValue, nil
+}
+
+// processProducedBlock runs the state transition of an unsigned block on a copy of the given state.
+func (a *ApiHandler) processProducedBlock(s *state.CachingBeaconState, block *cltypes.BeaconBlock, collector *eth2.BlockRewardsCollector) (*state.CachingBeaconState, error) {
+	postState, err := s.Copy()
+	if err != nil {
+		return nil, err
+	}
+	signedBlock := &cltypes.SignedBeaconBlock{Block: block}
+	if err := machine.ProcessBlock(&eth2.Impl{BlockRewardsCollector: collector}, postState, signedBlock); err != nil {
+		return nil, err
+	}
+	return postState, nil
+}
+
+// produceExecutionPayload asks the execution engine for a payload building on top of the state's latest payload.
+func (a *ApiHandler) produceExecutionPayload(ctx context.Context, s *state.CachingBeaconState, parentRoot libcommon.Hash, proposerIndex uint64) (*cltypes.Eth1Block, *engine_types.BlobsBundleV1, *big.Int, error) {
+	version := s.Version()
+	if version < clparams.BellatrixVersion || !state.IsMergeTransitionComplete(s) {
+		return cltypes.NewEth1Block(version, a.beaconChainCfg), nil, new(big.Int), nil
+	}
+	engine := a.forkchoiceStore.Engine()
+	if engine == nil {
+		return nil, nil, nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, fmt.Errorf("no execution engine available"))
+	}
+	attributes := &engine_types.PayloadAttributes{
+		Timestamp:  hexutil.Uint64(state.ComputeTimestampAtSlot(s, s.Slot())),
+		PrevRandao: s.GetRandaoMixes(state.Epoch(s)),
+	}
+	if a.validatorParams != nil {
+		attributes.SuggestedFeeRecipient, _ = a.validatorParams.GetFeeRecipient(proposerIndex)
+	}
+	if version >= clparams.CapellaVersion {
+		attributes.Withdrawals = []*types.Withdrawal{}
+		for _, w := range state.ExpectedWithdrawals(s) {
+			attributes.Withdrawals = append(attributes.Withdrawals, &types.Withdrawal{
+				Index:     w.Index,
+				Validator: w.Validator,
+				Address:   w.Address,
+				Amount:    w.Amount,
+			})
+		}
+	}
+	if version >= clparams.DenebVersion {
+		attributes.ParentBeaconBlockRoot = &parentRoot
+	}
+	payload, blobsBundle, blockValue, err := engine.GetAssembledBlock(ctx, s.LatestExecutionPayloadHeader().BlockHash, attributes)
+	if err != nil {
+		return nil, nil, nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, err)
+	}
+	if blockValue == nil {
+		blockValue = new(big.Int)
+	}
+
+	block := cltypes.NewEth1Block(version, a.beaconChainCfg)
+	block.ParentHash = payload.ParentHash
+	block.FeeRecipient = payload.FeeRecipient
+	block.StateRoot = payload.StateRoot
+	block.ReceiptsRoot = payload.ReceiptsRoot
+	copy(block.LogsBloom[:], payload.LogsBloom)
+	block.PrevRandao = payload.PrevRandao
+	block.BlockNumber = uint64(payload.BlockNumber)
+	block.GasLimit = uint64(payload.GasLimit)
+	block.GasUsed = uint64(payload.GasUsed)
+	block.Time = uint64(payload.Timestamp)
+	block.Extra = solid.NewExtraData()
+	block.Extra.SetBytes(payload.ExtraData)
+	if payload.BaseFeePerGas != nil {
+		// The consensus layer encodes the base fee as a little endian uint256.
+		baseFee := payload.BaseFeePerGas.ToInt().Bytes()
+		for i, j := 0, len(baseFee)-1; i < j; i, j = i+1, j-1 {
+			baseFee[i], baseFee[j] = baseFee[j], baseFee[i]
+		}
+		copy(block.BaseFeePerGas[:], baseFee)
+	}
+	block.BlockHash = payload.BlockHash
+	txs := make([][]byte, len(payload.Transactions))
+	for i, tx := range payload.Transactions {
+		txs[i] = tx
+	}
+	block.Transactions = solid.NewTransactionsSSZFromTransactions(txs)
+	withdrawals := make([]*cltypes.Withdrawal, 0, len(payload.Withdrawals))
+	for _, w := range payload.Withdrawals {
+		withdrawals = append(withdrawals, &cltypes.Withdrawal{
+			Index:     w.Index,
+			Validator: w.Validator,
+			Address:   w.Address,
+			Amount:    w.Amount,
+		})
+	}
+	block.Withdrawals = solid.NewStaticListSSZFromList(withdrawals, int(a.beaconChainCfg.MaxWithdrawalsPerPayload), 44)
+	if payload.BlobGasUsed != nil {
+		block.BlobGasUsed = uint64(*payload.BlobGasUsed)
+	}
+	if payload.ExcessBlobGas != nil {
+		block.ExcessBlobGas = uint64(*payload.ExcessBlobGas)
+	}
+	return block, blobsBundle, blockValue, nil
+}
+
+// produceSyncAggregate merges the pooled sync committee contributions voting for the parent block.
+func (a *ApiHandler) produceSyncAggregate(slot uint64, parentRoot libcommon.Hash) *cltypes.SyncAggregate {
+	aggregate := &cltypes.SyncAggregate{SyncCommiteeSignature: bls.InfiniteSignature}
+	subcommitteeSize := a.beaconChainCfg.SyncCommitteeSize / a.beaconChainCfg.SyncCommitteeSubnetCount
+	signatures := [][]byte{}
+	for _, signedContribution := range a.operationsPool.SignedContributionAndProofPool.Raw() {
+		contribution := signedContribution.Message.Contribution
+		if contribution.Slot+1 != slot || contribution.BeaconBlockRoot != parentRoot || contribution.SubcommitteeIndex >= a.beaconChainCfg.SyncCommitteeSubnetCount {
+			continue
+		}
+		// Skip contributions overlapping with the ones we already have.
+		offset := contribution.SubcommitteeIndex * subcommitteeSize
+		overlaps := false
+		for i := uint64(0); i < subcommitteeSize && !overlaps; i++ {
+			global := offset + i
+			overlaps = contribution.AggregationBits[i/8]&(1<<(i%8)) != 0 && aggregate.SyncCommiteeBits[global/8]&(1<<(global%8)) != 0
+		}
+		if overlaps {
+			continue
+		}
+		if _, err := bls.AggregateSignatures([][]byte{contribution.Signature[:]}); err != nil {
+			continue
+		}
+		for i := uint64(0); i < subcommitteeSize; i++ {
+			if contribution.AggregationBits[i/8]&(1<<(i%8)) == 0 {
+				continue
+			}
+			global := offset + i
+			aggregate.SyncCommiteeBits[global/8] |= 1 << (global % 8)
+		}
+		signatures = append(signatures, libcommon.Copy(contribution.Signature[:]))
+	}
+	if len(signatures) == 0 {
+		return aggregate
+	}
+	aggregatedSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return &cltypes.SyncAggregate{SyncCommiteeSignature: bls.InfiniteSignature}
+	}
+	copy(aggregate.SyncCommiteeSignature[:], aggregatedSignature)
+	return aggregate
+}
+
+// includeOperations fills the block body with the pooled operations which can be included at the state's slot.
+func (a *ApiHandler) includeOperations(s *state.CachingBeaconState, body *cltypes.BeaconBody) {
+	a.clearOperations(body)
+	for _, slashing := range a.operationsPool.ProposerSlashingsPool.Raw() {
+		if uint64(body.ProposerSlashings.Len()) >= a.beaconChainCfg.MaxProposerSlashings {
+			break
+		}
+		body.ProposerSlashings.Append(slashing)
+	}
+	for _, slashing := range a.operationsPool.AttesterSlashingsPool.Raw() {
+		if uint64(body.AttesterSlashings.Len()) >= a.beaconChainCfg.MaxAttesterSlashings {
+			break
+		}
+		body.AttesterSlashings.Append(slashing)
+	}
+	for _, attestation := range a.operationsPool.AttestationsPool.Raw() {
+		if uint64(body.Attestations.Len()) >= a.beaconChainCfg.MaxAttestations {
+			break
+		}
+		if a.isAttestationIncludable(s, attestation) {
+			body.Attestations.Append(attestation)
+		}
+	}
+	for _, exit := range a.operationsPool.VoluntaryExistsPool.Raw() {
+		if uint64(body.VoluntaryExits.Len()) >= a.beaconChainCfg.MaxVoluntaryExits {
+			break
+		}
+		body.VoluntaryExits.Append(exit)
+	}
+	if s.Version() < clparams.CapellaVersion {
+		return
+	}
+	for _, change := range a.operationsPool.BLSToExecutionChangesPool.Raw() {
+		if uint64(body.ExecutionChanges.Len()) >= a.beaconChainCfg.MaxBlsToExecutionChanges {
+			break
+		}
+		body.ExecutionChanges.Append(change)
+	}
+}
+
+// clearOperations empties all the operation lists of the block body.
+func (a *ApiHandler) clearOperations(body *cltypes.BeaconBody) {
+	body.ProposerSlashings = solid.NewStaticListSSZ[*cltypes.ProposerSlashing](int(a.beaconChainCfg.MaxProposerSlashings), 416)
+	body.AttesterSlashings = solid.NewDynamicListSSZ[*cltypes.AttesterSlashing](int(a.beaconChainCfg.MaxAttesterSlashings))
+	body.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](int(a.beaconChainCfg.MaxAttestations))
+	body.VoluntaryExits = solid.NewStaticListSSZ[*cltypes.SignedVoluntaryExit](int(a.beaconChainCfg.MaxVoluntaryExits), 112)
+	body.ExecutionChanges = solid.NewStaticListSSZ[*cltypes.SignedBLSToExecutionChange](int(a.beaconChainCfg.MaxBlsToExecutionChanges), 172)
+}
+
+// isAttestationIncludable checks the inclusion window and the source checkpoint of an attestation.
+func (a *ApiHandler) isAttestationIncludable(s *state.CachingBeaconState, attestation *solid.Attestation) bool {
+	data := attestation.AttestantionData()
+	if data.Slot()+a.beaconChainCfg.MinAttestationInclusionDelay > s.Slot() {
+		return false
+	}
+	if s.Version() < clparams.DenebVersion && s.Slot() > data.Slot()+a.beaconChainCfg.SlotsPerEpoch {
+		return false
+	}
+	currentEpoch := state.Epoch(s)
+	targetEpoch := data.Target().Epoch()
+	var justified solid.Checkpoint
+	switch {
+	case targetEpoch == currentEpoch:
+		justified = s.CurrentJustifiedCheckpoint()
+	case targetEpoch+1 == currentEpoch:
+		justified = s.PreviousJustifiedCheckpoint()
+	default:
+		return false
+	}
+	return data.Source().Epoch() == justified.Epoch() && data.Source().BlockRoot() == justified.BlockRoot()
+}
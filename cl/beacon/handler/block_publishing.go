@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/crypto/kzg"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	"github.com/ledgerwatch/erigon/cl/gossip"
+	"github.com/ledgerwatch/erigon/cl/persistence/beacon_indicies"
+	"github.com/ledgerwatch/erigon/cl/phase1/forkchoice"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
+)
+
+// broadcastValidation is the amount of validation a published block must pass before it is broadcast.
+type broadcastValidation int
+
+const (
+	broadcastValidationGossip broadcastValidation = iota
+	broadcastValidationConsensus
+	broadcastValidationConsensusAndEquivocation
+)
+
+// producedPayloadsCacheSize is the number of payloads of produced blocks kept around to unblind published blinded blocks.
+const producedPayloadsCacheSize = 32
+
+// producedPayload is the execution payload of a block produced by this node, along with its blobs.
+type producedPayload struct {
+	payload     *cltypes.Eth1Block
+	blobsBundle *engine_types.BlobsBundleV1
+}
+
+// blockPublication is a signed block submitted by a validator along with the blobs to publish with it.
+type blockPublication struct {
+	block     *cltypes.SignedBeaconBlock
+	kzgProofs []libcommon.Bytes48
+	blobs     []*cltypes.Blob
+}
+
+func (a *ApiHandler) PostEthV1BeaconBlocks(w http.ResponseWriter, r *http.Request) {
+	version, err := a.blockVersionFromRequest(r, false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	publication, err := a.decodeBlockPublication(r, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.publishBlock(w, r, publication, broadcastValidationGossip)
+}
+
+func (a *ApiHandler) PostEthV2BeaconBlocks(w http.ResponseWriter, r *http.Request) {
+	validation, err := broadcastValidationFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	version, err := a.blockVersionFromRequest(r, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	publication, err := a.decodeBlockPublication(r, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.publishBlock(w, r, publication, validation)
+}
+
+func (a *ApiHandler) PostEthV1BeaconBlindedBlocks(w http.ResponseWriter, r *http.Request) {
+	version, err := a.blockVersionFromRequest(r, false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	publication, err := a.decodeBlindedBlockPublication(r, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.publishBlock(w, r, publication, broadcastValidationGossip)
+}
+
+func (a *ApiHandler) PostEthV2BeaconBlindedBlocks(w http.ResponseWriter, r *http.Request) {
+	validation, err := broadcastValidationFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	version, err := a.blockVersionFromRequest(r, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	publication, err := a.decodeBlindedBlockPublication(r, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.publishBlock(w, r, publication, validation)
+}
+
+// publishBlock validates the block according to the requested broadcast validation, broadcasts it along with its
+// blob sidecars and imports it in the fork choice.
+func (a *ApiHandler) publishBlock(w http.ResponseWriter, r *http.Request, publication *blockPublication, validation broadcastValidation) {
+	ctx := r.Context()
+	block := publication.block
+	blockRoot, err := block.Block.HashSSZ()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sidecars, err := a.blobSidecarsForPublication(publication)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := a.validateBlockForGossip(block); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if validation != broadcastValidationGossip {
+		if err := a.importBlock(ctx, block, blockRoot, sidecars); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// Check for equivocations as late as possible, right before the block is broadcast.
+	if validation == broadcastValidationConsensusAndEquivocation {
+		if err := a.checkProposerEquivocation(block.Block, blockRoot); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := a.broadcastBlock(ctx, block, sidecars); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if validation == broadcastValidationGossip {
+		if err := a.importBlock(ctx, block, blockRoot, sidecars); err != nil {
+			// The block was broadcast, it just failed to be integrated into our own chain.
+			a.logger.Warn("[Beacon API] published block could not be imported", "slot", block.Block.Slot, "root", blockRoot, "err", err)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
+
+// validateBlockForGossip runs the checks a block must pass to be propagated on the beacon_block topic.
+func (a *ApiHandler) validateBlockForGossip(block *cltypes.SignedBeaconBlock) error {
+	if block.Block.Slot > a.forkchoiceStore.Slot() {
+		return fmt.Errorf("block is from a future slot %d", block.Block.Slot)
+	}
+	if block.Block.Slot <= a.forkchoiceStore.FinalizedSlot() {
+		return fmt.Errorf("block slot %d is not later than the finalized slot", block.Block.Slot)
+	}
+	if _, ok := a.forkchoiceStore.GetHeader(block.Block.ParentRoot); !ok {
+		return fmt.Errorf("parent block %x is unknown", block.Block.ParentRoot)
+	}
+	verifySignature := forkchoice.VerifyHeaderSignatureAgainstForkChoiceStoreFunction(a.forkchoiceStore, a.beaconChainCfg, a.genesisCfg.GenesisValidatorRoot)
+	return verifySignature(block.SignedBeaconBlockHeader())
+}
+
+// checkProposerEquivocation makes sure that the proposer of the block did not already propose another block for the same slot.
+func (a *ApiHandler) checkProposerEquivocation(block *cltypes.BeaconBlock, blockRoot libcommon.Hash) error {
+	for _, node := range a.forkchoiceStore.ForkNodes() {
+		if node.Slot != block.Slot || node.BlockRoot == blockRoot {
+			continue
+		}
+		header, ok := a.forkchoiceStore.GetHeader(node.BlockRoot)
+		if ok && header.ProposerIndex == block.ProposerIndex {
+			return fmt.Errorf("proposer %d already proposed block %x at slot %d", block.ProposerIndex, node.BlockRoot, block.Slot)
+		}
+	}
+	return nil
+}
+
+// importBlock stores the block and its blob sidecars and processes it in the fork choice.
+// The block is written to the indices the same way the forkchoice stage writes gossip blocks,
+// otherwise it could not be served by the block endpoints until it got downloaded again.
+func (a *ApiHandler) importBlock(ctx context.Context, block *cltypes.SignedBeaconBlock, blockRoot libcommon.Hash, sidecars []*cltypes.BlobSidecar) error {
+	if len(sidecars) > 0 {
+		if err := a.blobStoage.WriteBlobSidecars(ctx, blockRoot, sidecars); err != nil {
+			return err
+		}
+	}
+	if err := a.indiciesDB.Update(ctx, func(tx kv.RwTx) error {
+		return beacon_indicies.WriteBeaconBlockAndIndicies(ctx, tx, block, false)
+	}); err != nil {
+		return err
+	}
+	return a.forkchoiceStore.OnBlock(ctx, block, true, true, true)
+}
+
+// broadcastBlock publishes the block and its blob sidecars on gossip.
+func (a *ApiHandler) broadcastBlock(ctx context.Context, block *cltypes.SignedBeaconBlock, sidecars []*cltypes.BlobSidecar) error {
+	if a.sentinel == nil {
+		return nil
+	}
+	encodedSSZ, err := block.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := a.sentinel.PublishGossip(ctx, &sentinel.GossipData{
+		Data: encodedSSZ,
+		Name: gossip.TopicNameBeaconBlock,
+	}); err != nil {
+		return err
+	}
+	for _, sidecar := range sidecars {
+		encodedSSZ, err := sidecar.EncodeSSZ(nil)
+		if err != nil {
+			return err
+		}
+		subnet := sidecar.Index % a.beaconChainCfg.MaxBlobsPerBlock
+		if _, err := a.sentinel.PublishGossip(ctx, &sentinel.GossipData{
+			Data:     encodedSSZ,
+			Name:     gossip.TopicNameBlobSidecar(int(subnet)),
+			SubnetId: &subnet,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobSidecarsForPublication verifies the blobs against the block commitments and builds their sidecars.
+func (a *ApiHandler) blobSidecarsForPublication(publication *blockPublication) ([]*cltypes.BlobSidecar, error) {
+	block := publication.block
+	if block.Version() < clparams.DenebVersion {
+		return nil, nil
+	}
+	commitments := block.Block.Body.BlobKzgCommitments
+	if len(publication.blobs) != commitments.Len() || len(publication.kzgProofs) != commitments.Len() {
+		return nil, fmt.Errorf("expected %d blobs and proofs, got %d blobs and %d proofs", commitments.Len(), len(publication.blobs), len(publication.kzgProofs))
+	}
+	if commitments.Len() == 0 {
+		return nil, nil
+	}
+	blobs := make([]gokzg4844.Blob, commitments.Len())
+	kzgCommitments := make([]gokzg4844.KZGCommitment, commitments.Len())
+	kzgProofs := make([]gokzg4844.KZGProof, commitments.Len())
+	for i := range publication.blobs {
+		blobs[i] = gokzg4844.Blob(*publication.blobs[i])
+		kzgCommitments[i] = gokzg4844.KZGCommitment(*commitments.Get(i))
+		kzgProofs[i] = gokzg4844.KZGProof(publication.kzgProofs[i])
+	}
+	if err := kzg.Ctx().VerifyBlobKZGProofBatch(blobs, kzgCommitments, kzgProofs); err != nil {
+		return nil, fmt.Errorf("blob KZG proof verification failed: %v", err)
+	}
+
+	signedHeader := block.SignedBeaconBlockHeader()
+	sidecars := make([]*cltypes.BlobSidecar, 0, commitments.Len())
+	for i := range publication.blobs {
+		branch, err := block.Block.Body.KzgCommitmentMerkleProof(i)
+		if err != nil {
+			return nil, err
+		}
+		inclusionProof := solid.NewHashVector(cltypes.CommitmentBranchSize)
+		for j := range branch {
+			inclusionProof.Set(j, branch[j])
+		}
+		sidecars = append(sidecars, cltypes.NewBlobSidecar(uint64(i), publication.blobs[i], libcommon.Bytes48(*commitments.Get(i)), publication.kzgProofs[i], signedHeader, inclusionProof))
+	}
+	return sidecars, nil
+}
+
+// decodeBlockPublication decodes a signed block, or the deneb block contents, from either json or ssz.
+func (a *ApiHandler) decodeBlockPublication(r *http.Request, version clparams.StateVersion) (*blockPublication, error) {
+	publication := &blockPublication{block: a.newSignedBeaconBlock(version)}
+	if r.Header.Get("Content-Type") == "application/octet-stream" {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if version < clparams.DenebVersion {
+			return publication, publication.block.DecodeSSZ(buf, int(version))
+		}
+		return publication, publication.decodeBlockContentsSSZ(buf, version)
+	}
+	if version < clparams.DenebVersion {
+		return publication, json.NewDecoder(r.Body).Decode(publication.block)
+	}
+	contents := struct {
+		SignedBlock *cltypes.SignedBeaconBlock `json:"signed_block"`
+		KzgProofs   []hexutility.Bytes         `json:"kzg_proofs"`
+		Blobs       []hexutility.Bytes         `json:"blobs"`
+	}{SignedBlock: publication.block}
+	if err := json.NewDecoder(r.Body).Decode(&contents); err != nil {
+		return nil, err
+	}
+	return publication, publication.setBlobs(contents.Blobs, contents.KzgProofs)
+}
+
+// decodeBlindedBlockPublication decodes a signed blinded block and swaps its execution payload header back for
+// the payload this node produced for it.
+func (a *ApiHandler) decodeBlindedBlockPublication(r *http.Request, version clparams.StateVersion) (*blockPublication, error) {
+	blindedBlock := cltypes.NewSignedBlindedBeaconBlock(a.beaconChainCfg)
+	blindedBlock.Block.Body.Version = version
+	// Initialise the lists with their limits, json decoding only fills them.
+	blindedBlock.Block.Body.EncodingSizeSSZ()
+	if r.Header.Get("Content-Type") == "application/octet-stream" {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := blindedBlock.DecodeSSZ(buf, int(version)); err != nil {
+			return nil, err
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(blindedBlock); err != nil {
+		return nil, err
+	}
+
+	if version < clparams.BellatrixVersion {
+		return &blockPublication{block: blindedBlock.Full(&solid.TransactionsSSZ{}, nil)}, nil
+	}
+	blockHash := blindedBlock.Block.Body.ExecutionPayload.BlockHash
+	produced, ok := a.producedPayloads.Get(blockHash)
+	if !ok {
+		return nil, fmt.Errorf("execution payload %x was not produced by this node", blockHash)
+	}
+	publication := &blockPublication{block: blindedBlock.Full(produced.payload.Transactions, produced.payload.Withdrawals)}
+	if version >= clparams.DenebVersion && produced.blobsBundle != nil {
+		if err := publication.setBlobs(produced.blobsBundle.Blobs, produced.blobsBundle.Proofs); err != nil {
+			return nil, err
+		}
+	}
+	return publication, nil
+}
+
+// cacheProducedPayload remembers the payload of a produced block, so that the block can be published blinded.
+func (a *ApiHandler) cacheProducedPayload(block *cltypes.BeaconBlock, blobsBundle *engine_types.BlobsBundleV1) {
+	if block.Version() < clparams.BellatrixVersion {
+		return
+	}
+	a.producedPayloads.Add(block.Body.ExecutionPayload.BlockHash, &producedPayload{payload: block.Body.ExecutionPayload, blobsBundle: blobsBundle})
+}
+
+// newSignedBeaconBlock allocates a block of the given version ready to be decoded into.
+func (a *ApiHandler) newSignedBeaconBlock(version clparams.StateVersion) *cltypes.SignedBeaconBlock {
+	block := cltypes.NewSignedBeaconBlock(a.beaconChainCfg)
+	block.Block.Body.Version = version
+	block.Block.Body.ExecutionPayload = cltypes.NewEth1Block(version, a.beaconChainCfg)
+	block.Block.Body.ExecutionPayload.Transactions = &solid.TransactionsSSZ{}
+	// Initialise the lists with their limits, json decoding only fills them.
+	block.Block.Body.EncodingSizeSSZ()
+	return block
+}
+
+// decodeBlockContentsSSZ decodes the ssz container of a deneb block: (signed_block, kzg_proofs, blobs).
+func (p *blockPublication) decodeBlockContentsSSZ(buf []byte, version clparams.StateVersion) error {
+	if len(buf) < 12 {
+		return ssz.ErrLowBufferSize
+	}
+	blockOffset, proofsOffset, blobsOffset := ssz.DecodeOffset(buf), ssz.DecodeOffset(buf[4:]), ssz.DecodeOffset(buf[8:])
+	if blockOffset != 12 || proofsOffset < blockOffset || blobsOffset < proofsOffset || int(blobsOffset) > len(buf) {
+		return ssz.ErrBadOffset
+	}
+	if err := p.block.DecodeSSZ(buf[blockOffset:proofsOffset], int(version)); err != nil {
+		return err
+	}
+	proofsBuf, blobsBuf := buf[proofsOffset:blobsOffset], buf[blobsOffset:]
+	if len(proofsBuf)%length.Bytes48 != 0 || uint64(len(blobsBuf))%cltypes.BYTES_PER_BLOB != 0 {
+		return ssz.ErrBufferNotRounded
+	}
+	proofs := make([]hexutility.Bytes, 0, len(proofsBuf)/length.Bytes48)
+	for i := 0; i < len(proofsBuf); i += length.Bytes48 {
+		proofs = append(proofs, proofsBuf[i:i+length.Bytes48])
+	}
+	blobs := make([]hexutility.Bytes, 0, uint64(len(blobsBuf))/cltypes.BYTES_PER_BLOB)
+	for i := uint64(0); i < uint64(len(blobsBuf)); i += cltypes.BYTES_PER_BLOB {
+		blobs = append(blobs, blobsBuf[i:i+cltypes.BYTES_PER_BLOB])
+	}
+	return p.setBlobs(blobs, proofs)
+}
+
+func (p *blockPublication) setBlobs(blobs, kzgProofs []hexutility.Bytes) error {
+	p.blobs = make([]*cltypes.Blob, 0, len(blobs))
+	for _, b := range blobs {
+		if uint64(len(b)) != cltypes.BYTES_PER_BLOB {
+			return fmt.Errorf("blob must be %d bytes, got %d", cltypes.BYTES_PER_BLOB, len(b))
+		}
+		blob := new(cltypes.Blob)
+		copy(blob[:], b)
+		p.blobs = append(p.blobs, blob)
+	}
+	p.kzgProofs = make([]libcommon.Bytes48, 0, len(kzgProofs))
+	for _, proof := range kzgProofs {
+		if len(proof) != length.Bytes48 {
+			return fmt.Errorf("kzg proof must be %d bytes, got %d", length.Bytes48, len(proof))
+		}
+		p.kzgProofs = append(p.kzgProofs, libcommon.Bytes48(proof))
+	}
+	return nil
+}
+
+// blockVersionFromRequest reads the block version from the Eth-Consensus-Version header, falling back to the
+// version of the current slot when the header is optional.
+func (a *ApiHandler) blockVersionFromRequest(r *http.Request, required bool) (clparams.StateVersion, error) {
+	consensusVersion := r.Header.Get("Eth-Consensus-Version")
+	if consensusVersion == "" {
+		if required {
+			return 0, fmt.Errorf("Eth-Consensus-Version header is required")
+		}
+		return a.beaconChainCfg.GetCurrentStateVersion(a.forkchoiceStore.Slot() / a.beaconChainCfg.SlotsPerEpoch), nil
+	}
+	for version := clparams.Phase0Version; version <= clparams.DenebVersion; version++ {
+		if clparams.ClVersionToString(version) == consensusVersion {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported consensus version %q", consensusVersion)
+}
+
+func broadcastValidationFromRequest(r *http.Request) (broadcastValidation, error) {
+	switch validation := r.URL.Query().Get("broadcast_validation"); validation {
+	case "", "gossip":
+		return broadcastValidationGossip, nil
+	case "consensus":
+		return broadcastValidationConsensus, nil
+	case "consensus_and_equivocation":
+		return broadcastValidationConsensusAndEquivocation, nil
+	default:
+		return 0, fmt.Errorf("invalid broadcast_validation %q", validation)
+	}
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Giulio2002/bls"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/crypto/kzg"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/fork"
+	"github.com/ledgerwatch/erigon/cl/gossip"
+	"github.com/ledgerwatch/erigon/cl/persistence/beacon_indicies"
+	"github.com/ledgerwatch/erigon/cl/phase1/forkchoice"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// gossipRecorder is a sentinel client that records the published gossip messages.
+type gossipRecorder struct {
+	sentinel.SentinelClient
+	published []*sentinel.GossipData
+}
+
+func (g *gossipRecorder) PublishGossip(ctx context.Context, in *sentinel.GossipData, opts ...grpc.CallOption) (*sentinel.EmptyMessage, error) {
+	g.published = append(g.published, in)
+	return &sentinel.EmptyMessage{}, nil
+}
+
+// signedDenebBlockWithBlob builds a deneb block carrying one blob on top of a parent known to the fork choice,
+// signed by a proposer known to the fork choice.
+func signedDenebBlockWithBlob(t *testing.T, h *ApiHandler, fcu *forkchoice.ForkChoiceStorageMock) (*cltypes.SignedBeaconBlock, *cltypes.Blob, libcommon.Bytes48) {
+	const parentSlot, slot, proposerIndex = 1, 2, 3
+	parentRoot := libcommon.Hash{1}
+	fcu.GetHeaderVal[parentRoot] = &cltypes.BeaconBlockHeader{Slot: parentSlot}
+	fcu.SlotVal = slot
+
+	key, err := bls.GenerateKey()
+	require.NoError(t, err)
+	fcu.PublicKeysVal[proposerIndex] = libcommon.Bytes48(bls.CompressPublicKey(key.PublicKey()))
+
+	blob := new(cltypes.Blob)
+	blob[31] = 1
+	commitment, err := kzg.Ctx().BlobToKZGCommitment(gokzg4844.Blob(*blob), 0)
+	require.NoError(t, err)
+	proof, err := kzg.Ctx().ComputeBlobKZGProof(gokzg4844.Blob(*blob), commitment, 0)
+	require.NoError(t, err)
+
+	block := h.newSignedBeaconBlock(clparams.DenebVersion)
+	block.Block.Slot = slot
+	block.Block.ProposerIndex = proposerIndex
+	block.Block.ParentRoot = parentRoot
+	block.Block.Body.ExecutionPayload.BlockHash = libcommon.Hash{2}
+	kzgCommitment := cltypes.KZGCommitment(commitment)
+	block.Block.Body.BlobKzgCommitments.Append(&kzgCommitment)
+
+	cfg := h.beaconChainCfg
+	forkVersion := cfg.GetForkVersionByVersion(cfg.GetCurrentStateVersion(parentSlot / cfg.SlotsPerEpoch))
+	domain, err := fork.ComputeDomain(cfg.DomainBeaconProposer[:], utils.Uint32ToBytes4(forkVersion), h.genesisCfg.GenesisValidatorRoot)
+	require.NoError(t, err)
+	sigRoot, err := fork.ComputeSigningRoot(block.SignedBeaconBlockHeader().Header, domain)
+	require.NoError(t, err)
+	copy(block.Signature[:], key.Sign(sigRoot[:]).Bytes())
+	return block, blob, libcommon.Bytes48(proof)
+}
+
+// requireBlockPublished checks that the block was imported in the fork choice and the indices, that its blob
+// sidecar was stored and that both were gossiped.
+func requireBlockPublished(t *testing.T, h *ApiHandler, fcu *forkchoice.ForkChoiceStorageMock, recorder *gossipRecorder, block *cltypes.SignedBeaconBlock) {
+	ctx := context.Background()
+	blockRoot, err := block.Block.HashSSZ()
+	require.NoError(t, err)
+
+	require.Len(t, fcu.OnBlockCalls, 1)
+	importedRoot, err := fcu.OnBlockCalls[0].Block.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, blockRoot, importedRoot)
+
+	tx, err := h.indiciesDB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	header, ok, err := beacon_indicies.ReadSignedHeaderByBlockRoot(ctx, tx, blockRoot)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, block.Block.Slot, header.Header.Slot)
+
+	sidecars, found, err := h.blobStoage.ReadBlobSidecars(ctx, block.Block.Slot, blockRoot)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, sidecars, 1)
+
+	require.Len(t, recorder.published, 2)
+	require.Equal(t, gossip.TopicNameBeaconBlock, recorder.published[0].Name)
+	encodedBlock, err := block.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, encodedBlock, recorder.published[0].Data)
+	require.Equal(t, gossip.TopicNameBlobSidecar(0), recorder.published[1].Name)
+	encodedSidecar, err := sidecars[0].EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, encodedSidecar, recorder.published[1].Data)
+}
+
+func postBlock(t *testing.T, server *httptest.Server, path string, body []byte) {
+	req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Eth-Consensus-Version", "deneb")
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(out))
+}
+
+func TestPostBeaconBlocks(t *testing.T) {
+	_, _, _, _, _, handler, _, _, fcu, _ := setupTestingHandler(t, clparams.CapellaVersion, log.Root())
+	recorder := &gossipRecorder{}
+	handler.sentinel = recorder
+	server := httptest.NewServer(handler.mux)
+	defer server.Close()
+
+	block, blob, proof := signedDenebBlockWithBlob(t, handler, fcu)
+	encodedBlock, err := block.EncodeSSZ(nil)
+	require.NoError(t, err)
+	// ssz container of the block contents: (signed_block, kzg_proofs, blobs)
+	body := make([]byte, 12)
+	binary.LittleEndian.PutUint32(body, 12)
+	binary.LittleEndian.PutUint32(body[4:], uint32(12+len(encodedBlock)))
+	binary.LittleEndian.PutUint32(body[8:], uint32(12+len(encodedBlock)+len(proof)))
+	body = append(body, encodedBlock...)
+	body = append(body, proof[:]...)
+	body = append(body, blob[:]...)
+
+	postBlock(t, server, "/eth/v2/beacon/blocks?broadcast_validation=consensus", body)
+	requireBlockPublished(t, handler, fcu, recorder, block)
+}
+
+func TestPostBeaconBlindedBlocks(t *testing.T) {
+	_, _, _, _, _, handler, _, _, fcu, _ := setupTestingHandler(t, clparams.CapellaVersion, log.Root())
+	recorder := &gossipRecorder{}
+	handler.sentinel = recorder
+	server := httptest.NewServer(handler.mux)
+	defer server.Close()
+
+	block, blob, proof := signedDenebBlockWithBlob(t, handler, fcu)
+	commitment := block.Block.Body.BlobKzgCommitments.Get(0)
+	handler.cacheProducedPayload(block.Block, &engine_types.BlobsBundleV1{
+		Commitments: []hexutility.Bytes{commitment[:]},
+		Proofs:      []hexutility.Bytes{proof[:]},
+		Blobs:       []hexutility.Bytes{blob[:]},
+	})
+	blindedBlock, err := block.Blinded()
+	require.NoError(t, err)
+	body, err := blindedBlock.EncodeSSZ(nil)
+	require.NoError(t, err)
+
+	postBlock(t, server, "/eth/v2/beacon/blinded_blocks", body)
+	requireBlockPublished(t, handler, fcu, recorder, block)
+}
+
+func TestDecodeBlockPublication(t *testing.T) {
+	_, blocks, _, _, _, handler, _, _, _, _ := setupTestingHandler(t, clparams.CapellaVersion, log.Root())
+	block := blocks[len(blocks)-1]
+	expectedRoot, err := block.HashSSZ()
+	require.NoError(t, err)
+
+	encodedJSON, err := json.Marshal(block)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/eth/v2/beacon/blocks", bytes.NewBuffer(encodedJSON))
+	req.Header.Set("Content-Type", "application/json")
+	publication, err := handler.decodeBlockPublication(req, block.Version())
+	require.NoError(t, err)
+	root, err := publication.block.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+
+	encodedSSZ, err := block.EncodeSSZ(nil)
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/eth/v2/beacon/blocks", bytes.NewBuffer(encodedSSZ))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	publication, err = handler.decodeBlockPublication(req, block.Version())
+	require.NoError(t, err)
+	root, err = publication.block.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+}
+
+func TestPostBeaconBlocksRejected(t *testing.T) {
+	_, blocks, _, _, _, handler, _, _, _, _ := setupTestingHandler(t, clparams.CapellaVersion, log.Root())
+	server := httptest.NewServer(handler.mux)
+	defer server.Close()
+
+	encodedJSON, err := json.Marshal(blocks[len(blocks)-1])
+	require.NoError(t, err)
+
+	cases := []struct {
+		name             string
+		path             string
+		consensusVersion string
+		body             []byte
+		code             int
+		message          string
+	}{
+		{
+			name:    "missing version",
+			path:    "/eth/v2/beacon/blocks",
+			body:    encodedJSON,
+			code:    http.StatusBadRequest,
+			message: "Eth-Consensus-Version",
+		},
+		{
+			name:             "bad broadcast validation",
+			path:             "/eth/v2/beacon/blocks?broadcast_validation=none",
+			consensusVersion: "capella",
+			body:             encodedJSON,
+			code:             http.StatusBadRequest,
+			message:          "broadcast_validation",
+		},
+		{
+			name:             "future slot",
+			path:             "/eth/v2/beacon/blocks?broadcast_validation=consensus",
+			consensusVersion: "capella",
+			body:             encodedJSON,
+			code:             http.StatusBadRequest,
+			message:          "future slot",
+		},
+		{
+			name:             "v1 future slot",
+			path:             "/eth/v1/beacon/blocks",
+			consensusVersion: "capella",
+			body:             encodedJSON,
+			code:             http.StatusBadRequest,
+			message:          "future slot",
+		},
+		{
+			name:             "unknown blinded payload",
+			path:             "/eth/v2/beacon/blinded_blocks",
+			consensusVersion: "capella",
+			body:             []byte(`{"message":{"slot":"1","body":{"execution_payload_header":{"block_hash":"0x0000000000000000000000000000000000000000000000000000000000000001"}}}}`),
+			code:             http.StatusBadRequest,
+			message:          "was not produced by this node",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, server.URL+c.path, bytes.NewBuffer(c.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if c.consensusVersion != "" {
+				req.Header.Set("Eth-Consensus-Version", c.consensusVersion)
+			}
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, c.code, resp.StatusCode)
+			out, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(out), c.message)
+		})
+	}
+}
@@ -19,6 +19,7 @@ func TestHarnessPhase0(t *testing.T) {
 			beacontest.WithTestFromFs(Harnesses, "committees"),
 			beacontest.WithTestFromFs(Harnesses, "duties_attester"),
 			beacontest.WithTestFromFs(Harnesses, "duties_proposer"),
+			beacontest.WithTestFromFs(Harnesses, "validator_phase0"),
 		)...,
 	)
 }
@@ -44,6 +45,7 @@ func TestHarnessBellatrix(t *testing.T) {
 			beacontest.WithTestFromFs(Harnesses, "validators"),
 			beacontest.WithTestFromFs(Harnesses, "lighthouse"),
 			beacontest.WithTestFromFs(Harnesses, "blob_sidecars"),
+			beacontest.WithTestFromFs(Harnesses, "validator_bellatrix"),
 		)...,
 	)
 }
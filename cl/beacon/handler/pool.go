@@ -6,8 +6,11 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
 	"github.com/ledgerwatch/erigon/cl/beacon/beaconhttp"
+	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/gossip"
+	"github.com/ledgerwatch/erigon/cl/phase1/core/state"
 )
 
 func (a *ApiHandler) GetEthV1BeaconPoolVoluntaryExits(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
@@ -141,6 +144,117 @@ func (a *ApiHandler) PostEthV1BeaconPoolProposerSlashings(w http.ResponseWriter,
 	w.WriteHeader(http.StatusOK)
 }
 
+func (a *ApiHandler) PostEthV1BeaconPoolAttestations(w http.ResponseWriter, r *http.Request) {
+	req := []*solid.Attestation{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		http.Error(w, "beacon node is still syncing", http.StatusServiceUnavailable)
+		return
+	}
+
+	failures := []poolingFailure{}
+	for i, attestation := range req {
+		if err := a.forkchoiceStore.OnAttestation(attestation, false, true); err != nil {
+			failures = append(failures, poolingFailure{Index: i, Message: err.Error()})
+			continue
+		}
+		// Broadcast to gossip
+		if a.sentinel != nil {
+			encodedSSZ, err := attestation.EncodeSSZ(nil)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			data := attestation.AttestantionData()
+			committeesPerSlot := headState.CommitteeCount(data.Target().Epoch())
+			subnet := a.computeSubnetForAttestation(committeesPerSlot, data.Slot(), data.CommitteeIndex())
+			if _, err := a.sentinel.PublishGossip(r.Context(), &sentinel.GossipData{
+				Data:     encodedSSZ,
+				Name:     gossip.TopicNameBeaconAttestation(subnet),
+				SubnetId: &subnet,
+			}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(poolingError{Code: http.StatusBadRequest, Message: "some failures", Failures: failures})
+		return
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *ApiHandler) PostEthV1BeaconPoolSyncCommittees(w http.ResponseWriter, r *http.Request) {
+	req := []*cltypes.SyncCommitteeMessage{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		http.Error(w, "beacon node is still syncing", http.StatusServiceUnavailable)
+		return
+	}
+	if headState.Version() < clparams.AltairVersion {
+		http.Error(w, "sync committees are not active yet", http.StatusBadRequest)
+		return
+	}
+
+	failures := []poolingFailure{}
+	for i, msg := range req {
+		if err := a.forkchoiceStore.OnSyncCommitteeMessage(msg, false); err != nil {
+			failures = append(failures, poolingFailure{Index: i, Message: err.Error()})
+			continue
+		}
+		// Broadcast to gossip
+		if a.sentinel != nil {
+			if err := a.publishSyncCommitteeMessage(r, headState, msg); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(poolingError{Code: http.StatusBadRequest, Message: "some failures", Failures: failures})
+		return
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
+
+// publishSyncCommitteeMessage publishes the message on every sync committee subnet the validator is part of.
+func (a *ApiHandler) publishSyncCommitteeMessage(r *http.Request, headState *state.CachingBeaconState, msg *cltypes.SyncCommitteeMessage) error {
+	encodedSSZ, err := msg.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	subnets, err := a.syncSubnetsForValidator(headState, msg.ValidatorIndex)
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		subnet := subnet
+		if _, err := a.sentinel.PublishGossip(r.Context(), &sentinel.GossipData{
+			Data:     encodedSSZ,
+			Name:     gossip.TopicNameSyncCommitteeSubnet(subnet),
+			SubnetId: &subnet,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type poolingFailure struct {
 	Index   int    `json:"index"`
 	Message string `json:"message"`
@@ -221,3 +335,46 @@ func (a *ApiHandler) PostEthV1ValidatorAggregatesAndProof(w http.ResponseWriter,
 		}
 	}
 }
+
+func (a *ApiHandler) PostEthV1ValidatorContributionsAndProofs(w http.ResponseWriter, r *http.Request) {
+	req := []*cltypes.SignedContributionAndProof{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	failures := []poolingFailure{}
+	for i, v := range req {
+		if v.Message == nil || v.Message.Contribution == nil {
+			failures = append(failures, poolingFailure{Index: i, Message: "missing contribution"})
+			continue
+		}
+		if err := a.forkchoiceStore.OnSignedContributionAndProof(v, false); err != nil {
+			failures = append(failures, poolingFailure{Index: i, Message: err.Error()})
+			continue
+		}
+		// Broadcast to gossip
+		if a.sentinel != nil {
+			encodedSSZ, err := v.EncodeSSZ(nil)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if _, err := a.sentinel.PublishGossip(r.Context(), &sentinel.GossipData{
+				Data: encodedSSZ,
+				Name: gossip.TopicNameSyncCommitteeContributionAndProof,
+			}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(poolingError{Code: http.StatusBadRequest, Message: "some failures", Failures: failures})
+		return
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
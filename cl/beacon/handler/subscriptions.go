@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
+	"github.com/ledgerwatch/erigon/cl/beacon/building"
+	"github.com/ledgerwatch/erigon/cl/gossip"
+	"github.com/ledgerwatch/erigon/cl/phase1/core/state"
+)
+
+// computeSubnetForAttestation returns the attestation subnet of the given committee, as in the p2p spec.
+func (a *ApiHandler) computeSubnetForAttestation(committeesPerSlot, slot, committeeIndex uint64) uint64 {
+	slotsSinceEpochStart := slot % a.beaconChainCfg.SlotsPerEpoch
+	committeesSinceEpochStart := committeesPerSlot * slotsSinceEpochStart
+	return (committeesSinceEpochStart + committeeIndex) % a.netConfig.AttestationSubnetCount
+}
+
+// syncSubnetsForValidator returns the sync committee subnets a validator of the current sync committee is part of.
+func (a *ApiHandler) syncSubnetsForValidator(s *state.CachingBeaconState, validatorIndex uint64) ([]uint64, error) {
+	validator, err := s.ValidatorForValidatorIndex(int(validatorIndex))
+	if err != nil {
+		return nil, err
+	}
+	pk := validator.PublicKey()
+	subcommitteeSize := a.beaconChainCfg.SyncCommitteeSize / a.beaconChainCfg.SyncCommitteeSubnetCount
+	subnets := []uint64{}
+	for i, member := range s.CurrentSyncCommittee().GetCommittee() {
+		if member != pk {
+			continue
+		}
+		subnet := uint64(i) / subcommitteeSize
+		if len(subnets) == 0 || subnets[len(subnets)-1] != subnet {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
+// slotStartTime returns the unix time at which the given slot starts.
+func (a *ApiHandler) slotStartTime(slot uint64) uint64 {
+	return a.genesisCfg.GenesisTime + slot*a.beaconChainCfg.SecondsPerSlot
+}
+
+func (a *ApiHandler) PostEthV1ValidatorBeaconCommitteeSubscription(w http.ResponseWriter, r *http.Request) {
+	req := []building.BeaconCommitteeSubscription{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, sub := range req {
+		if sub.CommitteesAtSlot <= 0 || sub.CommitteeIndex < 0 || sub.CommitteeIndex >= sub.CommitteesAtSlot {
+			http.Error(w, fmt.Sprintf("invalid committee index %d for %d committees", sub.CommitteeIndex, sub.CommitteesAtSlot), http.StatusBadRequest)
+			return
+		}
+	}
+	if a.sentinel == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	for _, sub := range req {
+		// Only aggregators need to listen to the subnet, the others just publish their attestation to it.
+		if !sub.IsAggregator {
+			continue
+		}
+		subnet := a.computeSubnetForAttestation(uint64(sub.CommitteesAtSlot), uint64(sub.Slot), uint64(sub.CommitteeIndex))
+		// Stay in the subnet until the end of the slot.
+		if _, err := a.sentinel.SetSubscribeExpiry(r.Context(), &sentinel.RequestSubscribeExpiry{
+			Topic:          gossip.TopicNameBeaconAttestation(subnet),
+			ExpiryUnixSecs: a.slotStartTime(uint64(sub.Slot) + 1),
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *ApiHandler) PostEthV1ValidatorSyncCommitteeSubscriptions(w http.ResponseWriter, r *http.Request) {
+	req := []building.SyncCommitteeSubscription{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	subcommitteeSize := a.beaconChainCfg.SyncCommitteeSize / a.beaconChainCfg.SyncCommitteeSubnetCount
+	for _, sub := range req {
+		for _, idx := range sub.SyncCommitteeIndices {
+			if idx < 0 || uint64(idx) >= a.beaconChainCfg.SyncCommitteeSize {
+				http.Error(w, fmt.Sprintf("invalid sync committee index %d", idx), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if a.sentinel == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	for _, sub := range req {
+		// Stay in the subnets until the start of until_epoch.
+		expiry := a.slotStartTime(uint64(sub.UntilEpoch) * a.beaconChainCfg.SlotsPerEpoch)
+		for _, idx := range sub.SyncCommitteeIndices {
+			if _, err := a.sentinel.SetSubscribeExpiry(r.Context(), &sentinel.RequestSubscribeExpiry{
+				Topic:          gossip.TopicNameSyncCommitteeSubnet(uint64(idx) / subcommitteeSize),
+				ExpiryUnixSecs: expiry,
+			}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	// Only write 200
+	w.WriteHeader(http.StatusOK)
+}
@@ -79,10 +79,12 @@ func setupTestingHandler(t *testing.T, v clparams.StateVersion, logger log.Logge
 		},
 	})
 	vp = validator_params.NewValidatorParams()
+	netCfg := clparams.NetworkConfigs[clparams.MainnetNetwork]
 	h = NewApiHandler(
 		logger,
 		&gC,
 		&bcfg,
+		&netCfg,
 		db,
 		fcu,
 		opPool,
@@ -120,6 +120,10 @@ func (s *Contribution) Clone() clonable.Clonable {
 	return &Contribution{}
 }
 
+func (s *SyncCommitteeMessage) Clone() clonable.Clonable {
+	return &SyncCommitteeMessage{}
+}
+
 func (*Root) Clone() clonable.Clonable {
 	return &Root{}
 }
@@ -9,6 +9,7 @@ import (
 
 var _ ssz2.SizedObjectSSZ = (*ContributionAndProof)(nil)
 var _ ssz2.SizedObjectSSZ = (*Contribution)(nil)
+var _ ssz2.SizedObjectSSZ = (*SyncCommitteeMessage)(nil)
 
 /*
  * ContributionAndProof contains the index of the aggregator, the attestation
@@ -34,7 +35,7 @@ func (a *ContributionAndProof) DecodeSSZ(buf []byte, version int) error {
 }
 
 func (a *ContributionAndProof) EncodingSizeSSZ() int {
-	return 108 + a.Contribution.EncodingSizeSSZ()
+	return 104 + a.Contribution.EncodingSizeSSZ()
 }
 
 func (a *ContributionAndProof) HashSSZ() ([32]byte, error) {
@@ -57,27 +58,29 @@ func (a *SignedContributionAndProof) DecodeSSZ(buf []byte, version int) error {
 }
 
 func (a *SignedContributionAndProof) EncodingSizeSSZ() int {
-	return 100 + a.Message.EncodingSizeSSZ()
+	return 96 + a.Message.EncodingSizeSSZ()
 }
 
 func (a *SignedContributionAndProof) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(a.Message, a.Signature[:])
 }
 
-var syncCommitteeAggregationBitsSize = 8
+// SyncCommitteeAggregationBitsSize is SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT bits, in bytes.
+const SyncCommitteeAggregationBitsSize = 16
 
 type Contribution struct {
-	Slot              uint64           `json:"slot,string"`
-	BeaconBlockRoot   libcommon.Hash   `json:"beacon_block_root"`
-	SubcommitteeIndex uint64           `json:"subcommittee_index,string"`
-	AggregationBits   hexutility.Bytes `json:"aggregation_bits"`
+	Slot              uint64            `json:"slot,string"`
+	BeaconBlockRoot   libcommon.Hash    `json:"beacon_block_root"`
+	SubcommitteeIndex uint64            `json:"subcommittee_index,string"`
+	AggregationBits   hexutility.Bytes  `json:"aggregation_bits"`
+	Signature         libcommon.Bytes96 `json:"signature"`
 }
 
 func (a *Contribution) EncodeSSZ(dst []byte) ([]byte, error) {
 	if len(a.AggregationBits) == 0 {
-		a.AggregationBits = make([]byte, syncCommitteeAggregationBitsSize)
+		a.AggregationBits = make([]byte, SyncCommitteeAggregationBitsSize)
 	}
-	return ssz2.MarshalSSZ(dst, &a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits))
+	return ssz2.MarshalSSZ(dst, &a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits), a.Signature[:])
 }
 
 func (a *Contribution) Static() bool {
@@ -85,16 +88,19 @@ func (a *Contribution) Static() bool {
 }
 
 func (a *Contribution) DecodeSSZ(buf []byte, version int) error {
-	a.AggregationBits = make([]byte, syncCommitteeAggregationBitsSize)
-	return ssz2.UnmarshalSSZ(buf, version, &a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits))
+	a.AggregationBits = make([]byte, SyncCommitteeAggregationBitsSize)
+	return ssz2.UnmarshalSSZ(buf, version, &a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits), a.Signature[:])
 }
 
 func (a *Contribution) EncodingSizeSSZ() int {
-	return 72 + len(a.AggregationBits)
+	return 144 + SyncCommitteeAggregationBitsSize
 }
 
 func (a *Contribution) HashSSZ() ([32]byte, error) {
-	return merkle_tree.HashTreeRoot(&a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits))
+	if len(a.AggregationBits) == 0 {
+		a.AggregationBits = make([]byte, SyncCommitteeAggregationBitsSize)
+	}
+	return merkle_tree.HashTreeRoot(&a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits), a.Signature[:])
 }
 
 /*
@@ -146,3 +152,31 @@ func (agg *SyncContribution) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:])
 
 }
+
+// SyncCommitteeMessage is the signature of a single sync committee member over the head block root.
+type SyncCommitteeMessage struct {
+	Slot            uint64            `json:"slot,string"`
+	BeaconBlockRoot libcommon.Hash    `json:"beacon_block_root"`
+	ValidatorIndex  uint64            `json:"validator_index,string"`
+	Signature       libcommon.Bytes96 `json:"signature"`
+}
+
+func (a *SyncCommitteeMessage) EncodeSSZ(dst []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(dst, &a.Slot, a.BeaconBlockRoot[:], &a.ValidatorIndex, a.Signature[:])
+}
+
+func (a *SyncCommitteeMessage) Static() bool {
+	return true
+}
+
+func (a *SyncCommitteeMessage) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, &a.Slot, a.BeaconBlockRoot[:], &a.ValidatorIndex, a.Signature[:])
+}
+
+func (a *SyncCommitteeMessage) EncodingSizeSSZ() int {
+	return 144
+}
+
+func (a *SyncCommitteeMessage) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(&a.Slot, a.BeaconBlockRoot[:], &a.ValidatorIndex, a.Signature[:])
+}
@@ -0,0 +1,56 @@
+package cltypes_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+func TestSignedContributionAndProofSSZ(t *testing.T) {
+	contribution := &cltypes.Contribution{
+		Slot:              1,
+		BeaconBlockRoot:   common.BytesToHash(bytes.Repeat([]byte{0x11}, 32)),
+		SubcommitteeIndex: 2,
+		AggregationBits:   append([]byte{0x05}, make([]byte, cltypes.SyncCommitteeAggregationBitsSize-1)...),
+	}
+	copy(contribution.Signature[:], bytes.Repeat([]byte{0x22}, 96))
+	signed := &cltypes.SignedContributionAndProof{
+		Message: &cltypes.ContributionAndProof{AggregatorIndex: 3, Contribution: contribution},
+	}
+	copy(signed.Message.SelectionProof[:], bytes.Repeat([]byte{0x33}, 96))
+	copy(signed.Signature[:], bytes.Repeat([]byte{0x44}, 96))
+
+	// SyncCommitteeContribution: slot, root, subcommittee index, Bitvector[128] and signature
+	require.Equal(t, 8+32+8+16+96, contribution.EncodingSizeSSZ())
+	require.Equal(t, 8+contribution.EncodingSizeSSZ()+96, signed.Message.EncodingSizeSSZ())
+	require.Equal(t, signed.Message.EncodingSizeSSZ()+96, signed.EncodingSizeSSZ())
+
+	root, err := contribution.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("a4335a57cdfc5bf2906047168fae50681fb6d42ccf233799aaaf1de694eb1ea5"), common.Hash(root))
+	root, err = signed.Message.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("b0177a949a73ade413b73a6e0b94900f928d5bc416fe085b1a5229e23c4d3c91"), common.Hash(root))
+
+	encoded, err := signed.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, signed.EncodingSizeSSZ())
+	decoded := &cltypes.SignedContributionAndProof{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, signed, decoded)
+}
+
+func TestSyncCommitteeMessageSSZ(t *testing.T) {
+	msg := &cltypes.SyncCommitteeMessage{Slot: 1, BeaconBlockRoot: common.Hash{0x11}, ValidatorIndex: 2}
+	copy(msg.Signature[:], bytes.Repeat([]byte{0x22}, 96))
+	encoded, err := msg.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, msg.EncodingSizeSSZ())
+	decoded := &cltypes.SyncCommitteeMessage{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, msg, decoded)
+}
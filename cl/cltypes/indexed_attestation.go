@@ -1,6 +1,8 @@
 package cltypes
 
 import (
+	"encoding/json"
+
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
@@ -23,6 +25,17 @@ func NewIndexedAttestation() *IndexedAttestation {
 	}
 }
 
+// UnmarshalJSON allocates the attestation data and indices before decoding into them.
+func (i *IndexedAttestation) UnmarshalJSON(buf []byte) error {
+	type indexedAttestation IndexedAttestation
+	tmp := (*indexedAttestation)(NewIndexedAttestation())
+	if err := json.Unmarshal(buf, tmp); err != nil {
+		return err
+	}
+	*i = IndexedAttestation(*tmp)
+	return nil
+}
+
 func (i *IndexedAttestation) Static() bool {
 	return false
 }
@@ -1,6 +1,7 @@
 package solid
 
 import (
+	"encoding/binary"
 	"encoding/json"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
@@ -82,6 +83,8 @@ func (a *Attestation) AggregationBits() []byte {
 
 // SetAggregationBits sets the aggregation bits buffer of the Attestation instance.
 func (a *Attestation) SetAggregationBits(bits []byte) {
+	// Attestations built from parameters or JSON never went through DecodeSSZ, so make sure the offset is there.
+	binary.LittleEndian.PutUint32(a.staticBuffer[:4], aggregationBitsOffset)
 	a.aggregationBitsBuffer = bits
 }
 
@@ -91,3 +91,18 @@ func TestAttestation(t *testing.T) {
 	cloned := attestation.Clone()
 	assert.NotEqual(t, nil, cloned.(*Attestation))
 }
+
+func TestAttestationFromParametersSSZ(t *testing.T) {
+	data := NewAttestionDataFromParameters(1, 2, common.Hash{3}, NewCheckpointFromParameters(common.Hash{4}, 5), NewCheckpointFromParameters(common.Hash{6}, 7))
+	att := NewAttestionFromParameters([]byte{0x0f, 0x01}, data, [96]byte{8})
+
+	// the offset of aggregation bits must be set without going through DecodeSSZ
+	encoded, err := att.EncodeSSZ(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{aggregationBitsOffset, 0, 0, 0}, encoded[:4])
+	decoded := &Attestation{}
+	assert.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	assert.Equal(t, att.AggregationBits(), decoded.AggregationBits())
+	assert.Equal(t, att.AttestantionData(), decoded.AttestantionData())
+	assert.Equal(t, att.Signature(), decoded.Signature())
+}
@@ -118,6 +118,23 @@ func TestHashListEncodeDecodeSSZ(t *testing.T) {
 	assert.Equal(t, h.Get(0), hDecoded.Get(0), "Values should match after decoding")
 }
 
+func TestHashListJSON(t *testing.T) {
+	h := NewHashList(10)
+	h.Append(common.Hash{1, 2, 3})
+	h.Append(common.Hash{4, 5, 6})
+
+	encoded, err := h.MarshalJSON()
+	assert.Nil(t, err, "MarshalJSON should not return an error")
+
+	hDecoded := NewHashList(10)
+	err = hDecoded.UnmarshalJSON(encoded)
+	assert.Nil(t, err, "UnmarshalJSON should not return an error")
+
+	assert.Equal(t, 2, hDecoded.Length(), "Lengths should match after decoding")
+	assert.Equal(t, h.Get(0), hDecoded.Get(0), "Values should match after decoding")
+	assert.Equal(t, h.Get(1), hDecoded.Get(1), "Values should match after decoding")
+}
+
 func TestNewUint64ListSSZ(t *testing.T) {
 	h := NewUint64ListSSZ(10)
 	assert.Equal(t, 0, h.Length(), "Newly created Uint64ListSSZ should be empty")
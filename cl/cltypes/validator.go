@@ -1,6 +1,8 @@
 package cltypes
 
 import (
+	"encoding/json"
+
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
@@ -56,6 +58,17 @@ func (d *Deposit) EncodeSSZ(dst []byte) ([]byte, error) {
 	return ssz2.MarshalSSZ(dst, d.Proof, d.Data)
 }
 
+// UnmarshalJSON allocates the merkle proof before decoding into it.
+func (d *Deposit) UnmarshalJSON(buf []byte) error {
+	type deposit Deposit
+	tmp := &deposit{Proof: solid.NewHashVector(DepositProofLength), Data: new(DepositData)}
+	if err := json.Unmarshal(buf, tmp); err != nil {
+		return err
+	}
+	*d = Deposit(*tmp)
+	return nil
+}
+
 func (d *Deposit) DecodeSSZ(buf []byte, version int) error {
 	d.Proof = solid.NewHashVector(33)
 	d.Data = new(DepositData)
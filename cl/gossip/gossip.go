@@ -17,7 +17,9 @@ const (
 	TopicNameLightClientFinalityUpdate   = "light_client_finality_update"
 	TopicNameLightClientOptimisticUpdate = "light_client_optimistic_update"
 
-	TopicNamePrefixBlobSidecar = "blob_sidecar_%d" // {id} is a placeholder for the blob id
+	TopicNamePrefixBlobSidecar         = "blob_sidecar_%d"       // {id} is a placeholder for the blob id
+	TopicNamePrefixBeaconAttestation   = "beacon_attestation_%d" // {id} is a placeholder for the attestation subnet id
+	TopicNamePrefixSyncCommitteeSubnet = "sync_committee_%d"     // {id} is a placeholder for the sync committee subnet id
 )
 
 func TopicNameBlobSidecar(d int) string {
@@ -27,3 +29,19 @@ func TopicNameBlobSidecar(d int) string {
 func IsTopicBlobSidecar(d string) bool {
 	return strings.Contains(d, "blob_sidecar_")
 }
+
+func TopicNameBeaconAttestation(d uint64) string {
+	return fmt.Sprintf(TopicNamePrefixBeaconAttestation, d)
+}
+
+func IsTopicBeaconAttestation(d string) bool {
+	return strings.Contains(d, "beacon_attestation_")
+}
+
+func TopicNameSyncCommitteeSubnet(d uint64) string {
+	return fmt.Sprintf(TopicNamePrefixSyncCommitteeSubnet, d)
+}
+
+func IsTopicSyncCommitteeSubnet(d string) bool {
+	return strings.Contains(d, "sync_committee_") && !strings.Contains(d, TopicNameSyncCommitteeContributionAndProof)
+}
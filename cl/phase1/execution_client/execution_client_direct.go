@@ -3,11 +3,14 @@ package execution_client
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"time"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/execution"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
 	"github.com/ledgerwatch/erigon/turbo/execution/eth1/eth1_chain_reader.go"
 )
 
@@ -37,7 +40,7 @@ func (cc *ExecutionClientDirect) NewPayload(ctx context.Context, payload *cltype
 		return true, err
 	}
 
-	if err := cc.chainRW.InsertBlockAndWait(ctx, types.NewBlockFromStorage(payload.BlockHash, header, txs, nil, body.Withdrawals)); err != nil {
+	if err := cc.chainRW.InsertBlockAndWait(ctx, types.NewBlockFromStorage(payload.BlockHash, header, txs, nil, body.Withdrawals, nil)); err != nil {
 		return false, err
 	}
 
@@ -108,3 +111,45 @@ func (cc *ExecutionClientDirect) FrozenBlocks(ctx context.Context) uint64 {
 func (cc *ExecutionClientDirect) HasBlock(ctx context.Context, hash libcommon.Hash) (bool, error) {
 	return cc.chainRW.HasBlock(ctx, hash)
 }
+
+// busyRetryInterval is how long we wait before asking again a busy execution module to build a block.
+const busyRetryInterval = 50 * time.Millisecond
+
+func (cc *ExecutionClientDirect) GetAssembledBlock(ctx context.Context, parentHash libcommon.Hash, attributes *engine_types.PayloadAttributes) (*engine_types.ExecutionPayload, *engine_types.BlobsBundleV1, *big.Int, error) {
+	var (
+		id   uint64
+		busy = true
+		err  error
+	)
+	for busy {
+		if id, busy, err = cc.chainRW.AssembleBlock(ctx, parentHash, attributes); err != nil {
+			return nil, nil, nil, err
+		}
+		if busy {
+			if err := sleepCtx(ctx, busyRetryInterval); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+	for {
+		payload, blobsBundle, blockValue, busy, err := cc.chainRW.GetAssembledBlock(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !busy {
+			return payload, blobsBundle, blockValue, nil
+		}
+		if err := sleepCtx(ctx, busyRetryInterval); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
@@ -27,6 +28,8 @@ type ExecutionClientRpc struct {
 	client    *rpc.Client
 	addr      string
 	jwtSecret []byte
+
+	finalized atomic.Pointer[libcommon.Hash] // last finalized hash sent to the EL, needed when building blocks
 }
 
 func NewExecutionClientRPC(jwtSecret []byte, addr string, port int) (*ExecutionClientRpc, error) {
@@ -142,6 +145,7 @@ func (cc *ExecutionClientRpc) ForkChoiceUpdate(ctx context.Context, finalized li
 	if err != nil {
 		return fmt.Errorf("execution Client RPC failed to retrieve ForkChoiceUpdate response, err: %w", err)
 	}
+	cc.finalized.Store(&finalized)
 	// Ignore timeouts
 	if err != nil && err.Error() == errContextExceeded {
 		return nil
@@ -241,3 +245,53 @@ func (cc *ExecutionClientRpc) FrozenBlocks(ctx context.Context) uint64 {
 func (cc *ExecutionClientRpc) HasBlock(ctx context.Context, hash libcommon.Hash) (bool, error) {
 	panic("unimplemented")
 }
+
+func (cc *ExecutionClientRpc) GetAssembledBlock(ctx context.Context, parentHash libcommon.Hash, attributes *engine_types.PayloadAttributes) (*engine_types.ExecutionPayload, *engine_types.BlobsBundleV1, *big.Int, error) {
+	var forkChoiceMethod, getPayloadMethod string
+	// determine the engine methods from the attributes fields
+	switch {
+	case attributes.ParentBeaconBlockRoot != nil:
+		forkChoiceMethod, getPayloadMethod = rpc_helper.ForkChoiceUpdatedV3, rpc_helper.EngineGetPayloadV3
+	case attributes.Withdrawals != nil:
+		forkChoiceMethod, getPayloadMethod = rpc_helper.ForkChoiceUpdatedV2, rpc_helper.EngineGetPayloadV2
+	default:
+		forkChoiceMethod, getPayloadMethod = rpc_helper.ForkChoiceUpdatedV1, rpc_helper.EngineGetPayloadV1
+	}
+
+	forkChoiceRequest := engine_types.ForkChoiceState{
+		HeadHash:      parentHash,
+		SafeBlockHash: parentHash,
+	}
+	if finalized := cc.finalized.Load(); finalized != nil {
+		forkChoiceRequest.FinalizedBlockHash = *finalized
+	}
+	forkChoiceResp := &engine_types.ForkChoiceUpdatedResponse{}
+	log.Debug("[ExecutionClientRpc] Calling EL", "method", forkChoiceMethod)
+	if err := cc.client.CallContext(ctx, forkChoiceResp, forkChoiceMethod, forkChoiceRequest, attributes); err != nil {
+		return nil, nil, nil, fmt.Errorf("execution Client RPC failed to retrieve ForkChoiceUpdate response, err: %w", err)
+	}
+	if err := checkPayloadStatus(forkChoiceResp.PayloadStatus); err != nil {
+		return nil, nil, nil, err
+	}
+	if forkChoiceResp.PayloadId == nil {
+		return nil, nil, nil, fmt.Errorf("execution Client RPC did not start building a payload")
+	}
+
+	log.Debug("[ExecutionClientRpc] Calling EL", "method", getPayloadMethod)
+	if getPayloadMethod == rpc_helper.EngineGetPayloadV1 {
+		payload := &engine_types.ExecutionPayload{}
+		if err := cc.client.CallContext(ctx, payload, getPayloadMethod, forkChoiceResp.PayloadId); err != nil {
+			return nil, nil, nil, fmt.Errorf("execution Client RPC failed to retrieve GetPayload response, err: %w", err)
+		}
+		return payload, nil, nil, nil
+	}
+	getPayloadResp := &engine_types.GetPayloadResponse{}
+	if err := cc.client.CallContext(ctx, getPayloadResp, getPayloadMethod, forkChoiceResp.PayloadId); err != nil {
+		return nil, nil, nil, fmt.Errorf("execution Client RPC failed to retrieve GetPayload response, err: %w", err)
+	}
+	var blockValue *big.Int
+	if getPayloadResp.BlockValue != nil {
+		blockValue = getPayloadResp.BlockValue.ToInt()
+	}
+	return getPayloadResp.ExecutionPayload, getPayloadResp.BlobsBundle, blockValue, nil
+}
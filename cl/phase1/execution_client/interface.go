@@ -2,11 +2,13 @@ package execution_client
 
 import (
 	"context"
+	"math/big"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
 )
 
 var errContextExceeded = "rpc error: code = DeadlineExceeded desc = context deadline exceeded"
@@ -28,4 +30,6 @@ type ExecutionEngine interface {
 	HasBlock(ctx context.Context, hash libcommon.Hash) (bool, error)
 	// Snapshots
 	FrozenBlocks(ctx context.Context) uint64
+	// Block production
+	GetAssembledBlock(ctx context.Context, parentHash libcommon.Hash, attributes *engine_types.PayloadAttributes) (*engine_types.ExecutionPayload, *engine_types.BlobsBundleV1, *big.Int, error)
 }
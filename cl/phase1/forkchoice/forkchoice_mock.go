@@ -2,6 +2,7 @@ package forkchoice
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
@@ -14,6 +15,7 @@ import (
 )
 
 // Make mocks with maps and simple setters and getters, panic on methods from ForkChoiceStorageWriter
+// (except OnBlock, which only records the block)
 
 type ForkChoiceStorageMock struct {
 	Ancestors              map[uint64]common.Hash
@@ -39,8 +41,14 @@ type ForkChoiceStorageMock struct {
 	LightClientBootstraps     map[common.Hash]*cltypes.LightClientBootstrap
 	NewestLCUpdate            *cltypes.LightClientUpdate
 	LCUpdates                 map[uint64]*cltypes.LightClientUpdate
+	GetHeaderVal              map[common.Hash]*cltypes.BeaconBlockHeader
+	PublicKeysVal             map[uint64]common.Bytes48
 
-	Pool pool.OperationsPool
+	// OnBlockCalls records the blocks passed to OnBlock.
+	OnBlockCalls []*cltypes.SignedBeaconBlock
+
+	Pool      pool.OperationsPool
+	EngineVal execution_client.ExecutionEngine
 }
 
 func NewForkChoiceStorageMock() *ForkChoiceStorageMock {
@@ -62,6 +70,8 @@ func NewForkChoiceStorageMock() *ForkChoiceStorageMock {
 		GetFinalityCheckpointsVal: make(map[common.Hash][3]solid.Checkpoint),
 		LightClientBootstraps:     make(map[common.Hash]*cltypes.LightClientBootstrap),
 		LCUpdates:                 make(map[uint64]*cltypes.LightClientUpdate),
+		GetHeaderVal:              make(map[common.Hash]*cltypes.BeaconBlockHeader),
+		PublicKeysVal:             make(map[uint64]common.Bytes48),
 	}
 }
 
@@ -74,7 +84,7 @@ func (f *ForkChoiceStorageMock) AnchorSlot() uint64 {
 }
 
 func (f *ForkChoiceStorageMock) Engine() execution_client.ExecutionEngine {
-	panic("implement me")
+	return f.EngineVal
 }
 
 func (f *ForkChoiceStorageMock) FinalizedCheckpoint() solid.Checkpoint {
@@ -141,7 +151,8 @@ func (f *ForkChoiceStorageMock) OnAttesterSlashing(attesterSlashing *cltypes.Att
 }
 
 func (f *ForkChoiceStorageMock) OnBlock(ctx context.Context, block *cltypes.SignedBeaconBlock, newPayload bool, fullValidation bool, checkDataAvaiability bool) error {
-	panic("implement me")
+	f.OnBlockCalls = append(f.OnBlockCalls, block)
+	return nil
 }
 
 func (f *ForkChoiceStorageMock) OnTick(time uint64) {
@@ -183,6 +194,16 @@ func (f *ForkChoiceStorageMock) OnBlsToExecutionChange(signedChange *cltypes.Sig
 	return nil
 }
 
+func (f *ForkChoiceStorageMock) OnSignedContributionAndProof(signedContribution *cltypes.SignedContributionAndProof, test bool) error {
+	f.Pool.SignedContributionAndProofPool.Insert(signedContribution.Signature, signedContribution)
+	return nil
+}
+
+func (f *ForkChoiceStorageMock) OnSyncCommitteeMessage(syncCommitteeMessage *cltypes.SyncCommitteeMessage, test bool) error {
+	f.Pool.SyncCommitteeMessagesPool.Insert(syncCommitteeMessage.Signature, syncCommitteeMessage)
+	return nil
+}
+
 func (f *ForkChoiceStorageMock) ForkNodes() []ForkNode {
 	return f.WeightsMock
 }
@@ -213,7 +234,7 @@ func (f *ForkChoiceStorageMock) GetLightClientUpdate(period uint64) (*cltypes.Li
 }
 
 func (f *ForkChoiceStorageMock) GetHeader(blockRoot libcommon.Hash) (*cltypes.BeaconBlockHeader, bool) {
-	panic("implement me")
+	return f.GetHeaderVal[blockRoot], f.GetHeaderVal[blockRoot] != nil
 }
 
 func (f *ForkChoiceStorageMock) GetBalances(blockRoot libcommon.Hash) (solid.Uint64ListSSZ, error) {
@@ -237,5 +258,9 @@ func (f *ForkChoiceStorageMock) GetCurrentPartecipationIndicies(blockRoot libcom
 }
 
 func (f *ForkChoiceStorageMock) GetPublicKeyForValidator(blockRoot libcommon.Hash, idx uint64) (libcommon.Bytes48, error) {
-	panic("implement me")
+	pk, ok := f.PublicKeysVal[idx]
+	if !ok {
+		return libcommon.Bytes48{}, fmt.Errorf("validator %d not found", idx)
+	}
+	return pk, nil
 }
@@ -62,6 +62,8 @@ type ForkChoiceStorageWriter interface {
 	OnVoluntaryExit(signedVoluntaryExit *cltypes.SignedVoluntaryExit, test bool) error
 	OnProposerSlashing(proposerSlashing *cltypes.ProposerSlashing, test bool) error
 	OnBlsToExecutionChange(signedChange *cltypes.SignedBLSToExecutionChange, test bool) error
+	OnSignedContributionAndProof(signedContribution *cltypes.SignedContributionAndProof, test bool) error
+	OnSyncCommitteeMessage(syncCommitteeMessage *cltypes.SyncCommitteeMessage, test bool) error
 	OnBlock(ctx context.Context, block *cltypes.SignedBeaconBlock, newPayload bool, fullValidation bool, checkDataAvaibility bool) error
 	OnTick(time uint64)
 	SetSynced(synced bool)
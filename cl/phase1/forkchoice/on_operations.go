@@ -216,3 +216,54 @@ func (f *ForkChoiceStore) OnSignedContributionAndProof(signedChange *cltypes.Sig
 	f.emitters.Publish("contribution_and_proof", signedChange)
 	return nil
 }
+
+// OnSyncCommitteeMessage is a non-official handler for sync committee messages. it pushes the message in the pool so that
+// it can later be aggregated into a sync committee contribution.
+func (f *ForkChoiceStore) OnSyncCommitteeMessage(syncCommitteeMessage *cltypes.SyncCommitteeMessage, test bool) error {
+	if f.operationsPool.SyncCommitteeMessagesPool.Has(syncCommitteeMessage.Signature) {
+		return nil
+	}
+
+	s := f.syncedDataManager.HeadState()
+	if s == nil {
+		return nil
+	}
+	if s.Version() < clparams.AltairVersion {
+		return fmt.Errorf("sync committee messages are not supported before altair")
+	}
+
+	val, err := s.ValidatorForValidatorIndex(int(syncCommitteeMessage.ValidatorIndex))
+	if err != nil {
+		return err
+	}
+	pk := val.PublicKey()
+	// The validator must be part of the current sync committee.
+	isMember := false
+	for _, member := range s.CurrentSyncCommittee().GetCommittee() {
+		if member == pk {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return fmt.Errorf("validator %d is not part of the current sync committee", syncCommitteeMessage.ValidatorIndex)
+	}
+
+	if !test {
+		domain, err := s.GetDomain(f.beaconCfg.DomainSyncCommittee, state.GetEpochAtSlot(f.beaconCfg, syncCommitteeMessage.Slot))
+		if err != nil {
+			return err
+		}
+		signingRoot := utils.Sha256(syncCommitteeMessage.BeaconBlockRoot[:], domain)
+		valid, err := bls.Verify(syncCommitteeMessage.Signature[:], signingRoot[:], pk[:])
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errors.New("OnSyncCommitteeMessage: BLS verification failed")
+		}
+	}
+
+	f.operationsPool.SyncCommitteeMessagesPool.Insert(syncCommitteeMessage.Signature, syncCommitteeMessage)
+	return nil
+}
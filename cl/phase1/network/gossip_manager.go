@@ -20,6 +20,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/ledgerwatch/log/v3"
 )
@@ -204,6 +205,24 @@ func (g *GossipManager) onRecv(ctx context.Context, data *sentinel.GossipData, l
 			}
 
 			log.Debug("Received blob sidecar via gossip", "index", *data.SubnetId, "size", datasize.ByteSize(len(blobSideCar.Blob)))
+		case gossip.IsTopicSyncCommitteeSubnet(data.Name):
+			if err := operationsContract[*cltypes.SyncCommitteeMessage](ctx, g, l, data, int(version), "sync committee message", g.forkChoice.OnSyncCommitteeMessage); err != nil {
+				return err
+			}
+		case gossip.IsTopicBeaconAttestation(data.Name):
+			attestation := &solid.Attestation{}
+			if err := attestation.DecodeSSZ(common.CopyBytes(data.Data), int(version)); err != nil {
+				g.sentinel.BanPeer(ctx, data.Peer)
+				l["at"] = "decoding attestation"
+				return err
+			}
+			if err := g.forkChoice.OnAttestation(attestation, false, true); err != nil {
+				l["at"] = "verify attestation"
+				return err
+			}
+			if _, err := g.sentinel.PublishGossip(ctx, data); err != nil {
+				log.Debug("failed publish gossip", "err", err)
+			}
 		default:
 		}
 	}
@@ -491,7 +491,7 @@ func ConsensusClStages(ctx context.Context,
 								log.Warn("bad blocks segment received", "err", err)
 								return err
 							}
-							blocksBatch = append(blocksBatch, types.NewBlockFromStorage(executionPayload.BlockHash, header, txs, nil, body.Withdrawals))
+							blocksBatch = append(blocksBatch, types.NewBlockFromStorage(executionPayload.BlockHash, header, txs, nil, body.Withdrawals, nil))
 							if len(blocksBatch) >= blocksBatchLimit {
 								if err := cfg.executionClient.InsertBlocks(ctx, blocksBatch, true); err != nil {
 									logger.Warn("failed to insert blocks", "err", err)
@@ -30,6 +30,7 @@ type OperationsPool struct {
 	ProposerSlashingsPool          *OperationPool[libcommon.Bytes96, *cltypes.ProposerSlashing]
 	BLSToExecutionChangesPool      *OperationPool[libcommon.Bytes96, *cltypes.SignedBLSToExecutionChange]
 	SignedContributionAndProofPool *OperationPool[libcommon.Bytes96, *cltypes.SignedContributionAndProof]
+	SyncCommitteeMessagesPool      *OperationPool[libcommon.Bytes96, *cltypes.SyncCommitteeMessage]
 
 	VoluntaryExistsPool *OperationPool[uint64, *cltypes.SignedVoluntaryExit]
 }
@@ -41,6 +42,7 @@ func NewOperationsPool(beaconCfg *clparams.BeaconChainConfig) OperationsPool {
 		ProposerSlashingsPool:          NewOperationPool[libcommon.Bytes96, *cltypes.ProposerSlashing](int(beaconCfg.MaxAttestations), "proposerSlashingsPool"),
 		BLSToExecutionChangesPool:      NewOperationPool[libcommon.Bytes96, *cltypes.SignedBLSToExecutionChange](int(beaconCfg.MaxBlsToExecutionChanges), "blsExecutionChangesPool"),
 		SignedContributionAndProofPool: NewOperationPool[libcommon.Bytes96, *cltypes.SignedContributionAndProof](int(beaconCfg.MaxAttestations), "signedContributionAndProof"),
+		SyncCommitteeMessagesPool:      NewOperationPool[libcommon.Bytes96, *cltypes.SyncCommitteeMessage](int(beaconCfg.SyncCommitteeSize), "syncCommitteeMessagesPool"),
 		VoluntaryExistsPool:            NewOperationPool[uint64, *cltypes.SignedVoluntaryExit](int(beaconCfg.MaxBlsToExecutionChanges), "voluntaryExitsPool"),
 	}
 }
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ledgerwatch/erigon-lib/common"
@@ -92,7 +93,11 @@ type GossipManager struct {
 	subscriptions sync.Map // map from topic string to *GossipSubscription
 }
 
-const maxIncomingGossipMessages = 1 << 16
+const (
+	maxIncomingGossipMessages = 1 << 16
+	// expiryCheckInterval is how often subscriptions with an expiry are checked.
+	expiryCheckInterval = time.Second
+)
 
 // construct a new gossip manager that will handle packets with the given handlerfunc
 func NewGossipManager(
@@ -102,9 +107,30 @@ func NewGossipManager(
 		ch:            make(chan *GossipMessage, maxIncomingGossipMessages),
 		subscriptions: sync.Map{},
 	}
+	go g.expireSubscriptions(ctx)
 	return g
 }
 
+// expireSubscriptions drops the subscriptions whose expiry has passed.
+func (s *GossipManager) expireSubscriptions(ctx context.Context) {
+	ticker := time.NewTicker(expiryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.subscriptions.Range(func(topic, value interface{}) bool {
+				if value.(*GossipSubscription).expired(now) {
+					s.unsubscribe(topic.(string))
+				}
+				return true
+			})
+		}
+	}
+}
+
 func GossipSidecarTopics(maxBlobs uint64) (ret []GossipTopic) {
 	for i := uint64(0); i < maxBlobs; i++ {
 		ret = append(ret, GossipTopic{
@@ -168,7 +194,9 @@ func (s *Sentinel) forkWatcher() {
 					newSub, err := s.SubscribeGossip(sub.gossip_topic)
 					if err != nil {
 						log.Warn("[Gossip] Failed to resubscribe to topic", "err", err)
+						return true
 					}
+					newSub.OverwriteSubscriptionExpiry(sub.Expiry())
 					newSub.Listen()
 					return true
 				})
@@ -289,6 +317,24 @@ type GossipSubscription struct {
 	setup     sync.Once
 	stopCh    chan struct{}
 	closeOnce sync.Once
+
+	expiration atomic.Value // time.Time at which the subscription is dropped, zero means never
+}
+
+// OverwriteSubscriptionExpiry sets the time at which the subscription is dropped. A zero time keeps it forever.
+func (sub *GossipSubscription) OverwriteSubscriptionExpiry(expiry time.Time) {
+	sub.expiration.Store(expiry)
+}
+
+// Expiry returns the time at which the subscription is dropped, zero if it never expires.
+func (sub *GossipSubscription) Expiry() time.Time {
+	expiry, _ := sub.expiration.Load().(time.Time)
+	return expiry
+}
+
+func (sub *GossipSubscription) expired(now time.Time) bool {
+	expiry := sub.Expiry()
+	return !expiry.IsZero() && now.After(expiry)
 }
 
 func (sub *GossipSubscription) Listen() (err error) {
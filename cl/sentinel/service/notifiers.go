@@ -59,6 +59,22 @@ func (g *gossipNotifier) notifyBlob(data []byte, pid string, blobIndex int) {
 	}
 }
 
+func (g *gossipNotifier) notifySubnet(t string, data []byte, pid string, subnetId uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, ch := range g.notifiers {
+		sbI := new(uint64)
+		*sbI = subnetId
+		ch <- gossipObject{
+			data:     data,
+			t:        t,
+			pid:      pid,
+			subnetId: sbI,
+		}
+	}
+}
+
 func (g *gossipNotifier) addSubscriber() (chan gossipObject, int, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
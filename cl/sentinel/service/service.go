@@ -25,7 +25,11 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-const gracePeerCount = 8
+const (
+	gracePeerCount = 8
+	// publishOnlySubnetExpiry is how long we stay in a subnet we joined only to publish a message.
+	publishOnlySubnetExpiry = 2 * time.Minute
+)
 
 var _ sentinelrpc.SentinelServer = (*SentinelServer)(nil)
 
@@ -49,8 +53,8 @@ func NewSentinelServer(ctx context.Context, sentinel *sentinel.Sentinel, logger
 	}
 }
 
-// extractBlobSideCarIndex takes a topic and extract the blob sidecar
-func extractBlobSideCarIndex(topic string) int {
+// extractSubnetIndexByGossipTopic takes a topic and extract the subnet (or blob sidecar) index
+func extractSubnetIndexByGossipTopic(topic string) int {
 	// e.g /eth2/d31f6191/blob_sidecar_3/ssz_snappy, we want to extract 3
 	// split them by /
 	parts := strings.Split(topic, "/")
@@ -98,14 +102,34 @@ func (s *SentinelServer) PublishGossip(_ context.Context, msg *sentinelrpc.Gossi
 		subscription = manager.GetMatchingSubscription(msg.Name)
 	case gossip.TopicNameAttesterSlashing:
 		subscription = manager.GetMatchingSubscription(msg.Name)
+	case gossip.TopicNameBlsToExecutionChange:
+		subscription = manager.GetMatchingSubscription(msg.Name)
+	case gossip.TopicNameSyncCommitteeContributionAndProof:
+		subscription = manager.GetMatchingSubscription(msg.Name)
 	default:
 		// check subnets
 		switch {
-		case strings.Contains(msg.Name, gossip.TopicNamePrefixBlobSidecar):
+		case gossip.IsTopicBlobSidecar(msg.Name):
 			if msg.SubnetId == nil {
 				return nil, fmt.Errorf("subnetId is required for blob sidecar")
 			}
-			subscription = manager.GetMatchingSubscription(fmt.Sprintf("%s/%d", gossip.TopicNamePrefixBlobSidecar, *msg.SubnetId))
+			subscription = manager.GetMatchingSubscription(subnetTopicMatch(gossip.TopicNameBlobSidecar(int(*msg.SubnetId))))
+		case gossip.IsTopicBeaconAttestation(msg.Name):
+			if msg.SubnetId == nil {
+				return nil, fmt.Errorf("subnetId is required for beacon attestation")
+			}
+			var err error
+			if subscription, err = s.subnetSubscription(gossip.TopicNameBeaconAttestation(*msg.SubnetId)); err != nil {
+				return nil, err
+			}
+		case gossip.IsTopicSyncCommitteeSubnet(msg.Name):
+			if msg.SubnetId == nil {
+				return nil, fmt.Errorf("subnetId is required for sync committee message")
+			}
+			var err error
+			if subscription, err = s.subnetSubscription(gossip.TopicNameSyncCommitteeSubnet(*msg.SubnetId)); err != nil {
+				return nil, err
+			}
 		default:
 			return &sentinelrpc.EmptyMessage{}, nil
 		}
@@ -116,6 +140,49 @@ func (s *SentinelServer) PublishGossip(_ context.Context, msg *sentinelrpc.Gossi
 	return &sentinelrpc.EmptyMessage{}, subscription.Publish(compressedData)
 }
 
+// subnetTopicMatch returns the string used to match exactly a subnet topic, so that subnet 1 does not match subnet 10.
+func subnetTopicMatch(name string) string {
+	return "/" + name + "/"
+}
+
+// subnetSubscription returns the subscription to the given subnet topic. If we are not part of it yet, the topic is joined
+// for a short while so that the message can still be published to the subnet mesh.
+func (s *SentinelServer) subnetSubscription(name string) (*sentinel.GossipSubscription, error) {
+	if subscription := s.sentinel.GossipManager().GetMatchingSubscription(subnetTopicMatch(name)); subscription != nil {
+		return subscription, nil
+	}
+	subscription, err := s.sentinel.SubscribeGossip(sentinel.GossipTopic{Name: name, CodecStr: sentinel.SSZSnappyCodec})
+	if err != nil {
+		return nil, err
+	}
+	subscription.OverwriteSubscriptionExpiry(time.Now().Add(publishOnlySubnetExpiry))
+	return subscription, nil
+}
+
+// SetSubscribeExpiry subscribes to an attestation or sync committee subnet until the given unix time.
+func (s *SentinelServer) SetSubscribeExpiry(_ context.Context, req *sentinelrpc.RequestSubscribeExpiry) (*sentinelrpc.EmptyMessage, error) {
+	if !gossip.IsTopicBeaconAttestation(req.Topic) && !gossip.IsTopicSyncCommitteeSubnet(req.Topic) {
+		return nil, fmt.Errorf("topic %s is not a subnet topic", req.Topic)
+	}
+	expiry := time.Unix(int64(req.ExpiryUnixSecs), 0)
+	subscription := s.sentinel.GossipManager().GetMatchingSubscription(subnetTopicMatch(req.Topic))
+	if subscription == nil {
+		var err error
+		subscription, err = s.sentinel.SubscribeGossip(sentinel.GossipTopic{Name: req.Topic, CodecStr: sentinel.SSZSnappyCodec})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := subscription.Listen(); err != nil {
+		return nil, err
+	}
+	// Never shorten an existing subscription, several validators may be interested in the same subnet.
+	if expiry.After(subscription.Expiry()) {
+		subscription.OverwriteSubscriptionExpiry(expiry)
+	}
+	return &sentinelrpc.EmptyMessage{}, nil
+}
+
 func (s *SentinelServer) SubscribeGossip(data *sentinelrpc.SubscriptionData, stream sentinelrpc.Sentinel_SubscribeGossipServer) error {
 	// first of all subscribe
 	ch, subId, err := s.gossipNotifier.addSubscriber()
@@ -384,7 +451,13 @@ func (s *SentinelServer) handleGossipPacket(pkt *sentinel.GossipMessage) error {
 	} else if gossip.IsTopicBlobSidecar(topic) {
 
 		// extract the index
-		s.gossipNotifier.notifyBlob(data, string(textPid), extractBlobSideCarIndex(topic))
+		s.gossipNotifier.notifyBlob(data, string(textPid), extractSubnetIndexByGossipTopic(topic))
+	} else if gossip.IsTopicBeaconAttestation(topic) {
+		subnetId := uint64(extractSubnetIndexByGossipTopic(topic))
+		s.gossipNotifier.notifySubnet(gossip.TopicNameBeaconAttestation(subnetId), data, string(textPid), subnetId)
+	} else if gossip.IsTopicSyncCommitteeSubnet(topic) {
+		subnetId := uint64(extractSubnetIndexByGossipTopic(topic))
+		s.gossipNotifier.notifySubnet(gossip.TopicNameSyncCommitteeSubnet(subnetId), data, string(textPid), subnetId)
 	}
 	return nil
 }
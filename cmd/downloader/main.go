@@ -75,6 +75,8 @@ var (
 	disableIPV6                    bool
 	disableIPV4                    bool
 	seedbox                        bool
+	webseedServerAddr              string
+	downloadPolicy                 string
 )
 
 func init() {
@@ -84,6 +86,8 @@ func init() {
 	withChainFlag(rootCmd)
 
 	rootCmd.Flags().StringVar(&webseeds, utils.WebSeedsFlag.Name, utils.WebSeedsFlag.Value, utils.WebSeedsFlag.Usage)
+	rootCmd.Flags().StringVar(&webseedServerAddr, utils.WebSeedServerAddrFlag.Name, utils.WebSeedServerAddrFlag.Value, utils.WebSeedServerAddrFlag.Usage)
+	rootCmd.Flags().StringVar(&downloadPolicy, utils.SnapDownloadFlag.Name, utils.SnapDownloadFlag.Value, utils.SnapDownloadFlag.Usage)
 	rootCmd.Flags().StringVar(&natSetting, "nat", utils.NATFlag.Value, utils.NATFlag.Usage)
 	rootCmd.Flags().StringVar(&downloaderApiAddr, "downloader.api.addr", "127.0.0.1:9093", "external downloader api network address, for example: 127.0.0.1:9093 serves remote downloader interface")
 	rootCmd.Flags().StringVar(&downloadRateStr, "torrent.download.rate", utils.TorrentDownloadRateFlag.Value, utils.TorrentDownloadRateFlag.Usage)
@@ -215,6 +219,10 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 	downloadernat.DoNat(natif, cfg.ClientConfig, logger)
 
 	cfg.AddTorrentsFromDisk = true // always true unless using uploader - which wants control of torrent files
+	cfg.WebSeedServerAddr = webseedServerAddr
+	if cfg.DownloadPolicy, err = snapcfg.ParseDownloadPolicy(downloadPolicy); err != nil {
+		return err
+	}
 
 	d, err := downloader.New(ctx, cfg, dirs, logger, log.LvlInfo, seedbox)
 	if err != nil {
@@ -17,6 +17,7 @@ var (
 	unwindEvery                    uint64
 	batchSizeStr                   string
 	reset, warmup, noCommit        bool
+	dryRun                         bool
 	bucket                         string
 	datadirCli, toChaindata        string
 	migration                      string
@@ -102,6 +103,10 @@ func withReset(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&warmup, "warmup", false, "warmup relevant tables by parallel random reads")
 }
 
+func withDryRun(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only report what would be done")
+}
+
 func withBucket(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&bucket, "bucket", "", "reset given stage")
 }
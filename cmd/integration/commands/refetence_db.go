@@ -111,6 +111,27 @@ var cmdMdbxToMdbx = &cobra.Command{
 	},
 }
 
+var cmdCompact = &cobra.Command{
+	Use:   "compact",
+	Short: "replace '--chaindata' by its compacted copy. Node must be stopped. With '--dry-run' only reports fragmentation of tables, can run on live node",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common2.RootContext()
+		logger := debug.SetupCobra(cmd, "integration")
+		var err error
+		if dryRun {
+			_, err = backup.FragmentationReport(ctx, chaindata, kv.ChainDB, logger)
+		} else {
+			err = backup.Compact(ctx, chaindata, kv.ChainDB, backup.ReadAheadThreads, logger)
+		}
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
 var cmdFToMdbx = &cobra.Command{
 	Use:   "f_to_mdbx",
 	Short: "copy data from '--chaindata' to '--chaindata.to'",
@@ -151,6 +172,11 @@ func init() {
 
 	rootCmd.AddCommand(cmdMdbxToMdbx)
 
+	withDataDir(cmdCompact)
+	withDryRun(cmdCompact)
+
+	rootCmd.AddCommand(cmdCompact)
+
 	withToChaindata(cmdFToMdbx)
 	withFile(cmdFToMdbx)
 	withBucket(cmdFToMdbx)
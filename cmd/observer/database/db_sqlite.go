@@ -50,6 +50,12 @@ CREATE TABLE IF NOT EXISTS nodes (
     crawl_retry_time INTEGER
 );
 
+CREATE TABLE IF NOT EXISTS node_records (
+    id TEXT PRIMARY KEY,
+    enr TEXT NOT NULL,
+    updated INTEGER NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS handshake_errors (
     id TEXT NOT NULL,
     err TEXT NOT NULL,
@@ -197,6 +203,17 @@ UPDATE nodes SET handshake_retry_time = ? WHERE id IN (123)
 UPDATE nodes SET compat_fork = ?, compat_fork_updated = ? WHERE id = ?
 `
 
+	sqlUpdateENR = `
+INSERT INTO node_records(
+    id,
+    enr,
+    updated
+) VALUES (?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    enr = excluded.enr,
+    updated = excluded.updated
+`
+
 	sqlUpdateNeighborBucketKeys = `
 UPDATE nodes SET neighbor_keys = ? WHERE id = ?
 `
@@ -284,6 +301,15 @@ SELECT client_id FROM nodes
 WHERE (ping_try < ?)
     AND ((network_id = ?) OR (network_id IS NULL))
     AND ((compat_fork == TRUE) OR (compat_fork IS NULL))
+`
+
+	sqlEnumerateENRs = `
+SELECT nodes.id, node_records.enr, nodes.client_id FROM nodes
+JOIN node_records ON node_records.id = nodes.id
+WHERE (nodes.ping_try < ?)
+    AND (nodes.network_id = ?)
+    AND (nodes.compat_fork == TRUE)
+ORDER BY nodes.id
 `
 )
 
@@ -685,6 +711,16 @@ func (db *DBSQLite) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return nil
 }
 
+func (db *DBSQLite) UpdateENR(ctx context.Context, id NodeID, enr string) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpdateENR, id, enr, updated)
+	if err != nil {
+		return fmt.Errorf("UpdateENR failed to update a node: %w", err)
+	}
+	return nil
+}
+
 func (db *DBSQLite) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	keysStr := strings.Join(keys, ",")
 
@@ -920,6 +956,41 @@ func (db *DBSQLite) EnumerateClientIDs(
 	return nil
 }
 
+func (db *DBSQLite) EnumerateENRs(
+	ctx context.Context,
+	maxPingTries uint,
+	networkID uint,
+	enumFunc func(id NodeID, enr string, clientID *string),
+) error {
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateENRs, maxPingTries, networkID)
+	if err != nil {
+		return fmt.Errorf("EnumerateENRs failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var id string
+		var enr string
+		var clientID sql.NullString
+		err := cursor.Scan(&id, &enr, &clientID)
+		if err != nil {
+			return fmt.Errorf("EnumerateENRs failed to read data: %w", err)
+		}
+		if clientID.Valid {
+			enumFunc(NodeID(id), enr, &clientID.String)
+		} else {
+			enumFunc(NodeID(id), enr, nil)
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateENRs failed to iterate: %w", err)
+	}
+	return nil
+}
+
 func stringsToAny(strValues []NodeID) []interface{} {
 	values := make([]interface{}, 0, len(strValues))
 	for _, value := range strValues {
@@ -0,0 +1,205 @@
+package dnstree
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+)
+
+type BuildCommandFlags struct {
+	DataDir      string
+	Chain        string
+	MaxPingTries uint
+	Clients      []string
+	ForkHash     string
+
+	Domain  string
+	KeyFile string
+	Links   []string
+	Seq     uint
+	Limit   uint
+	OutDir  string
+}
+
+type VerifyCommandFlags struct {
+	TreeDir string
+	URL     string
+}
+
+type Command struct {
+	command       cobra.Command
+	buildCommand  cobra.Command
+	verifyCommand cobra.Command
+	buildFlags    BuildCommandFlags
+	verifyFlags   VerifyCommandFlags
+}
+
+func NewCommand() *Command {
+	command := cobra.Command{
+		Use:   "dnstree",
+		Short: "EIP-1459 DNS discovery tree tools",
+	}
+	buildCommand := cobra.Command{
+		Use:   "build",
+		Short: "Build and sign a DNS discovery tree from the crawler database",
+	}
+	verifyCommand := cobra.Command{
+		Use:   "verify",
+		Short: "Verify a DNS discovery tree directory or a published tree",
+	}
+
+	instance := Command{
+		command:       command,
+		buildCommand:  buildCommand,
+		verifyCommand: verifyCommand,
+	}
+	instance.withDatadir()
+	instance.withChain()
+	instance.withMaxPingTries()
+	instance.withClients()
+	instance.withForkHash()
+	instance.withDomain()
+	instance.withKeyFile()
+	instance.withLinks()
+	instance.withSeq()
+	instance.withLimit()
+	instance.withOutDir()
+	instance.withTreeDir()
+	instance.withURL()
+
+	instance.command.AddCommand(&instance.buildCommand)
+	instance.command.AddCommand(&instance.verifyCommand)
+
+	return &instance
+}
+
+func (command *Command) withDatadir() {
+	flag := utils.DataDirFlag
+	command.buildCommand.Flags().StringVar(&command.buildFlags.DataDir, flag.Name, flag.Value.String(), flag.Usage)
+	must(command.buildCommand.MarkFlagDirname(utils.DataDirFlag.Name))
+}
+
+func (command *Command) withChain() {
+	flag := utils.ChainFlag
+	command.buildCommand.Flags().StringVar(&command.buildFlags.Chain, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withMaxPingTries() {
+	flag := cli.UintFlag{
+		Name:  "max-ping-tries",
+		Usage: "A number of PING failures for a node to be considered dead",
+		Value: 3,
+	}
+	command.buildCommand.Flags().UintVar(&command.buildFlags.MaxPingTries, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withClients() {
+	flag := cli.StringSliceFlag{
+		Name:  "clients",
+		Usage: "Comma separated client ID prefixes to include (e.g. erigon,geth), all clients if empty",
+	}
+	command.buildCommand.Flags().StringSliceVar(&command.buildFlags.Clients, flag.Name, nil, flag.Usage)
+}
+
+func (command *Command) withForkHash() {
+	flag := cli.StringFlag{
+		Name:  "fork-hash",
+		Usage: "Include only nodes announcing this fork hash in the ENR 'eth' entry (e.g. 0xfc64ec04). By default all nodes with a fork ID compatible with the chain are included.",
+	}
+	command.buildCommand.Flags().StringVar(&command.buildFlags.ForkHash, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withDomain() {
+	flag := cli.StringFlag{
+		Name:  "domain",
+		Usage: "DNS domain name of the tree (e.g. nodes.example.org)",
+	}
+	command.buildCommand.Flags().StringVar(&command.buildFlags.Domain, flag.Name, flag.Value, flag.Usage)
+	must(command.buildCommand.MarkFlagRequired(flag.Name))
+}
+
+func (command *Command) withKeyFile() {
+	flag := cli.StringFlag{
+		Name:  "key",
+		Usage: "Path to the hex encoded private key file to sign the tree",
+	}
+	command.buildCommand.Flags().StringVar(&command.buildFlags.KeyFile, flag.Name, flag.Value, flag.Usage)
+	must(command.buildCommand.MarkFlagRequired(flag.Name))
+	must(command.buildCommand.MarkFlagFilename(flag.Name))
+}
+
+func (command *Command) withLinks() {
+	flag := cli.StringSliceFlag{
+		Name:  "links",
+		Usage: "Comma separated enrtree:// URLs of other trees to link",
+	}
+	command.buildCommand.Flags().StringSliceVar(&command.buildFlags.Links, flag.Name, nil, flag.Usage)
+}
+
+func (command *Command) withSeq() {
+	flag := cli.UintFlag{
+		Name:  "seq",
+		Usage: "Sequence number of the tree. If 0, the sequence number of the tree in the output directory is incremented.",
+	}
+	command.buildCommand.Flags().UintVar(&command.buildFlags.Seq, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withLimit() {
+	flag := cli.UintFlag{
+		Name:  "limit",
+		Usage: "Maximum number of nodes in the tree, 0 means no limit",
+	}
+	command.buildCommand.Flags().UintVar(&command.buildFlags.Limit, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withOutDir() {
+	flag := cli.StringFlag{
+		Name:  "out",
+		Usage: "Output directory for the tree files",
+	}
+	command.buildCommand.Flags().StringVar(&command.buildFlags.OutDir, flag.Name, flag.Value, flag.Usage)
+	must(command.buildCommand.MarkFlagRequired(flag.Name))
+	must(command.buildCommand.MarkFlagDirname(flag.Name))
+}
+
+func (command *Command) withTreeDir() {
+	flag := cli.StringFlag{
+		Name:  "dir",
+		Usage: "Tree directory created by the build command",
+	}
+	command.verifyCommand.Flags().StringVar(&command.verifyFlags.TreeDir, flag.Name, flag.Value, flag.Usage)
+	must(command.verifyCommand.MarkFlagDirname(flag.Name))
+}
+
+func (command *Command) withURL() {
+	flag := cli.StringFlag{
+		Name:  "url",
+		Usage: "enrtree:// URL of the tree. Without 'dir' the tree is fetched from DNS, with 'dir' the directory is checked against the URL public key.",
+	}
+	command.verifyCommand.Flags().StringVar(&command.verifyFlags.URL, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) RawCommand() *cobra.Command {
+	return &command.command
+}
+
+func (command *Command) OnBuild(runFunc func(ctx context.Context, flags BuildCommandFlags) error) {
+	command.buildCommand.RunE = func(cmd *cobra.Command, args []string) error {
+		return runFunc(cmd.Context(), command.buildFlags)
+	}
+}
+
+func (command *Command) OnVerify(runFunc func(ctx context.Context, flags VerifyCommandFlags) error) {
+	command.verifyCommand.RunE = func(cmd *cobra.Command, args []string) error {
+		return runFunc(cmd.Context(), command.verifyFlags)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
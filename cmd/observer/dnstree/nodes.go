@@ -0,0 +1,89 @@
+package dnstree
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/log/v3"
+)
+
+type NodesFilter struct {
+	MaxPingTries uint
+	NetworkID    uint
+	// ClientIDPrefixes are matched case-insensitively, empty means any client.
+	ClientIDPrefixes []string
+	// ForkHash is matched against the ENR "eth" entry, nil means any fork hash compatible with the chain.
+	ForkHash *[4]byte
+	// Limit of the nodes count, 0 means no limit.
+	Limit uint
+}
+
+func ParseForkHash(value string) (*[4]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	bytes, err := hexutil.Decode(value)
+	if (err != nil) || (len(bytes) != 4) {
+		return nil, fmt.Errorf("invalid fork hash '%s', expected 4 hex bytes", value)
+	}
+	var hash [4]byte
+	copy(hash[:], bytes)
+	return &hash, nil
+}
+
+// SelectNodes returns the records of live nodes which passed the crawler fork ID compatibility check
+// and match the filter.
+func SelectNodes(ctx context.Context, db database.DB, filter NodesFilter, logger log.Logger) ([]*enode.Node, error) {
+	var nodes []*enode.Node
+	err := db.EnumerateENRs(ctx, filter.MaxPingTries, filter.NetworkID, func(id database.NodeID, enr string, clientID *string) {
+		if (filter.Limit > 0) && (uint(len(nodes)) >= filter.Limit) {
+			return
+		}
+		if !isClientIDMatching(clientID, filter.ClientIDPrefixes) {
+			return
+		}
+
+		node, err := enode.Parse(enode.ValidSchemes, enr)
+		if err != nil {
+			logger.Debug("Skipping a node with an invalid ENR", "id", id, "err", err)
+			return
+		}
+		if (node.IP() == nil) || (node.TCP() == 0) {
+			return
+		}
+
+		if filter.ForkHash != nil {
+			forkID, err := eth.LoadENRForkID(node.Record())
+			if (err != nil) || (forkID == nil) || (forkID.Hash != *filter.ForkHash) {
+				return
+			}
+		}
+
+		nodes = append(nodes, node)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func isClientIDMatching(clientID *string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	if clientID == nil {
+		return false
+	}
+	id := strings.ToLower(*clientID)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(id, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package dnstree
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/observer/node_utils"
+	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/enr"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestNode(t *testing.T, port int, genesisHash common.Hash) *enode.Node {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	var record enr.Record
+	record.Set(enr.IPv4(net.IPv4(10, 0, 1, 16)))
+	record.Set(enr.TCP(port))
+	record.Set(enr.UDP(port))
+	record.Set(eth.CurrentENREntryFromForks(nil, nil, genesisHash, 0, 0))
+	require.Nil(t, enode.SignV4(&record, key))
+
+	node, err := enode.New(enode.ValidSchemes, &record)
+	require.Nil(t, err)
+	return node
+}
+
+func TestSelectNodes(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	genesis1 := common.Hash{1}
+	genesis2 := common.Hash{2}
+	nodes := []*enode.Node{
+		makeTestNode(t, 30301, genesis1),
+		makeTestNode(t, 30302, genesis1),
+		makeTestNode(t, 30303, genesis2),
+		makeTestNode(t, 30304, genesis1),
+	}
+	clientIDs := []string{"erigon/v2.48.0", "Geth/v1.12.0", "erigon/v2.48.1", "besu/v23.4.0"}
+	for i, node := range nodes {
+		id, err := node_utils.NodeID(node)
+		require.Nil(t, err)
+		require.Nil(t, db.UpsertNodeAddr(ctx, id, node_utils.MakeNodeAddr(node)))
+		require.Nil(t, db.UpdateENR(ctx, id, node.String()))
+		require.Nil(t, db.UpdateClientID(ctx, id, clientIDs[i]))
+		require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+		// the last node fails the crawler fork check
+		require.Nil(t, db.UpdateForkCompatibility(ctx, id, i != 3))
+	}
+
+	selectIDs := func(filter NodesFilter) []enode.ID {
+		selected, err := SelectNodes(ctx, db, filter, log.Root())
+		require.Nil(t, err)
+		var ids []enode.ID
+		for _, node := range selected {
+			ids = append(ids, node.ID())
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[1].ID(), nodes[2].ID()}, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 1}))
+	assert.Empty(t, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 5}))
+	assert.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[1].ID(), nodes[2].ID()}, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 1, ClientIDPrefixes: []string{"Erigon", "geth"}}))
+	assert.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[2].ID()}, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 1, ClientIDPrefixes: []string{"erigon"}}))
+
+	forkHash := forkid.NewIDFromForks(nil, nil, genesis1, 0, 0).Hash
+	assert.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[1].ID()}, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 1, ForkHash: &forkHash}))
+	assert.Len(t, selectIDs(NodesFilter{MaxPingTries: 3, NetworkID: 1, Limit: 2}), 2)
+
+	parsedHash, err := ParseForkHash(hexutility.Encode(forkHash[:]))
+	require.Nil(t, err)
+	assert.Equal(t, forkHash, *parsedHash)
+	_, err = ParseForkHash("0x0102")
+	assert.NotNil(t, err)
+}
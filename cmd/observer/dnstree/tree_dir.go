@@ -0,0 +1,208 @@
+package dnstree
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ledgerwatch/erigon/p2p/dnsdisc"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+// The tree directory layout:
+//
+//	enrtree-info.json - tree URL, sequence number, signature and links
+//	nodes.json        - node records
+//	TXT.json          - DNS TXT records of the tree: name -> value
+//	TXT.zone          - the same records in the zone file format
+const (
+	treeInfoFileName  = "enrtree-info.json"
+	treeNodesFileName = "nodes.json"
+	treeTXTFileName   = "TXT.json"
+	treeZoneFileName  = "TXT.zone"
+)
+
+const (
+	// rootTTL is short, because the root record changes with every tree update.
+	rootTTL = 30 * 60
+	// entries other than the root are content-addressed and never change
+	entryTTL = 28 * 24 * 60 * 60
+	// maximum length of a single character-string of a TXT record
+	txtStringMaxLen = 255
+)
+
+type TreeInfo struct {
+	URL       string   `json:"url"`
+	Seq       uint     `json:"seq"`
+	Signature string   `json:"signature"`
+	Links     []string `json:"links,omitempty"`
+}
+
+// MakeSignedTree creates a tree of the given nodes and links, signed for the domain.
+func MakeSignedTree(seq uint, nodes []*enode.Node, links []string, key *ecdsa.PrivateKey, domain string) (*dnsdisc.Tree, *TreeInfo, error) {
+	tree, err := dnsdisc.MakeTree(seq, nodes, links)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make a tree: %w", err)
+	}
+	url, err := tree.Sign(key, domain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign a tree: %w", err)
+	}
+	info := TreeInfo{
+		URL:       url,
+		Seq:       tree.Seq(),
+		Signature: tree.Signature(),
+		Links:     tree.Links(),
+	}
+	return tree, &info, nil
+}
+
+func WriteTreeDir(dir string, tree *dnsdisc.Tree, info *TreeInfo) error {
+	domain, _, err := dnsdisc.ParseURL(info.URL)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	records := tree.ToTXT(domain)
+	if err := writeJSONFile(filepath.Join(dir, treeInfoFileName), info); err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(dir, treeNodesFileName), tree.Nodes()); err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(dir, treeTXTFileName), records); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, treeZoneFileName), makeZoneFile(domain, info.URL, records), 0644) // nolint: gosec
+}
+
+// ReadTreeInfo reads the tree info from the directory, it returns nil if the directory has no tree.
+func ReadTreeInfo(dir string) (*TreeInfo, error) {
+	var info TreeInfo
+	err := readJSONFile(filepath.Join(dir, treeInfoFileName), &info)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// VerifyTreeDir checks that the tree in the directory is signed by the key of the given URL
+// (or the URL saved in the directory if empty), and that the DNS records match the tree.
+func VerifyTreeDir(dir string, url string) (*dnsdisc.Tree, *TreeInfo, error) {
+	info, err := ReadTreeInfo(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info == nil {
+		return nil, nil, fmt.Errorf("%s not found in %s", treeInfoFileName, dir)
+	}
+	if url == "" {
+		url = info.URL
+	}
+	domain, pubkey, err := dnsdisc.ParseURL(url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nodes []*enode.Node
+	if err := readJSONFile(filepath.Join(dir, treeNodesFileName), &nodes); err != nil {
+		return nil, nil, err
+	}
+	tree, err := dnsdisc.MakeTree(info.Seq, nodes, info.Links)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make a tree: %w", err)
+	}
+	if err := tree.SetSignature(pubkey, info.Signature); err != nil {
+		return nil, nil, fmt.Errorf("tree signature doesn't match %s: %w", url, err)
+	}
+
+	records := tree.ToTXT(domain)
+	var savedRecords map[string]string
+	if err := readJSONFile(filepath.Join(dir, treeTXTFileName), &savedRecords); err != nil {
+		return nil, nil, err
+	}
+	if !reflect.DeepEqual(records, savedRecords) {
+		return nil, nil, fmt.Errorf("%s doesn't match the tree", treeTXTFileName)
+	}
+	zone, err := os.ReadFile(filepath.Join(dir, treeZoneFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(zone, makeZoneFile(domain, url, records)) {
+		return nil, nil, fmt.Errorf("%s doesn't match the tree", treeZoneFileName)
+	}
+	return tree, info, nil
+}
+
+// VerifyTreeURL downloads the whole tree published at the URL, checking its signature and all entries.
+// If resolver is nil, the system DNS is used.
+func VerifyTreeURL(url string, resolver dnsdisc.Resolver) (*dnsdisc.Tree, error) {
+	client := dnsdisc.NewClient(dnsdisc.Config{Resolver: resolver})
+	return client.SyncTree(url)
+}
+
+func makeZoneFile(domain string, url string, records map[string]string) []byte {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		if name != domain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{domain}, names...)
+
+	var zone strings.Builder
+	fmt.Fprintf(&zone, "; %s\n", url)
+	for _, name := range names {
+		ttl := entryTTL
+		if name == domain {
+			ttl = rootTTL
+		}
+		fmt.Fprintf(&zone, "%s.\t%d\tIN\tTXT\t%s\n", name, ttl, zoneTXTValue(records[name]))
+	}
+	return []byte(zone.String())
+}
+
+// zoneTXTValue splits the value into quoted character-strings of the allowed length.
+// Record values (base32/base64 and URLs) contain no characters requiring escaping.
+func zoneTXTValue(value string) string {
+	var parts []string
+	for len(value) > txtStringMaxLen {
+		parts = append(parts, `"`+value[:txtStringMaxLen]+`"`)
+		value = value[txtStringMaxLen:]
+	}
+	parts = append(parts, `"`+value+`"`)
+	return strings.Join(parts, " ")
+}
+
+func writeJSONFile(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644) // nolint: gosec
+}
+
+func readJSONFile(path string, value interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package dnstree
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapResolver map[string]string
+
+func (resolver mapResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if record, ok := resolver[name]; ok {
+		return []string{record}, nil
+	}
+	return nil, fmt.Errorf("%s not found", name)
+}
+
+func TestTreeDir(t *testing.T) {
+	const domain = "nodes.example.org"
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	var nodes []*enode.Node
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, makeTestNode(t, 30300+i, common.Hash{1}))
+	}
+
+	dir := filepath.Join(t.TempDir(), "tree")
+	info, err := ReadTreeInfo(dir)
+	require.Nil(t, err)
+	assert.Nil(t, info)
+
+	tree, info, err := MakeSignedTree(3, nodes, nil, key, domain)
+	require.Nil(t, err)
+	require.Nil(t, WriteTreeDir(dir, tree, info))
+
+	savedInfo, err := ReadTreeInfo(dir)
+	require.Nil(t, err)
+	assert.Equal(t, info, savedInfo)
+
+	verifiedTree, verifiedInfo, err := VerifyTreeDir(dir, "")
+	require.Nil(t, err)
+	assert.Equal(t, uint(3), verifiedTree.Seq())
+	assert.Equal(t, info.URL, verifiedInfo.URL)
+	assert.ElementsMatch(t, nodes, verifiedTree.Nodes())
+
+	// the tree is published
+	remoteTree, err := VerifyTreeURL(info.URL, mapResolver(tree.ToTXT(domain)))
+	require.Nil(t, err)
+	assert.Equal(t, tree.ToTXT(domain), remoteTree.ToTXT(domain))
+
+	// the tree is signed by another key
+	otherTree, otherInfo, err := MakeSignedTree(3, nodes, nil, otherKey, domain)
+	require.Nil(t, err)
+	otherURL := otherInfo.URL
+	_, _, err = VerifyTreeDir(dir, otherURL)
+	assert.NotNil(t, err)
+	_, err = VerifyTreeURL(otherURL, mapResolver(tree.ToTXT(domain)))
+	assert.NotNil(t, err)
+	_, err = VerifyTreeURL(info.URL, mapResolver(otherTree.ToTXT(domain)))
+	assert.NotNil(t, err)
+
+	// the records are modified
+	zonePath := filepath.Join(dir, treeZoneFileName)
+	zone, err := os.ReadFile(zonePath)
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(zonePath, zone[:len(zone)-10], 0600))
+	_, _, err = VerifyTreeDir(dir, "")
+	assert.NotNil(t, err)
+}
+
+func TestZoneTXTValue(t *testing.T) {
+	assert.Equal(t, `"abc"`, zoneTXTValue("abc"))
+	value := strings.Repeat("a", txtStringMaxLen) + "bc"
+	assert.Equal(t, `"`+strings.Repeat("a", txtStringMaxLen)+`" "bc"`, zoneTXTValue(value))
+}
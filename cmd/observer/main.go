@@ -8,9 +8,11 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cmd/observer/database"
+	"github.com/ledgerwatch/erigon/cmd/observer/dnstree"
 	"github.com/ledgerwatch/erigon/cmd/observer/observer"
 	"github.com/ledgerwatch/erigon/cmd/observer/reports"
 	"github.com/ledgerwatch/erigon/cmd/utils"
+	"github.com/ledgerwatch/erigon/crypto"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/log/v3"
 )
@@ -103,6 +105,81 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 	return nil
 }
 
+func buildDNSTreeWithFlags(ctx context.Context, flags dnstree.BuildCommandFlags) error {
+	key, err := crypto.LoadECDSA(flags.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the tree signing key: %w", err)
+	}
+	forkHash, err := dnstree.ParseForkHash(flags.ForkHash)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewDBSQLite(filepath.Join(flags.DataDir, "observer.sqlite"))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	filter := dnstree.NodesFilter{
+		MaxPingTries:     flags.MaxPingTries,
+		NetworkID:        uint(params.NetworkIDByChainName(flags.Chain)),
+		ClientIDPrefixes: flags.Clients,
+		ForkHash:         forkHash,
+		Limit:            flags.Limit,
+	}
+	nodes, err := dnstree.SelectNodes(ctx, db, filter, log.Root())
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return errors.New("no nodes matching the filter were found")
+	}
+
+	seq := flags.Seq
+	if seq == 0 {
+		prevInfo, err := dnstree.ReadTreeInfo(flags.OutDir)
+		if err != nil {
+			return err
+		}
+		seq = 1
+		if prevInfo != nil {
+			seq = prevInfo.Seq + 1
+		}
+	}
+
+	tree, info, err := dnstree.MakeSignedTree(seq, nodes, flags.Links, key, flags.Domain)
+	if err != nil {
+		return err
+	}
+	if err := dnstree.WriteTreeDir(flags.OutDir, tree, info); err != nil {
+		return err
+	}
+	fmt.Printf("Created tree %s (seq %d, %d nodes, %d links) in %s\n", info.URL, info.Seq, len(nodes), len(info.Links), flags.OutDir)
+	return nil
+}
+
+func verifyDNSTreeWithFlags(ctx context.Context, flags dnstree.VerifyCommandFlags) error {
+	if flags.TreeDir != "" {
+		tree, info, err := dnstree.VerifyTreeDir(flags.TreeDir, flags.URL)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Tree %s (seq %d, %d nodes, %d links) is valid\n", info.URL, tree.Seq(), len(tree.Nodes()), len(tree.Links()))
+		return nil
+	}
+	if flags.URL == "" {
+		return errors.New("either 'dir' or 'url' is required")
+	}
+
+	tree, err := dnstree.VerifyTreeURL(flags.URL, nil)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Tree %s (seq %d, %d nodes, %d links) is valid\n", flags.URL, tree.Seq(), len(tree.Nodes()), len(tree.Links()))
+	return nil
+}
+
 func main() {
 	ctx, cancel := common.RootContext()
 	defer cancel()
@@ -113,6 +190,11 @@ func main() {
 	reportCommand.OnRun(reportWithFlags)
 	command.AddSubCommand(reportCommand.RawCommand())
 
+	dnsTreeCommand := dnstree.NewCommand()
+	dnsTreeCommand.OnBuild(buildDNSTreeWithFlags)
+	dnsTreeCommand.OnVerify(verifyDNSTreeWithFlags)
+	command.AddSubCommand(dnsTreeCommand.RawCommand())
+
 	err := command.ExecuteContext(ctx, mainWithFlags)
 	if (err != nil) && !errors.Is(err, context.Canceled) {
 		utils.Fatalf("%v", err)
@@ -466,6 +466,13 @@ func (crawler *Crawler) saveInterrogationResult(
 		}
 	}
 
+	if (result != nil) && (result.ENR != nil) {
+		dbErr := crawler.db.UpdateENR(ctx, id, result.ENR.String())
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if clientID != nil {
 		dbErr := crawler.db.UpdateClientID(ctx, id, *clientID)
 		if dbErr != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/rpc/rpccfg"
 )
 
@@ -81,5 +82,7 @@ type HttpCfg struct {
 	// Ots API
 	OtsMaxPageSize uint64
 
+	LogIndexes []logindex.Indexer // user-defined log indices, queried by erigon_getLogsByIndex
+
 	RPCSlowLogThreshold time.Duration
 }
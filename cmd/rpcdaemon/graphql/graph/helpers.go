@@ -1,18 +1,27 @@
 package graph
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	hexutil2 "github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/holiman/uint256"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
 
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/graphql/graph/model"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+	"github.com/ledgerwatch/erigon/turbo/jsonrpc"
 )
 
 func convertDataToStringP(abstractMap map[string]interface{}, field string) *string {
@@ -134,3 +143,375 @@ func convertStrHexToDec(hexString *string) *string {
 
 	return &result
 }
+
+// stringOrZero dereferences a converted quantity, converters return nil for zero valued big integers.
+func stringOrZero(s *string) string {
+	if s == nil {
+		return "0x0"
+	}
+	return *s
+}
+
+// parseBigInt parses a BigInt scalar, which is either a 0x prefixed hexadecimal or a decimal number.
+func parseBigInt(s string) (*hexutil2.Big, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		b, err := hexutil2.DecodeBig(s)
+		return (*hexutil2.Big)(b), err
+	}
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid big integer: %s", s)
+	}
+	return (*hexutil2.Big)(b), nil
+}
+
+// parseBlockNumber parses a BlockNum scalar, which is either a decimal or a 0x prefixed hexadecimal number.
+func parseBlockNumber(number string) (rpc.BlockNumber, error) {
+	if bNum, err := strconv.ParseUint(number, 10, 64); err == nil {
+		return rpc.BlockNumber(bNum), nil
+	}
+	bNum, err := hexutil2.DecodeUint64(number)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number: %s", number)
+	}
+	return rpc.BlockNumber(bNum), nil
+}
+
+// accountAt returns the account at the given block, or at the latest block if no block is given.
+func accountAt(address string, block *uint64) *model.Account {
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if block != nil {
+		blockNrOrHash = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*block))
+	}
+	return &model.Account{Address: address, BlockNrOrHash: blockNrOrHash}
+}
+
+func convertCallData(data model.CallData) (ethapi.CallArgs, error) {
+	var args ethapi.CallArgs
+	if data.From != nil {
+		from := libcommon.HexToAddress(*data.From)
+		args.From = &from
+	}
+	if data.To != nil {
+		to := libcommon.HexToAddress(*data.To)
+		args.To = &to
+	}
+	if data.Gas != nil {
+		gas := hexutil2.Uint64(*data.Gas)
+		args.Gas = &gas
+	}
+	var err error
+	for _, field := range []struct {
+		value *string
+		dst   **hexutil2.Big
+	}{
+		{data.GasPrice, &args.GasPrice},
+		{data.MaxFeePerGas, &args.MaxFeePerGas},
+		{data.MaxPriorityFeePerGas, &args.MaxPriorityFeePerGas},
+		{data.Value, &args.Value},
+	} {
+		if field.value == nil {
+			continue
+		}
+		if *field.dst, err = parseBigInt(*field.value); err != nil {
+			return args, err
+		}
+	}
+	if data.Data != nil {
+		input, err := hexutil2.Decode(*data.Data)
+		if err != nil {
+			return args, err
+		}
+		args.Data = (*hexutility.Bytes)(&input)
+	}
+	return args, nil
+}
+
+func convertFilterAddresses(addresses []string) []libcommon.Address {
+	result := make([]libcommon.Address, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, libcommon.HexToAddress(address))
+	}
+	return result
+}
+
+func convertFilterTopics(topics [][]string) [][]libcommon.Hash {
+	result := make([][]libcommon.Hash, 0, len(topics))
+	for _, alternatives := range topics {
+		hashes := make([]libcommon.Hash, 0, len(alternatives))
+		for _, topic := range alternatives {
+			hashes = append(hashes, libcommon.HexToHash(topic))
+		}
+		result = append(result, hashes)
+	}
+	return result
+}
+
+func convertAccessList(accessList types2.AccessList) []*model.AccessTuple {
+	result := make([]*model.AccessTuple, 0, len(accessList))
+	for _, tuple := range accessList {
+		storageKeys := make([]string, 0, len(tuple.StorageKeys))
+		for _, key := range tuple.StorageKeys {
+			storageKeys = append(storageKeys, key.String())
+		}
+		result = append(result, &model.AccessTuple{
+			Address:     strings.ToLower(tuple.Address.String()),
+			StorageKeys: storageKeys,
+		})
+	}
+	return result
+}
+
+// convertLog converts a log, its transaction only carries the hash and is loaded by the Log.transaction resolver.
+func convertLog(rlog *types.Log) *model.Log {
+	tlog := &model.Log{
+		Index:       int(rlog.Index),
+		Data:        "0x" + hex.EncodeToString(rlog.Data),
+		Account:     &model.Account{Address: strings.ToLower(rlog.Address.String())},
+		Topics:      []string{},
+		Transaction: &model.Transaction{Hash: rlog.TxHash.String()},
+	}
+	for _, rtopic := range rlog.Topics {
+		tlog.Topics = append(tlog.Topics, rtopic.String())
+	}
+	return tlog
+}
+
+// convertBlock converts the block details returned by GraphQLAPI.GetBlockDetails. The transactions and ommers
+// of the block are fully populated, the parent only carries its hash.
+func convertBlock(res map[string]interface{}) *model.Block {
+	blk, ok := res["block"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	block := &model.Block{}
+	block.Difficulty = stringOrZero(convertDataToStringP(blk, "difficulty"))
+	block.ExtraData = *convertDataToStringP(blk, "extraData")
+	block.GasLimit = *convertDataToUint64P(blk, "gasLimit")
+	block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
+	if hash := convertDataToStringP(blk, "hash"); hash != nil {
+		block.Hash = *hash
+	}
+	block.Miner = &model.Account{}
+	if address := convertDataToStringP(blk, "miner"); address != nil {
+		block.Miner.Address = strings.ToLower(*address)
+	}
+	if mixHash := convertDataToStringP(blk, "mixHash"); mixHash != nil {
+		block.MixHash = *mixHash
+	}
+	if blockNonce := convertDataToStringP(blk, "nonce"); blockNonce != nil {
+		block.Nonce = *blockNonce
+	}
+	block.Number = *convertDataToUint64P(blk, "number")
+	block.Parent = &model.Block{Hash: *convertDataToStringP(blk, "parentHash")}
+	block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
+	block.StateRoot = *convertDataToStringP(blk, "stateRoot")
+	block.Timestamp = *convertDataToStringP(blk, "timestamp")
+	block.TransactionCount = convertDataToIntP(blk, "transactionCount")
+	block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
+	block.TotalDifficulty = stringOrZero(convertDataToStringP(blk, "totalDifficulty"))
+	if _, ok := blk["baseFeePerGas"]; ok {
+		block.BaseFeePerGas = convertDataToStringP(blk, "baseFeePerGas")
+	}
+	if _, ok := blk["nextBaseFeePerGas"]; ok {
+		block.NextBaseFeePerGas = convertDataToStringP(blk, "nextBaseFeePerGas")
+	}
+	block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
+	block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
+	block.RawHeader = *convertDataToStringP(blk, "rawHeader")
+	block.Raw = *convertDataToStringP(blk, "raw")
+
+	block.Ommers = []*model.Block{}
+	ommers, _ := res["ommers"].([]map[string]interface{})
+	for _, ommer := range ommers {
+		block.Ommers = append(block.Ommers, convertOmmer(ommer))
+	}
+	ommerCount := len(block.Ommers)
+	block.OmmerCount = &ommerCount
+
+	block.Transactions = []*model.Transaction{}
+	receipts, _ := res["receipts"].([]map[string]interface{})
+	for _, transReceipt := range receipts {
+		trans := convertReceipt(transReceipt)
+		trans.Block = block
+		block.Transactions = append(block.Transactions, trans)
+	}
+
+	return block
+}
+
+func convertOmmer(header map[string]interface{}) *model.Block {
+	ommer := &model.Block{
+		Number:           *convertDataToUint64P(header, "number"),
+		Hash:             *convertDataToStringP(header, "hash"),
+		Parent:           &model.Block{Hash: *convertDataToStringP(header, "parentHash")},
+		Nonce:            *convertDataToStringP(header, "nonce"),
+		TransactionsRoot: *convertDataToStringP(header, "transactionsRoot"),
+		StateRoot:        *convertDataToStringP(header, "stateRoot"),
+		ReceiptsRoot:     *convertDataToStringP(header, "receiptsRoot"),
+		Miner:            &model.Account{Address: strings.ToLower(*convertDataToStringP(header, "miner"))},
+		ExtraData:        *convertDataToStringP(header, "extraData"),
+		GasLimit:         *convertDataToUint64P(header, "gasLimit"),
+		GasUsed:          *convertDataToUint64P(header, "gasUsed"),
+		Timestamp:        *convertDataToStringP(header, "timestamp"),
+		LogsBloom:        "0x" + *convertDataToStringP(header, "logsBloom"),
+		MixHash:          *convertDataToStringP(header, "mixHash"),
+		Difficulty:       stringOrZero(convertDataToStringP(header, "difficulty")),
+		OmmerHash:        *convertDataToStringP(header, "sha3Uncles"),
+	}
+	if _, ok := header["baseFeePerGas"]; ok {
+		ommer.BaseFeePerGas = convertDataToStringP(header, "baseFeePerGas")
+	}
+	return ommer
+}
+
+// convertReceipt converts a transaction of the block details returned by GraphQLAPI.GetBlockDetails, the
+// transaction fields are merged with its receipt.
+func convertReceipt(transReceipt map[string]interface{}) *model.Transaction {
+	trans := &model.Transaction{}
+	trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
+	trans.InputData = *convertDataToStringP(transReceipt, "data")
+	trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
+	trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
+	trans.Gas = *convertDataToUint64P(transReceipt, "gas")
+	trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
+	trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
+	trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
+	if transNonce := convertDataToStringP(transReceipt, "nonce"); transNonce != nil {
+		trans.Nonce = *transNonce
+	}
+	trans.Status = convertDataToUint64P(transReceipt, "status")
+	trans.Type = convertDataToIntP(transReceipt, "type")
+	trans.Value = stringOrZero(convertDataToStringP(transReceipt, "value"))
+	if _, ok := transReceipt["maxFeePerGas"]; ok {
+		trans.MaxFeePerGas = convertDataToStringP(transReceipt, "maxFeePerGas")
+		trans.MaxPriorityFeePerGas = convertDataToStringP(transReceipt, "maxPriorityFeePerGas")
+	}
+	if _, ok := transReceipt["effectiveTip"]; ok {
+		effectiveTip := stringOrZero(convertDataToStringP(transReceipt, "effectiveTip"))
+		trans.EffectiveTip = &effectiveTip
+	}
+	trans.V = stringOrZero(convertDataToStringP(transReceipt, "v"))
+	trans.R = stringOrZero(convertDataToStringP(transReceipt, "r"))
+	trans.S = stringOrZero(convertDataToStringP(transReceipt, "s"))
+	if accessList, ok := transReceipt["accessList"].(types2.AccessList); ok && len(accessList) > 0 {
+		trans.AccessList = convertAccessList(accessList)
+	}
+	trans.Raw = *convertDataToStringP(transReceipt, "raw")
+	trans.RawReceipt = *convertDataToStringP(transReceipt, "rawReceipt")
+
+	trans.Logs = make([]*model.Log, 0)
+	for _, rlog := range transReceipt["logs"].(types.Logs) {
+		tlog := convertLog(rlog)
+		tlog.Transaction = trans
+		trans.Logs = append(trans.Logs, tlog)
+	}
+
+	trans.From = &model.Account{}
+	trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+
+	// To address could be nil in case of contract creation
+	if address := convertDataToStringP(transReceipt, "to"); address != nil {
+		trans.To = &model.Account{Address: strings.ToLower(*address)}
+	}
+	if contractAddress, ok := transReceipt["contractAddress"].(libcommon.Address); ok {
+		trans.CreatedContract = &model.Account{Address: strings.ToLower(contractAddress.String())}
+	}
+
+	return trans
+}
+
+// convertRPCTransaction converts a transaction that is not part of a block, receipt fields are left empty.
+func convertRPCTransaction(txn *jsonrpc.RPCTransaction) *model.Transaction {
+	trans := &model.Transaction{
+		Hash:      txn.Hash.String(),
+		Nonce:     txn.Nonce.String(),
+		From:      &model.Account{Address: strings.ToLower(txn.From.String())},
+		Gas:       uint64(txn.Gas),
+		InputData: txn.Input.String(),
+		Logs:      []*model.Log{},
+	}
+	if txn.To != nil {
+		trans.To = &model.Account{Address: strings.ToLower(txn.To.String())}
+	}
+	if txn.Value != nil {
+		trans.Value = txn.Value.String()
+	}
+	if txn.GasPrice != nil {
+		trans.GasPrice = txn.GasPrice.String()
+	}
+	if txn.FeeCap != nil {
+		maxFeePerGas := txn.FeeCap.String()
+		trans.MaxFeePerGas = &maxFeePerGas
+		if txn.GasPrice == nil {
+			trans.GasPrice = maxFeePerGas
+		}
+	}
+	if txn.Tip != nil {
+		maxPriorityFeePerGas := txn.Tip.String()
+		trans.MaxPriorityFeePerGas = &maxPriorityFeePerGas
+	}
+	if txn.V != nil {
+		trans.V, trans.R, trans.S = txn.V.String(), txn.R.String(), txn.S.String()
+	}
+	txType := int(txn.Type)
+	trans.Type = &txType
+	if txn.Accesses != nil && len(*txn.Accesses) > 0 {
+		trans.AccessList = convertAccessList(*txn.Accesses)
+	}
+	return trans
+}
+
+func (r *Resolver) blockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*model.Block, error) {
+	res, err := r.GraphQLAPI.GetBlockDetails(ctx, blockNrOrHash)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return convertBlock(res), ctx.Err()
+}
+
+func (r *Resolver) blockByHash(ctx context.Context, hash string) (*model.Block, error) {
+	return r.blockByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(libcommon.HexToHash(hash), false))
+}
+
+func (r *Resolver) call(ctx context.Context, data model.CallData, blockNrOrHash rpc.BlockNumberOrHash) (*model.CallResult, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.GraphQLAPI.Call(ctx, args, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	status := uint64(1)
+	if result.Failed() {
+		status = 0
+	}
+	return &model.CallResult{
+		Data:    hexutility.Bytes(result.ReturnData).String(),
+		GasUsed: result.UsedGas,
+		Status:  status,
+	}, nil
+}
+
+func (r *Resolver) estimateGas(ctx context.Context, data model.CallData, blockNrOrHash rpc.BlockNumberOrHash) (uint64, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := r.GraphQLAPI.EstimateGas(ctx, args, blockNrOrHash)
+	return uint64(gas), err
+}
+
+func (r *Resolver) logs(ctx context.Context, crit filters.FilterCriteria) ([]*model.Log, error) {
+	logs, err := r.GraphQLAPI.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Log, 0, len(logs))
+	for _, rlog := range logs {
+		result = append(result, convertLog(rlog))
+	}
+	return result, nil
+}
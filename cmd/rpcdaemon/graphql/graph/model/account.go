@@ -0,0 +1,11 @@
+package model
+
+import "github.com/ledgerwatch/erigon/rpc"
+
+// Account is an Ethereum account at a particular block. Its state is only read when the
+// balance, transactionCount, code or storage fields are requested.
+type Account struct {
+	Address string `json:"address"`
+	// BlockNrOrHash is the block at whose state the account is read.
+	BlockNrOrHash rpc.BlockNumberOrHash `json:"-"`
+}
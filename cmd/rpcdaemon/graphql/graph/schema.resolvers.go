@@ -6,157 +6,167 @@ package graph
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
-	"strings"
 
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/graphql/graph/model"
-	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/filters"
 	"github.com/ledgerwatch/erigon/rpc"
 )
 
-// SendRawTransaction is the resolver for the sendRawTransaction field.
-func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
-	panic(fmt.Errorf("not implemented: SendRawTransaction - sendRawTransaction"))
+// Balance is the resolver for the balance field.
+func (r *accountResolver) Balance(ctx context.Context, obj *model.Account) (string, error) {
+	balance, err := r.GraphQLAPI.GetBalance(ctx, libcommon.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	return balance.String(), nil
 }
 
-// Block is the resolver for the block field.
-func (r *queryResolver) Block(ctx context.Context, number *string, hash *string) (*model.Block, error) {
-	var blockNumber rpc.BlockNumber
+// TransactionCount is the resolver for the transactionCount field.
+func (r *accountResolver) TransactionCount(ctx context.Context, obj *model.Account) (uint64, error) {
+	nonce, err := r.GraphQLAPI.GetTransactionCount(ctx, libcommon.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*nonce), nil
+}
 
-	if number != nil {
-		// Block number is not null, test for a positive long integer
-		bNum, err := strconv.ParseUint(*number, 10, 64)
-		if err == nil {
-			// Positive integer, go ahead
-			blockNumber = rpc.BlockNumber(bNum)
-		} else {
-			bNum, err := hexutil.DecodeUint64(*number)
-			if err == nil {
-				// Hexadecimal, 0x prefixed
-				blockNumber = rpc.BlockNumber(bNum)
-			} else {
-				var err error
-				return nil, err
-			}
-		}
-	} else {
-		if hash != nil {
-			blockHash, _ := hexutil.DecodeBig(*hash)
-			fmt.Println("TODO/GraphQL/Implement me, get Block by hash=", blockHash)
-			hash = nil
-		}
+// Code is the resolver for the code field.
+func (r *accountResolver) Code(ctx context.Context, obj *model.Account) (string, error) {
+	code, err := r.GraphQLAPI.GetCode(ctx, libcommon.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return "", err
 	}
+	return code.String(), nil
+}
+
+// Storage is the resolver for the storage field.
+func (r *accountResolver) Storage(ctx context.Context, obj *model.Account, slot string) (string, error) {
+	return r.GraphQLAPI.GetStorageAt(ctx, libcommon.HexToAddress(obj.Address), slot, obj.BlockNrOrHash)
+}
 
-	if number == nil && hash == nil {
-		// If neither number or hash is specified (nil), we should deliver "latest" block
-		/*
-			rpc.LatestExecutedBlockNumber = BlockNumber(-5)
-			rpc.FinalizedBlockNumber      = BlockNumber(-4)
-			rpc.SafeBlockNumber           = BlockNumber(-3)
-			rpc.PendingBlockNumber        = BlockNumber(-2)
-			rpc.LatestBlockNumber         = BlockNumber(-1)
-		*/
-		blockNumber = rpc.LatestBlockNumber
+// Parent is the resolver for the parent field.
+func (r *blockResolver) Parent(ctx context.Context, obj *model.Block) (*model.Block, error) {
+	if obj.Number == 0 || obj.Parent == nil {
+		return nil, nil
 	}
+	return r.blockByHash(ctx, obj.Parent.Hash)
+}
 
-	res, err := r.GraphQLAPI.GetBlockDetails(ctx, blockNumber)
-	if err != nil {
-		fmt.Println(err)
-		return nil, err
+// Miner is the resolver for the miner field.
+func (r *blockResolver) Miner(ctx context.Context, obj *model.Block, block *uint64) (*model.Account, error) {
+	return accountAt(obj.Miner.Address, block), nil
+}
+
+// OmmerAt is the resolver for the ommerAt field.
+func (r *blockResolver) OmmerAt(ctx context.Context, obj *model.Block, index int) (*model.Block, error) {
+	if index < 0 || index >= len(obj.Ommers) {
+		return nil, nil
+	}
+	return obj.Ommers[index], nil
+}
+
+// TransactionAt is the resolver for the transactionAt field.
+func (r *blockResolver) TransactionAt(ctx context.Context, obj *model.Block, index int) (*model.Transaction, error) {
+	if index < 0 || index >= len(obj.Transactions) {
+		return nil, nil
 	}
+	return obj.Transactions[index], nil
+}
 
-	block := &model.Block{}
-	absBlk := res["block"]
+// Logs is the resolver for the logs field.
+func (r *blockResolver) Logs(ctx context.Context, obj *model.Block, filter model.BlockFilterCriteria) ([]*model.Log, error) {
+	blockHash := libcommon.HexToHash(obj.Hash)
+	return r.logs(ctx, filters.FilterCriteria{
+		BlockHash: &blockHash,
+		Addresses: convertFilterAddresses(filter.Addresses),
+		Topics:    convertFilterTopics(filter.Topics),
+	})
+}
 
-	if absBlk != nil {
-		blk := absBlk.(map[string]interface{})
+// Account is the resolver for the account field.
+func (r *blockResolver) Account(ctx context.Context, obj *model.Block, address string) (*model.Account, error) {
+	return &model.Account{
+		Address:       address,
+		BlockNrOrHash: rpc.BlockNumberOrHashWithHash(libcommon.HexToHash(obj.Hash), false),
+	}, nil
+}
 
-		block.Difficulty = *convertDataToStringP(blk, "difficulty")
-		block.ExtraData = *convertDataToStringP(blk, "extraData")
-		block.GasLimit = uint64(*convertDataToUint64P(blk, "gasLimit"))
-		block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
-		block.Hash = *convertDataToStringP(blk, "hash")
-		block.Miner = &model.Account{}
-		address := convertDataToStringP(blk, "miner")
-		if address != nil {
-			block.Miner.Address = strings.ToLower(*address)
-		}
-		mixHash := convertDataToStringP(blk, "mixHash")
-		if mixHash != nil {
-			block.MixHash = *mixHash
-		}
-		blockNonce := convertDataToStringP(blk, "nonce")
-		if blockNonce != nil {
-			block.Nonce = *blockNonce
-		}
-		block.Number = *convertDataToUint64P(blk, "number")
-		block.Ommers = []*model.Block{}
-		block.Parent = &model.Block{}
-		block.Parent.Hash = *convertDataToStringP(blk, "parentHash")
-		block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
-		block.StateRoot = *convertDataToStringP(blk, "stateRoot")
-		block.Timestamp = *convertDataToStringP(blk, "timestamp")
-		block.TransactionCount = convertDataToIntP(blk, "transactionCount")
-		block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
-		block.TotalDifficulty = *convertDataToStringP(blk, "totalDifficulty")
-		block.Transactions = []*model.Transaction{}
-
-		block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
-		block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
-
-		absRcp := res["receipts"]
-		rcp := absRcp.([]map[string]interface{})
-		for _, transReceipt := range rcp {
-			trans := &model.Transaction{}
-			trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
-			trans.InputData = *convertDataToStringP(transReceipt, "data")
-			trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
-			trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
-			trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
-			transNonce := convertDataToStringP(transReceipt, "nonce")
-			if transNonce != nil {
-				trans.Nonce = *transNonce
-			}
-			trans.Status = convertDataToUint64P(transReceipt, "status")
-			trans.Type = convertDataToIntP(transReceipt, "type")
-			trans.Value = *convertDataToStringP(transReceipt, "value")
-
-			trans.Logs = make([]*model.Log, 0)
-			for _, rlog := range transReceipt["logs"].(types.Logs) {
-				tlog := model.Log{
-					Index: int(rlog.Index),
-					Data:  "0x" + hex.EncodeToString(rlog.Data),
-				}
-				tlog.Account = &model.Account{}
-				tlog.Account.Address = strings.ToLower(rlog.Address.String())
-
-				for _, rtopic := range rlog.Topics {
-					tlog.Topics = append(tlog.Topics, rtopic.String())
-				}
-
-				trans.Logs = append(trans.Logs, &tlog)
-			}
+// Call is the resolver for the call field.
+func (r *blockResolver) Call(ctx context.Context, obj *model.Block, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, rpc.BlockNumberOrHashWithHash(libcommon.HexToHash(obj.Hash), false))
+}
 
-			trans.From = &model.Account{}
-			trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+// EstimateGas is the resolver for the estimateGas field.
+func (r *blockResolver) EstimateGas(ctx context.Context, obj *model.Block, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, rpc.BlockNumberOrHashWithHash(libcommon.HexToHash(obj.Hash), false))
+}
 
-			trans.To = &model.Account{}
-			address := convertDataToStringP(transReceipt, "to")
-			// To address could be nil in case of contract creation
-			if address != nil {
-				trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
-			}
+// Account is the resolver for the account field.
+func (r *logResolver) Account(ctx context.Context, obj *model.Log, block *uint64) (*model.Account, error) {
+	return accountAt(obj.Account.Address, block), nil
+}
+
+// Transaction is the resolver for the transaction field.
+func (r *logResolver) Transaction(ctx context.Context, obj *model.Log) (*model.Transaction, error) {
+	// Logs of a block already link to their transaction, filtered logs only carry the transaction hash
+	if obj.Transaction.From != nil {
+		return obj.Transaction, nil
+	}
+	return r.Query().Transaction(ctx, obj.Transaction.Hash)
+}
+
+// SendRawTransaction is the resolver for the sendRawTransaction field.
+func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
+	encodedTx, err := hexutil.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	hash, err := r.GraphQLAPI.SendRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// Account is the resolver for the account field.
+func (r *pendingResolver) Account(ctx context.Context, obj *model.Pending, address string) (*model.Account, error) {
+	return &model.Account{
+		Address:       address,
+		BlockNrOrHash: rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber),
+	}, nil
+}
+
+// Call is the resolver for the call field.
+func (r *pendingResolver) Call(ctx context.Context, obj *model.Pending, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+}
+
+// EstimateGas is the resolver for the estimateGas field.
+func (r *pendingResolver) EstimateGas(ctx context.Context, obj *model.Pending, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+}
 
-			block.Transactions = append(block.Transactions, trans)
+// Block is the resolver for the block field.
+func (r *queryResolver) Block(ctx context.Context, number *string, hash *string) (*model.Block, error) {
+	// If neither number or hash is specified (nil), we should deliver "latest" block
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	if number != nil {
+		blockNumber, err := parseBlockNumber(*number)
+		if err != nil {
+			return nil, err
 		}
+		blockNrOrHash = rpc.BlockNumberOrHashWithNumber(blockNumber)
+	} else if hash != nil {
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(libcommon.HexToHash(*hash), false)
 	}
 
-	return block, ctx.Err()
+	return r.blockByNumberOrHash(ctx, blockNrOrHash)
 }
 
 // Blocks is the resolver for the blocks field.
@@ -166,8 +176,20 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 	const maxBlocks = 25
 
-	fromBlockNumber := *from
-	toBlockNumber := *to
+	var fromBlockNumber uint64
+	if from != nil {
+		fromBlockNumber = *from
+	}
+	var toBlockNumber uint64
+	if to != nil {
+		toBlockNumber = *to
+	} else {
+		latest, err := r.Block(ctx, nil, nil)
+		if err != nil || latest == nil {
+			return blocks, err
+		}
+		toBlockNumber = latest.Number
+	}
 
 	if toBlockNumber >= fromBlockNumber && (toBlockNumber-fromBlockNumber+1) < maxBlocks {
 
@@ -185,32 +207,101 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 // Pending is the resolver for the pending field.
 func (r *queryResolver) Pending(ctx context.Context) (*model.Pending, error) {
-	panic(fmt.Errorf("not implemented: Pending - pending"))
+	txs, err := r.GraphQLAPI.GetPendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending := &model.Pending{
+		TransactionCount: len(txs),
+		Transactions:     make([]*model.Transaction, 0, len(txs)),
+	}
+	for _, txn := range txs {
+		pending.Transactions = append(pending.Transactions, convertRPCTransaction(txn))
+	}
+	return pending, nil
 }
 
 // Transaction is the resolver for the transaction field.
 func (r *queryResolver) Transaction(ctx context.Context, hash string) (*model.Transaction, error) {
-	panic(fmt.Errorf("not implemented: Transaction - transaction"))
+	// Mined transactions are served with their receipt fields. GetTransactionDetails reads the whole block
+	// and its receipts (a receipt depends on the preceding transactions), but the block object of the
+	// response carries only hash and number, and is built by blockByHash only if other fields are queried.
+	details, err := r.GraphQLAPI.GetTransactionDetails(ctx, libcommon.HexToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	if details != nil {
+		trans := convertReceipt(details)
+		trans.Block = &model.Block{
+			Hash:   *convertDataToStringP(details, "blockHash"),
+			Number: *convertDataToUint64P(details, "blockNumber"),
+		}
+		return trans, nil
+	}
+
+	txn, err := r.GraphQLAPI.GetTransactionByHash(ctx, libcommon.HexToHash(hash))
+	if err != nil || txn == nil {
+		return nil, err
+	}
+	return convertRPCTransaction(txn), nil
 }
 
 // Logs is the resolver for the logs field.
 func (r *queryResolver) Logs(ctx context.Context, filter model.FilterCriteria) ([]*model.Log, error) {
-	panic(fmt.Errorf("not implemented: Logs - logs"))
+	crit := filters.FilterCriteria{
+		Addresses: convertFilterAddresses(filter.Addresses),
+		Topics:    convertFilterTopics(filter.Topics),
+	}
+	if filter.FromBlock != nil {
+		crit.FromBlock = new(big.Int).SetUint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*filter.ToBlock)
+	}
+	return r.logs(ctx, crit)
 }
 
 // GasPrice is the resolver for the gasPrice field.
 func (r *queryResolver) GasPrice(ctx context.Context) (string, error) {
-	panic(fmt.Errorf("not implemented: GasPrice - gasPrice"))
+	gasPrice, err := r.GraphQLAPI.GasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+	return gasPrice.String(), nil
 }
 
 // MaxPriorityFeePerGas is the resolver for the maxPriorityFeePerGas field.
 func (r *queryResolver) MaxPriorityFeePerGas(ctx context.Context) (string, error) {
-	panic(fmt.Errorf("not implemented: MaxPriorityFeePerGas - maxPriorityFeePerGas"))
+	tipCap, err := r.GraphQLAPI.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tipCap.String(), nil
 }
 
 // Syncing is the resolver for the syncing field.
 func (r *queryResolver) Syncing(ctx context.Context) (*model.SyncState, error) {
-	panic(fmt.Errorf("not implemented: Syncing - syncing"))
+	res, err := r.GraphQLAPI.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress, ok := res.(map[string]interface{})
+	if !ok {
+		// Not syncing
+		return nil, nil
+	}
+	currentBlock, ok := progress["currentBlock"].(hexutil.Uint64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected sync progress: currentBlock is %T", progress["currentBlock"])
+	}
+	highestBlock, ok := progress["highestBlock"].(hexutil.Uint64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected sync progress: highestBlock is %T", progress["highestBlock"])
+	}
+	return &model.SyncState{
+		CurrentBlock: uint64(currentBlock),
+		HighestBlock: uint64(highestBlock),
+	}, nil
 }
 
 // ChainID is the resolver for the chainID field.
@@ -220,11 +311,62 @@ func (r *queryResolver) ChainID(ctx context.Context) (string, error) {
 	return "0x" + strconv.FormatUint(chainID.Uint64(), 16), err
 }
 
+// From is the resolver for the from field.
+func (r *transactionResolver) From(ctx context.Context, obj *model.Transaction, block *uint64) (*model.Account, error) {
+	return accountAt(obj.From.Address, block), nil
+}
+
+// To is the resolver for the to field.
+func (r *transactionResolver) To(ctx context.Context, obj *model.Transaction, block *uint64) (*model.Account, error) {
+	if obj.To == nil {
+		return nil, nil
+	}
+	return accountAt(obj.To.Address, block), nil
+}
+
+// Block is the resolver for the block field.
+func (r *transactionResolver) Block(ctx context.Context, obj *model.Transaction) (*model.Block, error) {
+	// Pending transactions have no block, mined ones link to the block they were served from.
+	// Transactions queried by hash only carry the block hash and number, the rest is loaded on demand.
+	if obj.Block == nil || obj.Block.Transactions != nil {
+		return obj.Block, nil
+	}
+	return r.blockByHash(ctx, obj.Block.Hash)
+}
+
+// CreatedContract is the resolver for the createdContract field.
+func (r *transactionResolver) CreatedContract(ctx context.Context, obj *model.Transaction, block *uint64) (*model.Account, error) {
+	if obj.CreatedContract == nil {
+		return nil, nil
+	}
+	return accountAt(obj.CreatedContract.Address, block), nil
+}
+
+// Account returns AccountResolver implementation.
+func (r *Resolver) Account() AccountResolver { return &accountResolver{r} }
+
+// Block returns BlockResolver implementation.
+func (r *Resolver) Block() BlockResolver { return &blockResolver{r} }
+
+// Log returns LogResolver implementation.
+func (r *Resolver) Log() LogResolver { return &logResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Pending returns PendingResolver implementation.
+func (r *Resolver) Pending() PendingResolver { return &pendingResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Transaction returns TransactionResolver implementation.
+func (r *Resolver) Transaction() TransactionResolver { return &transactionResolver{r} }
+
+type accountResolver struct{ *Resolver }
+type blockResolver struct{ *Resolver }
+type logResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type pendingResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type transactionResolver struct{ *Resolver }
@@ -1,11 +1,21 @@
 package graphql
 
 import (
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+	"github.com/ledgerwatch/erigon/turbo/jsonrpc"
 )
 
 func TestGraphQLQueryBlock(t *testing.T) {
@@ -85,22 +95,17 @@ func TestGraphQLQueryBlock(t *testing.T) {
 			want: `{"errors":[{"message":"Cannot query field \"bleh\" on type \"Query\".","locations":[{"line":1,"column":2}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}],"data":null}`,
 			code: 422,
 		},
-		// should return `estimateGas` as decimal
-		/*
-			{
-				body: `{"query": "{block{ estimateGas(data:{}) }}"}`,
-				want: `{"data":{"block":{"estimateGas":53000}}}`,
-				code: 200,
-			},
-		*/
-		// should return `status` as decimal
-		/*
-			{
-				body: `{"query": "{block {number call (data : {from : \"0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b\", to: \"0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\", data :\"0x12a7b914\"}){data status}}}"}`,
-				want: `{"data":{"block":{"number":10,"call":{"data":"0x","status":1}}}}`,
-				code: 200,
-			},
-		*/
+		{ // should return `estimateGas` as decimal
+			body: `{"query": "{block{ estimateGas(data:{}) }}"}`,
+			want: `{"data":{"block":{"estimateGas":53000}}}`,
+			code: 200,
+		},
+		{ // should return `status` as decimal
+			body: `{"query": "{block {number call (data : {from : \"0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b\", to: \"0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\", data :\"0x12a7b914\"}){data status}}}"}`,
+			want: `{"data":{"block":{"number":\d+,"call":{"data":"0x","status":1}}}}`,
+			code: 200,
+			comp: "regexp",
+		},
 	} {
 		resp, err := http.Post("http://localhost:8545/graphql", "application/json", strings.NewReader(tt.body))
 		if err != nil {
@@ -126,3 +131,58 @@ func TestGraphQLQueryBlock(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphQLResolvers(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	agg := m.HistoryV3Components()
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	base := jsonrpc.NewBaseApi(nil, stateCache, m.BlockReader, agg, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs)
+	eth := jsonrpc.NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Service: jsonrpc.NewGraphQLAPI(base, m.DB, eth, nil)}}))
+	defer server.Close()
+
+	block := chain.Blocks[0]
+	txn := block.Transactions()[0]
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: fmt.Sprintf(`{"query": "{block(hash:\"%s\"){number,parent{number},transactionAt(index:0){hash,block{number}}}}"}`, block.Hash()),
+			want: fmt.Sprintf(`{"data":{"block":{"number":1,"parent":{"number":0},"transactionAt":{"hash":"%s","block":{"number":1}}}}}`, txn.Hash()),
+		},
+		{
+			body: fmt.Sprintf(`{"query": "{transaction(hash:\"%s\"){hash,index,status,from{address},block{hash}}}"}`, txn.Hash()),
+			want: fmt.Sprintf(`{"data":{"transaction":{"hash":"%s","index":0,"status":1,"from":{"address":"0x71562b71999873db5b286df957af199ec94617f7"},"block":{"hash":"%s"}}}}`, txn.Hash(), block.Hash()),
+		},
+		{
+			body: `{"query": "{block(number:\"0\"){account(address:\"0x71562b71999873db5b286df957af199ec94617f7\"){balance,transactionCount}}}"}`,
+			want: `{"data":{"block":{"account":{"balance":"0x7ce66c50e2840000","transactionCount":0}}}}`,
+		},
+		{
+			body: `{"query": "{block(number:\"0x1\"){logs(filter:{}){index}}}"}`,
+			want: `{"data":{"block":{"logs":[]}}}`,
+		},
+		{
+			body: `{"query": "{block{call(data:{from:\"0x71562b71999873db5b286df957af199ec94617f7\",to:\"0x0d3ab14bbad3d99f4203bd7a11acb94882050e7e\"}){data,gasUsed,status}}}"}`,
+			want: `{"data":{"block":{"call":{"data":"0x","gasUsed":21000,"status":1}}}}`,
+		},
+		{
+			body: `{"query": "{block(number:\"100\"){number}}"}`,
+			want: `{"data":{"block":null}}`,
+		},
+	} {
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("could not post: %v", err)
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("could not read from response body: %v", err)
+		}
+		if have := string(bodyBytes); have != tt.want {
+			t.Errorf("testcase %d %s,\nhave:\n%v\nwant:\n%v", i, tt.body, have, tt.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/cli"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/debug"
@@ -29,6 +30,16 @@ func main() {
 		}
 		defer db.Close()
 		defer engine.Close()
+		if cfg.StateCache.WarmStartFile != "" {
+			defer func() {
+				saved, err := kvcache.SaveHotKeys(stateCache, cfg.StateCache.WarmStartFile)
+				if err != nil {
+					logger.Warn("Could not save state cache", "err", err)
+					return
+				}
+				logger.Info("State cache saved", "keys", saved)
+			}()
+		}
 
 		apiList := jsonrpc.APIList(db, backend, txPool, mining, ff, stateCache, blockReader, agg, cfg, engine, logger)
 		rpc.PreAllocateRPCMetricLabels(apiList)
@@ -376,6 +376,30 @@ func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerR
 	return result, nil
 }
 
+func (back *RemoteBackend) RemovePeer(ctx context.Context, request *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	result, err := back.remoteEthBackend.RemovePeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemovePeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) AddTrustedPeer(ctx context.Context, request *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	result, err := back.remoteEthBackend.AddTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.AddTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) RemoveTrustedPeer(ctx context.Context, request *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	result, err := back.remoteEthBackend.RemoveTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemoveTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
 func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	rpcPeers, err := back.remoteEthBackend.Peers(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -404,7 +428,8 @@ func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 				Trusted:       rpcPeer.ConnIsTrusted,
 				Static:        rpcPeer.ConnIsStatic,
 			},
-			Protocols: nil,
+			Protocols:  nil,
+			Reputation: rpcPeer.Reputation,
 		}
 
 		peers = append(peers, &peer)
@@ -96,21 +96,21 @@ func buildIndex(cliCtx *cli.Context, dataDir string, snapshotPaths []string, min
 				jobProgress := &background.Progress{}
 				ps.Add(jobProgress)
 				defer ps.Delete(jobProgress)
-				return freezeblocks.HeadersIdx(ctx, segment, dirs.Tmp, jobProgress, logLevel, logger)
+				return freezeblocks.HeadersIdx(ctx, segment, dirs.Tmp, freezeblocks.IndexCfg{}, jobProgress, logLevel, logger)
 			})
 		case snaptype.Enums.Bodies:
 			g.Go(func() error {
 				jobProgress := &background.Progress{}
 				ps.Add(jobProgress)
 				defer ps.Delete(jobProgress)
-				return freezeblocks.BodiesIdx(ctx, segment, dirs.Tmp, jobProgress, logLevel, logger)
+				return freezeblocks.BodiesIdx(ctx, segment, dirs.Tmp, freezeblocks.IndexCfg{}, jobProgress, logLevel, logger)
 			})
 		case snaptype.Enums.Transactions:
 			g.Go(func() error {
 				jobProgress := &background.Progress{}
 				ps.Add(jobProgress)
 				defer ps.Delete(jobProgress)
-				return freezeblocks.TransactionsIdx(ctx, chainConfig, segment, dirs.Tmp, jobProgress, logLevel, logger)
+				return freezeblocks.TransactionsIdx(ctx, chainConfig, segment, dirs.Tmp, freezeblocks.IndexCfg{}, jobProgress, logLevel, logger)
 			})
 		}
 	}
@@ -613,7 +613,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 
 					logger.Info(fmt.Sprintf("Indexing %s", ent1.Body.Name()))
 
-					return freezeblocks.BodiesIdx(ctx, info, c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return freezeblocks.BodiesIdx(ctx, info, c.session1.LocalFsRoot(), freezeblocks.IndexCfg{}, nil, log.LvlDebug, logger)
 				})
 
 				g.Go(func() error {
@@ -651,7 +651,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info(fmt.Sprintf("Indexing %s", ent1.Transactions.Name()))
-					return freezeblocks.TransactionsIdx(ctx, c.chainConfig(), info, c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return freezeblocks.TransactionsIdx(ctx, c.chainConfig(), info, c.session1.LocalFsRoot(), freezeblocks.IndexCfg{}, nil, log.LvlDebug, logger)
 				})
 
 				b2err := make(chan error, 1)
@@ -687,7 +687,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info(fmt.Sprintf("Indexing %s", ent2.Body.Name()))
-					return freezeblocks.BodiesIdx(ctx, info, c.session1.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return freezeblocks.BodiesIdx(ctx, info, c.session1.LocalFsRoot(), freezeblocks.IndexCfg{}, nil, log.LvlDebug, logger)
 				})
 
 				g.Go(func() error {
@@ -728,7 +728,7 @@ func (c comparitor) compareBodies(ctx context.Context, f1ents []*BodyEntry, f2en
 					}()
 
 					logger.Info(fmt.Sprintf("Indexing %s", ent2.Transactions.Name()))
-					return freezeblocks.TransactionsIdx(ctx, c.chainConfig(), info, c.session2.LocalFsRoot(), nil, log.LvlDebug, logger)
+					return freezeblocks.TransactionsIdx(ctx, c.chainConfig(), info, c.session2.LocalFsRoot(), freezeblocks.IndexCfg{}, nil, log.LvlDebug, logger)
 				})
 
 				if err := g.Wait(); err != nil {
@@ -99,13 +99,17 @@ func copy(cliCtx *cli.Context) error {
 	if cliCtx.Args().Len() > pos {
 		val := cliCtx.Args().Get(pos)
 
-		if src, err = sync.ParseLocator(val); err != nil {
+		if dst, err = sync.ParseLocator(val); err != nil {
 			return err
 		}
 
 		pos++
 	}
 
+	if src == nil || dst == nil {
+		return fmt.Errorf("expected <src> <dst> locations")
+	}
+
 	switch dst.LType {
 	case sync.TorrentFs:
 		return fmt.Errorf("can't copy to torrent - need intermediate local fs")
@@ -119,7 +123,7 @@ func copy(cliCtx *cli.Context) error {
 			}
 		}
 
-		if err = sync.CheckRemote(rcCli, src.Src); err != nil {
+		if err = sync.CheckRemote(rcCli, dst.Src); err != nil {
 			return err
 		}
 	}
@@ -189,17 +193,17 @@ func copy(cliCtx *cli.Context) error {
 		switch dst.LType {
 		case sync.LocalFs:
 			return localToLocal(src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
-		case sync.RemoteFs:
-			return localToRemote(rcCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
+		case sync.RemoteFs, sync.S3Fs:
+			return localToRemote(cliCtx.Context, rcCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
 		default:
 			return fmt.Errorf("unhandled torrent destination: %s", dst)
 		}
 
-	case sync.RemoteFs:
+	case sync.RemoteFs, sync.S3Fs:
 		switch dst.LType {
 		case sync.LocalFs:
 			return remoteToLocal(cliCtx.Context, rcCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
-		case sync.RemoteFs:
+		case sync.RemoteFs, sync.S3Fs:
 			return remoteToRemote(rcCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
 		default:
 			return fmt.Errorf("unhandled torrent destination: %s", dst)
@@ -209,7 +213,7 @@ func copy(cliCtx *cli.Context) error {
 		switch dst.LType {
 		case sync.LocalFs:
 			return torrentToLocal(torrentCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
-		case sync.RemoteFs:
+		case sync.RemoteFs, sync.S3Fs:
 			return torrentToRemote(torrentCli, rcCli, src, dst, firstBlock, lastBlock, snapTypes, torrents, hashes, manifest)
 		default:
 			return fmt.Errorf("unhandled torrent destination: %s", dst)
@@ -227,8 +231,28 @@ func torrentToRemote(torrentCli *sync.TorrentClient, rcCli *downloader.RCloneCli
 	return fmt.Errorf("TODO")
 }
 
-func localToRemote(rcCli *downloader.RCloneClient, src *sync.Locator, dst *sync.Locator, from uint64, to uint64, snapTypes []snaptype.Type, torrents, hashes, manifest bool) error {
-	return fmt.Errorf("TODO")
+func localToRemote(ctx context.Context, rcCli *downloader.RCloneClient, src *sync.Locator, dst *sync.Locator, from uint64, to uint64, snapTypes []snaptype.Type, torrents, hashes, manifest bool) error {
+	logger := sync.Logger(ctx)
+
+	session, err := sync.NewRemoteSession(ctx, rcCli, src.Root, dst, logger)
+
+	if err != nil {
+		return err
+	}
+
+	defer session.Stop()
+
+	fileEntries, err := session.ReadLocalDir(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	files := selectFiles(fileEntries, dst.Version, from, to, snapTypes, torrents, hashes, manifest)
+
+	logger.Info(fmt.Sprintf("Uploading %s", files), "remoteFs", session.RemoteFsRoot(), "label", session.Label())
+
+	return session.Upload(ctx, files...)
 }
 
 func localToLocal(src *sync.Locator, dst *sync.Locator, from uint64, to uint64, snapTypes []snaptype.Type, torrents, hashes, manifest bool) error {
@@ -238,16 +262,14 @@ func localToLocal(src *sync.Locator, dst *sync.Locator, from uint64, to uint64,
 func remoteToLocal(ctx context.Context, rcCli *downloader.RCloneClient, src *sync.Locator, dst *sync.Locator, from uint64, to uint64, snapTypes []snaptype.Type, torrents, hashes, manifest bool) error {
 	logger := sync.Logger(ctx)
 
-	if rcCli == nil {
-		return fmt.Errorf("no remote downloader")
-	}
-
-	session, err := rcCli.NewSession(ctx, dst.Root, src.Src+":"+src.Root)
+	session, err := sync.NewRemoteSession(ctx, rcCli, dst.Root, src, logger)
 
 	if err != nil {
 		return err
 	}
 
+	defer session.Stop()
+
 	logger.Info("Reading src dir", "remoteFs", session.RemoteFsRoot(), "label", session.Label())
 	fileEntries, err := session.ReadRemoteDir(ctx, true)
 
@@ -293,6 +315,13 @@ func selectFiles(entries []fs.DirEntry, version snaptype.Version, firstBlock, la
 		if info, err := ent.Info(); err == nil {
 			snapInfo, _ := info.Sys().(downloader.SnapInfo)
 
+			if snapInfo == nil {
+				// local files
+				if fileInfo, isStateFile, ok := snaptype.ParseFileName("", info.Name()); ok && !isStateFile {
+					snapInfo = sinf{fileInfo}
+				}
+			}
+
 			if torrents {
 				if ext := filepath.Ext(info.Name()); ext == ".torrent" {
 					fileName := strings.TrimSuffix(info.Name(), ".torrent")
@@ -325,7 +354,8 @@ func selectFiles(entries []fs.DirEntry, version snaptype.Version, firstBlock, la
 					}
 				}
 
-			case manifest:
+			case manifest && info.Name() == "manifest.txt":
+				files = append(files, info.Name())
 
 			case hashes:
 
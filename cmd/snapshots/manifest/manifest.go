@@ -106,7 +106,7 @@ func manifest(cliCtx *cli.Context, command string) error {
 		}
 	}
 
-	var srcSession *downloader.RCloneSession
+	var srcSession downloader.RemoteSession
 
 	tempDir, err := os.MkdirTemp("", "snapshot-manifest-")
 
@@ -116,13 +116,11 @@ func manifest(cliCtx *cli.Context, command string) error {
 
 	defer os.RemoveAll(tempDir)
 
-	if rcCli != nil {
-		if src != nil && src.LType == sync.RemoteFs {
-			srcSession, err = rcCli.NewSession(cliCtx.Context, tempDir, src.Src+":"+src.Root)
+	if src != nil && (src.LType == sync.RemoteFs || src.LType == sync.S3Fs) {
+		srcSession, err = sync.NewRemoteSession(cliCtx.Context, rcCli, tempDir, src, logger)
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
 		}
 	}
 
@@ -149,7 +147,7 @@ func manifest(cliCtx *cli.Context, command string) error {
 	}
 }
 
-func listManifest(ctx context.Context, srcSession *downloader.RCloneSession, out *os.File) error {
+func listManifest(ctx context.Context, srcSession downloader.RemoteSession, out *os.File) error {
 	entries, err := DownloadManifest(ctx, srcSession)
 
 	if err != nil {
@@ -163,7 +161,7 @@ func listManifest(ctx context.Context, srcSession *downloader.RCloneSession, out
 	return nil
 }
 
-func updateManifest(ctx context.Context, tmpDir string, srcSession *downloader.RCloneSession, version *snaptype.Version) error {
+func updateManifest(ctx context.Context, tmpDir string, srcSession downloader.RemoteSession, version *snaptype.Version) error {
 	entities, err := srcSession.ReadRemoteDir(ctx, true)
 
 	if err != nil {
@@ -220,7 +218,7 @@ func updateManifest(ctx context.Context, tmpDir string, srcSession *downloader.R
 	return srcSession.Upload(ctx, manifestFile)
 }
 
-func verifyManifest(ctx context.Context, srcSession *downloader.RCloneSession, version *snaptype.Version, out *os.File) error {
+func verifyManifest(ctx context.Context, srcSession downloader.RemoteSession, version *snaptype.Version, out *os.File) error {
 	manifestEntries, err := DownloadManifest(ctx, srcSession)
 
 	if err != nil {
@@ -347,7 +345,7 @@ func (e dirEntry) Info() (fs.FileInfo, error) {
 	return e, nil
 }
 
-func DownloadManifest(ctx context.Context, session *downloader.RCloneSession) ([]fs.DirEntry, error) {
+func DownloadManifest(ctx context.Context, session downloader.RemoteSession) ([]fs.DirEntry, error) {
 
 	reader, err := session.Cat(ctx, "manifest.txt")
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -38,6 +39,7 @@ const (
 	TorrentFs LType = iota
 	LocalFs
 	RemoteFs
+	S3Fs // s3://bucket/prefix - served by native s3 client, doesn't need rclone
 )
 
 type Locator struct {
@@ -56,8 +58,8 @@ func (l Locator) String() string {
 		val = "torrent"
 	case LocalFs:
 		val = l.Root
-	case RemoteFs:
-		val = l.Src + ":" + l.Root
+	case RemoteFs, S3Fs:
+		val = l.RemoteFs()
 	}
 
 	if l.Version > 0 {
@@ -67,10 +69,48 @@ func (l Locator) String() string {
 	return val
 }
 
+// RemoteFs - remote location as accepted by downloader.NewRemoteSession
+func (l Locator) RemoteFs() string {
+	if l.LType == S3Fs {
+		return downloader.S3Scheme + l.Root
+	}
+
+	return l.Src + ":" + l.Root
+}
+
 var locatorExp, _ = regexp.Compile(`^(?:(\w+)\:)?([^\:]*)(?:\:(v\d+))?`)
 var srcExp, _ = regexp.Compile(`^erigon-v\d+-snapshots-(.*)$`)
 
 func ParseLocator(value string) (*Locator, error) {
+	if root, ok := strings.CutPrefix(value, downloader.S3Scheme); ok {
+		matches := locatorExp.FindStringSubmatch(root)
+
+		if len(matches) == 0 || len(matches[1]) > 0 || len(matches[2]) == 0 {
+			return nil, fmt.Errorf("invalid s3 locator: %s", value)
+		}
+
+		loc := Locator{
+			LType: S3Fs,
+			Src:   "s3",
+			Root:  strings.TrimSuffix(matches[2], "/"),
+		}
+
+		if matches := srcExp.FindStringSubmatch(path.Base(loc.Root)); len(matches) > 1 {
+			loc.Chain = matches[1]
+		}
+
+		if len(matches[3]) > 0 {
+			version, err := strconv.ParseUint(matches[3][1:], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse version: %s: %w", matches[3], err)
+			}
+
+			loc.Version = snaptype.Version(version)
+		}
+
+		return &loc, nil
+	}
+
 	if matches := locatorExp.FindStringSubmatch(value); len(matches) > 0 {
 		var loc Locator
 
@@ -410,7 +450,7 @@ func NewTorrentSession(cli *TorrentClient, chain string) *torrentSession {
 }
 
 func DownloadManifest(ctx context.Context, session DownloadSession) ([]fs.DirEntry, error) {
-	if session, ok := session.(*downloader.RCloneSession); ok {
+	if session, ok := session.(downloader.RemoteSession); ok {
 		reader, err := session.Cat(ctx, "manifest.txt")
 
 		if err != nil {
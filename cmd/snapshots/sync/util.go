@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ledgerwatch/erigon-lib/downloader"
+	"github.com/ledgerwatch/log/v3"
 )
 
 func CheckRemote(rcCli *downloader.RCloneClient, src string) error {
@@ -30,3 +31,19 @@ func CheckRemote(rcCli *downloader.RCloneClient, src string) error {
 
 	return nil
 }
+
+// NewRemoteSession - session between localFs and remote: native s3 session for S3Fs, rclone session for RemoteFs
+func NewRemoteSession(ctx context.Context, rcCli *downloader.RCloneClient, localFs string, remote *Locator, logger log.Logger) (downloader.RemoteSession, error) {
+	switch remote.LType {
+	case S3Fs:
+		return downloader.NewS3Client(downloader.S3ConfigFromEnv(), logger).NewSession(ctx, localFs, remote.RemoteFs())
+	case RemoteFs:
+		if rcCli == nil {
+			return nil, fmt.Errorf("no remote downloader")
+		}
+
+		return rcCli.NewSession(ctx, localFs, remote.RemoteFs())
+	default:
+		return nil, fmt.Errorf("not a remote location: %s", remote)
+	}
+}
@@ -28,6 +28,7 @@ type TxTask struct {
 	Uncles          []*types.Header
 	Coinbase        libcommon.Address
 	Withdrawals     types.Withdrawals
+	Requests        types.Requests
 	BlockHash       libcommon.Hash
 	Sender          *libcommon.Address
 	SkipAnalysis    bool
@@ -85,7 +85,7 @@ func runBlock(bp BlockProvider, ibs *state.IntraBlockState, txnWriter state.Stat
 	}
 
 	if !vmConfig.ReadOnly {
-		if _, _, _, err := engine.FinalizeAndAssemble(chainConfig, block.Header(), ibs, block.Transactions(), block.Uncles(), receipts, block.Withdrawals(), nil, nil, nil, nil); err != nil {
+		if _, _, _, err := engine.FinalizeAndAssemble(chainConfig, block.Header(), ibs, block.Transactions(), block.Uncles(), receipts, block.Withdrawals(), block.Requests(), nil, nil, nil, nil); err != nil {
 			return nil, err
 		}
 
@@ -395,7 +395,7 @@ func Stateless(
 			receipts = append(receipts, receipt)
 		}
 
-		if _, _, _, err = engine.FinalizeAndAssemble(chainConfig, block.Header(), statedb, block.Transactions(), block.Uncles(), receipts, block.Withdrawals(), nil, nil, nil, nil); err != nil {
+		if _, _, _, err = engine.FinalizeAndAssemble(chainConfig, block.Header(), statedb, block.Transactions(), block.Uncles(), receipts, block.Withdrawals(), block.Requests(), nil, nil, nil, nil); err != nil {
 			fmt.Printf("Finalize of block %d failed: %v\n", blockNum, err)
 			return
 		}
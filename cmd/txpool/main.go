@@ -59,7 +59,14 @@ var (
 
 	noTxGossip bool
 
-	commitEvery time.Duration
+	commitEvery    time.Duration
+	journal        string
+	rejournalEvery time.Duration
+
+	ordering        string
+	orderingFeeBand uint64
+
+	stateCacheWarmStartFile string
 )
 
 func init() {
@@ -85,8 +92,13 @@ func init() {
 	rootCmd.PersistentFlags().Uint64Var(&priceBump, "txpool.pricebump", txpoolcfg.DefaultConfig.PriceBump, "Price bump percentage to replace an already existing transaction")
 	rootCmd.PersistentFlags().Uint64Var(&blobPriceBump, "txpool.blobpricebump", txpoolcfg.DefaultConfig.BlobPriceBump, "Price bump percentage to replace an existing blob (type-3) transaction")
 	rootCmd.PersistentFlags().DurationVar(&commitEvery, utils.TxPoolCommitEveryFlag.Name, utils.TxPoolCommitEveryFlag.Value, utils.TxPoolCommitEveryFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&journal, utils.TxPoolJournalFlag.Name, utils.TxPoolJournalFlag.Value, utils.TxPoolJournalFlag.Usage)
+	rootCmd.PersistentFlags().DurationVar(&rejournalEvery, utils.TxPoolRejournalFlag.Name, utils.TxPoolRejournalFlag.Value, utils.TxPoolRejournalFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&ordering, utils.TxPoolOrderingFlag.Name, utils.TxPoolOrderingFlag.Value, utils.TxPoolOrderingFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&orderingFeeBand, utils.TxPoolOrderingFeeBandFlag.Name, utils.TxPoolOrderingFeeBandFlag.Value, utils.TxPoolOrderingFeeBandFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&stateCacheWarmStartFile, "state.cache.warmup.file", "", "File to save hottest keys of state cache at shutdown and restore them on start")
 }
 
 var rootCmd = &cobra.Command{
@@ -154,9 +166,40 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.PriceBump = priceBump
 	cfg.BlobPriceBump = blobPriceBump
 	cfg.NoGossip = noTxGossip
+	cfg.RejournalEvery = rejournalEvery
+	if cfg.Ordering, err = txpoolcfg.ParseOrdering(ordering); err != nil {
+		return err
+	}
+	cfg.OrderingFeeBand = orderingFeeBand
+	if journal != "" {
+		cfg.Journal = journal
+		if !filepath.IsAbs(journal) {
+			cfg.Journal = filepath.Join(dirs.DataDir, journal)
+		}
+	}
 
 	cacheConfig := kvcache.DefaultCoherentConfig
 	cacheConfig.MetricsLabel = "txpool"
+	cacheConfig.WarmStartFile = stateCacheWarmStartFile
+	stateCache := kvcache.New(cacheConfig)
+	if cacheConfig.WarmStartFile != "" {
+		go func() {
+			loaded, err := kvcache.WarmUp(ctx, stateCache, coreDB, cacheConfig.WarmStartFile, logger)
+			if err != nil {
+				logger.Warn("[txpool] state cache warm up failed", "err", err)
+				return
+			}
+			logger.Info("[txpool] state cache warmed up", "keys", loaded)
+		}()
+		defer func() {
+			saved, err := kvcache.SaveHotKeys(stateCache, cacheConfig.WarmStartFile)
+			if err != nil {
+				logger.Warn("[txpool] could not save state cache", "err", err)
+				return
+			}
+			logger.Info("[txpool] state cache saved", "keys", saved)
+		}()
+	}
 
 	cfg.TracedSenders = make([]string, len(traceSenders))
 	for i, senderHex := range traceSenders {
@@ -167,7 +210,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	newTxs := make(chan types.Announcements, 1024)
 	defer close(newTxs)
 	txPoolDB, txPool, fetch, send, txpoolGrpcServer, err := txpooluitl.AllComponents(ctx, cfg,
-		kvcache.New(cacheConfig), newTxs, coreDB, sentryClients, kvClient, misc.Eip1559FeeCalculator, logger)
+		stateCache, newTxs, coreDB, sentryClients, kvClient, misc.Eip1559FeeCalculator, logger)
 	if err != nil {
 		return err
 	}
@@ -182,7 +225,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	}
 
 	notifyMiner := func() {}
-	txpool.MainLoop(ctx, txPoolDB, txPool, newTxs, send, txpoolGrpcServer.NewSlotsStreams, notifyMiner)
+	txpool.MainLoop(ctx, txPoolDB, txPool, newTxs, send, txpoolGrpcServer.NewSlotsStreams, txpoolGrpcServer.DiscardedTxsStreams, notifyMiner)
 
 	grpcServer.GracefulStop()
 	return nil
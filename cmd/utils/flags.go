@@ -228,6 +228,26 @@ var (
 		Usage: "How often transactions should be committed to the storage",
 		Value: txpoolcfg.DefaultConfig.CommitEvery,
 	}
+	TxPoolJournalFlag = cli.StringFlag{
+		Name:  "txpool.journal",
+		Usage: "Disk journal for local transactions to survive txpool db wipes and node restarts, relative to datadir (disabled by default, e.g. transactions.journal)",
+		Value: ethconfig.Defaults.DeprecatedTxPool.Journal,
+	}
+	TxPoolRejournalFlag = cli.DurationFlag{
+		Name:  "txpool.rejournal",
+		Usage: "Time interval to regenerate the local transaction journal",
+		Value: ethconfig.Defaults.DeprecatedTxPool.Rejournal,
+	}
+	TxPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool.ordering",
+		Usage: "Order of pending transactions for block building: tip (highest effective tip first), fifo (first-seen first within a band of effective tip), roundrobin (one transaction per sender per round)",
+		Value: txpoolcfg.DefaultConfig.Ordering.String(),
+	}
+	TxPoolOrderingFeeBandFlag = cli.Uint64Flag{
+		Name:  "txpool.ordering.feeband",
+		Usage: "Width (in wei) of the effective tip bands used by --txpool.ordering=fifo, 0 means a single band",
+		Value: txpoolcfg.DefaultConfig.OrderingFeeBand,
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -514,6 +534,10 @@ var (
 		Value: "0MB",
 		Usage: "Amount of data to store in StateCache (enabled if no --datadir set). Set 0 to disable StateCache. Defaults to 0MB",
 	}
+	StateCacheWarmStartFileFlag = cli.StringFlag{
+		Name:  "state.cache.warmup.file",
+		Usage: "File to save hottest keys of StateCache at shutdown and restore them on start - to avoid latency spike after restart. Used only if --state.cache is enabled",
+	}
 
 	// Network Settings
 	MaxPeersFlag = cli.IntFlag{
@@ -541,6 +565,10 @@ var (
 		Usage: "Allowed ports to pick for different eth p2p protocol versions as follows <porta>,<portb>,..,<porti>",
 		Value: cli.NewUintSlice(uint(ListenPortFlag.Value), 30304, 30305, 30306, 30307),
 	}
+	P2pSnapServerFlag = cli.BoolFlag{
+		Name:  "p2p.snap",
+		Usage: "Serve snap/1 protocol, so other clients can snap-sync from this node. Serves state of the last 128 blocks from hashed state tables (older states are rewound by changesets, so history must not be pruned below that). --history.v3 and external sentries are not supported yet",
+	}
 	SentryAddrFlag = cli.StringFlag{
 		Name:  "sentry.api.addr",
 		Usage: "Comma separated sentry addresses '<host>:<port>,<host>:<port>'",
@@ -685,6 +713,23 @@ var (
 		Name:  ethconfig.FlagSnapStop,
 		Usage: "Workaround to stop producing new snapshots, if you meet some snapshots-related critical bug. It will stop move historical data from DB to new immutable snapshots. DB will grow and may slightly slow-down - and removing this flag in future will not fix this effect (db size will not greatly reduce).",
 	}
+	SnapCodecsFlag = cli.StringFlag{
+		Name:  ethconfig.FlagSnapCodecs,
+		Usage: "Codecs of new snapshot files, per snapshot type. Supported: patterns (default), zstd - every word compressed separately with a dictionary trained on sampled words: faster to build, but bigger files. Example: headers=zstd,transactions=zstd",
+	}
+	SnapDownloadFlag = cli.StringFlag{
+		Name:  ethconfig.FlagSnapDownload,
+		Usage: "Download only part of block snapshots: first block to download, per snapshot type. Other types are downloaded fully, headers - always. Requires --experimental.history.v3, first blocks must be at or below the state downloaded from snapshots (newer blocks are executed). Example: bodies=15000000,transactions=15000000",
+	}
+	SnapIndexWorkersFlag = cli.IntFlag{
+		Name:  ethconfig.FlagSnapIndexWorkers,
+		Usage: "Goroutines building one index of block snapshots. Produces the same index for any value",
+		Value: 1,
+	}
+	SnapIndexCheckpointFlag = cli.BoolFlag{
+		Name:  ethconfig.FlagSnapIndexCheckpoint,
+		Usage: "Periodically save progress of building indices of block snapshots, and continue from it after restart",
+	}
 	TorrentVerbosityFlag = cli.IntFlag{
 		Name:  "torrent.verbosity",
 		Value: 2,
@@ -770,6 +815,11 @@ var (
 		Usage: "Comma-separated URL's, holding metadata about network-support infrastructure (like S3 buckets with snapshots, bootnodes, etc...)",
 		Value: "",
 	}
+	WebSeedServerAddrFlag = cli.StringFlag{
+		Name:  "webseed.serve.addr",
+		Usage: "Serve downloaded snapshot files over HTTP on this address (e.g. 0.0.0.0:8090), so other nodes can use this one as --webseed. Disabled if empty",
+		Value: "",
+	}
 
 	HeimdallURLFlag = cli.StringFlag{
 		Name:  "bor.heimdall",
@@ -838,6 +888,11 @@ var (
 		Value: 25,
 	}
 
+	LogIndexesFlag = cli.StringFlag{
+		Name:  "logindex.custom",
+		Usage: `JSON file with user-defined log indices, they are built by LogIndex stage and queried by erigon_getLogsByIndex. Example: [{"name":"nft_by_token","address":"0x...","event":"Transfer(address,address,uint256)","keys":["topic3"]}]`,
+	}
+
 	DiagnosticsURLFlag = cli.StringFlag{
 		Name:  "diagnostics.addr",
 		Usage: "Address of the diagnostics system provided by the support team",
@@ -1154,6 +1209,7 @@ func setListenAddress(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(SentryAddrFlag.Name) {
 		cfg.SentryAddr = libcommon.CliString2Array(ctx.String(SentryAddrFlag.Name))
 	}
+	cfg.SnapServer = ctx.Bool(P2pSnapServerFlag.Name)
 	// TODO cli lib doesn't store defaults for UintSlice properly so we have to get value directly
 	cfg.AllowedPorts = P2pProtocolAllowedPorts.Value.Value()
 	if ctx.IsSet(P2pProtocolAllowedPorts.Name) {
@@ -1422,6 +1478,18 @@ func setTxPool(ctx *cli.Context, fullCfg *ethconfig.Config) {
 		fullCfg.TxPool.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
 	}
 	cfg.CommitEvery = common2.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
+	cfg.Journal = ctx.String(TxPoolJournalFlag.Name)
+	cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
+	if ctx.IsSet(TxPoolOrderingFlag.Name) {
+		ordering, err := txpoolcfg.ParseOrdering(ctx.String(TxPoolOrderingFlag.Name))
+		if err != nil {
+			Fatalf("Option %s: %v", TxPoolOrderingFlag.Name, err)
+		}
+		fullCfg.TxPool.Ordering = ordering
+	}
+	if ctx.IsSet(TxPoolOrderingFeeBandFlag.Name) {
+		fullCfg.TxPool.OrderingFeeBand = ctx.Uint64(TxPoolOrderingFeeBandFlag.Name)
+	}
 }
 
 func setEthash(ctx *cli.Context, datadir string, cfg *ethconfig.Config) {
@@ -1662,6 +1730,18 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Dirs = nodeConfig.Dirs
 	cfg.Snapshot.KeepBlocks = ctx.Bool(SnapKeepBlocksFlag.Name)
 	cfg.Snapshot.Produce = !ctx.Bool(SnapStopFlag.Name)
+	codecs, err := ethconfig.ParseSnapshotCodecs(ctx.String(SnapCodecsFlag.Name))
+	if err != nil {
+		Fatalf("Option %s: %v", SnapCodecsFlag.Name, err)
+	}
+	cfg.Snapshot.Codecs = codecs
+	downloadPolicy, err := snapcfg.ParseDownloadPolicy(ctx.String(SnapDownloadFlag.Name))
+	if err != nil {
+		Fatalf("Option %s: %v", SnapDownloadFlag.Name, err)
+	}
+	cfg.Snapshot.DownloadPolicy = downloadPolicy
+	cfg.Snapshot.IndexWorkers = ctx.Int(SnapIndexWorkersFlag.Name)
+	cfg.Snapshot.IndexCheckpoint = ctx.Bool(SnapIndexCheckpointFlag.Name)
 	cfg.Snapshot.NoDownloader = ctx.Bool(NoDownloaderFlag.Name)
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
@@ -1690,6 +1770,8 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 			panic(err)
 		}
 		downloadernat.DoNat(nodeConfig.P2P.NAT, cfg.Downloader.ClientConfig, logger)
+		cfg.Downloader.WebSeedServerAddr = ctx.String(WebSeedServerAddrFlag.Name)
+		cfg.Downloader.DownloadPolicy = cfg.Snapshot.DownloadPolicy
 	}
 
 	nodeConfig.Http.Snap = cfg.Snapshot
@@ -1704,6 +1786,9 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	setTxPool(ctx, cfg)
 	cfg.TxPool = ethconfig.DefaultTxPool2Config(cfg)
 	cfg.TxPool.DBDir = nodeConfig.Dirs.TxPool
+	if cfg.TxPool.Journal != "" && !filepath.IsAbs(cfg.TxPool.Journal) {
+		cfg.TxPool.Journal = filepath.Join(nodeConfig.Dirs.DataDir, cfg.TxPool.Journal)
+	}
 
 	setEthash(ctx, nodeConfig.Dirs.DataDir, cfg)
 	setClique(ctx, &cfg.Clique, nodeConfig.Dirs.DataDir)
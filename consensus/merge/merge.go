@@ -131,17 +131,39 @@ func (s *Merge) CalculateRewards(config *chain.Config, header *types.Header, unc
 }
 
 func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *state.IntraBlockState,
-	txs types.Transactions, uncles []*types.Header, r types.Receipts, withdrawals []*types.Withdrawal,
+	txs types.Transactions, uncles []*types.Header, r types.Receipts, withdrawals []*types.Withdrawal, requests types.Requests,
 	chain consensus.ChainReader, syscall consensus.SystemCall, logger log.Logger,
 ) (types.Transactions, types.Receipts, error) {
 	if !misc.IsPoSHeader(header) {
-		return s.eth1Engine.Finalize(config, header, state, txs, uncles, r, withdrawals, chain, syscall, logger)
+		return s.eth1Engine.Finalize(config, header, state, txs, uncles, r, withdrawals, requests, chain, syscall, logger)
 	}
 
-	rewards, err := s.CalculateRewards(config, header, uncles, syscall)
+	outTxs, outReceipts, rs, err := s.finalize(config, header, state, txs, uncles, r, withdrawals, requests, syscall)
 	if err != nil {
 		return nil, nil, err
 	}
+	// Without receipts (e.g. exec3) deposits can't be re-derived from the logs, so they are taken from the block
+	if config.IsPrague(header.Time) {
+		if header.RequestsRoot == nil {
+			return nil, nil, fmt.Errorf("%w: missing requestsRoot", consensus.ErrInvalidBlock)
+		}
+		if root := types.DeriveSha(rs); root != *header.RequestsRoot {
+			return nil, nil, fmt.Errorf("%w: invalid requests root: have %x, exp %x", consensus.ErrInvalidBlock, root, *header.RequestsRoot)
+		}
+	}
+	return outTxs, outReceipts, nil
+}
+
+// finalize applies the rewards and withdrawals and, since Prague, collects the EIP-7685 requests of the block:
+// deposits (EIP-6110), then withdrawal requests (EIP-7002), then consolidation requests (EIP-7251)
+func (s *Merge) finalize(config *chain.Config, header *types.Header, state *state.IntraBlockState,
+	txs types.Transactions, uncles []*types.Header, r types.Receipts, withdrawals []*types.Withdrawal, requests types.Requests,
+	syscall consensus.SystemCall,
+) (types.Transactions, types.Receipts, types.Requests, error) {
+	rewards, err := s.CalculateRewards(config, header, uncles, syscall)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	for _, r := range rewards {
 		state.AddBalance(r.Beneficiary, &r.Amount)
 	}
@@ -149,7 +171,7 @@ func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *stat
 	if withdrawals != nil {
 		if auraEngine, ok := s.eth1Engine.(*aura.AuRa); ok {
 			if err := auraEngine.ExecuteSystemWithdrawals(withdrawals, syscall); err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		} else {
 			for _, w := range withdrawals {
@@ -159,21 +181,51 @@ func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *stat
 		}
 	}
 
-	return txs, r, nil
+	if !config.IsPrague(header.Time) {
+		return txs, r, nil, nil
+	}
+
+	rs := types.Requests{}
+	if r != nil {
+		var logs []*types.Log
+		for _, receipt := range r {
+			logs = append(logs, receipt.Logs...)
+		}
+		deposits, err := types.ParseDepositLogs(logs, config.DepositContract)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %v", consensus.ErrInvalidBlock, err)
+		}
+		rs = append(rs, deposits...)
+	} else {
+		for _, d := range requests.Deposits() {
+			rs = append(rs, d)
+		}
+	}
+	withdrawalRequests, err := misc.DequeueWithdrawalRequests7002(syscall)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rs = append(rs, withdrawalRequests...)
+	consolidations, err := misc.DequeueConsolidationRequests7251(syscall)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rs = append(rs, consolidations...)
+	return txs, r, rs, nil
 }
 
 func (s *Merge) FinalizeAndAssemble(config *chain.Config, header *types.Header, state *state.IntraBlockState,
-	txs types.Transactions, uncles []*types.Header, receipts types.Receipts, withdrawals []*types.Withdrawal,
+	txs types.Transactions, uncles []*types.Header, receipts types.Receipts, withdrawals []*types.Withdrawal, requests types.Requests,
 	chain consensus.ChainReader, syscall consensus.SystemCall, call consensus.Call, logger log.Logger,
 ) (*types.Block, types.Transactions, types.Receipts, error) {
 	if !misc.IsPoSHeader(header) {
-		return s.eth1Engine.FinalizeAndAssemble(config, header, state, txs, uncles, receipts, withdrawals, chain, syscall, call, logger)
+		return s.eth1Engine.FinalizeAndAssemble(config, header, state, txs, uncles, receipts, withdrawals, requests, chain, syscall, call, logger)
 	}
-	outTxs, outReceipts, err := s.Finalize(config, header, state, txs, uncles, receipts, withdrawals, chain, syscall, logger)
+	outTxs, outReceipts, rs, err := s.finalize(config, header, state, txs, uncles, receipts, withdrawals, requests, syscall)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	return types.NewBlock(header, outTxs, uncles, outReceipts, withdrawals), outTxs, outReceipts, nil
+	return types.NewBlockWithRequests(header, outTxs, uncles, outReceipts, withdrawals, rs), outTxs, outReceipts, nil
 }
 
 func (s *Merge) SealHash(header *types.Header) (hash libcommon.Hash) {
@@ -242,6 +294,15 @@ func (s *Merge) verifyHeader(chain consensus.ChainHeaderReader, header, parent *
 		return consensus.ErrUnexpectedWithdrawals
 	}
 
+	// Verify existence / non-existence of requestsRoot
+	prague := chain.Config().IsPrague(header.Time)
+	if prague && header.RequestsRoot == nil {
+		return fmt.Errorf("%w: missing requestsRoot", consensus.ErrInvalidBlock)
+	}
+	if !prague && header.RequestsRoot != nil {
+		return consensus.ErrUnexpectedRequests
+	}
+
 	if !chain.Config().IsCancun(header.Time) {
 		return misc.VerifyAbsenceOfCancunHeaderFields(header)
 	}
@@ -1,6 +1,7 @@
 package merge
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -89,3 +90,27 @@ func TestVerifyHeaderNonce(t *testing.T) {
 		}
 	}
 }
+
+func TestFinalizeRequestsRoot(t *testing.T) {
+	config := &chain.Config{PragueTime: big.NewInt(0)}
+	syscall := func(libcommon.Address, []byte) ([]byte, error) { return nil, nil }
+	mergeEngine := New(nil)
+
+	// Blocks without receipts and requests are checked too
+	header := &types.Header{Difficulty: big.NewInt(0), Time: 1}
+	_, _, err := mergeEngine.Finalize(config, header, nil, nil, nil, nil, nil, nil, nil, syscall, nil)
+	if !errors.Is(err, consensus.ErrInvalidBlock) {
+		t.Fatalf("Merge engine should not accept a Prague block without requestsRoot, got %v", err)
+	}
+
+	header.RequestsRoot = &libcommon.Hash{1}
+	_, _, err = mergeEngine.Finalize(config, header, nil, nil, nil, nil, nil, nil, nil, syscall, nil)
+	if !errors.Is(err, consensus.ErrInvalidBlock) {
+		t.Fatalf("Merge engine should not accept an invalid requestsRoot, got %v", err)
+	}
+
+	header.RequestsRoot = &types.EmptyRootHash
+	if _, _, err = mergeEngine.Finalize(config, header, nil, nil, nil, nil, nil, nil, nil, syscall, nil); err != nil {
+		t.Fatalf("Merge engine should accept an empty requestsRoot, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package misc
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon/consensus"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/params"
+)
+
+// DequeueConsolidationRequests7251 calls the EIP-7251 system contract at the end of the block,
+// which dequeues the consolidation requests to be included in the block
+func DequeueConsolidationRequests7251(syscall consensus.SystemCall) (types.Requests, error) {
+	res, err := syscall(params.ConsolidationRequestAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consolidation requests contract call: %w", err)
+	}
+	return types.ParseConsolidationRequests(res)
+}
@@ -538,6 +538,11 @@ func GenesisToBlock(g *types.Genesis, tmpDir string, logger log.Logger) (*types.
 		withdrawals = []*types.Withdrawal{}
 	}
 
+	var requests types.Requests
+	if g.Config != nil && g.Config.IsPrague(g.Timestamp) {
+		requests = types.Requests{}
+	}
+
 	if g.Config != nil && g.Config.IsCancun(g.Timestamp) {
 		if g.BlobGasUsed != nil {
 			head.BlobGasUsed = g.BlobGasUsed
@@ -632,7 +637,7 @@ func GenesisToBlock(g *types.Genesis, tmpDir string, logger log.Logger) (*types.
 
 	head.Root = root
 
-	return types.NewBlock(head, nil, nil, nil, withdrawals), statedb, nil
+	return types.NewBlockWithRequests(head, nil, nil, nil, withdrawals, requests), statedb, nil
 }
 
 func sortedAllocKeys(m types.GenesisAlloc) []string {
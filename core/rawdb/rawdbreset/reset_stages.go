@@ -182,7 +182,7 @@ var Tables = map[stages.SyncStage][]string{
 	stages.HashState:           {kv.HashedAccounts, kv.HashedStorage, kv.ContractCode},
 	stages.IntermediateHashes:  {kv.TrieOfAccounts, kv.TrieOfStorage},
 	stages.CallTraces:          {kv.CallFromIndex, kv.CallToIndex},
-	stages.LogIndex:            {kv.LogAddressIndex, kv.LogTopicIndex},
+	stages.LogIndex:            {kv.LogAddressIndex, kv.LogTopicIndex, kv.LogCustomIndex},
 	stages.AccountHistoryIndex: {kv.E2AccountsHistory},
 	stages.StorageHistoryIndex: {kv.E2StorageHistory},
 	stages.Finish:              {},
@@ -105,6 +105,8 @@ type Header struct {
 
 	ParentBeaconBlockRoot *libcommon.Hash `json:"parentBeaconBlockRoot"` // EIP-4788
 
+	RequestsRoot *libcommon.Hash `json:"requestsRoot"` // EIP-7685
+
 	// The verkle proof is ignored in legacy headers
 	Verkle        bool
 	VerkleProof   []byte
@@ -161,6 +163,10 @@ func (h *Header) EncodingSize() int {
 		encodingSize += 33
 	}
 
+	if h.RequestsRoot != nil {
+		encodingSize += 33
+	}
+
 	if h.Verkle {
 		// Encoding of Verkle Proof
 		encodingSize += rlp2.StringLen(h.VerkleProof)
@@ -310,6 +316,16 @@ func (h *Header) EncodeRLP(w io.Writer) error {
 		}
 	}
 
+	if h.RequestsRoot != nil {
+		b[0] = 128 + 32
+		if _, err := w.Write(b[:1]); err != nil {
+			return err
+		}
+		if _, err := w.Write(h.RequestsRoot.Bytes()); err != nil {
+			return err
+		}
+	}
+
 	if h.Verkle {
 		if err := rlp.EncodeString(h.VerkleProof, w, b[:]); err != nil {
 			return err
@@ -498,6 +514,23 @@ func (h *Header) DecodeRLP(s *rlp.Stream) error {
 	h.ParentBeaconBlockRoot = new(libcommon.Hash)
 	h.ParentBeaconBlockRoot.SetBytes(b)
 
+	// RequestsRoot
+	if b, err = s.Bytes(); err != nil {
+		if errors.Is(err, rlp.EOL) {
+			h.RequestsRoot = nil
+			if err := s.ListEnd(); err != nil {
+				return fmt.Errorf("close header struct (no RequestsRoot): %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("read RequestsRoot: %w", err)
+	}
+	if len(b) != 32 {
+		return fmt.Errorf("wrong size for RequestsRoot: %d", len(b))
+	}
+	h.RequestsRoot = new(libcommon.Hash)
+	h.RequestsRoot.SetBytes(b)
+
 	if h.Verkle {
 		if h.VerkleProof, err = s.Bytes(); err != nil {
 			return fmt.Errorf("read VerkleProof: %w", err)
@@ -557,6 +590,9 @@ func (h *Header) Size() common.StorageSize {
 	if h.ParentBeaconBlockRoot != nil {
 		s += common.StorageSize(32)
 	}
+	if h.RequestsRoot != nil {
+		s += common.StorageSize(32)
+	}
 	return s
 }
 
@@ -591,6 +627,7 @@ type Body struct {
 	Transactions []Transaction
 	Uncles       []*Header
 	Withdrawals  []*Withdrawal
+	Requests     Requests
 }
 
 // RawBody is semi-parsed variant of Body, where transactions are still unparsed RLP strings
@@ -600,6 +637,7 @@ type RawBody struct {
 	Transactions [][]byte
 	Uncles       []*Header
 	Withdrawals  []*Withdrawal
+	Requests     Requests
 }
 
 type BodyForStorage struct {
@@ -607,6 +645,7 @@ type BodyForStorage struct {
 	TxAmount    uint32
 	Uncles      []*Header
 	Withdrawals []*Withdrawal
+	Requests    Requests
 }
 
 // Alternative representation of the Block.
@@ -619,6 +658,7 @@ func (r RawBlock) AsBlock() (*Block, error) {
 	b := &Block{header: r.Header}
 	b.uncles = r.Body.Uncles
 	b.withdrawals = r.Body.Withdrawals
+	b.requests = r.Body.Requests
 
 	txs := make([]Transaction, len(r.Body.Transactions))
 	for i, tx := range r.Body.Transactions {
@@ -638,6 +678,7 @@ type Block struct {
 	uncles       []*Header
 	transactions Transactions
 	withdrawals  []*Withdrawal
+	requests     Requests
 
 	// caches
 	hash atomic.Value
@@ -693,6 +734,12 @@ func (rb RawBody) payloadSize() (payloadSize, txsLen, unclesLen, withdrawalsLen
 		payloadSize += rlp2.ListPrefixLen(withdrawalsLen) + withdrawalsLen
 	}
 
+	// size of Requests
+	if rb.Requests != nil {
+		requestsLen := rb.Requests.payloadSize()
+		payloadSize += rlp2.ListPrefixLen(requestsLen) + requestsLen
+	}
+
 	return payloadSize, txsLen, unclesLen, withdrawalsLen
 }
 
@@ -732,6 +779,13 @@ func (rb RawBody) EncodeRLP(w io.Writer) error {
 			}
 		}
 	}
+	// encode Requests
+	// nil if pre-prague, empty slice if prague and no requests in block, otherwise non-empty
+	if rb.Requests != nil {
+		if err := rb.Requests.encodeRLP(rb.Requests.payloadSize(), w, b[:]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -803,6 +857,11 @@ func (rb *RawBody) DecodeRLP(s *rlp.Stream) error {
 		return err
 	}
 
+	// decode Requests
+	if rb.Requests, err = decodeRequests(s); err != nil {
+		return err
+	}
+
 	return s.ListEnd()
 }
 
@@ -829,6 +888,12 @@ func (bfs BodyForStorage) payloadSize() (payloadSize, unclesLen, withdrawalsLen
 		payloadSize += rlp2.ListPrefixLen(withdrawalsLen) + withdrawalsLen
 	}
 
+	// size of Requests
+	if bfs.Requests != nil {
+		requestsLen := bfs.Requests.payloadSize()
+		payloadSize += rlp2.ListPrefixLen(requestsLen) + requestsLen
+	}
+
 	return payloadSize, unclesLen, withdrawalsLen
 }
 
@@ -872,6 +937,13 @@ func (bfs BodyForStorage) EncodeRLP(w io.Writer) error {
 			}
 		}
 	}
+	// encode Requests
+	// nil if pre-prague, empty slice if prague and no requests in block, otherwise non-empty
+	if bfs.Requests != nil {
+		if err := bfs.Requests.encodeRLP(bfs.Requests.payloadSize(), w, b[:]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -938,6 +1010,11 @@ func (bfs *BodyForStorage) DecodeRLP(s *rlp.Stream) error {
 		return err
 	}
 
+	// decode Requests
+	if bfs.Requests, err = decodeRequests(s); err != nil {
+		return err
+	}
+
 	return s.ListEnd()
 }
 
@@ -970,6 +1047,12 @@ func (bb Body) payloadSize() (payloadSize int, txsLen, unclesLen, withdrawalsLen
 		payloadSize += rlp2.ListPrefixLen(withdrawalsLen) + withdrawalsLen
 	}
 
+	// size of Requests
+	if bb.Requests != nil {
+		requestsLen := bb.Requests.payloadSize()
+		payloadSize += rlp2.ListPrefixLen(requestsLen) + requestsLen
+	}
+
 	return payloadSize, txsLen, unclesLen, withdrawalsLen
 }
 
@@ -1009,6 +1092,13 @@ func (bb Body) EncodeRLP(w io.Writer) error {
 			}
 		}
 	}
+	// encode Requests
+	// nil if pre-prague, empty slice if prague and no requests in block, otherwise non-empty
+	if bb.Requests != nil {
+		if err := bb.Requests.encodeRLP(bb.Requests.payloadSize(), w, b[:]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1078,6 +1168,11 @@ func (bb *Body) DecodeRLP(s *rlp.Stream) error {
 		return err
 	}
 
+	// decode Requests
+	if bb.Requests, err = decodeRequests(s); err != nil {
+		return err
+	}
+
 	return s.ListEnd()
 }
 
@@ -1088,6 +1183,12 @@ func (bb *Body) DecodeRLP(s *rlp.Stream) error {
 // in the header are ignored and set to the values derived from
 // the given txs, uncles, receipts, and withdrawals.
 func NewBlock(header *Header, txs []Transaction, uncles []*Header, receipts []*Receipt, withdrawals []*Withdrawal) *Block {
+	return NewBlockWithRequests(header, txs, uncles, receipts, withdrawals, nil)
+}
+
+// NewBlockWithRequests is NewBlock for post-Prague blocks: RequestsRoot in the header
+// is also ignored and set to the value derived from the given requests.
+func NewBlockWithRequests(header *Header, txs []Transaction, uncles []*Header, receipts []*Receipt, withdrawals []*Withdrawal, requests Requests) *Block {
 	b := &Block{header: CopyHeader(header)}
 
 	// TODO: panic if len(txs) != len(receipts)
@@ -1134,13 +1235,24 @@ func NewBlock(header *Header, txs []Transaction, uncles []*Header, receipts []*R
 
 	b.header.ParentBeaconBlockRoot = header.ParentBeaconBlockRoot
 
+	if requests == nil {
+		b.header.RequestsRoot = nil
+	} else if len(requests) == 0 {
+		b.header.RequestsRoot = &EmptyRootHash
+		b.requests = Requests{}
+	} else {
+		h := DeriveSha(requests)
+		b.header.RequestsRoot = &h
+		b.requests = requests.copy()
+	}
+
 	return b
 }
 
 // NewBlockFromStorage like NewBlock but used to create Block object when read it from DB
 // in this case no reason to copy parts, or re-calculate headers fields - they are all stored in DB
-func NewBlockFromStorage(hash libcommon.Hash, header *Header, txs []Transaction, uncles []*Header, withdrawals []*Withdrawal) *Block {
-	b := &Block{header: header, transactions: txs, uncles: uncles, withdrawals: withdrawals}
+func NewBlockFromStorage(hash libcommon.Hash, header *Header, txs []Transaction, uncles []*Header, withdrawals []*Withdrawal, requests Requests) *Block {
+	b := &Block{header: header, transactions: txs, uncles: uncles, withdrawals: withdrawals, requests: requests}
 	b.hash.Store(hash)
 	return b
 }
@@ -1160,6 +1272,7 @@ func NewBlockFromNetwork(header *Header, body *Body) *Block {
 		transactions: body.Transactions,
 		uncles:       body.Uncles,
 		withdrawals:  body.Withdrawals,
+		requests:     body.Requests,
 	}
 }
 
@@ -1201,6 +1314,10 @@ func CopyHeader(h *Header) *Header {
 		cpy.ParentBeaconBlockRoot = new(libcommon.Hash)
 		cpy.ParentBeaconBlockRoot.SetBytes(h.ParentBeaconBlockRoot.Bytes())
 	}
+	if h.RequestsRoot != nil {
+		cpy.RequestsRoot = new(libcommon.Hash)
+		cpy.RequestsRoot.SetBytes(h.RequestsRoot.Bytes())
+	}
 	return &cpy
 }
 
@@ -1279,6 +1396,11 @@ func (bb *Block) DecodeRLP(s *rlp.Stream) error {
 		return err
 	}
 
+	// decode Requests
+	if bb.requests, err = decodeRequests(s); err != nil {
+		return err
+	}
+
 	return s.ListEnd()
 }
 
@@ -1310,6 +1432,12 @@ func (bb Block) payloadSize() (payloadSize int, txsLen, unclesLen, withdrawalsLe
 		payloadSize += rlp2.ListPrefixLen(withdrawalsLen) + withdrawalsLen
 	}
 
+	// size of Requests
+	if bb.requests != nil {
+		requestsLen := bb.requests.payloadSize()
+		payloadSize += rlp2.ListPrefixLen(requestsLen) + requestsLen
+	}
+
 	return payloadSize, txsLen, unclesLen, withdrawalsLen
 }
 
@@ -1359,6 +1487,13 @@ func (bb Block) EncodeRLP(w io.Writer) error {
 			}
 		}
 	}
+	// encode Requests
+	// nil if pre-prague, empty slice if prague and no requests in block, otherwise non-empty
+	if bb.requests != nil {
+		if err := bb.requests.encodeRLP(bb.requests.payloadSize(), w, b[:]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1401,6 +1536,8 @@ func (b *Block) BaseFee() *big.Int {
 func (b *Block) WithdrawalsHash() *libcommon.Hash       { return b.header.WithdrawalsHash }
 func (b *Block) Withdrawals() Withdrawals               { return b.withdrawals }
 func (b *Block) ParentBeaconBlockRoot() *libcommon.Hash { return b.header.ParentBeaconBlockRoot }
+func (b *Block) RequestsRoot() *libcommon.Hash          { return b.header.RequestsRoot }
+func (b *Block) Requests() Requests                     { return b.requests }
 
 // Header returns a deep-copy of the entire block header using CopyHeader()
 func (b *Block) Header() *Header       { return CopyHeader(b.header) }
@@ -1408,7 +1545,7 @@ func (b *Block) HeaderNoCopy() *Header { return b.header }
 
 // Body returns the non-header content of the block.
 func (b *Block) Body() *Body {
-	bd := &Body{Transactions: b.transactions, Uncles: b.uncles, Withdrawals: b.withdrawals}
+	bd := &Body{Transactions: b.transactions, Uncles: b.uncles, Withdrawals: b.withdrawals, Requests: b.requests}
 	bd.SendersFromTxs()
 	return bd
 }
@@ -1424,7 +1561,7 @@ func (b *Block) SendersToTxs(senders []libcommon.Address) {
 // RawBody creates a RawBody based on the block. It is not very efficient, so
 // will probably be removed in favour of RawBlock. Also it panics
 func (b *Block) RawBody() *RawBody {
-	br := &RawBody{Transactions: make([][]byte, len(b.transactions)), Uncles: b.uncles, Withdrawals: b.withdrawals}
+	br := &RawBody{Transactions: make([][]byte, len(b.transactions)), Uncles: b.uncles, Withdrawals: b.withdrawals, Requests: b.requests}
 	for i, tx := range b.transactions {
 		var err error
 		br.Transactions[i], err = rlp.EncodeToBytes(tx)
@@ -1437,7 +1574,7 @@ func (b *Block) RawBody() *RawBody {
 
 // RawBody creates a RawBody based on the body.
 func (b *Body) RawBody() *RawBody {
-	br := &RawBody{Transactions: make([][]byte, len(b.Transactions)), Uncles: b.Uncles, Withdrawals: b.Withdrawals}
+	br := &RawBody{Transactions: make([][]byte, len(b.Transactions)), Uncles: b.Uncles, Withdrawals: b.Withdrawals, Requests: b.Requests}
 	for i, tx := range b.Transactions {
 		var err error
 		br.Transactions[i], err = rlp.EncodeToBytes(tx)
@@ -1466,7 +1603,7 @@ func (b *Block) SanityCheck() error {
 	return b.header.SanityCheck()
 }
 
-// HashCheck checks that transactions, receipts, uncles and withdrawals hashes are correct.
+// HashCheck checks that transactions, receipts, uncles, withdrawals and requests hashes are correct.
 func (b *Block) HashCheck() error {
 	if hash := DeriveSha(b.Transactions()); hash != b.TxHash() {
 		return fmt.Errorf("block has invalid transaction hash: have %x, exp: %x", hash, b.TxHash())
@@ -1496,6 +1633,19 @@ func (b *Block) HashCheck() error {
 	if hash := DeriveSha(b.Withdrawals()); hash != *b.WithdrawalsHash() {
 		return fmt.Errorf("block has invalid withdrawals hash: have %x, exp: %x", hash, b.WithdrawalsHash())
 	}
+
+	if b.RequestsRoot() == nil {
+		if b.Requests() != nil {
+			return errors.New("header missing RequestsRoot")
+		}
+		return nil
+	}
+	if b.Requests() == nil {
+		return errors.New("body missing Requests")
+	}
+	if hash := DeriveSha(b.Requests()); hash != *b.RequestsRoot() {
+		return fmt.Errorf("block has invalid requests root: have %x, exp: %x", hash, b.RequestsRoot())
+	}
 	return nil
 }
 
@@ -1569,6 +1719,7 @@ func (b *Block) Copy() *Block {
 		uncles:       uncles,
 		transactions: CopyTxs(b.transactions),
 		withdrawals:  withdrawals,
+		requests:     b.requests.copy(),
 		hash:         hashValue,
 		size:         sizeValue,
 	}
@@ -1584,6 +1735,7 @@ func (b *Block) WithSeal(header *Header) *Block {
 		transactions: b.transactions,
 		uncles:       b.uncles,
 		withdrawals:  b.withdrawals,
+		requests:     b.requests,
 	}
 }
 
@@ -530,6 +530,60 @@ func TestWithdrawalsEncoding(t *testing.T) {
 	assert.Equal(t, block2, &decoded2)
 }
 
+func TestRequestsEncoding(t *testing.T) {
+	t.Parallel()
+	header := Header{
+		ParentHash: libcommon.HexToHash("0x8b00fcf1e541d371a3a1b79cc999a85cc3db5ee5637b5159646e1acd3613fd15"),
+		Coinbase:   libcommon.HexToAddress("0x571846e42308df2dad8ed792f44a8bfddf0acb4d"),
+		Root:       libcommon.HexToHash("0x351780124dae86b84998c6d4fe9a88acfb41b4856b4f2c56767b51a4e2f94dd4"),
+		Difficulty: libcommon.Big0,
+		Number:     big.NewInt(20_000_000),
+		GasLimit:   30_000_000,
+		Time:       1666343339,
+		Extra:      make([]byte, 0),
+		BaseFee:    big.NewInt(7_000_000_000),
+	}
+	var blobGasUsed, excessBlobGas uint64
+	header.BlobGasUsed = &blobGasUsed
+	header.ExcessBlobGas = &excessBlobGas
+	header.ParentBeaconBlockRoot = &libcommon.Hash{}
+
+	requests := Requests{
+		&DepositRequest{
+			Pubkey:                [BLSPubKeyLen]byte{0x01},
+			WithdrawalCredentials: libcommon.HexToHash("0x010000000000000000000000690b9a9e9aa1c9db991c7721a92d351db4fac990"),
+			Amount:                32_000_000_000,
+			Signature:             [BLSSignatureLen]byte{0x02},
+			Index:                 7,
+		},
+		&WithdrawalRequest{
+			SourceAddress: libcommon.HexToAddress("0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"),
+			Amount:        1_000_000_000,
+		},
+		&ConsolidationRequest{
+			SourceAddress: libcommon.HexToAddress("0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"),
+			SourcePubKey:  [BLSPubKeyLen]byte{0x03},
+			TargetPubKey:  [BLSPubKeyLen]byte{0x04},
+		},
+	}
+
+	block := NewBlockWithRequests(&header, nil, nil, nil, []*Withdrawal{}, requests)
+	_ = block.Size()
+	require.NotNil(t, block.RequestsRoot())
+	assert.Equal(t, DeriveSha(requests), *block.RequestsRoot())
+
+	encoded, err := rlp.EncodeToBytes(block)
+	require.NoError(t, err)
+
+	var decoded Block
+	require.NoError(t, rlp.DecodeBytes(encoded, &decoded))
+
+	assert.Equal(t, block, &decoded)
+	assert.Equal(t, 1, len(decoded.Requests().Deposits()))
+	assert.Equal(t, 1, len(decoded.Requests().Withdrawals()))
+	assert.Equal(t, 1, len(decoded.Requests().Consolidations()))
+}
+
 func TestBlockRawBodyPreShanghai(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
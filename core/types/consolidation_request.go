@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of the Erigon library.
+//
+// The Erigon library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Erigon library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Erigon library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+)
+
+// consolidationRequestLen - length of a request in the EIP-7251 system contract output:
+// source_address ++ source_pubkey ++ target_pubkey
+const consolidationRequestLen = length.Addr + 2*BLSPubKeyLen
+
+// ConsolidationRequest is an execution layer triggered validator consolidation, see EIP-7251.
+type ConsolidationRequest struct {
+	SourceAddress libcommon.Address
+	SourcePubKey  [BLSPubKeyLen]byte
+	TargetPubKey  [BLSPubKeyLen]byte
+}
+
+type consolidationRequestJson struct {
+	SourceAddress libcommon.Address `json:"sourceAddress"`
+	SourcePubKey  hexutility.Bytes  `json:"sourcePubkey"`
+	TargetPubKey  hexutility.Bytes  `json:"targetPubkey"`
+}
+
+func (c *ConsolidationRequest) RequestType() byte { return ConsolidationRequestType }
+func (c *ConsolidationRequest) MarshalBinary(w io.Writer) error {
+	return writeTypedRequest(ConsolidationRequestType, c, w)
+}
+func (c *ConsolidationRequest) EncodingSize() int { return typedRequestSize(c) }
+func (c *ConsolidationRequest) copy() Request {
+	cpy := *c
+	return &cpy
+}
+
+func (c ConsolidationRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(consolidationRequestJson{
+		SourceAddress: c.SourceAddress,
+		SourcePubKey:  c.SourcePubKey[:],
+		TargetPubKey:  c.TargetPubKey[:],
+	})
+}
+
+func (c *ConsolidationRequest) UnmarshalJSON(input []byte) error {
+	var dec consolidationRequestJson
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if len(dec.SourcePubKey) != BLSPubKeyLen || len(dec.TargetPubKey) != BLSPubKeyLen {
+		return fmt.Errorf("ConsolidationRequest: invalid pubkey length: source %d, target %d", len(dec.SourcePubKey), len(dec.TargetPubKey))
+	}
+	c.SourceAddress = dec.SourceAddress
+	copy(c.SourcePubKey[:], dec.SourcePubKey)
+	copy(c.TargetPubKey[:], dec.TargetPubKey)
+	return nil
+}
+
+// ParseConsolidationRequests parses the output of the EIP-7251 system contract dequeue call
+func ParseConsolidationRequests(data []byte) (Requests, error) {
+	if len(data)%consolidationRequestLen != 0 {
+		return nil, fmt.Errorf("invalid consolidation requests length: %d", len(data))
+	}
+	reqs := make(Requests, 0, len(data)/consolidationRequestLen)
+	for i := 0; i < len(data); i += consolidationRequestLen {
+		c := &ConsolidationRequest{
+			SourceAddress: libcommon.BytesToAddress(data[i : i+length.Addr]),
+		}
+		copy(c.SourcePubKey[:], data[i+length.Addr:])
+		copy(c.TargetPubKey[:], data[i+length.Addr+BLSPubKeyLen:])
+		reqs = append(reqs, c)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of the Erigon library.
+//
+// The Erigon library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Erigon library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Erigon library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+
+	"github.com/ledgerwatch/erigon/accounts/abi"
+)
+
+const (
+	BLSPubKeyLen    = 48
+	BLSSignatureLen = 96
+)
+
+const depositEventABI = `[{"anonymous":false,"inputs":[
+	{"indexed":false,"internalType":"bytes","name":"pubkey","type":"bytes"},
+	{"indexed":false,"internalType":"bytes","name":"withdrawal_credentials","type":"bytes"},
+	{"indexed":false,"internalType":"bytes","name":"amount","type":"bytes"},
+	{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"},
+	{"indexed":false,"internalType":"bytes","name":"index","type":"bytes"}],
+	"name":"DepositEvent","type":"event"}]`
+
+var (
+	depositABI = func() abi.ABI {
+		a, err := abi.JSON(strings.NewReader(depositEventABI))
+		if err != nil {
+			panic(err)
+		}
+		return a
+	}()
+	// DepositEventSignature - keccak256("DepositEvent(bytes,bytes,bytes,bytes,bytes)")
+	DepositEventSignature = depositABI.Events["DepositEvent"].ID
+)
+
+// DepositRequest is a validator deposit made through the deposit contract, see EIP-6110.
+type DepositRequest struct {
+	Pubkey                [BLSPubKeyLen]byte    // public key of validator
+	WithdrawalCredentials libcommon.Hash        // beneficiary of the validator funds
+	Amount                uint64                // deposit size in Gwei
+	Signature             [BLSSignatureLen]byte // signature over deposit msg
+	Index                 uint64                // deposit count value
+}
+
+type depositRequestJson struct {
+	Pubkey                hexutility.Bytes `json:"pubkey"`
+	WithdrawalCredentials libcommon.Hash   `json:"withdrawalCredentials"`
+	Amount                hexutil.Uint64   `json:"amount"`
+	Signature             hexutility.Bytes `json:"signature"`
+	Index                 hexutil.Uint64   `json:"index"`
+}
+
+func (d *DepositRequest) RequestType() byte { return DepositRequestType }
+func (d *DepositRequest) MarshalBinary(w io.Writer) error {
+	return writeTypedRequest(DepositRequestType, d, w)
+}
+func (d *DepositRequest) EncodingSize() int { return typedRequestSize(d) }
+func (d *DepositRequest) copy() Request {
+	cpy := *d
+	return &cpy
+}
+
+func (d DepositRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(depositRequestJson{
+		Pubkey:                d.Pubkey[:],
+		WithdrawalCredentials: d.WithdrawalCredentials,
+		Amount:                hexutil.Uint64(d.Amount),
+		Signature:             d.Signature[:],
+		Index:                 hexutil.Uint64(d.Index),
+	})
+}
+
+func (d *DepositRequest) UnmarshalJSON(input []byte) error {
+	var dec depositRequestJson
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if len(dec.Pubkey) != BLSPubKeyLen {
+		return fmt.Errorf("DepositRequest: invalid pubkey length: %d", len(dec.Pubkey))
+	}
+	if len(dec.Signature) != BLSSignatureLen {
+		return fmt.Errorf("DepositRequest: invalid signature length: %d", len(dec.Signature))
+	}
+	copy(d.Pubkey[:], dec.Pubkey)
+	d.WithdrawalCredentials = dec.WithdrawalCredentials
+	d.Amount = uint64(dec.Amount)
+	copy(d.Signature[:], dec.Signature)
+	d.Index = uint64(dec.Index)
+	return nil
+}
+
+// unpackDepositLog unpacks the DepositEvent of the deposit contract.
+// Amount and index are little-endian uint64 (SSZ encoding), as emitted by the contract.
+func unpackDepositLog(data []byte) (*DepositRequest, error) {
+	values, err := depositABI.Unpack("DepositEvent", data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unpack deposit log: %w", err)
+	}
+	if len(values) != 5 {
+		return nil, fmt.Errorf("unexpected number of fields in deposit log: %d", len(values))
+	}
+	fields := make([][]byte, len(values))
+	for i, v := range values {
+		fields[i] = v.([]byte)
+	}
+	pubkey, credentials, amount, signature, index := fields[0], fields[1], fields[2], fields[3], fields[4]
+	if len(pubkey) != BLSPubKeyLen || len(credentials) != length.Hash || len(amount) != 8 ||
+		len(signature) != BLSSignatureLen || len(index) != 8 {
+		return nil, fmt.Errorf("invalid deposit log field lengths: pubkey %d, withdrawal_credentials %d, amount %d, signature %d, index %d",
+			len(pubkey), len(credentials), len(amount), len(signature), len(index))
+	}
+	d := &DepositRequest{
+		WithdrawalCredentials: libcommon.BytesToHash(credentials),
+		Amount:                binary.LittleEndian.Uint64(amount),
+		Index:                 binary.LittleEndian.Uint64(index),
+	}
+	copy(d.Pubkey[:], pubkey)
+	copy(d.Signature[:], signature)
+	return d, nil
+}
+
+// ParseDepositLogs extracts the EIP-6110 deposit requests from the logs of the deposit contract
+func ParseDepositLogs(logs []*Log, depositContractAddress libcommon.Address) (Requests, error) {
+	deposits := Requests{}
+	for _, log := range logs {
+		if log.Address != depositContractAddress || len(log.Topics) == 0 || log.Topics[0] != DepositEventSignature {
+			continue
+		}
+		d, err := unpackDepositLog(log.Data)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, d)
+	}
+	return deposits, nil
+}
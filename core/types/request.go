@@ -0,0 +1,188 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of the Erigon library.
+//
+// The Erigon library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Erigon library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Erigon library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	rlp2 "github.com/ledgerwatch/erigon-lib/rlp"
+
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+// EIP-7685 request types
+const (
+	DepositRequestType       byte = 0x00 // EIP-6110
+	WithdrawalRequestType    byte = 0x01 // EIP-7002
+	ConsolidationRequestType byte = 0x02 // EIP-7251
+)
+
+var errShortTypedRequest = errors.New("typed request too short")
+
+// Request is an execution layer triggered request to the consensus layer, see EIP-7685.
+type Request interface {
+	RequestType() byte
+	// MarshalBinary writes the EIP-7685 encoding of the request: request_type ++ rlp(request_data)
+	MarshalBinary(w io.Writer) error
+	// EncodingSize returns the length of MarshalBinary output
+	EncodingSize() int
+	copy() Request
+}
+
+// UnmarshalRequest decodes the EIP-7685 encoding of a request, as produced by Request.MarshalBinary
+func UnmarshalRequest(data []byte) (Request, error) {
+	if len(data) <= 1 {
+		return nil, errShortTypedRequest
+	}
+	var r Request
+	switch data[0] {
+	case DepositRequestType:
+		r = new(DepositRequest)
+	case WithdrawalRequestType:
+		r = new(WithdrawalRequest)
+	case ConsolidationRequestType:
+		r = new(ConsolidationRequest)
+	default:
+		return nil, fmt.Errorf("unknown request type: %d", data[0])
+	}
+	if err := rlp.DecodeBytes(data[1:], r); err != nil {
+		return nil, fmt.Errorf("decode request type %d: %w", data[0], err)
+	}
+	return r, nil
+}
+
+func marshalRequest(r Request) []byte {
+	var buf bytes.Buffer
+	buf.Grow(r.EncodingSize())
+	if err := r.MarshalBinary(&buf); err != nil {
+		panic(fmt.Errorf("encode request: %w", err))
+	}
+	return buf.Bytes()
+}
+
+// Requests implements DerivableList for requests. Like withdrawals, nil means pre-Prague.
+type Requests []Request
+
+func (r Requests) Len() int { return len(r) }
+
+// EncodeIndex encodes the i'th request to w. Note that this does not check for errors
+// because we assume that *Request will only ever contain valid requests that were either
+// constructed by decoding or via public API in this package.
+func (r Requests) EncodeIndex(i int, w *bytes.Buffer) {
+	r[i].MarshalBinary(w)
+}
+
+func (r Requests) Deposits() []*DepositRequest {
+	return requestsOfType[*DepositRequest](r)
+}
+
+func (r Requests) Withdrawals() []*WithdrawalRequest {
+	return requestsOfType[*WithdrawalRequest](r)
+}
+
+func (r Requests) Consolidations() []*ConsolidationRequest {
+	return requestsOfType[*ConsolidationRequest](r)
+}
+
+func requestsOfType[T Request](r Requests) []T {
+	res := make([]T, 0, len(r))
+	for _, req := range r {
+		if t, ok := req.(T); ok {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func (r Requests) copy() Requests {
+	if r == nil {
+		return nil
+	}
+	res := make(Requests, len(r))
+	for i, req := range r {
+		res[i] = req.copy()
+	}
+	return res
+}
+
+// payloadSize - size of the rlp list payload, where every request is an rlp string of its EIP-7685 encoding
+func (r Requests) payloadSize() (size int) {
+	for _, req := range r {
+		reqLen := req.EncodingSize()
+		size += rlp2.ListPrefixLen(reqLen) + reqLen // string prefix has the same length as list prefix for len > 1
+	}
+	return size
+}
+
+func (r Requests) encodeRLP(payloadSize int, w io.Writer, b []byte) error {
+	if err := EncodeStructSizePrefix(payloadSize, w, b); err != nil {
+		return err
+	}
+	for _, req := range r {
+		if err := rlp.EncodeString(marshalRequest(req), w, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeRequests - decodes optional requests list at the end of the block body.
+// Returns nil for pre-Prague bodies, which don't have it.
+func decodeRequests(s *rlp.Stream) (Requests, error) {
+	if _, err := s.List(); err != nil {
+		if errors.Is(err, rlp.EOL) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read Requests: %w", err)
+	}
+	requests := Requests{}
+	var b []byte
+	var err error
+	for b, err = s.Bytes(); err == nil; b, err = s.Bytes() {
+		req, err := UnmarshalRequest(b)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	if !errors.Is(err, rlp.EOL) {
+		return nil, err
+	}
+	// end of Requests
+	if err = s.ListEnd(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func writeTypedRequest(t byte, data interface{}, w io.Writer) error {
+	if _, err := w.Write([]byte{t}); err != nil {
+		return err
+	}
+	return rlp.Encode(w, data)
+}
+
+func typedRequestSize(data interface{}) int {
+	var c writeCounter
+	if err := rlp.Encode(&c, data); err != nil {
+		panic(fmt.Errorf("encode request: %w", err))
+	}
+	return 1 + int(c)
+}
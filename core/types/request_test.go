@@ -0,0 +1,68 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWithdrawalRequests(t *testing.T) {
+	t.Parallel()
+	data := make([]byte, 0, 2*withdrawalRequestLen)
+	for i := byte(1); i <= 2; i++ {
+		data = append(data, bytes.Repeat([]byte{i}, 20)...)
+		data = append(data, bytes.Repeat([]byte{i + 0x10}, BLSPubKeyLen)...)
+		data = append(data, 0, 0, 0, 0, 0, 0, 0x01, i)
+	}
+
+	reqs, err := ParseWithdrawalRequests(data)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reqs))
+
+	w := reqs.Withdrawals()[1]
+	assert.Equal(t, libcommon.BytesToAddress(bytes.Repeat([]byte{2}, 20)), w.SourceAddress)
+	assert.Equal(t, byte(0x12), w.ValidatorPubkey[BLSPubKeyLen-1])
+	assert.Equal(t, uint64(0x0102), w.Amount)
+
+	_, err = ParseWithdrawalRequests(data[1:])
+	require.Error(t, err)
+}
+
+func TestParseConsolidationRequests(t *testing.T) {
+	t.Parallel()
+	data := bytes.Repeat([]byte{0xaa}, 20)
+	data = append(data, bytes.Repeat([]byte{0xbb}, BLSPubKeyLen)...)
+	data = append(data, bytes.Repeat([]byte{0xcc}, BLSPubKeyLen)...)
+
+	reqs, err := ParseConsolidationRequests(data)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reqs))
+
+	c := reqs.Consolidations()[0]
+	assert.Equal(t, byte(0xbb), c.SourcePubKey[0])
+	assert.Equal(t, byte(0xcc), c.TargetPubKey[BLSPubKeyLen-1])
+}
+
+func TestRequestBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+	reqs := Requests{
+		&DepositRequest{Amount: 32_000_000_000, Index: 3},
+		&WithdrawalRequest{Amount: 1},
+		&ConsolidationRequest{SourceAddress: libcommon.HexToAddress("0x01")},
+	}
+	for _, r := range reqs {
+		enc := marshalRequest(r)
+		assert.Equal(t, r.EncodingSize(), len(enc))
+		assert.Equal(t, r.RequestType(), enc[0])
+
+		dec, err := UnmarshalRequest(enc)
+		require.NoError(t, err)
+		assert.Equal(t, r, dec)
+	}
+
+	_, err := UnmarshalRequest([]byte{0x7f, 0xc0})
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of the Erigon library.
+//
+// The Erigon library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Erigon library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Erigon library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+)
+
+// withdrawalRequestLen - length of a request in the EIP-7002 system contract output:
+// source_address ++ validator_pubkey ++ amount (big-endian uint64)
+const withdrawalRequestLen = length.Addr + BLSPubKeyLen + 8
+
+// WithdrawalRequest is an execution layer triggered validator exit or partial withdrawal, see EIP-7002.
+type WithdrawalRequest struct {
+	SourceAddress   libcommon.Address
+	ValidatorPubkey [BLSPubKeyLen]byte
+	Amount          uint64 // in Gwei, 0 means full exit
+}
+
+type withdrawalRequestJson struct {
+	SourceAddress   libcommon.Address `json:"sourceAddress"`
+	ValidatorPubkey hexutility.Bytes  `json:"validatorPubkey"`
+	Amount          hexutil.Uint64    `json:"amount"`
+}
+
+func (w *WithdrawalRequest) RequestType() byte { return WithdrawalRequestType }
+func (w *WithdrawalRequest) MarshalBinary(wr io.Writer) error {
+	return writeTypedRequest(WithdrawalRequestType, w, wr)
+}
+func (w *WithdrawalRequest) EncodingSize() int { return typedRequestSize(w) }
+func (w *WithdrawalRequest) copy() Request {
+	cpy := *w
+	return &cpy
+}
+
+func (w WithdrawalRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(withdrawalRequestJson{
+		SourceAddress:   w.SourceAddress,
+		ValidatorPubkey: w.ValidatorPubkey[:],
+		Amount:          hexutil.Uint64(w.Amount),
+	})
+}
+
+func (w *WithdrawalRequest) UnmarshalJSON(input []byte) error {
+	var dec withdrawalRequestJson
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if len(dec.ValidatorPubkey) != BLSPubKeyLen {
+		return fmt.Errorf("WithdrawalRequest: invalid validatorPubkey length: %d", len(dec.ValidatorPubkey))
+	}
+	w.SourceAddress = dec.SourceAddress
+	copy(w.ValidatorPubkey[:], dec.ValidatorPubkey)
+	w.Amount = uint64(dec.Amount)
+	return nil
+}
+
+// ParseWithdrawalRequests parses the output of the EIP-7002 system contract dequeue call
+func ParseWithdrawalRequests(data []byte) (Requests, error) {
+	if len(data)%withdrawalRequestLen != 0 {
+		return nil, fmt.Errorf("invalid withdrawal requests length: %d", len(data))
+	}
+	reqs := make(Requests, 0, len(data)/withdrawalRequestLen)
+	for i := 0; i < len(data); i += withdrawalRequestLen {
+		w := &WithdrawalRequest{
+			SourceAddress: libcommon.BytesToAddress(data[i : i+length.Addr]),
+			Amount:        binary.BigEndian.Uint64(data[i+length.Addr+BLSPubKeyLen : i+withdrawalRequestLen]),
+		}
+		copy(w.ValidatorPubkey[:], data[i+length.Addr:])
+		reqs = append(reqs, w)
+	}
+	return reqs, nil
+}
@@ -181,6 +181,26 @@ func init() {
 	}
 }
 
+// ActivePrecompiledContracts returns the precompiled contracts enabled with the current configuration.
+func ActivePrecompiledContracts(rules *chain.Rules) map[libcommon.Address]PrecompiledContract {
+	switch {
+	case rules.IsPrague:
+		return PrecompiledContractsPrague
+	case rules.IsNapoli:
+		return PrecompiledContractsNapoli
+	case rules.IsCancun:
+		return PrecompiledContractsCancun
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules *chain.Rules) []libcommon.Address {
 	switch {
@@ -35,22 +35,9 @@ import (
 var emptyCodeHash = crypto.Keccak256Hash(nil)
 
 func (evm *EVM) precompile(addr libcommon.Address) (PrecompiledContract, bool) {
-	var precompiles map[libcommon.Address]PrecompiledContract
-	switch {
-	case evm.chainRules.IsPrague:
-		precompiles = PrecompiledContractsPrague
-	case evm.chainRules.IsNapoli:
-		precompiles = PrecompiledContractsNapoli
-	case evm.chainRules.IsCancun:
-		precompiles = PrecompiledContractsCancun
-	case evm.chainRules.IsBerlin:
-		precompiles = PrecompiledContractsBerlin
-	case evm.chainRules.IsIstanbul:
-		precompiles = PrecompiledContractsIstanbul
-	case evm.chainRules.IsByzantium:
-		precompiles = PrecompiledContractsByzantium
-	default:
-		precompiles = PrecompiledContractsHomestead
+	precompiles := evm.precompiles
+	if precompiles == nil {
+		precompiles = ActivePrecompiledContracts(evm.chainRules)
 	}
 	p, ok := precompiles[addr]
 	return p, ok
@@ -94,6 +81,8 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// precompiles, when set, replaces the set of precompiled contracts selected by chainRules
+	precompiles map[libcommon.Address]PrecompiledContract
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -136,6 +125,12 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	atomic.StoreInt32(&evm.abort, 0)
 }
 
+// SetPrecompiles overrides the precompiled contracts available to the EVM.
+// Passing nil restores the default set for the current chain rules.
+func (evm *EVM) SetPrecompiles(precompiles map[libcommon.Address]PrecompiledContract) {
+	evm.precompiles = precompiles
+}
+
 // Cancel cancels any running EVM operation. This may be called concurrently and
 // it's safe to be called multiple times.
 func (evm *EVM) Cancel() {
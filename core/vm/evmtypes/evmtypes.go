@@ -45,6 +45,8 @@ type TxContext struct {
 	BlobHashes        []common.Hash  // Provides versioned blob hashes for BLOBHASH
 	Txn               types.Transaction
 	CumulativeGasUsed *uint64
+	BlockHash         common.Hash // Hash of the block the tx is contained within, only used by tracers
+	TxIndex           int         // Index of the tx within its block, only used by tracers
 }
 
 type (
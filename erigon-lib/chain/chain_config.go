@@ -75,6 +75,9 @@ type Config struct {
 	TargetBlobGasPerBlock      *uint64 `json:"targetBlobGasPerBlock,omitempty"`
 	BlobGasPriceUpdateFraction *uint64 `json:"blobGasPriceUpdateFraction,omitempty"`
 
+	// EIP-6110: deposit contract, whose logs are included in the block as deposit requests since Prague
+	DepositContract common.Address `json:"depositContractAddress,omitempty"`
+
 	// (Optional) governance contract where EIP-1559 fees will be sent to that otherwise would be burnt since the London fork
 	BurntContract map[string]common.Address `json:"burntContract,omitempty"`
 
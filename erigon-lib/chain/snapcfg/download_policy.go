@@ -0,0 +1,101 @@
+package snapcfg
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+)
+
+// DownloadPolicy - allows to download only part of preverified block snapshots: snapshot type => first block to download.
+// Files of listed types are downloaded only if they have blocks >= first block, files of other types
+// and state files are downloaded fully. Headers are always downloaded fully - they are needed to validate the chain.
+//
+// Format: "bodies=15000000,transactions=15000000"
+type DownloadPolicy map[snaptype.Enum]uint64
+
+func ParseDownloadPolicy(s string) (DownloadPolicy, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	p := DownloadPolicy{}
+	for _, rule := range strings.Split(s, ",") {
+		typeName, from, ok := strings.Cut(strings.TrimSpace(rule), "=")
+		if !ok {
+			return nil, fmt.Errorf("download policy: expected <type>=<from block>, got %q", rule)
+		}
+		t, ok := snaptype.ParseEnum(strings.TrimSpace(typeName))
+		if !ok {
+			return nil, fmt.Errorf("download policy: unknown snapshot type %q", typeName)
+		}
+		if t == snaptype.Enums.Headers {
+			return nil, fmt.Errorf("download policy: headers are always downloaded")
+		}
+		if _, ok := p[t]; ok {
+			return nil, fmt.Errorf("download policy: duplicated snapshot type %s", t)
+		}
+		blockNum, err := strconv.ParseUint(strings.TrimSpace(from), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("download policy: %s: %w", t, err)
+		}
+		p[t] = blockNum
+	}
+	return p, nil
+}
+
+func (p DownloadPolicy) String() string {
+	types := make([]snaptype.Enum, 0, len(p))
+	for t := range p {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	rules := make([]string, 0, len(types))
+	for _, t := range types {
+		rules = append(rules, fmt.Sprintf("%s=%d", t, p[t]))
+	}
+	return strings.Join(rules, ",")
+}
+
+// From - first block of given snapshot type to download, 0 if type is downloaded fully
+func (p DownloadPolicy) From(t snaptype.Enum) uint64 { return p[t] }
+
+// FirstFullBlock - first block which has snapshots of all types downloaded, 0 if all types are downloaded fully
+func (p DownloadPolicy) FirstFullBlock() (first uint64) {
+	for _, from := range p {
+		if from > first {
+			first = from
+		}
+	}
+	return first
+}
+
+// Include - if file must be downloaded. Block snapshots of restricted types are included if they have blocks >= From,
+// unknown files are always included.
+func (p DownloadPolicy) Include(name string) bool {
+	if len(p) == 0 {
+		return true
+	}
+	info, isStateFile, ok := snaptype.ParseFileName("", name)
+	if !ok || isStateFile || info.Type == nil {
+		return true
+	}
+	return info.To > p.From(info.Type.Enum())
+}
+
+func (p DownloadPolicy) Filter(items Preverified) Preverified {
+	if len(p) == 0 {
+		return items
+	}
+	filtered := make(Preverified, 0, len(items))
+	for _, item := range items {
+		if p.Include(item.Name) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
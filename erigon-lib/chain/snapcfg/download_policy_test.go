@@ -0,0 +1,51 @@
+package snapcfg
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadPolicy(t *testing.T) {
+	p, err := ParseDownloadPolicy("transactions=1000000, bodies=500000")
+	require.NoError(t, err)
+	require.Equal(t, uint64(500_000), p.From(snaptype.Enums.Bodies))
+	require.Equal(t, uint64(0), p.From(snaptype.Enums.Headers))
+	require.Equal(t, "bodies=500000,transactions=1000000", p.String())
+	require.Equal(t, uint64(1_000_000), p.FirstFullBlock())
+	require.Equal(t, uint64(0), DownloadPolicy(nil).FirstFullBlock())
+
+	items := Preverified{
+		{Name: "v1-000000-000500-bodies.seg"},
+		{Name: "v1-000000-000500-headers.seg"},
+		{Name: "v1-000000-000500-transactions.seg"},
+		{Name: "v1-000500-001000-bodies.seg"},
+		{Name: "v1-000500-001000-headers.seg"},
+		{Name: "v1-000500-001000-transactions.seg"},
+		{Name: "v1-000500-001000-transactions-to-block.idx"},
+		{Name: "v1-001000-001500-transactions.seg"},
+		{Name: "v1-accounts.0-32.kv"},
+		{Name: "salt-blocks.txt"},
+	}
+	var names []string
+	for _, item := range p.Filter(items) {
+		names = append(names, item.Name)
+	}
+	require.Equal(t, []string{
+		"v1-000000-000500-headers.seg",
+		"v1-000500-001000-bodies.seg",
+		"v1-000500-001000-headers.seg",
+		"v1-001000-001500-transactions.seg",
+		"v1-accounts.0-32.kv",
+		"salt-blocks.txt",
+	}, names)
+
+	var empty DownloadPolicy
+	require.Equal(t, items, empty.Filter(items))
+
+	for _, invalid := range []string{"bodies", "logs=1", "headers=1", "bodies=x", "bodies=1,bodies=2"} {
+		_, err = ParseDownloadPolicy(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -211,6 +211,18 @@ func (s *EthBackendClientDirect) AddPeer(ctx context.Context, in *remote.AddPeer
 	return s.server.AddPeer(ctx, in)
 }
 
+func (s *EthBackendClientDirect) RemovePeer(ctx context.Context, in *remote.RemovePeerRequest, opts ...grpc.CallOption) (*remote.RemovePeerReply, error) {
+	return s.server.RemovePeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) AddTrustedPeer(ctx context.Context, in *remote.AddPeerRequest, opts ...grpc.CallOption) (*remote.AddPeerReply, error) {
+	return s.server.AddTrustedPeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) RemoveTrustedPeer(ctx context.Context, in *remote.RemovePeerRequest, opts ...grpc.CallOption) (*remote.RemovePeerReply, error) {
+	return s.server.RemoveTrustedPeer(ctx, in)
+}
+
 func (s *EthBackendClientDirect) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PendingBlockReply, error) {
 	return s.server.PendingBlock(ctx, in)
 }
@@ -69,6 +69,10 @@ func (s *SentinelClientDirect) PeersInfo(ctx context.Context, in *sentinel.Peers
 	return s.server.PeersInfo(ctx, in)
 }
 
+func (s *SentinelClientDirect) SetSubscribeExpiry(ctx context.Context, in *sentinel.RequestSubscribeExpiry, opts ...grpc.CallOption) (*sentinel.EmptyMessage, error) {
+	return s.server.SetSubscribeExpiry(ctx, in)
+}
+
 // Subscribe gossip part. the only complex section of this bullshit
 
 func (s *SentinelClientDirect) SubscribeGossip(ctx context.Context, in *sentinel.SubscriptionData, opts ...grpc.CallOption) (sentinel.Sentinel_SubscribeGossipClient, error) {
@@ -63,6 +63,26 @@ func (mr *MockSentryClientMockRecorder) AddPeer(arg0, arg1 any, arg2 ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeer", reflect.TypeOf((*MockSentryClient)(nil).AddPeer), varargs...)
 }
 
+// AddTrustedPeer mocks base method.
+func (m *MockSentryClient) AddTrustedPeer(arg0 context.Context, arg1 *sentry.AddPeerRequest, arg2 ...grpc.CallOption) (*sentry.AddPeerReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTrustedPeer", varargs...)
+	ret0, _ := ret[0].(*sentry.AddPeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustedPeer indicates an expected call of AddTrustedPeer.
+func (mr *MockSentryClientMockRecorder) AddTrustedPeer(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustedPeer", reflect.TypeOf((*MockSentryClient)(nil).AddTrustedPeer), varargs...)
+}
+
 // HandShake mocks base method.
 func (m *MockSentryClient) HandShake(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*sentry.HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +303,46 @@ func (mr *MockSentryClientMockRecorder) Ready() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockSentryClient)(nil).Ready))
 }
 
+// RemovePeer mocks base method.
+func (m *MockSentryClient) RemovePeer(arg0 context.Context, arg1 *sentry.RemovePeerRequest, arg2 ...grpc.CallOption) (*sentry.RemovePeerReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemovePeer", varargs...)
+	ret0, _ := ret[0].(*sentry.RemovePeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePeer indicates an expected call of RemovePeer.
+func (mr *MockSentryClientMockRecorder) RemovePeer(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeer", reflect.TypeOf((*MockSentryClient)(nil).RemovePeer), varargs...)
+}
+
+// RemoveTrustedPeer mocks base method.
+func (m *MockSentryClient) RemoveTrustedPeer(arg0 context.Context, arg1 *sentry.RemovePeerRequest, arg2 ...grpc.CallOption) (*sentry.RemovePeerReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveTrustedPeer", varargs...)
+	ret0, _ := ret[0].(*sentry.RemovePeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTrustedPeer indicates an expected call of RemoveTrustedPeer.
+func (mr *MockSentryClientMockRecorder) RemoveTrustedPeer(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustedPeer", reflect.TypeOf((*MockSentryClient)(nil).RemoveTrustedPeer), varargs...)
+}
+
 // SendMessageById mocks base method.
 func (m *MockSentryClient) SendMessageById(arg0 context.Context, arg1 *sentry.SendMessageByIdRequest, arg2 ...grpc.CallOption) (*sentry.SentPeers, error) {
 	m.ctrl.T.Helper()
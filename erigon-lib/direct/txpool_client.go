@@ -112,6 +112,58 @@ func (c *TxPoolOnAddC) Context() context.Context { return c.ctx }
 
 // -- end OnAdd
 
+// -- start OnDiscard
+
+func (s *TxPoolClient) OnDiscard(ctx context.Context, in *txpool_proto.OnDiscardRequest, opts ...grpc.CallOption) (txpool_proto.Txpool_OnDiscardClient, error) {
+	ch := make(chan *onDiscardReply, 16384)
+	streamServer := &TxPoolOnDiscardS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.OnDiscard(in, streamServer))
+	}()
+	return &TxPoolOnDiscardC{ch: ch, ctx: ctx}, nil
+}
+
+type onDiscardReply struct {
+	r   *txpool_proto.OnDiscardReply
+	err error
+}
+
+type TxPoolOnDiscardS struct {
+	ch  chan *onDiscardReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolOnDiscardS) Send(m *txpool_proto.OnDiscardReply) error {
+	s.ch <- &onDiscardReply{r: m}
+	return nil
+}
+func (s *TxPoolOnDiscardS) Context() context.Context { return s.ctx }
+func (s *TxPoolOnDiscardS) Err(err error) {
+	if err == nil {
+		return
+	}
+	s.ch <- &onDiscardReply{err: err}
+}
+
+type TxPoolOnDiscardC struct {
+	ch  chan *onDiscardReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolOnDiscardC) Recv() (*txpool_proto.OnDiscardReply, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolOnDiscardC) Context() context.Context { return c.ctx }
+
+// -- end OnDiscard
+
 func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusRequest, opts ...grpc.CallOption) (*txpool_proto.StatusReply, error) {
 	return s.server.Status(ctx, in)
 }
@@ -119,3 +171,7 @@ func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusReques
 func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest, opts ...grpc.CallOption) (*txpool_proto.NonceReply, error) {
 	return s.server.Nonce(ctx, in)
 }
+
+func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
+	return s.server.GetBlobs(ctx, in)
+}
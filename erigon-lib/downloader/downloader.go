@@ -82,6 +82,7 @@ type Downloader struct {
 
 	webseeds         *WebSeeds
 	webseedsDiscover bool
+	webSeedServer    *webSeedServer
 
 	logger    log.Logger
 	verbosity log.Lvl
@@ -124,13 +125,23 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, dirs datadir.Dirs, logger
 		return nil, fmt.Errorf("openClient: %w", err)
 	}
 
+	// closeClient - cleanup of error paths before Downloader is created, later ones use Downloader.Close
+	closeClient := func() {
+		torrentClient.Close()
+		m.Close()
+		c.Close()
+		db.Close()
+	}
+
 	peerID, err := readPeerID(db)
 	if err != nil {
+		closeClient()
 		return nil, fmt.Errorf("get peer id: %w", err)
 	}
 	cfg.ClientConfig.PeerID = string(peerID)
 	if len(peerID) == 0 {
 		if err = savePeerID(db, torrentClient.PeerID()); err != nil {
+			closeClient()
 			return nil, fmt.Errorf("save peer id: %w", err)
 		}
 	}
@@ -141,6 +152,7 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, dirs datadir.Dirs, logger
 	lock, err := getSnapshotLock(ctx, cfg, db, &stats, mutex, logger)
 
 	if err != nil {
+		closeClient()
 		return nil, fmt.Errorf("can't initialize snapshot lock: %w", err)
 	}
 
@@ -210,6 +222,7 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, dirs datadir.Dirs, logger
 		}
 
 		if len(downloadMismatches) > 0 {
+			d.Close()
 			return nil, fmt.Errorf("downloaded files have mismatched hashes: %s", strings.Join(downloadMismatches, ","))
 		}
 
@@ -223,12 +236,23 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, dirs datadir.Dirs, logger
 		//}
 
 		if err := d.BuildTorrentFilesIfNeed(d.ctx, lock.Chain, lock.Downloads); err != nil {
+			d.Close()
 			return nil, err
 		}
 
 		if err := d.addTorrentFilesFromDisk(false); err != nil {
+			d.Close()
+			return nil, err
+		}
+	}
+
+	if cfg.WebSeedServerAddr != "" {
+		webSeedServer := newWebSeedServer(cfg.Dirs.Snap, d.torrentFiles, d.completedTorrents, logger)
+		if err := webSeedServer.start(cfg.WebSeedServerAddr); err != nil {
+			d.Close()
 			return nil, err
 		}
+		d.webSeedServer = webSeedServer
 	}
 
 	return d, nil
@@ -355,7 +379,7 @@ func initSnapshotLock(ctx context.Context, cfg *downloadercfg.Cfg, db kv.RoDB, s
 	}
 
 	//if len(files) == 0 {
-	lock.Downloads = snapCfg.Preverified
+	lock.Downloads = cfg.DownloadPolicy.Filter(snapCfg.Preverified)
 	//}
 
 	// if files exist on disk we assume that the lock file has been removed
@@ -536,6 +560,10 @@ func initSnapshotLock(ctx context.Context, cfg *downloadercfg.Cfg, db kv.RoDB, s
 			continue
 		}
 
+		if !cfg.DownloadPolicy.Include(item.Name) {
+			continue
+		}
+
 		if !downloads.Contains(item.Name, true) {
 			missingItems = append(missingItems, item)
 		}
@@ -2299,6 +2327,11 @@ func (d *Downloader) Stats() AggStats {
 
 func (d *Downloader) Close() {
 	d.logger.Debug("[snapshots] stopping downloader")
+	if d.webSeedServer != nil {
+		if err := d.webSeedServer.Close(); err != nil {
+			d.logger.Warn("[snapshots] webseed server close", "err", err)
+		}
+	}
 	d.stopMainLoop()
 	d.wg.Wait()
 	d.logger.Debug("[snapshots] closing torrents")
@@ -2,6 +2,7 @@ package downloader
 
 import (
 	"context"
+	"net"
 	"path/filepath"
 	"testing"
 
@@ -65,3 +66,24 @@ func TestNoEscape(t *testing.T) {
 	err = BuildTorrentIfNeed(ctx, "./../a.seg", dirs.Snap, tf)
 	require.Error(err)
 }
+
+func TestNewClosesOnWebSeedServerError(t *testing.T) {
+	require := require.New(t)
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer busy.Close()
+
+	dirs := datadir.New(t.TempDir())
+	cfg, err := downloadercfg2.New(dirs, "", lg.Info, 0, 0, 0, 0, 0, nil, nil, "testnet", false)
+	require.NoError(err)
+	cfg.WebSeedServerAddr = busy.Addr().String()
+	_, err = New(context.Background(), cfg, dirs, log.New(), log.LvlInfo, true)
+	require.Error(err)
+
+	// db and torrent client of the failed downloader are closed, so it can be opened again
+	cfg, err = downloadercfg2.New(dirs, "", lg.Info, 0, 0, 0, 0, 0, nil, nil, "testnet", false)
+	require.NoError(err)
+	d, err := New(context.Background(), cfg, dirs, log.New(), log.LvlInfo, true)
+	require.NoError(err)
+	d.Close()
+}
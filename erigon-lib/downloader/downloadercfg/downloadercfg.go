@@ -52,10 +52,12 @@ type Cfg struct {
 	WebSeedUrls                     []*url.URL
 	WebSeedFiles                    []string
 	SnapshotConfig                  *snapcfg.Cfg
+	DownloadPolicy                  snapcfg.DownloadPolicy // download only part of preverified block snapshots
 	DownloadTorrentFilesFromWebseed bool
 	AddTorrentsFromDisk             bool
 	SnapshotLock                    bool
 	ChainName                       string
+	WebSeedServerAddr               string // if set - serve completed files over HTTP, as webseed for other nodes
 
 	Dirs datadir.Dirs
 }
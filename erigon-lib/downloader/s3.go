@@ -0,0 +1,760 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spaolacci/murmur3"
+)
+
+// RemoteSession - session between local snapshots dir and remote store.
+// Implemented by RCloneSession (any rclone remote) and S3Session (native S3 API, no rclone needed).
+type RemoteSession interface {
+	RemoteFsRoot() string
+	LocalFsRoot() string
+	Label() string
+	Stop()
+	Upload(ctx context.Context, files ...string) error
+	Download(ctx context.Context, files ...string) error
+	Cat(ctx context.Context, file string) (io.Reader, error)
+	ReadLocalDir(ctx context.Context) ([]fs.DirEntry, error)
+	ReadRemoteDir(ctx context.Context, refresh bool) ([]fs.DirEntry, error)
+}
+
+var (
+	_ RemoteSession = (*RCloneSession)(nil)
+	_ RemoteSession = (*S3Session)(nil)
+)
+
+const S3Scheme = "s3://"
+
+// IsS3Location - true for locations like s3://bucket/prefix which are served by S3Session
+func IsS3Location(location string) bool {
+	return strings.HasPrefix(location, S3Scheme)
+}
+
+// NewRemoteSession - S3Session for s3:// locations, otherwise rclone session
+func NewRemoteSession(ctx context.Context, localFs string, remoteFs string, logger log.Logger) (RemoteSession, error) {
+	if IsS3Location(remoteFs) {
+		return NewS3Client(S3ConfigFromEnv(), logger).NewSession(ctx, localFs, remoteFs)
+	}
+
+	rcCli, err := NewRCloneClient(logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rcCli.NewSession(ctx, localFs, remoteFs)
+}
+
+type S3Config struct {
+	Endpoint     string // path-style endpoint: http://127.0.0.1:9000 for minio. https://s3.<region>.amazonaws.com if empty
+	Region       string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+// S3ConfigFromEnv - reads the same variables as aws cli: AWS_ENDPOINT_URL_S3, AWS_ENDPOINT_URL, AWS_REGION,
+// AWS_DEFAULT_REGION, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+func S3ConfigFromEnv() S3Config {
+	cfg := S3Config{
+		Endpoint:     os.Getenv("AWS_ENDPOINT_URL_S3"),
+		Region:       os.Getenv("AWS_REGION"),
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	if len(cfg.Endpoint) == 0 {
+		cfg.Endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+
+	if len(cfg.Region) == 0 {
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+
+	return cfg
+}
+
+const (
+	s3DefaultRegion = "us-east-1"
+	s3PartSize      = 64 * 1024 * 1024 // files above it are uploaded by multipart upload
+	s3Retries       = 5
+	s3RetryDelay    = 2 * time.Second
+
+	s3MetaSha256  = "X-Amz-Meta-Sha256"
+	s3EmptySha256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type S3Client struct {
+	cfg    S3Config
+	http   *http.Client
+	logger log.Logger
+}
+
+func NewS3Client(cfg S3Config, logger log.Logger) *S3Client {
+	if len(cfg.Region) == 0 {
+		cfg.Region = s3DefaultRegion
+	}
+
+	if len(cfg.Endpoint) == 0 {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &S3Client{cfg: cfg, http: &http.Client{}, logger: logger} // no timeout - segments are large
+}
+
+// S3Session - uploads/downloads files between localFs and s3://bucket/prefix.
+// Uploads are content addressed: sha256 of the file is stored in object metadata and
+// files which are already in the bucket with the same content are not uploaded again.
+// Files are uploaded straight to their keys: s3 makes an object visible only after the whole upload
+// has completed, so readers never see partially written files.
+type S3Session struct {
+	*S3Client
+	sync.Mutex
+	files    map[string]*rcloneInfo
+	remoteFs string
+	localFs  string
+	bucket   string
+	prefix   string
+	partSize int64
+}
+
+func (c *S3Client) NewSession(ctx context.Context, localFs string, remoteFs string) (*S3Session, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(remoteFs, S3Scheme), "/")
+
+	if len(bucket) == 0 {
+		return nil, fmt.Errorf("invalid s3 location: %q, expected %sbucket/prefix", remoteFs, S3Scheme)
+	}
+
+	return &S3Session{
+		S3Client: c,
+		files:    map[string]*rcloneInfo{},
+		remoteFs: remoteFs,
+		localFs:  localFs,
+		bucket:   bucket,
+		prefix:   strings.Trim(prefix, "/"),
+		partSize: s3PartSize,
+	}, nil
+}
+
+func (c *S3Session) RemoteFsRoot() string {
+	return c.remoteFs
+}
+
+func (c *S3Session) LocalFsRoot() string {
+	return c.localFs
+}
+
+// Stop - no-op, the session has no background work: requests are bound to the caller's context
+func (c *S3Session) Stop() {
+}
+
+func (c *S3Session) Label() string {
+	return strconv.FormatUint(murmur3.Sum64([]byte(c.localFs+"<->"+c.remoteFs)), 36)
+}
+
+func (c *S3Session) ReadLocalDir(ctx context.Context) ([]fs.DirEntry, error) {
+	return os.ReadDir(c.localFs)
+}
+
+func (c *S3Session) key(file string) string {
+	return path.Join(c.prefix, file)
+}
+
+func (c *S3Session) Upload(ctx context.Context, files ...string) error {
+	for _, file := range files {
+		localInfo, err := os.Stat(filepath.Join(c.localFs, file))
+
+		if err != nil {
+			return fmt.Errorf("can't upload: %s: %w", file, err)
+		}
+
+		if !localInfo.Mode().IsRegular() || localInfo.Size() == 0 {
+			return fmt.Errorf("can't upload: %s: %s", file, "file is not uploadable")
+		}
+
+		if err := c.upload(ctx, file); err != nil {
+			return fmt.Errorf("can't upload: %s: %w", file, err)
+		}
+
+		c.updateInfo(file, localInfo, remoteInfo{Name: file, Size: uint64(localInfo.Size()), ModTime: localInfo.ModTime()})
+	}
+
+	return nil
+}
+
+func (c *S3Session) upload(ctx context.Context, file string) error {
+	f, err := os.Open(filepath.Join(c.localFs, file))
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	stat, err := f.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, f); err != nil {
+		return err
+	}
+
+	contentHash := hex.EncodeToString(hash.Sum(nil))
+	key := c.key(file)
+
+	if remoteHash, err := c.contentHash(ctx, key); err == nil && remoteHash == contentHash {
+		c.logger.Trace("[s3] skipping upload, content unchanged", "file", file, "sha256", contentHash)
+		return nil
+	}
+
+	return c.putObject(ctx, key, f, stat.Size(), contentHash)
+}
+
+func (c *S3Session) putObject(ctx context.Context, key string, f *os.File, size int64, contentHash string) error {
+	header := http.Header{}
+	header.Set(s3MetaSha256, contentHash)
+
+	if size <= c.partSize {
+		resp, err := c.do(ctx, http.MethodPut, key, nil, header, io.NewSectionReader(f, 0, size), contentHash)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, header, nil, s3EmptySha256)
+
+	if err != nil {
+		return err
+	}
+
+	var initiated struct {
+		UploadId string `xml:"UploadId"`
+	}
+
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	resp.Body.Close()
+
+	if err != nil {
+		return fmt.Errorf("can't decode multipart upload: %w", err)
+	}
+
+	type part struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+
+	var complete struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}
+
+	uploadErr := func() error {
+		for offset, partNumber := int64(0), 1; offset < size; offset, partNumber = offset+c.partSize, partNumber+1 {
+			partSize := c.partSize
+			if offset+partSize > size {
+				partSize = size - offset
+			}
+
+			section := io.NewSectionReader(f, offset, partSize)
+			hash := sha256.New()
+
+			if _, err := io.Copy(hash, section); err != nil {
+				return err
+			}
+
+			resp, err := c.do(ctx, http.MethodPut, key, url.Values{
+				"partNumber": {strconv.Itoa(partNumber)},
+				"uploadId":   {initiated.UploadId},
+			}, nil, section, hex.EncodeToString(hash.Sum(nil)))
+
+			if err != nil {
+				return fmt.Errorf("part %d: %w", partNumber, err)
+			}
+
+			resp.Body.Close()
+			complete.Parts = append(complete.Parts, part{partNumber, resp.Header.Get("ETag")})
+		}
+
+		body, err := xml.Marshal(complete)
+
+		if err != nil {
+			return err
+		}
+
+		bodyHash := sha256.Sum256(body)
+		resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": {initiated.UploadId}}, nil,
+			bytes.NewReader(body), hex.EncodeToString(bodyHash[:]))
+
+		if err != nil {
+			return err
+		}
+
+		return checkS3ResponseBody(resp)
+	}()
+
+	if uploadErr != nil {
+		if resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {initiated.UploadId}}, nil, nil, s3EmptySha256); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	return uploadErr
+}
+
+func (c *S3Session) contentHash(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil, s3EmptySha256)
+
+	if err != nil {
+		return "", err
+	}
+
+	resp.Body.Close()
+	return resp.Header.Get(s3MetaSha256), nil
+}
+
+func (c *S3Session) Download(ctx context.Context, files ...string) error {
+	for _, file := range files {
+		if err := c.download(ctx, file); err != nil {
+			return fmt.Errorf("can't download: %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *S3Session) download(ctx context.Context, file string) error {
+	resp, err := c.do(ctx, http.MethodGet, c.key(file), nil, nil, nil, s3EmptySha256)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	localPath := filepath.Join(c.localFs, file)
+	tmpPath := localPath + ".tmp"
+
+	f, err := os.Create(tmpPath)
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmpPath)
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(f, hash), resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if expected := resp.Header.Get(s3MetaSha256); len(expected) > 0 && expected != hex.EncodeToString(hash.Sum(nil)) {
+		return fmt.Errorf("content hash mismatch: expected %s, got %x", expected, hash.Sum(nil))
+	}
+
+	if err := os.Rename(tmpPath, localPath); err != nil {
+		return err
+	}
+
+	localInfo, err := os.Stat(localPath)
+
+	if err != nil {
+		return err
+	}
+
+	c.updateInfo(file, localInfo, remoteInfo{Name: file, Size: uint64(localInfo.Size()), ModTime: localInfo.ModTime()})
+	return nil
+}
+
+func (c *S3Session) Cat(ctx context.Context, file string) (io.Reader, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.key(file), nil, nil, nil, s3EmptySha256)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+func (c *S3Session) updateInfo(file string, localInfo fs.FileInfo, remote remoteInfo) {
+	c.Lock()
+	defer c.Unlock()
+
+	info, ok := c.files[file]
+
+	if !ok {
+		info = &rcloneInfo{file: file}
+
+		if snapInfo, isStateFile, ok := snaptype.ParseFileName(c.localFs, file); ok && !isStateFile {
+			info.snapInfo = &snapInfo
+		}
+
+		c.files[file] = info
+	}
+
+	info.Lock()
+	info.localInfo = localInfo
+	info.remoteInfo = remote
+	info.Unlock()
+}
+
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         uint64    `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+func (c *S3Session) ReadRemoteDir(ctx context.Context, refresh bool) ([]fs.DirEntry, error) {
+	c.Lock()
+	fileCount := len(c.files)
+	c.Unlock()
+
+	if fileCount == 0 || refresh {
+		var prefix string
+
+		if len(c.prefix) > 0 {
+			prefix = c.prefix + "/"
+		}
+
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}, "delimiter": {"/"}}
+
+		for {
+			resp, err := c.do(ctx, http.MethodGet, "", query, nil, nil, s3EmptySha256)
+
+			if err != nil {
+				return nil, fmt.Errorf("can't get remote list: %w", err)
+			}
+
+			var list s3ListResult
+			err = xml.NewDecoder(resp.Body).Decode(&list)
+			resp.Body.Close()
+
+			if err != nil {
+				return nil, fmt.Errorf("can't decode remote list: %w", err)
+			}
+
+			for _, obj := range list.Contents {
+				file := strings.TrimPrefix(obj.Key, prefix)
+				localInfo, _ := os.Stat(filepath.Join(c.localFs, file))
+				c.updateInfo(file, localInfo, remoteInfo{Name: file, Size: obj.Size, ModTime: obj.LastModified})
+			}
+
+			if !list.IsTruncated || len(list.NextContinuationToken) == 0 {
+				break
+			}
+
+			query.Set("continuation-token", list.NextContinuationToken)
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	var entries = make([]fs.DirEntry, 0, len(c.files))
+
+	for _, info := range c.files {
+		if info.remoteInfo.Size > 0 {
+			entries = append(entries, &dirEntry{&fileInfo{info}})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+type s3Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("s3: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *s3Error) Unwrap() error {
+	switch {
+	case e.Code == "AccessDenied" || e.StatusCode == http.StatusForbidden:
+		return ErrAccessDenied
+	case e.Code == "NoSuchKey" || e.StatusCode == http.StatusNotFound:
+		return os.ErrNotExist
+	}
+	return nil
+}
+
+func checkS3ResponseBody(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if bytes.Contains(body, []byte("<Error>")) {
+		e := &s3Error{StatusCode: resp.StatusCode}
+		if err := xml.Unmarshal(body, e); err != nil {
+			return fmt.Errorf("s3: can't decode error: %w", err)
+		}
+		return e
+	}
+
+	return nil
+}
+
+// do - sends signed request, retrying on connection errors and 5xx. body must be nil or seekable to be re-sent.
+func (c *S3Session) do(ctx context.Context, method string, key string, query url.Values, header http.Header, body io.ReadSeeker, payloadHash string) (*http.Response, error) {
+	u, err := url.Parse(c.cfg.Endpoint + s3EscapePath("/"+c.bucket+"/"+key))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key == "" {
+		u.Path = "/" + c.bucket
+		u.RawPath = ""
+	}
+
+	u.RawQuery = s3CanonicalQuery(query)
+
+	var lastErr error
+
+	for i := 0; i < s3Retries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(s3RetryDelay * time.Duration(i)):
+			}
+		}
+
+		var reqBody io.Reader
+
+		if body != nil {
+			if _, err := body.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			reqBody = io.NopCloser(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if body != nil {
+			if req.ContentLength, err = body.Seek(0, io.SeekEnd); err != nil {
+				return nil, err
+			}
+			if _, err := body.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		c.sign(req, payloadHash, time.Now())
+
+		resp, err := c.http.Do(req)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		e := &s3Error{StatusCode: resp.StatusCode}
+
+		if method != http.MethodHead {
+			data, _ := io.ReadAll(resp.Body)
+			_ = xml.Unmarshal(data, e)
+		}
+
+		resp.Body.Close()
+
+		if len(e.Code) == 0 {
+			e.Code = http.StatusText(resp.StatusCode)
+		}
+
+		if resp.StatusCode < 500 {
+			return nil, e
+		}
+
+		lastErr = e
+	}
+
+	return nil, lastErr
+}
+
+// sign - AWS signature version 4, signs host and all headers set on the request
+func (c *S3Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if len(c.cfg.SessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", c.cfg.SessionToken)
+	}
+
+	host := req.Host
+
+	if len(host) == 0 {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.cfg.Region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSha256([]byte("AWS4"+c.cfg.SecretKey), date)
+	key = hmacSha256(key, c.cfg.Region)
+	key = hmacSha256(key, "s3")
+	key = hmacSha256(key, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%x",
+		c.cfg.AccessKey, scope, signedHeaders, hmacSha256(key, stringToSign)))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath - uri encodes everything except unreserved characters and '/'
+func s3EscapePath(p string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(p); i++ {
+		switch ch := p[i]; {
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~', ch == '/':
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+
+	return b.String()
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+
+	for k := range query {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var parts []string
+
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+
+		for _, v := range values {
+			parts = append(parts, strings.ReplaceAll(s3EscapePath(k), "/", "%2F")+"="+strings.ReplaceAll(s3EscapePath(v), "/", "%2F"))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
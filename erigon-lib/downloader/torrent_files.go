@@ -96,6 +96,16 @@ func (tf *TorrentFiles) createTorrentFromMetaInfo(fPath string, mi *metainfo.Met
 	return nil
 }
 
+// Read - raw content of .torrent file
+func (tf *TorrentFiles) Read(name string) ([]byte, error) {
+	tf.lock.Lock()
+	defer tf.lock.Unlock()
+	if !strings.HasSuffix(name, ".torrent") {
+		name += ".torrent"
+	}
+	return os.ReadFile(filepath.Join(tf.dir, name))
+}
+
 func (tf *TorrentFiles) LoadByName(name string) (*torrent.TorrentSpec, error) {
 	tf.lock.Lock()
 	defer tf.lock.Unlock()
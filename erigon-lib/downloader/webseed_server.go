@@ -0,0 +1,159 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/log/v3"
+)
+
+// webSeedServer - serves completed files of Dirs.Snap over HTTP, so other nodes can use this downloader
+// as a webseed: --webseed=http://<addr>/
+//   - GET /manifest.txt - generated list of served files and their .torrent files (format of WebSeeds.retrieveManifest)
+//   - GET /<name> and /<name>.torrent - supports range requests, ETag is infohash of the file
+//
+// Only files which torrent client has fully downloaded and verified are served.
+type webSeedServer struct {
+	snapDir      string
+	torrentFiles *TorrentFiles
+	completed    func() map[string]metainfo.Hash
+	server       *http.Server
+	listener     net.Listener
+	logger       log.Logger
+}
+
+func newWebSeedServer(snapDir string, torrentFiles *TorrentFiles, completed func() map[string]metainfo.Hash, logger log.Logger) *webSeedServer {
+	return &webSeedServer{snapDir: snapDir, torrentFiles: torrentFiles, completed: completed, logger: logger}
+}
+
+func (s *webSeedServer) start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("webseed server: %w", err)
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Warn("[snapshots] webseed server stopped", "err", err)
+		}
+	}()
+	s.logger.Info("[snapshots] webseed server started", "addr", listener.Addr())
+	return nil
+}
+
+func (s *webSeedServer) Addr() net.Addr { return s.listener.Addr() }
+
+func (s *webSeedServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+func (s *webSeedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	completed := s.completed()
+
+	if name == "manifest.txt" {
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(s.manifest(completed)))
+		return
+	}
+
+	if fileName, isTorrent := strings.CutSuffix(name, ".torrent"); isTorrent {
+		hash, ok := completed[fileName]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := s.torrentFiles.Read(fileName)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-bittorrent")
+		w.Header().Set("ETag", fmt.Sprintf(`"%s.torrent"`, hash.HexString()))
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+		return
+	}
+
+	hash, ok := completed[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(s.snapDir, filepath.FromSlash(name)))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		s.logger.Debug("[snapshots] webseed server", "file", name, "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", fmt.Sprintf(`"%s"`, hash.HexString()))
+	http.ServeContent(w, r, name, stat.ModTime(), f)
+}
+
+func (s *webSeedServer) manifest(completed map[string]metainfo.Hash) []byte {
+	files := make([]string, 0, 2*len(completed))
+	for name := range completed {
+		files = append(files, name)
+		if s.torrentFiles.Exists(name) {
+			files = append(files, name+".torrent")
+		}
+	}
+	sort.Strings(files)
+
+	var manifest bytes.Buffer
+	for _, file := range files {
+		fmt.Fprintln(&manifest, file)
+	}
+	return manifest.Bytes()
+}
+
+// completedTorrents - name => infohash of files which are fully downloaded and verified
+func (d *Downloader) completedTorrents() map[string]metainfo.Hash {
+	completed := map[string]metainfo.Hash{}
+	for _, t := range d.torrentClient.Torrents() {
+		select {
+		case <-t.GotInfo():
+		default:
+			continue
+		}
+		if t.Complete.Bool() {
+			completed[t.Name()] = t.InfoHash()
+		}
+	}
+	return completed
+}
+
+// WebSeedServerAddr - address of webseed server, nil if it's not enabled by downloadercfg.Cfg.WebSeedServerAddr
+func (d *Downloader) WebSeedServerAddr() net.Addr {
+	if d.webSeedServer == nil {
+		return nil
+	}
+	return d.webSeedServer.Addr()
+}
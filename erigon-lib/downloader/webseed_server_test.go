@@ -0,0 +1,81 @@
+package downloader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSeedServer(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	ctx := context.Background()
+	tf := NewAtomicTorrentFiles(dirs.Snap)
+
+	data := []byte("0123456789abcdef")
+	require.NoError(os.WriteFile(filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg"), data, 0644))
+	require.NoError(os.WriteFile(filepath.Join(dirs.Snap, "v1-000500-001000-headers.seg"), data, 0644))
+	require.NoError(BuildTorrentIfNeed(ctx, "v1-000000-000500-headers.seg", dirs.Snap, tf))
+	spec, err := tf.LoadByName("v1-000000-000500-headers.seg")
+	require.NoError(err)
+
+	// second file is still downloading
+	completed := map[string]metainfo.Hash{"v1-000000-000500-headers.seg": spec.InfoHash}
+	srv := httptest.NewServer(newWebSeedServer(dirs.Snap, tf, func() map[string]metainfo.Hash { return completed }, log.New()))
+	defer srv.Close()
+
+	get := func(name string, header http.Header) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/"+name, nil)
+		require.NoError(err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		return resp, body
+	}
+
+	resp, body := get("manifest.txt", nil)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("v1-000000-000500-headers.seg\nv1-000000-000500-headers.seg.torrent\n", string(body))
+
+	resp, body = get("v1-000000-000500-headers.seg", http.Header{"Range": {"bytes=4-7"}})
+	require.Equal(http.StatusPartialContent, resp.StatusCode)
+	require.Equal("4567", string(body))
+	require.Equal(`"`+spec.InfoHash.HexString()+`"`, resp.Header.Get("ETag"))
+
+	resp, _ = get("v1-000000-000500-headers.seg", http.Header{"If-None-Match": {resp.Header.Get("ETag")}})
+	require.Equal(http.StatusNotModified, resp.StatusCode)
+
+	resp, body = get("v1-000000-000500-headers.seg.torrent", nil)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	torrent, err := tf.Read("v1-000000-000500-headers.seg")
+	require.NoError(err)
+	require.Equal(torrent, body)
+
+	for _, name := range []string{"v1-000500-001000-headers.seg", "v1-000500-001000-headers.seg.torrent", "../snapshots/v1-000500-001000-headers.seg", "snapshot-lock.json"} {
+		resp, _ = get(name, nil)
+		require.Equal(http.StatusNotFound, resp.StatusCode, name)
+	}
+
+	// served manifest is usable by webseed consumers
+	u, err := url.Parse(srv.URL + "/")
+	require.NoError(err)
+	ws := &WebSeeds{logger: log.New()}
+	files, err := ws.retrieveManifest(ctx, u)
+	require.NoError(err)
+	require.Equal(srv.URL+"/v1-000000-000500-headers.seg.torrent", files["v1-000000-000500-headers.seg.torrent"])
+}
@@ -10,6 +10,9 @@ require (
 	github.com/ledgerwatch/secp256k1 v1.0.0
 )
 
+// ./interfaces - .proto files of github.com/ledgerwatch/interfaces with changes not upstreamed yet, see ./interfaces/README.md
+replace github.com/ledgerwatch/interfaces => ./interfaces
+
 require (
 	github.com/RoaringBitmap/roaring v1.2.3
 	github.com/anacrolix/dht/v2 v2.20.0
@@ -27,6 +30,7 @@ require (
 	github.com/grpc-ecosystem/go-grpc-middleware v1.4.0
 	github.com/hashicorp/golang-lru/v2 v2.0.6
 	github.com/holiman/uint256 v1.2.3
+	github.com/klauspost/compress v1.17.9
 	github.com/matryer/moq v0.3.3
 	github.com/pbnjay/memory v0.0.0-20210728143218-7b4eea64cf58
 	github.com/pelletier/go-toml/v2 v2.1.0
@@ -19,17 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Sentinel_SubscribeGossip_FullMethodName = "/sentinel.Sentinel/SubscribeGossip"
-	Sentinel_SendRequest_FullMethodName     = "/sentinel.Sentinel/SendRequest"
-	Sentinel_SetStatus_FullMethodName       = "/sentinel.Sentinel/SetStatus"
-	Sentinel_GetPeers_FullMethodName        = "/sentinel.Sentinel/GetPeers"
-	Sentinel_BanPeer_FullMethodName         = "/sentinel.Sentinel/BanPeer"
-	Sentinel_UnbanPeer_FullMethodName       = "/sentinel.Sentinel/UnbanPeer"
-	Sentinel_PenalizePeer_FullMethodName    = "/sentinel.Sentinel/PenalizePeer"
-	Sentinel_RewardPeer_FullMethodName      = "/sentinel.Sentinel/RewardPeer"
-	Sentinel_PublishGossip_FullMethodName   = "/sentinel.Sentinel/PublishGossip"
-	Sentinel_Identity_FullMethodName        = "/sentinel.Sentinel/Identity"
-	Sentinel_PeersInfo_FullMethodName       = "/sentinel.Sentinel/PeersInfo"
+	Sentinel_SubscribeGossip_FullMethodName    = "/sentinel.Sentinel/SubscribeGossip"
+	Sentinel_SendRequest_FullMethodName        = "/sentinel.Sentinel/SendRequest"
+	Sentinel_SetStatus_FullMethodName          = "/sentinel.Sentinel/SetStatus"
+	Sentinel_GetPeers_FullMethodName           = "/sentinel.Sentinel/GetPeers"
+	Sentinel_BanPeer_FullMethodName            = "/sentinel.Sentinel/BanPeer"
+	Sentinel_UnbanPeer_FullMethodName          = "/sentinel.Sentinel/UnbanPeer"
+	Sentinel_PenalizePeer_FullMethodName       = "/sentinel.Sentinel/PenalizePeer"
+	Sentinel_RewardPeer_FullMethodName         = "/sentinel.Sentinel/RewardPeer"
+	Sentinel_PublishGossip_FullMethodName      = "/sentinel.Sentinel/PublishGossip"
+	Sentinel_Identity_FullMethodName           = "/sentinel.Sentinel/Identity"
+	Sentinel_PeersInfo_FullMethodName          = "/sentinel.Sentinel/PeersInfo"
+	Sentinel_SetSubscribeExpiry_FullMethodName = "/sentinel.Sentinel/SetSubscribeExpiry"
 )
 
 // SentinelClient is the client API for Sentinel service.
@@ -47,6 +48,7 @@ type SentinelClient interface {
 	PublishGossip(ctx context.Context, in *GossipData, opts ...grpc.CallOption) (*EmptyMessage, error)
 	Identity(ctx context.Context, in *EmptyMessage, opts ...grpc.CallOption) (*IdentityResponse, error)
 	PeersInfo(ctx context.Context, in *PeersInfoRequest, opts ...grpc.CallOption) (*PeersInfoResponse, error)
+	SetSubscribeExpiry(ctx context.Context, in *RequestSubscribeExpiry, opts ...grpc.CallOption) (*EmptyMessage, error)
 }
 
 type sentinelClient struct {
@@ -179,6 +181,15 @@ func (c *sentinelClient) PeersInfo(ctx context.Context, in *PeersInfoRequest, op
 	return out, nil
 }
 
+func (c *sentinelClient) SetSubscribeExpiry(ctx context.Context, in *RequestSubscribeExpiry, opts ...grpc.CallOption) (*EmptyMessage, error) {
+	out := new(EmptyMessage)
+	err := c.cc.Invoke(ctx, Sentinel_SetSubscribeExpiry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SentinelServer is the server API for Sentinel service.
 // All implementations must embed UnimplementedSentinelServer
 // for forward compatibility
@@ -194,6 +205,7 @@ type SentinelServer interface {
 	PublishGossip(context.Context, *GossipData) (*EmptyMessage, error)
 	Identity(context.Context, *EmptyMessage) (*IdentityResponse, error)
 	PeersInfo(context.Context, *PeersInfoRequest) (*PeersInfoResponse, error)
+	SetSubscribeExpiry(context.Context, *RequestSubscribeExpiry) (*EmptyMessage, error)
 	mustEmbedUnimplementedSentinelServer()
 }
 
@@ -234,6 +246,9 @@ func (UnimplementedSentinelServer) Identity(context.Context, *EmptyMessage) (*Id
 func (UnimplementedSentinelServer) PeersInfo(context.Context, *PeersInfoRequest) (*PeersInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersInfo not implemented")
 }
+func (UnimplementedSentinelServer) SetSubscribeExpiry(context.Context, *RequestSubscribeExpiry) (*EmptyMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSubscribeExpiry not implemented")
+}
 func (UnimplementedSentinelServer) mustEmbedUnimplementedSentinelServer() {}
 
 // UnsafeSentinelServer may be embedded to opt out of forward compatibility for this service.
@@ -448,6 +463,24 @@ func _Sentinel_PeersInfo_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sentinel_SetSubscribeExpiry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSubscribeExpiry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentinelServer).SetSubscribeExpiry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sentinel_SetSubscribeExpiry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentinelServer).SetSubscribeExpiry(ctx, req.(*RequestSubscribeExpiry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Sentinel_ServiceDesc is the grpc.ServiceDesc for Sentinel service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,6 +528,10 @@ var Sentinel_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersInfo",
 			Handler:    _Sentinel_PeersInfo_Handler,
 		},
+		{
+			MethodName: "SetSubscribeExpiry",
+			Handler:    _Sentinel_SetSubscribeExpiry_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -24,6 +24,9 @@ var _ SentryServer = &SentryServerMock{}
 //			AddPeerFunc: func(contextMoqParam context.Context, addPeerRequest *AddPeerRequest) (*AddPeerReply, error) {
 //				panic("mock out the AddPeer method")
 //			},
+//			AddTrustedPeerFunc: func(contextMoqParam context.Context, addPeerRequest *AddPeerRequest) (*AddPeerReply, error) {
+//				panic("mock out the AddTrustedPeer method")
+//			},
 //			HandShakeFunc: func(contextMoqParam context.Context, empty *emptypb.Empty) (*HandShakeReply, error) {
 //				panic("mock out the HandShake method")
 //			},
@@ -51,6 +54,12 @@ var _ SentryServer = &SentryServerMock{}
 //			PenalizePeerFunc: func(contextMoqParam context.Context, penalizePeerRequest *PenalizePeerRequest) (*emptypb.Empty, error) {
 //				panic("mock out the PenalizePeer method")
 //			},
+//			RemovePeerFunc: func(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error) {
+//				panic("mock out the RemovePeer method")
+//			},
+//			RemoveTrustedPeerFunc: func(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error) {
+//				panic("mock out the RemoveTrustedPeer method")
+//			},
 //			SendMessageByIdFunc: func(contextMoqParam context.Context, sendMessageByIdRequest *SendMessageByIdRequest) (*SentPeers, error) {
 //				panic("mock out the SendMessageById method")
 //			},
@@ -79,6 +88,9 @@ type SentryServerMock struct {
 	// AddPeerFunc mocks the AddPeer method.
 	AddPeerFunc func(contextMoqParam context.Context, addPeerRequest *AddPeerRequest) (*AddPeerReply, error)
 
+	// AddTrustedPeerFunc mocks the AddTrustedPeer method.
+	AddTrustedPeerFunc func(contextMoqParam context.Context, addPeerRequest *AddPeerRequest) (*AddPeerReply, error)
+
 	// HandShakeFunc mocks the HandShake method.
 	HandShakeFunc func(contextMoqParam context.Context, empty *emptypb.Empty) (*HandShakeReply, error)
 
@@ -106,6 +118,12 @@ type SentryServerMock struct {
 	// PenalizePeerFunc mocks the PenalizePeer method.
 	PenalizePeerFunc func(contextMoqParam context.Context, penalizePeerRequest *PenalizePeerRequest) (*emptypb.Empty, error)
 
+	// RemovePeerFunc mocks the RemovePeer method.
+	RemovePeerFunc func(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error)
+
+	// RemoveTrustedPeerFunc mocks the RemoveTrustedPeer method.
+	RemoveTrustedPeerFunc func(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error)
+
 	// SendMessageByIdFunc mocks the SendMessageById method.
 	SendMessageByIdFunc func(contextMoqParam context.Context, sendMessageByIdRequest *SendMessageByIdRequest) (*SentPeers, error)
 
@@ -133,6 +151,13 @@ type SentryServerMock struct {
 			// AddPeerRequest is the addPeerRequest argument value.
 			AddPeerRequest *AddPeerRequest
 		}
+		// AddTrustedPeer holds details about calls to the AddTrustedPeer method.
+		AddTrustedPeer []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// AddPeerRequest is the addPeerRequest argument value.
+			AddPeerRequest *AddPeerRequest
+		}
 		// HandShake holds details about calls to the HandShake method.
 		HandShake []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -196,6 +221,20 @@ type SentryServerMock struct {
 			// PenalizePeerRequest is the penalizePeerRequest argument value.
 			PenalizePeerRequest *PenalizePeerRequest
 		}
+		// RemovePeer holds details about calls to the RemovePeer method.
+		RemovePeer []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// RemovePeerRequest is the removePeerRequest argument value.
+			RemovePeerRequest *RemovePeerRequest
+		}
+		// RemoveTrustedPeer holds details about calls to the RemoveTrustedPeer method.
+		RemoveTrustedPeer []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// RemovePeerRequest is the removePeerRequest argument value.
+			RemovePeerRequest *RemovePeerRequest
+		}
 		// SendMessageById holds details about calls to the SendMessageById method.
 		SendMessageById []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -236,6 +275,7 @@ type SentryServerMock struct {
 		}
 	}
 	lockAddPeer                            sync.RWMutex
+	lockAddTrustedPeer                     sync.RWMutex
 	lockHandShake                          sync.RWMutex
 	lockMessages                           sync.RWMutex
 	lockNodeInfo                           sync.RWMutex
@@ -245,6 +285,8 @@ type SentryServerMock struct {
 	lockPeerMinBlock                       sync.RWMutex
 	lockPeers                              sync.RWMutex
 	lockPenalizePeer                       sync.RWMutex
+	lockRemovePeer                         sync.RWMutex
+	lockRemoveTrustedPeer                  sync.RWMutex
 	lockSendMessageById                    sync.RWMutex
 	lockSendMessageByMinBlock              sync.RWMutex
 	lockSendMessageToAll                   sync.RWMutex
@@ -293,6 +335,46 @@ func (mock *SentryServerMock) AddPeerCalls() []struct {
 	return calls
 }
 
+// AddTrustedPeer calls AddTrustedPeerFunc.
+func (mock *SentryServerMock) AddTrustedPeer(contextMoqParam context.Context, addPeerRequest *AddPeerRequest) (*AddPeerReply, error) {
+	callInfo := struct {
+		ContextMoqParam context.Context
+		AddPeerRequest  *AddPeerRequest
+	}{
+		ContextMoqParam: contextMoqParam,
+		AddPeerRequest:  addPeerRequest,
+	}
+	mock.lockAddTrustedPeer.Lock()
+	mock.calls.AddTrustedPeer = append(mock.calls.AddTrustedPeer, callInfo)
+	mock.lockAddTrustedPeer.Unlock()
+	if mock.AddTrustedPeerFunc == nil {
+		var (
+			addPeerReplyOut *AddPeerReply
+			errOut          error
+		)
+		return addPeerReplyOut, errOut
+	}
+	return mock.AddTrustedPeerFunc(contextMoqParam, addPeerRequest)
+}
+
+// AddTrustedPeerCalls gets all the calls that were made to AddTrustedPeer.
+// Check the length with:
+//
+//	len(mockedSentryServer.AddTrustedPeerCalls())
+func (mock *SentryServerMock) AddTrustedPeerCalls() []struct {
+	ContextMoqParam context.Context
+	AddPeerRequest  *AddPeerRequest
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		AddPeerRequest  *AddPeerRequest
+	}
+	mock.lockAddTrustedPeer.RLock()
+	calls = mock.calls.AddTrustedPeer
+	mock.lockAddTrustedPeer.RUnlock()
+	return calls
+}
+
 // HandShake calls HandShakeFunc.
 func (mock *SentryServerMock) HandShake(contextMoqParam context.Context, empty *emptypb.Empty) (*HandShakeReply, error) {
 	callInfo := struct {
@@ -651,6 +733,86 @@ func (mock *SentryServerMock) PenalizePeerCalls() []struct {
 	return calls
 }
 
+// RemovePeer calls RemovePeerFunc.
+func (mock *SentryServerMock) RemovePeer(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error) {
+	callInfo := struct {
+		ContextMoqParam   context.Context
+		RemovePeerRequest *RemovePeerRequest
+	}{
+		ContextMoqParam:   contextMoqParam,
+		RemovePeerRequest: removePeerRequest,
+	}
+	mock.lockRemovePeer.Lock()
+	mock.calls.RemovePeer = append(mock.calls.RemovePeer, callInfo)
+	mock.lockRemovePeer.Unlock()
+	if mock.RemovePeerFunc == nil {
+		var (
+			removePeerReplyOut *RemovePeerReply
+			errOut             error
+		)
+		return removePeerReplyOut, errOut
+	}
+	return mock.RemovePeerFunc(contextMoqParam, removePeerRequest)
+}
+
+// RemovePeerCalls gets all the calls that were made to RemovePeer.
+// Check the length with:
+//
+//	len(mockedSentryServer.RemovePeerCalls())
+func (mock *SentryServerMock) RemovePeerCalls() []struct {
+	ContextMoqParam   context.Context
+	RemovePeerRequest *RemovePeerRequest
+} {
+	var calls []struct {
+		ContextMoqParam   context.Context
+		RemovePeerRequest *RemovePeerRequest
+	}
+	mock.lockRemovePeer.RLock()
+	calls = mock.calls.RemovePeer
+	mock.lockRemovePeer.RUnlock()
+	return calls
+}
+
+// RemoveTrustedPeer calls RemoveTrustedPeerFunc.
+func (mock *SentryServerMock) RemoveTrustedPeer(contextMoqParam context.Context, removePeerRequest *RemovePeerRequest) (*RemovePeerReply, error) {
+	callInfo := struct {
+		ContextMoqParam   context.Context
+		RemovePeerRequest *RemovePeerRequest
+	}{
+		ContextMoqParam:   contextMoqParam,
+		RemovePeerRequest: removePeerRequest,
+	}
+	mock.lockRemoveTrustedPeer.Lock()
+	mock.calls.RemoveTrustedPeer = append(mock.calls.RemoveTrustedPeer, callInfo)
+	mock.lockRemoveTrustedPeer.Unlock()
+	if mock.RemoveTrustedPeerFunc == nil {
+		var (
+			removePeerReplyOut *RemovePeerReply
+			errOut             error
+		)
+		return removePeerReplyOut, errOut
+	}
+	return mock.RemoveTrustedPeerFunc(contextMoqParam, removePeerRequest)
+}
+
+// RemoveTrustedPeerCalls gets all the calls that were made to RemoveTrustedPeer.
+// Check the length with:
+//
+//	len(mockedSentryServer.RemoveTrustedPeerCalls())
+func (mock *SentryServerMock) RemoveTrustedPeerCalls() []struct {
+	ContextMoqParam   context.Context
+	RemovePeerRequest *RemovePeerRequest
+} {
+	var calls []struct {
+		ContextMoqParam   context.Context
+		RemovePeerRequest *RemovePeerRequest
+	}
+	mock.lockRemoveTrustedPeer.RLock()
+	calls = mock.calls.RemoveTrustedPeer
+	mock.lockRemoveTrustedPeer.RUnlock()
+	return calls
+}
+
 // SendMessageById calls SendMessageByIdFunc.
 func (mock *SentryServerMock) SendMessageById(contextMoqParam context.Context, sendMessageByIdRequest *SendMessageByIdRequest) (*SentPeers, error) {
 	callInfo := struct {
@@ -891,6 +1053,9 @@ var _ SentryClient = &SentryClientMock{}
 //			AddPeerFunc: func(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error) {
 //				panic("mock out the AddPeer method")
 //			},
+//			AddTrustedPeerFunc: func(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error) {
+//				panic("mock out the AddTrustedPeer method")
+//			},
 //			HandShakeFunc: func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HandShakeReply, error) {
 //				panic("mock out the HandShake method")
 //			},
@@ -918,6 +1083,12 @@ var _ SentryClient = &SentryClientMock{}
 //			PenalizePeerFunc: func(ctx context.Context, in *PenalizePeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 //				panic("mock out the PenalizePeer method")
 //			},
+//			RemovePeerFunc: func(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error) {
+//				panic("mock out the RemovePeer method")
+//			},
+//			RemoveTrustedPeerFunc: func(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error) {
+//				panic("mock out the RemoveTrustedPeer method")
+//			},
 //			SendMessageByIdFunc: func(ctx context.Context, in *SendMessageByIdRequest, opts ...grpc.CallOption) (*SentPeers, error) {
 //				panic("mock out the SendMessageById method")
 //			},
@@ -943,6 +1114,9 @@ type SentryClientMock struct {
 	// AddPeerFunc mocks the AddPeer method.
 	AddPeerFunc func(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error)
 
+	// AddTrustedPeerFunc mocks the AddTrustedPeer method.
+	AddTrustedPeerFunc func(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error)
+
 	// HandShakeFunc mocks the HandShake method.
 	HandShakeFunc func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HandShakeReply, error)
 
@@ -970,6 +1144,12 @@ type SentryClientMock struct {
 	// PenalizePeerFunc mocks the PenalizePeer method.
 	PenalizePeerFunc func(ctx context.Context, in *PenalizePeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 
+	// RemovePeerFunc mocks the RemovePeer method.
+	RemovePeerFunc func(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error)
+
+	// RemoveTrustedPeerFunc mocks the RemoveTrustedPeer method.
+	RemoveTrustedPeerFunc func(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error)
+
 	// SendMessageByIdFunc mocks the SendMessageById method.
 	SendMessageByIdFunc func(ctx context.Context, in *SendMessageByIdRequest, opts ...grpc.CallOption) (*SentPeers, error)
 
@@ -996,6 +1176,15 @@ type SentryClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// AddTrustedPeer holds details about calls to the AddTrustedPeer method.
+		AddTrustedPeer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *AddPeerRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// HandShake holds details about calls to the HandShake method.
 		HandShake []struct {
 			// Ctx is the ctx argument value.
@@ -1077,6 +1266,24 @@ type SentryClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// RemovePeer holds details about calls to the RemovePeer method.
+		RemovePeer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *RemovePeerRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// RemoveTrustedPeer holds details about calls to the RemoveTrustedPeer method.
+		RemoveTrustedPeer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *RemovePeerRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// SendMessageById holds details about calls to the SendMessageById method.
 		SendMessageById []struct {
 			// Ctx is the ctx argument value.
@@ -1124,6 +1331,7 @@ type SentryClientMock struct {
 		}
 	}
 	lockAddPeer                  sync.RWMutex
+	lockAddTrustedPeer           sync.RWMutex
 	lockHandShake                sync.RWMutex
 	lockMessages                 sync.RWMutex
 	lockNodeInfo                 sync.RWMutex
@@ -1133,6 +1341,8 @@ type SentryClientMock struct {
 	lockPeerMinBlock             sync.RWMutex
 	lockPeers                    sync.RWMutex
 	lockPenalizePeer             sync.RWMutex
+	lockRemovePeer               sync.RWMutex
+	lockRemoveTrustedPeer        sync.RWMutex
 	lockSendMessageById          sync.RWMutex
 	lockSendMessageByMinBlock    sync.RWMutex
 	lockSendMessageToAll         sync.RWMutex
@@ -1184,6 +1394,50 @@ func (mock *SentryClientMock) AddPeerCalls() []struct {
 	return calls
 }
 
+// AddTrustedPeer calls AddTrustedPeerFunc.
+func (mock *SentryClientMock) AddTrustedPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *AddPeerRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockAddTrustedPeer.Lock()
+	mock.calls.AddTrustedPeer = append(mock.calls.AddTrustedPeer, callInfo)
+	mock.lockAddTrustedPeer.Unlock()
+	if mock.AddTrustedPeerFunc == nil {
+		var (
+			addPeerReplyOut *AddPeerReply
+			errOut          error
+		)
+		return addPeerReplyOut, errOut
+	}
+	return mock.AddTrustedPeerFunc(ctx, in, opts...)
+}
+
+// AddTrustedPeerCalls gets all the calls that were made to AddTrustedPeer.
+// Check the length with:
+//
+//	len(mockedSentryClient.AddTrustedPeerCalls())
+func (mock *SentryClientMock) AddTrustedPeerCalls() []struct {
+	Ctx  context.Context
+	In   *AddPeerRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *AddPeerRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockAddTrustedPeer.RLock()
+	calls = mock.calls.AddTrustedPeer
+	mock.lockAddTrustedPeer.RUnlock()
+	return calls
+}
+
 // HandShake calls HandShakeFunc.
 func (mock *SentryClientMock) HandShake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HandShakeReply, error) {
 	callInfo := struct {
@@ -1580,6 +1834,94 @@ func (mock *SentryClientMock) PenalizePeerCalls() []struct {
 	return calls
 }
 
+// RemovePeer calls RemovePeerFunc.
+func (mock *SentryClientMock) RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *RemovePeerRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemovePeer.Lock()
+	mock.calls.RemovePeer = append(mock.calls.RemovePeer, callInfo)
+	mock.lockRemovePeer.Unlock()
+	if mock.RemovePeerFunc == nil {
+		var (
+			removePeerReplyOut *RemovePeerReply
+			errOut             error
+		)
+		return removePeerReplyOut, errOut
+	}
+	return mock.RemovePeerFunc(ctx, in, opts...)
+}
+
+// RemovePeerCalls gets all the calls that were made to RemovePeer.
+// Check the length with:
+//
+//	len(mockedSentryClient.RemovePeerCalls())
+func (mock *SentryClientMock) RemovePeerCalls() []struct {
+	Ctx  context.Context
+	In   *RemovePeerRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *RemovePeerRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRemovePeer.RLock()
+	calls = mock.calls.RemovePeer
+	mock.lockRemovePeer.RUnlock()
+	return calls
+}
+
+// RemoveTrustedPeer calls RemoveTrustedPeerFunc.
+func (mock *SentryClientMock) RemoveTrustedPeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *RemovePeerRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemoveTrustedPeer.Lock()
+	mock.calls.RemoveTrustedPeer = append(mock.calls.RemoveTrustedPeer, callInfo)
+	mock.lockRemoveTrustedPeer.Unlock()
+	if mock.RemoveTrustedPeerFunc == nil {
+		var (
+			removePeerReplyOut *RemovePeerReply
+			errOut             error
+		)
+		return removePeerReplyOut, errOut
+	}
+	return mock.RemoveTrustedPeerFunc(ctx, in, opts...)
+}
+
+// RemoveTrustedPeerCalls gets all the calls that were made to RemoveTrustedPeer.
+// Check the length with:
+//
+//	len(mockedSentryClient.RemoveTrustedPeerCalls())
+func (mock *SentryClientMock) RemoveTrustedPeerCalls() []struct {
+	Ctx  context.Context
+	In   *RemovePeerRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *RemovePeerRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRemoveTrustedPeer.RLock()
+	calls = mock.calls.RemoveTrustedPeer
+	mock.lockRemoveTrustedPeer.RUnlock()
+	return calls
+}
+
 // SendMessageById calls SendMessageByIdFunc.
 func (mock *SentryClientMock) SendMessageById(ctx context.Context, in *SendMessageByIdRequest, opts ...grpc.CallOption) (*SentPeers, error) {
 	callInfo := struct {
@@ -30,6 +30,8 @@ const (
 	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
 	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
 	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
+	Txpool_OnDiscard_FullMethodName    = "/txpool.Txpool/OnDiscard"
+	Txpool_GetBlobs_FullMethodName     = "/txpool.Txpool/GetBlobs"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -55,6 +57,10 @@ type TxpoolClient interface {
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
+	// subscribe to transactions discarded from the pool, with the reason of discarding
+	OnDiscard(ctx context.Context, in *OnDiscardRequest, opts ...grpc.CallOption) (Txpool_OnDiscardClient, error)
+	// returns blobs and their KZG proofs looked up by versioned hash
+	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
 }
 
 type txpoolClient struct {
@@ -169,6 +175,47 @@ func (c *txpoolClient) Nonce(ctx context.Context, in *NonceRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *txpoolClient) OnDiscard(ctx context.Context, in *OnDiscardRequest, opts ...grpc.CallOption) (Txpool_OnDiscardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[1], Txpool_OnDiscard_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txpoolOnDiscardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Txpool_OnDiscardClient interface {
+	Recv() (*OnDiscardReply, error)
+	grpc.ClientStream
+}
+
+type txpoolOnDiscardClient struct {
+	grpc.ClientStream
+}
+
+func (x *txpoolOnDiscardClient) Recv() (*OnDiscardReply, error) {
+	m := new(OnDiscardReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error) {
+	out := new(GetBlobsReply)
+	err := c.cc.Invoke(ctx, Txpool_GetBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility
@@ -192,6 +239,10 @@ type TxpoolServer interface {
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
+	// subscribe to transactions discarded from the pool, with the reason of discarding
+	OnDiscard(*OnDiscardRequest, Txpool_OnDiscardServer) error
+	// returns blobs and their KZG proofs looked up by versioned hash
+	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -226,6 +277,12 @@ func (UnimplementedTxpoolServer) Status(context.Context, *StatusRequest) (*Statu
 func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Nonce not implemented")
 }
+func (UnimplementedTxpoolServer) OnDiscard(*OnDiscardRequest, Txpool_OnDiscardServer) error {
+	return status.Errorf(codes.Unimplemented, "method OnDiscard not implemented")
+}
+func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 
 // UnsafeTxpoolServer may be embedded to opt out of forward compatibility for this service.
@@ -404,6 +461,45 @@ func _Txpool_Nonce_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_OnDiscard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OnDiscardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxpoolServer).OnDiscard(m, &txpoolOnDiscardServer{stream})
+}
+
+type Txpool_OnDiscardServer interface {
+	Send(*OnDiscardReply) error
+	grpc.ServerStream
+}
+
+type txpoolOnDiscardServer struct {
+	grpc.ServerStream
+}
+
+func (x *txpoolOnDiscardServer) Send(m *OnDiscardReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).GetBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_GetBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).GetBlobs(ctx, req.(*GetBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -443,6 +539,10 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Nonce",
 			Handler:    _Txpool_Nonce_Handler,
 		},
+		{
+			MethodName: "GetBlobs",
+			Handler:    _Txpool_GetBlobs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -450,6 +550,11 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Txpool_OnAdd_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "OnDiscard",
+			Handler:       _Txpool_OnDiscard_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "txpool/txpool.proto",
 }
@@ -0,0 +1,3 @@
+module github.com/ledgerwatch/interfaces
+
+go 1.18
@@ -32,6 +32,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -114,6 +115,8 @@ type Coherent struct {
 	stateEvict           *ThreadSafeEvictionList
 	codeEvict            *ThreadSafeEvictionList
 	miss                 metrics.Counter
+	classHits            [keyClasses]metrics.Counter
+	classMiss            [keyClasses]metrics.Counter
 	cfg                  CoherentConfig
 	latestStateVersionID uint64
 	lock                 sync.Mutex
@@ -162,6 +165,24 @@ type CoherentConfig struct {
 	MetricsLabel    string
 	NewBlockWait    time.Duration // how long wait
 	KeepViews       uint64        // keep in memory up to this amount of views, evict older
+	WarmStartFile   string        // hottest keys are saved to this file at shutdown and restored on start, see SaveHotKeys and WarmUp
+}
+
+// key classes - to see hit rate of accounts, storage and code separately
+const (
+	classAccounts = iota
+	classStorage
+	classCode
+	keyClasses
+)
+
+var keyClassNames = [keyClasses]string{"accounts", "storage", "code"}
+
+func keyClass(k []byte) int {
+	if len(k) == length.Addr {
+		return classAccounts
+	}
+	return classStorage
 }
 
 var DefaultCoherentConfig = CoherentConfig{
@@ -179,7 +200,7 @@ func New(cfg CoherentConfig) *Coherent {
 		panic("empty config passed")
 	}
 
-	return &Coherent{
+	c := &Coherent{
 		roots:        map[uint64]*CoherentRoot{},
 		stateEvict:   &ThreadSafeEvictionList{l: NewList()},
 		codeEvict:    &ThreadSafeEvictionList{l: NewList()},
@@ -195,6 +216,11 @@ func New(cfg CoherentConfig) *Coherent {
 		codeKeys:     metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_keys_total{name="%s"}`, cfg.MetricsLabel)),
 		codeEvictLen: metrics.GetOrCreateGauge(fmt.Sprintf(`cache_code_list_total{name="%s"}`, cfg.MetricsLabel)),
 	}
+	for class, className := range keyClassNames {
+		c.classHits[class] = metrics.GetOrCreateCounter(fmt.Sprintf(`cache_class_total{class="%s",result="hit",name="%s"}`, className, cfg.MetricsLabel))
+		c.classMiss[class] = metrics.GetOrCreateCounter(fmt.Sprintf(`cache_class_total{class="%s",result="miss",name="%s"}`, className, cfg.MetricsLabel))
+	}
+	return c
 }
 
 // selectOrCreateRoot - used for usual getting root
@@ -326,16 +352,21 @@ func (c *Coherent) OnNewBlock(stateChanges *remote.StateChangeBatch) {
 	//log.Info("on new block handled", "viewID", stateChanges.StateVersionID)
 }
 
-func (c *Coherent) View(ctx context.Context, tx kv.Tx) (CacheView, error) {
+func stateVersionID(tx kv.Tx) (uint64, error) {
 	idBytes, err := tx.GetOne(kv.Sequence, kv.PlainStateVersion)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	var id uint64
 	if len(idBytes) == 0 {
-		id = 0
-	} else {
-		id = binary.BigEndian.Uint64(idBytes)
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(idBytes), nil
+}
+
+func (c *Coherent) View(ctx context.Context, tx kv.Tx) (CacheView, error) {
+	id, err := stateVersionID(tx)
+	if err != nil {
+		return nil, err
 	}
 	r := c.selectOrCreateRoot(id)
 
@@ -395,9 +426,11 @@ func (c *Coherent) Get(k []byte, tx kv.Tx, id uint64) ([]byte, error) {
 	if it != nil {
 		//fmt.Printf("from cache:  %#x,%x\n", k, it.(*Element).V)
 		c.hits.Inc()
+		c.classHits[keyClass(k)].Inc()
 		return it.V, nil
 	}
 	c.miss.Inc()
+	c.classMiss[keyClass(k)].Inc()
 
 	v, err := tx.GetOne(kv.PlainState, k)
 	if err != nil {
@@ -420,9 +453,11 @@ func (c *Coherent) GetCode(k []byte, tx kv.Tx, id uint64) ([]byte, error) {
 	if it != nil {
 		//fmt.Printf("from cache:  %#x,%x\n", k, it.(*Element).V)
 		c.codeHits.Inc()
+		c.classHits[classCode].Inc()
 		return it.V, nil
 	}
 	c.codeMiss.Inc()
+	c.classMiss[classCode].Inc()
 
 	v, err := tx.GetOne(kv.Code, k)
 	if err != nil {
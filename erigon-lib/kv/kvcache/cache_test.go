@@ -19,9 +19,13 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/crypto/sha3"
+
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
@@ -372,3 +376,97 @@ func TestCode(t *testing.T) {
 		return nil
 	})
 }
+
+func TestWarmUp(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	cfg := DefaultCoherentConfig
+	cfg.NewBlockWait = 0
+	db := memdb.NewTestDB(t)
+	file := filepath.Join(t.TempDir(), "hot_keys")
+	k1, k2, k3 := [20]byte{1}, [20]byte{2}, [20]byte{3}
+	code := []byte{0x60, 0x01}
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(code)
+	codeHash := hasher.Sum(nil)
+
+	setState := func(id uint64, v []byte) {
+		require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+			var versionID [8]byte
+			binary.BigEndian.PutUint64(versionID[:], id)
+			if err := tx.Put(kv.Sequence, kv.PlainStateVersion, versionID[:]); err != nil {
+				return err
+			}
+			if err := tx.Put(kv.Code, codeHash, code); err != nil {
+				return err
+			}
+			return tx.Put(kv.PlainState, k1[:], v)
+		}))
+	}
+	setState(1, []byte{1})
+
+	c := New(cfg)
+	saved, err := SaveHotKeys(c, file)
+	require.NoError(err)
+	require.Zero(saved)
+	c.OnNewBlock(&remote.StateChangeBatch{StateVersionId: 1})
+	require.NoError(db.View(ctx, func(tx kv.Tx) error {
+		view, err := c.View(ctx, tx)
+		require.NoError(err)
+		for _, k := range [][]byte{k2[:], k1[:], k3[:]} { // k3 - most recently used, k2 - least
+			_, err = view.Get(k)
+			require.NoError(err)
+		}
+		_, err = view.GetCode(codeHash)
+		return err
+	}))
+	saved, err = SaveHotKeys(c, file)
+	require.NoError(err)
+	require.Equal(4, saved)
+
+	assertRestored := func(c *Coherent, v1 []byte) {
+		require.Equal(k2[:], c.stateEvict.Oldest().K) // eviction order is restored
+		require.NoError(db.View(ctx, func(tx kv.Tx) error {
+			view, err := c.View(ctx, tx)
+			require.NoError(err)
+			accountHits, codeHits := c.classHits[classAccounts].GetValueUint64(), c.classHits[classCode].GetValueUint64()
+			v, err := view.Get(k1[:])
+			require.NoError(err)
+			require.Equal(v1, v)
+			v, err = view.Get(k2[:])
+			require.NoError(err)
+			require.Nil(v) // absence of key is cached too
+			v, err = view.GetCode(codeHash)
+			require.NoError(err)
+			require.Equal(code, v)
+			require.Equal(accountHits+2, c.classHits[classAccounts].GetValueUint64())
+			require.Equal(codeHits+1, c.classHits[classCode].GetValueUint64())
+			return nil
+		}))
+	}
+
+	// same state
+	restored := New(cfg)
+	loaded, err := WarmUp(ctx, restored, db, file, log.New())
+	require.NoError(err)
+	require.Equal(4, loaded)
+	assertRestored(restored, []byte{1})
+
+	// state changed after save - values must be re-read
+	setState(2, []byte{2})
+	restored = New(cfg)
+	loaded, err = WarmUp(ctx, restored, db, file, log.New())
+	require.NoError(err)
+	require.Equal(4, loaded)
+	assertRestored(restored, []byte{2})
+
+	// cache already has newer state
+	restored = New(cfg)
+	restored.OnNewBlock(&remote.StateChangeBatch{StateVersionId: 3})
+	loaded, err = WarmUp(ctx, restored, db, file, log.New())
+	require.NoError(err)
+	require.Zero(loaded)
+
+	loaded, err = WarmUp(ctx, New(cfg), db, file+".missing", log.New())
+	require.NoError(err)
+	require.Zero(loaded)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2021 Erigon contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvcache
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+)
+
+// Hot keys file format:
+//
+//	magic, stateVersionID_u64, records...
+//	record: isCode_u8, len(k)_u32, k, len(v)_u32 (nilValue if value is nil - marker of absent key), v
+//
+// records are ordered from most to least recently used
+var hotKeysMagic = []byte("kvcache1")
+
+const nilValue = ^uint32(0)
+
+type hotKey struct {
+	k, v []byte
+	code bool
+}
+
+// SaveHotKeys - saves keys of latest cache view (most recently used first) to file, to restore them by WarmUp
+// after restart. Does nothing if cache is not Coherent or has no views yet.
+func SaveHotKeys(cache Cache, file string) (int, error) {
+	c, ok := cache.(*Coherent)
+	if !ok {
+		return 0, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.latestStateView == nil {
+		return 0, nil
+	}
+
+	tmpFile := file + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	defer os.Remove(tmpFile)
+
+	w := bufio.NewWriter(f)
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], c.latestStateVersionID)
+	if _, err := w.Write(hotKeysMagic); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(num[:]); err != nil {
+		return 0, err
+	}
+	var saved int
+	for _, l := range []*ThreadSafeEvictionList{c.stateEvict, c.codeEvict} {
+		isCode := l == c.codeEvict
+		l.lock.Lock()
+		for e := l.l.Front(); e != nil && err == nil; e = e.Next() {
+			err = writeHotKey(w, hotKey{k: e.K, v: e.V, code: isCode})
+			saved++
+		}
+		l.lock.Unlock()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpFile, file); err != nil {
+		return 0, err
+	}
+	return saved, nil
+}
+
+func writeHotKey(w io.Writer, h hotKey) error {
+	var buf [4]byte
+	isCode := []byte{0}
+	if h.code {
+		isCode[0] = 1
+	}
+	if _, err := w.Write(isCode); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(buf[:], uint32(len(h.k)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(h.k); err != nil {
+		return err
+	}
+	if h.v == nil {
+		binary.BigEndian.PutUint32(buf[:], nilValue)
+	} else {
+		binary.BigEndian.PutUint32(buf[:], uint32(len(h.v)))
+	}
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(h.v)
+	return err
+}
+
+func readHotKeys(file string) (stateVersionID uint64, keys []hotKey, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	header := make([]byte, len(hotKeysMagic)+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	if string(header[:len(hotKeysMagic)]) != string(hotKeysMagic) {
+		return 0, nil, fmt.Errorf("unknown format")
+	}
+	stateVersionID = binary.BigEndian.Uint64(header[len(hotKeysMagic):])
+
+	var buf [4]byte
+	for {
+		isCode, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return stateVersionID, keys, nil
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		h := hotKey{code: isCode == 1}
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, nil, err
+		}
+		h.k = make([]byte, binary.BigEndian.Uint32(buf[:]))
+		if _, err := io.ReadFull(r, h.k); err != nil {
+			return 0, nil, err
+		}
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, nil, err
+		}
+		if vLen := binary.BigEndian.Uint32(buf[:]); vLen != nilValue {
+			h.v = make([]byte, vLen)
+			if _, err := io.ReadFull(r, h.v); err != nil {
+				return 0, nil, err
+			}
+		}
+		keys = append(keys, h)
+	}
+}
+
+// WarmUp - restores keys saved by SaveHotKeys as latest cache view. View stays coherent:
+//   - saved values are used only if db has the same stateVersionID as at saving time, otherwise values are re-read from db
+//   - if cache already received newer state changes - nothing is restored
+//
+// Missing file is not an error. Does nothing if cache is not Coherent.
+func WarmUp(ctx context.Context, cache Cache, db kv.RoDB, file string, logger log.Logger) (int, error) {
+	c, ok := cache.(*Coherent)
+	if !ok {
+		return 0, nil
+	}
+	savedID, keys, err := readHotKeys(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("kvcache warm up: %s: %w", file, err)
+	}
+
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	id, err := stateVersionID(tx)
+	if err != nil {
+		return 0, err
+	}
+	if id != savedID {
+		logger.Info("[kvcache] state changed since hot keys were saved, reading values from db", "saved", savedID, "current", id, "keys", len(keys))
+		for i := range keys {
+			if keys[i].code { // code is addressed by hash - never changes
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			v, err := tx.GetOne(kv.PlainState, keys[i].k)
+			if err != nil {
+				return 0, err
+			}
+			keys[i].v = common.Copy(v)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.latestStateVersionID > id {
+		logger.Info("[kvcache] skip warm up: cache already has newer state", "cache", c.latestStateVersionID, "db", id)
+		return 0, nil
+	}
+	r := c.advanceRoot(id)
+	// from least recently used - to keep eviction order
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].code {
+			c.addCode(keys[i].k, keys[i].v, r, id)
+		} else {
+			c.add(keys[i].k, keys[i].v, r, id)
+		}
+	}
+	c.keys.SetInt(r.cache.Len())
+	c.codeKeys.SetInt(r.codeCache.Len())
+	c.evict.SetInt(c.stateEvict.Len())
+	c.codeEvictLen.SetInt(c.codeEvict.Len())
+	if r.readyChanClosed.CompareAndSwap(false, true) {
+		close(r.ready)
+	}
+	return len(keys), nil
+}
@@ -0,0 +1,132 @@
+/*
+   Copyright 2021 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remotedbserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+)
+
+// AnyClient - ACL rule for clients which have no own rule (or have no client certificate)
+const AnyClient = "*"
+
+// ACL - allow-list of kv tables and snapshot types per client identity.
+// Client identity is Common Name of client TLS certificate - it's verified only if server requires client
+// certificates (--tls.cacert), without it all clients are AnyClient.
+//
+// File format (JSON), "*" in lists allows everything:
+//
+//	{
+//	  "rpc-team-a": {"tables": ["*"], "snapshots": ["*"]},
+//	  "rpc-team-b": {"tables": ["Header", "CanonicalHeader", "HeaderNumber", "BlockBody"], "snapshots": ["headers", "bodies"]},
+//	  "*": {"tables": [], "snapshots": []}
+//	}
+type ACL map[string]ACLRule
+
+type ACLRule struct {
+	Tables    []string `json:"tables"`
+	Snapshots []string `json:"snapshots"` // snapshot types: headers, bodies, transactions, accounts, storage, ...
+}
+
+func LoadACL(file string) (ACL, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("kv acl: %w", err)
+	}
+	var acl ACL
+	if err := json.Unmarshal(data, &acl); err != nil {
+		return nil, fmt.Errorf("kv acl: parse %s: %w", file, err)
+	}
+	if acl == nil { // `null` in file must not disable checks
+		acl = ACL{}
+	}
+	return acl, nil
+}
+
+func (a ACL) rule(client string) (ACLRule, bool) {
+	if r, ok := a[client]; ok {
+		return r, true
+	}
+	r, ok := a[AnyClient]
+	return r, ok
+}
+
+// AllowTable - nil ACL allows everything
+func (a ACL) AllowTable(client, table string) bool {
+	if a == nil {
+		return true
+	}
+	r, ok := a.rule(client)
+	return ok && contains(r.Tables, table)
+}
+
+// AllowSnapshot - if client can see snapshot file with given name. nil ACL allows everything
+func (a ACL) AllowSnapshot(client, fileName string) bool {
+	if a == nil {
+		return true
+	}
+	r, ok := a.rule(client)
+	return ok && contains(r.Snapshots, snapshotType(fileName))
+}
+
+func contains(allowed []string, name string) bool {
+	for _, a := range allowed {
+		if a == AnyClient || a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotType - "headers" for "v1-000000-000500-headers.seg", "accounts" for "v1-accounts.0-32.kv"
+func snapshotType(fileName string) string {
+	_, fileName = filepath.Split(fileName)
+	if info, _, ok := snaptype.ParseFileName("", fileName); ok && info.Type != nil {
+		return info.Type.String()
+	}
+	_, name, _ := strings.Cut(fileName, "-")
+	name, _, _ = strings.Cut(name, ".")
+	return name
+}
+
+// ClientIdentity - Common Name of verified client certificate, AnyClient if connection has no verified client certificate
+func ClientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return AnyClient
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return AnyClient
+	}
+	if cn := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+		return cn
+	}
+	return AnyClient
+}
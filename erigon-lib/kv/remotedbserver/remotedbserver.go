@@ -28,6 +28,8 @@ import (
 	"time"
 
 	"github.com/ledgerwatch/log/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -84,6 +86,8 @@ type KvServer struct {
 	trace     bool
 	rangeStep int // make sure `s.with` has limited time
 	logger    log.Logger
+
+	acl ACL // nil - all clients can read everything
 }
 
 type threadSafeTx struct {
@@ -112,6 +116,18 @@ func NewKvServer(ctx context.Context, db kv.RoDB, snapshots Snapshots, borSnapsh
 	}
 }
 
+// SetACL - restricts tables and snapshots available to clients, must be called before serving
+func (s *KvServer) SetACL(acl ACL) { s.acl = acl }
+
+// checkTable - returns PermissionDenied error if client has no access to table. Denied accesses are logged for audit.
+func (s *KvServer) checkTable(client, table string) error {
+	if s.acl.AllowTable(client, table) {
+		return nil
+	}
+	s.logger.Warn("[kv_server] access denied", "client", client, "table", table)
+	return status.Errorf(codes.PermissionDenied, "kvserver: client %s has no access to table %s", client, table)
+}
+
 // Version returns the service-side interface version number
 func (s *KvServer) Version(context.Context, *emptypb.Empty) (*types.VersionReply, error) {
 	dbSchemaVersion := &kv.DBSchemaVersion
@@ -214,6 +230,7 @@ func (s *KvServer) with(id uint64, f func(kv.Tx) error) error {
 }
 
 func (s *KvServer) Tx(stream remote.KV_TxServer) error {
+	client := ClientIdentity(stream.Context())
 	id, errBegin := s.begin(stream.Context())
 	if errBegin != nil {
 		return fmt.Errorf("server-side error: %w", errBegin)
@@ -309,6 +326,9 @@ func (s *KvServer) Tx(stream remote.KV_TxServer) error {
 		}
 		switch in.Op {
 		case remote.Op_OPEN:
+			if err := s.checkTable(client, in.BucketName); err != nil {
+				return err
+			}
 			CursorID++
 			var err error
 			if err := s.with(id, func(tx kv.Tx) error {
@@ -329,6 +349,9 @@ func (s *KvServer) Tx(stream remote.KV_TxServer) error {
 			}
 			continue
 		case remote.Op_OPEN_DUP_SORT:
+			if err := s.checkTable(client, in.BucketName); err != nil {
+				return err
+			}
 			CursorID++
 			var err error
 			if err := s.with(id, func(tx kv.Tx) error {
@@ -438,6 +461,10 @@ func bytesCopy(b []byte) []byte {
 }
 
 func (s *KvServer) StateChanges(_ *remote.StateChangeRequest, server remote.KV_StateChangesServer) error {
+	// stream has all changes of accounts and storage
+	if err := s.checkTable(ClientIdentity(server.Context()), kv.PlainState); err != nil {
+		return err
+	}
 	ch, remove := s.stateChangeStreams.Sub()
 	defer remove()
 	for {
@@ -458,7 +485,7 @@ func (s *KvServer) SendStateChanges(_ context.Context, sc *remote.StateChangeBat
 	s.stateChangeStreams.Pub(sc)
 }
 
-func (s *KvServer) Snapshots(_ context.Context, _ *remote.SnapshotsRequest) (reply *remote.SnapshotsReply, err error) {
+func (s *KvServer) Snapshots(ctx context.Context, _ *remote.SnapshotsRequest) (reply *remote.SnapshotsReply, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("%v, %s", rec, dbg.Stack())
@@ -478,9 +505,30 @@ func (s *KvServer) Snapshots(_ context.Context, _ *remote.SnapshotsRequest) (rep
 		reply.HistoryFiles = s.historySnapshots.Files()
 	}
 
+	if s.acl != nil {
+		client := ClientIdentity(ctx)
+		reply.BlocksFiles = s.allowedSnapshots(client, reply.BlocksFiles)
+		reply.HistoryFiles = s.allowedSnapshots(client, reply.HistoryFiles)
+	}
 	return reply, nil
 }
 
+func (s *KvServer) allowedSnapshots(client string, files []string) []string {
+	allowed := make([]string, 0, len(files))
+	var denied int
+	for _, f := range files {
+		if s.acl.AllowSnapshot(client, f) {
+			allowed = append(allowed, f)
+			continue
+		}
+		denied++
+	}
+	if denied > 0 {
+		s.logger.Debug("[kv_server] snapshots hidden by acl", "client", client, "files", denied)
+	}
+	return allowed
+}
+
 type StateChangePubSub struct {
 	chans map[uint]chan *remote.StateChangeBatch
 	id    uint
@@ -533,7 +581,10 @@ func (s *StateChangePubSub) remove(id uint) {
 // Temporal methods
 //
 
-func (s *KvServer) DomainGet(_ context.Context, req *remote.DomainGetReq) (reply *remote.DomainGetReply, err error) {
+func (s *KvServer) DomainGet(ctx context.Context, req *remote.DomainGetReq) (reply *remote.DomainGetReply, err error) {
+	if err := s.checkTable(ClientIdentity(ctx), req.Table); err != nil {
+		return nil, err
+	}
 	reply = &remote.DomainGetReply{}
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
@@ -557,7 +608,10 @@ func (s *KvServer) DomainGet(_ context.Context, req *remote.DomainGetReq) (reply
 	}
 	return reply, nil
 }
-func (s *KvServer) HistoryGet(_ context.Context, req *remote.HistoryGetReq) (reply *remote.HistoryGetReply, err error) {
+func (s *KvServer) HistoryGet(ctx context.Context, req *remote.HistoryGetReq) (reply *remote.HistoryGetReply, err error) {
+	if err := s.checkTable(ClientIdentity(ctx), req.Table); err != nil {
+		return nil, err
+	}
 	reply = &remote.HistoryGetReply{}
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
@@ -577,7 +631,10 @@ func (s *KvServer) HistoryGet(_ context.Context, req *remote.HistoryGetReq) (rep
 
 const PageSizeLimit = 4 * 4096
 
-func (s *KvServer) IndexRange(_ context.Context, req *remote.IndexRangeReq) (*remote.IndexRangeReply, error) {
+func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*remote.IndexRangeReply, error) {
+	if err := s.checkTable(ClientIdentity(ctx), req.Table); err != nil {
+		return nil, err
+	}
 	reply := &remote.IndexRangeReply{}
 	from, limit := int(req.FromTs), int(req.Limit)
 	if req.PageToken != "" {
@@ -625,7 +682,10 @@ func (s *KvServer) IndexRange(_ context.Context, req *remote.IndexRangeReq) (*re
 	return reply, nil
 }
 
-func (s *KvServer) Range(_ context.Context, req *remote.RangeReq) (*remote.Pairs, error) {
+func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (*remote.Pairs, error) {
+	if err := s.checkTable(ClientIdentity(ctx), req.Table); err != nil {
+		return nil, err
+	}
 	from, limit := req.FromPrefix, int(req.Limit)
 	if req.PageToken != "" {
 		var pagination remote.ParisPagination
@@ -18,6 +18,9 @@ package remotedbserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"runtime"
 	"testing"
 
@@ -25,7 +28,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 )
@@ -139,3 +147,57 @@ func TestKVServerSnapshotsReturnsEmptyIfNoBlockSnapshots(t *testing.T) {
 	require.Empty(t, reply.BlocksFiles)
 	require.Empty(t, reply.HistoryFiles)
 }
+
+func TestKVServerACL(t *testing.T) {
+	require, ctx, db := require.New(t), context.Background(), memdb.NewTestDB(t)
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.Headers, []byte{1}, []byte{1})
+	}))
+	ctrl := gomock.NewController(t)
+	blockSnapshots := NewMockSnapshots(ctrl)
+	blockSnapshots.EXPECT().Files().Return([]string{"v1-000000-000500-headers.seg", "v1-000000-000500-bodies.seg"}).AnyTimes()
+	historySnapshots := NewMockSnapshots(ctrl)
+	historySnapshots.EXPECT().Files().Return([]string{"v1-accounts.0-32.kv", "v1-code.0-32.kv"}).AnyTimes()
+
+	s := NewKvServer(ctx, db, blockSnapshots, nil, historySnapshots, log.New())
+	s.SetACL(ACL{
+		"team-a": {Tables: []string{"*"}, Snapshots: []string{"*"}},
+		"team-b": {Tables: []string{kv.Headers}, Snapshots: []string{"headers", "accounts"}},
+	})
+	clientCtx := func(cn string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}})
+	}
+	require.Equal("team-b", ClientIdentity(clientCtx("team-b")))
+	require.Equal(AnyClient, ClientIdentity(ctx))
+
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+	for _, tc := range []struct {
+		client string
+		table  string
+		denied bool
+	}{
+		{"team-a", kv.PlainState, false},
+		{"team-b", kv.Headers, false},
+		{"team-b", kv.PlainState, true},
+		{"unknown", kv.Headers, true},
+	} {
+		_, err = s.Range(clientCtx(tc.client), &remote.RangeReq{TxId: id, Table: tc.table, OrderAscend: true, Limit: -1})
+		if tc.denied {
+			require.Equal(codes.PermissionDenied, status.Code(err), tc)
+		} else {
+			require.NoError(err, tc)
+		}
+	}
+
+	reply, err := s.Snapshots(clientCtx("team-b"), nil)
+	require.NoError(err)
+	require.Equal([]string{"v1-000000-000500-headers.seg"}, reply.BlocksFiles)
+	require.Equal([]string{"v1-accounts.0-32.kv"}, reply.HistoryFiles)
+	reply, err = s.Snapshots(ctx, nil)
+	require.NoError(err)
+	require.Empty(reply.BlocksFiles)
+	require.Empty(reply.HistoryFiles)
+}
@@ -290,6 +290,11 @@ const (
 	LogTopicIndex   = "LogTopicIndex"
 	LogAddressIndex = "LogAddressIndex"
 
+	// LogCustomIndex - user-defined log indices (see eth/logindex), same format as LogTopicIndex:
+	// index_id_8bytes + key + [4 bytes shard number] -> bitmap(blockN)
+	// index_id_8bytes -> block_num_u64 (last indexed block of index)
+	LogCustomIndex = "LogCustomIndex"
+
 	// CallTraceSet is the name of the table that contain the mapping of block number to the set (sorted) of all accounts
 	// touched by call traces. It is DupSort-ed table
 	// 8-byte BE block number -> account address -> two bits (one for "from", another for "to")
@@ -569,6 +574,7 @@ var ChaindataTables = []string{
 	Migrations,
 	LogTopicIndex,
 	LogAddressIndex,
+	LogCustomIndex,
 	CallTraceSet,
 	CallFromIndex,
 	CallToIndex,
@@ -0,0 +1,270 @@
+/*
+   Copyright 2021 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package recsplit
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/slices"
+)
+
+const defaultCheckpointEvery = 5 * time.Minute
+
+// Checkpoint file format - magic, then sequence of BigEndian uint64:
+//
+//	len(params), params (salt, leafSize, bytesPerRec, keysAdded, keysHash, bucketCount, bucketSize, baseDataID, startSeed...)
+//	lastBucket - all buckets up to this one (inclusive) are written to the .tmp index file
+//	indexSize - size of .tmp index file at the moment of checkpoint
+//	len(golombRice), gr.bitCount, len(gr.data), gr.data
+//	len(bucketSizeAcc), bucketSizeAcc, len(bucketPosAcc), bucketPosAcc
+var checkpointMagic = []byte("rsckpt01")
+
+type checkpointState struct {
+	params        []uint64
+	lastBucket    uint64
+	indexSize     uint64
+	golombRiceLen uint64
+	gr            GolombRice
+	bucketSizeAcc []uint64
+	bucketPosAcc  []uint64
+}
+
+func (rs *RecSplit) checkpointFile() string { return rs.indexFile + ".ckp" }
+
+// checkpointParams - checkpoint can be used only by Build of the same keys with the same parameters
+func (rs *RecSplit) checkpointParams() []uint64 {
+	params := []uint64{uint64(rs.salt), uint64(rs.leafSize), uint64(rs.bytesPerRec), rs.keysAdded, rs.keysHash, rs.bucketCount, uint64(rs.bucketSize), rs.baseDataID}
+	return append(params, rs.startSeed...)
+}
+
+func (c *checkpointState) encode() []byte {
+	buf := append([]byte{}, checkpointMagic...)
+	appendSlice := func(s []uint64) {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(len(s)))
+		for _, v := range s {
+			buf = binary.BigEndian.AppendUint64(buf, v)
+		}
+	}
+	appendSlice(c.params)
+	buf = binary.BigEndian.AppendUint64(buf, c.lastBucket)
+	buf = binary.BigEndian.AppendUint64(buf, c.indexSize)
+	buf = binary.BigEndian.AppendUint64(buf, c.golombRiceLen)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(c.gr.bitCount))
+	appendSlice(c.gr.data)
+	appendSlice(c.bucketSizeAcc)
+	appendSlice(c.bucketPosAcc)
+	return buf
+}
+
+var errCorruptCheckpoint = errors.New("corrupt checkpoint")
+
+func decodeCheckpoint(buf []byte) (*checkpointState, error) {
+	if len(buf) < len(checkpointMagic) || string(buf[:len(checkpointMagic)]) != string(checkpointMagic) {
+		return nil, errCorruptCheckpoint
+	}
+	buf = buf[len(checkpointMagic):]
+	readNum := func() (uint64, error) {
+		if len(buf) < 8 {
+			return 0, errCorruptCheckpoint
+		}
+		v := binary.BigEndian.Uint64(buf)
+		buf = buf[8:]
+		return v, nil
+	}
+	readSlice := func() ([]uint64, error) {
+		n, err := readNum()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(buf)/8) {
+			return nil, errCorruptCheckpoint
+		}
+		s := make([]uint64, n)
+		for i := range s {
+			s[i], _ = readNum()
+		}
+		return s, nil
+	}
+	c := &checkpointState{}
+	var err error
+	var bitCount uint64
+	if c.params, err = readSlice(); err != nil {
+		return nil, err
+	}
+	for _, num := range []*uint64{&c.lastBucket, &c.indexSize, &c.golombRiceLen, &bitCount} {
+		if *num, err = readNum(); err != nil {
+			return nil, err
+		}
+	}
+	if c.gr.data, err = readSlice(); err != nil {
+		return nil, err
+	}
+	c.gr.bitCount = int(bitCount)
+	if c.bucketSizeAcc, err = readSlice(); err != nil {
+		return nil, err
+	}
+	if c.bucketPosAcc, err = readSlice(); err != nil {
+		return nil, err
+	}
+	if len(buf) != 0 || len(c.bucketSizeAcc) == 0 || len(c.bucketPosAcc) == 0 || c.golombRiceLen > 1<<16 {
+		return nil, errCorruptCheckpoint
+	}
+	return c, nil
+}
+
+// checkpointSalt - salt of the keys of existing checkpoint file, 0 if there is no valid checkpoint
+func checkpointSalt(file string, logger log.Logger) uint32 {
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		return 0
+	}
+	c, err := decodeCheckpoint(buf)
+	if err != nil {
+		logger.Warn("[index] ignoring checkpoint", "file", file, "err", err)
+		return 0
+	}
+	if len(c.params) == 0 {
+		return 0
+	}
+	return uint32(c.params[0])
+}
+
+// resumeFromCheckpoint - opens .tmp index file and restores state of Build, if there is checkpoint of the same keys.
+// Returns false if Build must start from scratch
+func (rs *RecSplit) resumeFromCheckpoint() (bool, error) {
+	if !rs.checkpoint {
+		return false, nil
+	}
+	buf, err := os.ReadFile(rs.checkpointFile())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	c, err := decodeCheckpoint(buf)
+	if err != nil {
+		rs.logger.Warn("[index] ignoring checkpoint", "file", rs.checkpointFile(), "err", err)
+		return false, nil
+	}
+	if !slices.Equal(c.params, rs.checkpointParams()) {
+		rs.logger.Info("[index] checkpoint belongs to other keys, building from scratch", "file", rs.indexFileName)
+		return false, nil
+	}
+	f, err := os.OpenFile(rs.tmpFilePath, os.O_RDWR, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return false, err
+	}
+	if uint64(st.Size()) < c.indexSize {
+		f.Close()
+		rs.logger.Warn("[index] ignoring checkpoint: index file is too short", "file", rs.tmpFilePath, "size", st.Size(), "expected", c.indexSize)
+		return false, nil
+	}
+	if err = f.Truncate(int64(c.indexSize)); err != nil {
+		f.Close()
+		return false, err
+	}
+	if _, err = f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return false, err
+	}
+
+	rs.indexF = f
+	rs.gr = c.gr
+	rs.bucketSizeAcc = c.bucketSizeAcc
+	rs.bucketPosAcc = c.bucketPosAcc
+	if c.golombRiceLen > 0 {
+		rs.golombParam(uint16(c.golombRiceLen - 1))
+	}
+	rs.resumeAfter = c.lastBucket
+	rs.logger.Info("[index] resuming from checkpoint", "file", rs.indexFileName, "bucket", c.lastBucket+1, "buckets", rs.bucketCount)
+	return true, nil
+}
+
+func (rs *RecSplit) maybeCheckpoint(lastBucket uint64) error {
+	if !rs.checkpoint || time.Since(rs.lastCheckpoint) < rs.checkpointEvery {
+		return nil
+	}
+	if err := rs.saveCheckpoint(lastBucket); err != nil {
+		return fmt.Errorf("recsplit checkpoint %s: %w", rs.checkpointFile(), err)
+	}
+	return nil
+}
+
+// saveCheckpoint - must be called after all buckets up to lastBucket (inclusive) are written to index
+func (rs *RecSplit) saveCheckpoint(lastBucket uint64) error {
+	if err := rs.indexW.Flush(); err != nil {
+		return err
+	}
+	if err := rs.fsync(); err != nil {
+		return err
+	}
+	indexSize, err := rs.indexF.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	c := &checkpointState{
+		params:        rs.checkpointParams(),
+		lastBucket:    lastBucket,
+		indexSize:     uint64(indexSize),
+		golombRiceLen: uint64(len(rs.golombRice)),
+		gr:            rs.gr,
+		bucketSizeAcc: rs.bucketSizeAcc,
+		bucketPosAcc:  rs.bucketPosAcc,
+	}
+
+	tmpFile := rs.checkpointFile() + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	defer os.Remove(tmpFile)
+	if _, err = f.Write(c.encode()); err != nil {
+		return err
+	}
+	if !rs.noFsync {
+		if err = f.Sync(); err != nil {
+			return err
+		}
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFile, rs.checkpointFile()); err != nil {
+		return err
+	}
+	rs.lastCheckpoint = time.Now()
+	if rs.onCheckpoint != nil {
+		rs.onCheckpoint(lastBucket)
+	}
+	return nil
+}
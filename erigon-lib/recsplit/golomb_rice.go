@@ -82,6 +82,36 @@ func (g *GolombRice) appendFixed(v uint64, log2golomb int) {
 	g.bitCount += log2golomb
 }
 
+// appendBits adds the whole encoding of another GolombRice to the end of the current encoding
+func (g *GolombRice) appendBits(other *GolombRice) {
+	if other.bitCount == 0 {
+		return
+	}
+	usedBits := g.bitCount & 63
+	targetSize := (g.bitCount + other.bitCount + 63) / 64
+	for len(g.data) < targetSize {
+		g.data = append(g.data, 0)
+	}
+	appendPtr := g.bitCount / 64
+	if usedBits == 0 {
+		copy(g.data[appendPtr:], other.data)
+	} else {
+		for _, word := range other.data {
+			g.data[appendPtr] |= word << usedBits
+			appendPtr++
+			if appendPtr < len(g.data) {
+				g.data[appendPtr] = word >> (64 - usedBits)
+			}
+		}
+	}
+	g.bitCount += other.bitCount
+}
+
+func (g *GolombRice) reset() {
+	g.data = g.data[:0]
+	g.bitCount = 0
+}
+
 // Bits returns currrent number of bits in the compact encoding of the hash function representation
 func (g *GolombRice) Bits() int {
 	return g.bitCount
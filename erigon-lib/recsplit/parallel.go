@@ -0,0 +1,116 @@
+/*
+   Copyright 2021 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package recsplit
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// bucketsPerWorker - how many buckets are accumulated per worker before they are split in parallel
+const bucketsPerWorker = 64
+
+// bucketBatch - buckets which are split in parallel. Every bucket is encoded into own GolombRice and offsets buffer,
+// then they are appended to the index in order of buckets - so the index doesn't depend on number of workers
+type bucketBatch struct {
+	buckets   []batchBucket
+	n         int // number of filled buckets
+	splitters []bucketSplitter
+}
+
+type batchBucket struct {
+	idx     uint64
+	keys    []uint64
+	offsets []uint64
+	gr      GolombRice
+	index   bytes.Buffer
+	err     error
+}
+
+func (rs *RecSplit) newBucketBatch() *bucketBatch {
+	b := &bucketBatch{
+		buckets:   make([]batchBucket, rs.workers*bucketsPerWorker),
+		splitters: make([]bucketSplitter, rs.workers),
+	}
+	for i := range b.splitters {
+		b.splitters[i] = rs.newSplitter()
+	}
+	return b
+}
+
+func (rs *RecSplit) batchCurrentBucket() error {
+	if len(rs.currentBucket) > 1 {
+		// Workers only read the table of golomb params, grow it here - in the same order as single-threaded build does
+		rs.golombParam(uint16(len(rs.currentBucket)))
+	}
+	b := &rs.batch.buckets[rs.batch.n]
+	b.idx = rs.currentBucketIdx
+	b.keys = append(b.keys[:0], rs.currentBucket...)
+	b.offsets = append(b.offsets[:0], rs.currentBucketOffs...)
+	rs.batch.n++
+	// clear for the next buckey
+	rs.currentBucket = rs.currentBucket[:0]
+	rs.currentBucketOffs = rs.currentBucketOffs[:0]
+	if rs.batch.n < len(rs.batch.buckets) {
+		return nil
+	}
+	return rs.flushBatch()
+}
+
+// flushBatch splits accumulated buckets in parallel and appends results to the index
+func (rs *RecSplit) flushBatch() error {
+	batch := rs.batch
+	if batch.n == 0 {
+		return nil
+	}
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for i := range batch.splitters {
+		wg.Add(1)
+		go func(s *bucketSplitter) {
+			defer wg.Done()
+			for j := int(next.Add(1) - 1); j < batch.n; j = int(next.Add(1) - 1) {
+				b := &batch.buckets[j]
+				b.gr.reset()
+				b.index.Reset()
+				s.gr, s.w = &b.gr, &b.index
+				b.err = s.split(b.keys, b.offsets)
+			}
+		}(&batch.splitters[i])
+	}
+	wg.Wait()
+
+	for i := range batch.buckets[:batch.n] {
+		b := &batch.buckets[i]
+		if b.err != nil {
+			return b.err
+		}
+		if rs.trace && len(b.keys) > 1 {
+			fmt.Printf("recsplitBucket(%d, %d, bitsize = %d)\n", b.idx, len(b.keys), b.gr.bitCount)
+		}
+		rs.gr.appendBits(&b.gr)
+		if _, err := rs.indexW.Write(b.index.Bytes()); err != nil {
+			return err
+		}
+		rs.bucketDone(b.idx, len(b.keys))
+	}
+	lastIdx := batch.buckets[batch.n-1].idx
+	batch.n = 0
+	return rs.maybeCheckpoint(lastIdx)
+}
@@ -0,0 +1,297 @@
+/*
+   Copyright 2021 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package recsplit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+)
+
+type testKeys struct {
+	keys    [][]byte
+	offsets []uint64
+}
+
+func randomKeys(r *rand.Rand, count int) testKeys {
+	var tk testKeys
+	seen := map[string]struct{}{}
+	var offset uint64
+	for len(tk.keys) < count {
+		key := make([]byte, 1+r.Intn(40))
+		r.Read(key)
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		seen[string(key)] = struct{}{}
+		offset += uint64(r.Intn(1000)) // sorted offsets - to allow Enums
+		tk.keys = append(tk.keys, key)
+		tk.offsets = append(tk.offsets, offset)
+	}
+	return tk
+}
+
+func buildTestIndex(t *testing.T, ctx context.Context, args RecSplitArgs, tk testKeys, prepare func(rs *RecSplit)) error {
+	t.Helper()
+	args.KeyCount = len(tk.keys)
+	rs, err := NewRecSplit(args, log.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rs.Close()
+	rs.DisableFsync()
+	if prepare != nil {
+		prepare(rs)
+	}
+	for i, key := range tk.keys {
+		if err := rs.AddKey(key, tk.offsets[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rs.Build(ctx)
+}
+
+func checkLookups(t *testing.T, indexFile string, tk testKeys, enums bool) {
+	t.Helper()
+	idx := MustOpen(indexFile)
+	defer idx.Close()
+	reader := NewIndexReader(idx)
+	for i, key := range tk.keys {
+		v, _ := reader.Lookup(key)
+		if enums {
+			if v != uint64(i) {
+				t.Fatalf("key %d: expected enumeration %d, looked up: %d", i, i, v)
+			}
+			v = idx.OrdinalLookup(v)
+		}
+		if v != tk.offsets[i] {
+			t.Fatalf("key %d: expected offset %d, looked up: %d", i, tk.offsets[i], v)
+		}
+	}
+}
+
+func TestParallelBuild(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		tk := randomKeys(r, 1+r.Intn(5000))
+		args := RecSplitArgs{
+			BucketSize:         []int{10, 100, 2000}[r.Intn(3)],
+			LeafSize:           uint16(4 + r.Intn(7)),
+			Salt:               r.Uint32() | 1,
+			Enums:              r.Intn(2) == 0,
+			LessFalsePositives: r.Intn(2) == 0,
+		}
+		workers := 2 + r.Intn(7)
+		t.Run(fmt.Sprintf("seed=%d,keys=%d,bucket=%d,leaf=%d,workers=%d", seed, len(tk.keys), args.BucketSize, args.LeafSize, workers), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			args.TmpDir = tmpDir
+
+			args.IndexFile = filepath.Join(tmpDir, "single")
+			if err := buildTestIndex(t, context.Background(), args, tk, nil); err != nil {
+				t.Fatal(err)
+			}
+			single, err := os.ReadFile(args.IndexFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			args.IndexFile = filepath.Join(tmpDir, "parallel")
+			args.Workers = workers
+			if err := buildTestIndex(t, context.Background(), args, tk, nil); err != nil {
+				t.Fatal(err)
+			}
+			parallel, err := os.ReadFile(args.IndexFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(single, parallel) {
+				t.Fatalf("parallel build differs from single-threaded: %d and %d bytes", len(single), len(parallel))
+			}
+			checkLookups(t, args.IndexFile, tk, args.Enums)
+		})
+	}
+}
+
+func TestParallelBuildDuplicate(t *testing.T) {
+	tmpDir := t.TempDir()
+	tk := randomKeys(rand.New(rand.NewSource(1)), 1000)
+	tk.keys[700] = tk.keys[100]
+	err := buildTestIndex(t, context.Background(), RecSplitArgs{
+		BucketSize: 10,
+		LeafSize:   8,
+		Salt:       1,
+		TmpDir:     tmpDir,
+		IndexFile:  filepath.Join(tmpDir, "index"),
+		Workers:    4,
+	}, tk, nil)
+	if err == nil {
+		t.Errorf("test is expected to fail, duplicate key")
+	}
+}
+
+func TestCheckpointResume(t *testing.T) {
+	tk := randomKeys(rand.New(rand.NewSource(42)), 20_000)
+	for _, workers := range [][2]int{{1, 1}, {4, 4}, {4, 1}, {1, 3}} {
+		t.Run(fmt.Sprintf("workers=%d,resume_workers=%d", workers[0], workers[1]), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			args := RecSplitArgs{
+				BucketSize:         10,
+				LeafSize:           8,
+				Salt:               7,
+				TmpDir:             tmpDir,
+				Enums:              true,
+				LessFalsePositives: true,
+			}
+			args.IndexFile = filepath.Join(tmpDir, "expected")
+			if err := buildTestIndex(t, context.Background(), args, tk, nil); err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile(args.IndexFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// interrupt build after few checkpoints
+			args.IndexFile = filepath.Join(tmpDir, "index")
+			args.Checkpoint = true
+			args.Workers = workers[0]
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var checkpoints int
+			var stoppedAt uint64
+			err = buildTestIndex(t, ctx, args, tk, func(rs *RecSplit) {
+				rs.checkpointEvery = 0
+				rs.onCheckpoint = func(bucketIdx uint64) {
+					if checkpoints++; checkpoints == 3 {
+						stoppedAt = bucketIdx
+						cancel()
+					}
+				}
+			})
+			if err == nil {
+				t.Fatal("build is expected to be interrupted")
+			}
+			if _, err := os.Stat(args.IndexFile + ".ckp"); err != nil {
+				t.Fatal(err)
+			}
+
+			args.Workers = workers[1]
+			resumedFrom := uint64(1 << 63)
+			err = buildTestIndex(t, context.Background(), args, tk, func(rs *RecSplit) {
+				rs.checkpointEvery = 0
+				rs.onCheckpoint = func(bucketIdx uint64) {
+					if bucketIdx < resumedFrom {
+						resumedFrom = bucketIdx
+					}
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resumedFrom <= stoppedAt {
+				t.Fatalf("expected to resume after bucket %d, got checkpoint at bucket %d", stoppedAt, resumedFrom)
+			}
+			if _, err := os.Stat(args.IndexFile + ".ckp"); !os.IsNotExist(err) {
+				t.Fatalf("checkpoint is expected to be removed: %v", err)
+			}
+			index, err := os.ReadFile(args.IndexFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(expected, index) {
+				t.Fatalf("resumed build differs from uninterrupted: %d and %d bytes", len(expected), len(index))
+			}
+			checkLookups(t, args.IndexFile, tk, args.Enums)
+		})
+	}
+}
+
+func TestCheckpointOtherKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	args := RecSplitArgs{
+		BucketSize: 10,
+		LeafSize:   8,
+		Salt:       7,
+		TmpDir:     tmpDir,
+		IndexFile:  filepath.Join(tmpDir, "index"),
+		Checkpoint: true,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := buildTestIndex(t, ctx, args, randomKeys(rand.New(rand.NewSource(1)), 5000), func(rs *RecSplit) {
+		rs.checkpointEvery = 0
+		rs.onCheckpoint = func(uint64) { cancel() }
+	})
+	if err == nil {
+		t.Fatal("build is expected to be interrupted")
+	}
+	// checkpoint of other keys must be ignored
+	tk := randomKeys(rand.New(rand.NewSource(2)), 5000)
+	if err := buildTestIndex(t, context.Background(), args, tk, nil); err != nil {
+		t.Fatal(err)
+	}
+	checkLookups(t, args.IndexFile, tk, false)
+}
+
+func TestCheckpointResumeRandomSalt(t *testing.T) {
+	tmpDir := t.TempDir()
+	tk := randomKeys(rand.New(rand.NewSource(3)), 5000)
+	args := RecSplitArgs{
+		BucketSize: 10,
+		LeafSize:   8,
+		TmpDir:     tmpDir,
+		IndexFile:  filepath.Join(tmpDir, "index"),
+		Checkpoint: true,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var stoppedAt uint64
+	err := buildTestIndex(t, ctx, args, tk, func(rs *RecSplit) {
+		rs.checkpointEvery = 0
+		rs.onCheckpoint = func(bucketIdx uint64) {
+			stoppedAt = bucketIdx
+			cancel()
+		}
+	})
+	if err == nil {
+		t.Fatal("build is expected to be interrupted")
+	}
+
+	// new instance takes the salt of checkpoint
+	resumedFrom := uint64(1 << 63)
+	err = buildTestIndex(t, context.Background(), args, tk, func(rs *RecSplit) {
+		rs.checkpointEvery = 0
+		rs.onCheckpoint = func(bucketIdx uint64) {
+			if bucketIdx < resumedFrom {
+				resumedFrom = bucketIdx
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumedFrom <= stoppedAt {
+		t.Fatalf("expected to resume after bucket %d, got checkpoint at bucket %d", stoppedAt, resumedFrom)
+	}
+	checkLookups(t, args.IndexFile, tk, false)
+}
@@ -27,6 +27,7 @@ import (
 	"math/bits"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/ledgerwatch/log/v3"
@@ -82,11 +83,10 @@ type RecSplit struct {
 	gr                GolombRice // Helper object to encode the tree of hash function salts using Golomb-Rice code.
 	bucketPosAcc      []uint64   // Accumulator for position of every bucket in the encoding of the hash function
 	startSeed         []uint64
-	count             []uint16
-	currentBucket     []uint64 // 64-bit fingerprints of keys in the current bucket accumulated before the recsplit is performed for that bucket
-	currentBucketOffs []uint64 // Index offsets for the current bucket
-	offsetBuffer      []uint64
-	buffer            []uint64
+	splitter          bucketSplitter // Splits buckets of single-threaded build
+	batch             *bucketBatch   // Buckets waiting to be split by workers of parallel build
+	currentBucket     []uint64       // 64-bit fingerprints of keys in the current bucket accumulated before the recsplit is performed for that bucket
+	currentBucketOffs []uint64       // Index offsets for the current bucket
 	golombRice        []uint32
 	bucketSizeAcc     []uint64 // Bucket size accumulator
 	// Helper object to encode the sequence of cumulative number of keys in the buckets
@@ -99,6 +99,7 @@ type RecSplit struct {
 	bucketSize         int
 	keyExpectedCount   uint64 // Number of keys in the hash table
 	keysAdded          uint64 // Number of keys actually added to the recSplit (to check the match with keyExpectedCount)
+	keysHash           uint64 // Order-independent hash of added keys and offsets - to check that checkpoint belongs to the same keys
 	maxOffset          uint64 // Maximum value of index offset to later decide how many bytes to use for the encoding
 	currentBucketIdx   uint64 // Current bucket being accumulated
 	baseDataID         uint64 // Minimal app-specific ID of entries of this index - helps app understand what data stored in given shard - persistent field
@@ -116,6 +117,13 @@ type RecSplit struct {
 	built              bool // Flag indicating that the hash function has been built and no more keys can be added
 	trace              bool
 	logger             log.Logger
+	workers            int
+
+	checkpoint      bool
+	checkpointEvery time.Duration
+	lastCheckpoint  time.Time
+	resumeAfter     uint64 // Buckets up to this one (inclusive) are restored from checkpoint, math.MaxUint64 - nothing is restored
+	onCheckpoint    func(bucketIdx uint64)
 
 	noFsync bool // fsync is enabled by default, but tests can manually disable
 }
@@ -136,6 +144,13 @@ type RecSplitArgs struct {
 	EtlBufLimit datasize.ByteSize
 	Salt        uint32 // Hash seed (salt) for the hash function used for allocating the initial buckets - need to be generated randomly
 	LeafSize    uint16
+
+	// Number of goroutines splitting buckets in parallel, 0 or 1 - single-threaded. Produces the same index file for any value
+	Workers int
+	// Periodically save progress of Build to IndexFile+".ckp", then Build with the same keys (and same Salt) continues
+	// from last checkpoint after interruption. Checkpoint is removed when index is built.
+	// If Salt is not set, the salt of existing checkpoint is used
+	Checkpoint bool
 }
 
 // NewRecSplit creates a new RecSplit instance with given number of keys and given bucket size
@@ -151,6 +166,10 @@ func NewRecSplit(args RecSplitArgs, logger log.Logger) (*RecSplit, error) {
 			0x4ef95e25f4b4983d, 0x81175195173b92d3, 0x4e50927d8dd15978, 0x1ea2099d1fafae7f, 0x425c8a06fbaaa815, 0xcd4216006c74052a}
 	}
 	rs.salt = args.Salt
+	if rs.salt == 0 && args.Checkpoint {
+		// keys must be hashed with the same salt to resume Build from checkpoint
+		rs.salt = checkpointSalt(args.IndexFile+".ckp", logger)
+	}
 	if rs.salt == 0 {
 		seedBytes := make([]byte, 4)
 		if _, err := rand.Read(seedBytes); err != nil {
@@ -205,7 +224,14 @@ func NewRecSplit(args RecSplitArgs, logger log.Logger) (*RecSplit, error) {
 		rs.secondaryAggrBound = rs.primaryAggrBound * uint16(math.Ceil(0.21*float64(rs.leafSize)+9./10.))
 	}
 	rs.startSeed = args.StartSeed
-	rs.count = make([]uint16, rs.secondaryAggrBound)
+	rs.splitter = rs.newSplitter()
+	rs.workers = args.Workers
+	if rs.workers > 1 {
+		rs.batch = rs.newBucketBatch()
+	}
+	rs.checkpoint = args.Checkpoint
+	rs.checkpointEvery = defaultCheckpointEvery
+	rs.resumeAfter = math.MaxUint64
 	return rs, nil
 }
 
@@ -251,6 +277,8 @@ func (rs *RecSplit) ResetNextSalt() {
 	rs.built = false
 	rs.collision = false
 	rs.keysAdded = 0
+	rs.keysHash = 0
+	rs.resumeAfter = math.MaxUint64
 	rs.salt++
 	rs.hasher = murmur3.New128WithSeed(rs.salt)
 	if rs.bucketCollector != nil {
@@ -359,6 +387,7 @@ func (rs *RecSplit) AddKey(key []byte, offset uint64) error {
 			rs.minDelta = delta
 		}
 	}
+	rs.keysHash += remix(lo ^ remix(offset))
 
 	if rs.enums {
 		if err := rs.offsetCollector.Collect(rs.numBuf[:], nil); err != nil {
@@ -395,58 +424,97 @@ func (rs *RecSplit) AddOffset(offset uint64) error {
 }
 
 func (rs *RecSplit) recsplitCurrentBucket() error {
+	if err := rs.checkCollision(rs.currentBucket); err != nil {
+		return err
+	}
+	if rs.batch != nil {
+		return rs.batchCurrentBucket()
+	}
+	bitPos := rs.gr.bitCount
+	if err := rs.splitter.split(rs.currentBucket, rs.currentBucketOffs); err != nil {
+		return err
+	}
+	if rs.trace && len(rs.currentBucket) > 1 {
+		fmt.Printf("recsplitBucket(%d, %d, bitsize = %d)\n", rs.currentBucketIdx, len(rs.currentBucket), rs.gr.bitCount-bitPos)
+	}
+	rs.bucketDone(rs.currentBucketIdx, len(rs.currentBucket))
+	// clear for the next buckey
+	rs.currentBucket = rs.currentBucket[:0]
+	rs.currentBucketOffs = rs.currentBucketOffs[:0]
+	return rs.maybeCheckpoint(rs.currentBucketIdx)
+}
+
+// checkCollision expects sorted fingerprints of the bucket
+func (rs *RecSplit) checkCollision(bucket []uint64) error {
+	if len(bucket) <= 1 {
+		return nil
+	}
+	for i, key := range bucket[1:] {
+		if key == bucket[i] {
+			rs.collision = true
+			return fmt.Errorf("%w: %x", ErrCollision, key)
+		}
+	}
+	return nil
+}
+
+// bucketDone updates accumulators after encoding of the bucket was appended to rs.gr
+func (rs *RecSplit) bucketDone(bucketIdx uint64, bucketSize int) {
 	// Extend rs.bucketSizeAcc to accomodate current bucket index + 1
-	for len(rs.bucketSizeAcc) <= int(rs.currentBucketIdx)+1 {
+	for len(rs.bucketSizeAcc) <= int(bucketIdx)+1 {
 		rs.bucketSizeAcc = append(rs.bucketSizeAcc, rs.bucketSizeAcc[len(rs.bucketSizeAcc)-1])
 	}
-	rs.bucketSizeAcc[int(rs.currentBucketIdx)+1] += uint64(len(rs.currentBucket))
+	rs.bucketSizeAcc[int(bucketIdx)+1] += uint64(bucketSize)
+	// Extend rs.bucketPosAcc to accomodate current bucket index + 1
+	for len(rs.bucketPosAcc) <= int(bucketIdx)+1 {
+		rs.bucketPosAcc = append(rs.bucketPosAcc, rs.bucketPosAcc[len(rs.bucketPosAcc)-1])
+	}
+	rs.bucketPosAcc[int(bucketIdx)+1] = uint64(rs.gr.Bits())
+}
+
+// bucketSplitter holds buffers for recursive split of one bucket. Single-threaded build has one splitter which writes
+// directly to the index file, parallel build has one splitter per worker
+type bucketSplitter struct {
+	rs           *RecSplit // only parameters are read, rs.golombRice must be large enough in parallel build
+	gr           *GolombRice
+	w            io.Writer
+	count        []uint16
+	offsetBuffer []uint64
+	buffer       []uint64
+	numBuf       [8]byte
+}
+
+func (rs *RecSplit) newSplitter() bucketSplitter {
+	return bucketSplitter{rs: rs, gr: &rs.gr, count: make([]uint16, rs.secondaryAggrBound)}
+}
+
+// split appends offsets of the bucket to s.w and encoding of its hash function to s.gr
+func (s *bucketSplitter) split(bucket []uint64, offsets []uint64) error {
 	// Sets of size 0 and 1 are not further processed, just write them to index
-	if len(rs.currentBucket) > 1 {
-		for i, key := range rs.currentBucket[1:] {
-			if key == rs.currentBucket[i] {
-				rs.collision = true
-				return fmt.Errorf("%w: %x", ErrCollision, key)
-			}
-		}
-		bitPos := rs.gr.bitCount
-		if rs.buffer == nil {
-			rs.buffer = make([]uint64, len(rs.currentBucket))
-			rs.offsetBuffer = make([]uint64, len(rs.currentBucketOffs))
-		} else {
-			for len(rs.buffer) < len(rs.currentBucket) {
-				rs.buffer = append(rs.buffer, 0)
-				rs.offsetBuffer = append(rs.offsetBuffer, 0)
-			}
-		}
-		unary, err := rs.recsplit(0 /* level */, rs.currentBucket, rs.currentBucketOffs, nil /* unary */)
-		if err != nil {
-			return err
-		}
-		rs.gr.appendUnaryAll(unary)
-		if rs.trace {
-			fmt.Printf("recsplitBucket(%d, %d, bitsize = %d)\n", rs.currentBucketIdx, len(rs.currentBucket), rs.gr.bitCount-bitPos)
-		}
-	} else {
-		for _, offset := range rs.currentBucketOffs {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offset)
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
+	if len(bucket) <= 1 {
+		for _, offset := range offsets {
+			binary.BigEndian.PutUint64(s.numBuf[:], offset)
+			if _, err := s.w.Write(s.numBuf[8-s.rs.bytesPerRec:]); err != nil {
 				return err
 			}
 		}
+		return nil
 	}
-	// Extend rs.bucketPosAcc to accomodate current bucket index + 1
-	for len(rs.bucketPosAcc) <= int(rs.currentBucketIdx)+1 {
-		rs.bucketPosAcc = append(rs.bucketPosAcc, rs.bucketPosAcc[len(rs.bucketPosAcc)-1])
+	for len(s.buffer) < len(bucket) {
+		s.buffer = append(s.buffer, 0)
+		s.offsetBuffer = append(s.offsetBuffer, 0)
 	}
-	rs.bucketPosAcc[int(rs.currentBucketIdx)+1] = uint64(rs.gr.Bits())
-	// clear for the next buckey
-	rs.currentBucket = rs.currentBucket[:0]
-	rs.currentBucketOffs = rs.currentBucketOffs[:0]
+	unary, err := s.recsplit(0 /* level */, bucket, offsets, nil /* unary */)
+	if err != nil {
+		return err
+	}
+	s.gr.appendUnaryAll(unary)
 	return nil
 }
 
 // recsplit applies recSplit algorithm to the given bucket
-func (rs *RecSplit) recsplit(level int, bucket []uint64, offsets []uint64, unary []uint64) ([]uint64, error) {
+func (s *bucketSplitter) recsplit(level int, bucket []uint64, offsets []uint64, unary []uint64) ([]uint64, error) {
+	rs := s.rs
 	if rs.trace {
 		fmt.Printf("recsplit(%d, %d, %x)\n", level, len(bucket), bucket)
 	}
@@ -474,24 +542,24 @@ func (rs *RecSplit) recsplit(level int, bucket []uint64, offsets []uint64, unary
 		}
 		for i := uint16(0); i < m; i++ {
 			j := remap16(remix(bucket[i]+salt), m)
-			rs.offsetBuffer[j] = offsets[i]
+			s.offsetBuffer[j] = offsets[i]
 		}
-		for _, offset := range rs.offsetBuffer[:m] {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offset)
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
+		for _, offset := range s.offsetBuffer[:m] {
+			binary.BigEndian.PutUint64(s.numBuf[:], offset)
+			if _, err := s.w.Write(s.numBuf[8-rs.bytesPerRec:]); err != nil {
 				return nil, err
 			}
 		}
 		salt -= rs.startSeed[level]
 		log2golomb := rs.golombParam(m)
 		if rs.trace {
-			fmt.Printf("encode bij %d with log2golomn %d at p = %d\n", salt, log2golomb, rs.gr.bitCount)
+			fmt.Printf("encode bij %d with log2golomn %d at p = %d\n", salt, log2golomb, s.gr.bitCount)
 		}
-		rs.gr.appendFixed(salt, log2golomb)
+		s.gr.appendFixed(salt, log2golomb)
 		unary = append(unary, salt>>log2golomb)
 	} else {
 		fanout, unit := splitParams(m, rs.leafSize, rs.primaryAggrBound, rs.secondaryAggrBound)
-		count := rs.count
+		count := s.count
 		for {
 			for i := uint16(0); i < fanout-1; i++ {
 				count[i] = 0
@@ -514,33 +582,33 @@ func (rs *RecSplit) recsplit(level int, bucket []uint64, offsets []uint64, unary
 		}
 		for i := uint16(0); i < m; i++ {
 			j := remap16(remix(bucket[i]+salt), m) / unit
-			rs.buffer[count[j]] = bucket[i]
-			rs.offsetBuffer[count[j]] = offsets[i]
+			s.buffer[count[j]] = bucket[i]
+			s.offsetBuffer[count[j]] = offsets[i]
 			count[j]++
 		}
-		copy(bucket, rs.buffer)
-		copy(offsets, rs.offsetBuffer)
+		copy(bucket, s.buffer)
+		copy(offsets, s.offsetBuffer)
 		salt -= rs.startSeed[level]
 		log2golomb := rs.golombParam(m)
 		if rs.trace {
-			fmt.Printf("encode fanout %d: %d with log2golomn %d at p = %d\n", fanout, salt, log2golomb, rs.gr.bitCount)
+			fmt.Printf("encode fanout %d: %d with log2golomn %d at p = %d\n", fanout, salt, log2golomb, s.gr.bitCount)
 		}
-		rs.gr.appendFixed(salt, log2golomb)
+		s.gr.appendFixed(salt, log2golomb)
 		unary = append(unary, salt>>log2golomb)
 		var err error
 		var i uint16
 		for i = 0; i < m-unit; i += unit {
-			if unary, err = rs.recsplit(level+1, bucket[i:i+unit], offsets[i:i+unit], unary); err != nil {
+			if unary, err = s.recsplit(level+1, bucket[i:i+unit], offsets[i:i+unit], unary); err != nil {
 				return nil, err
 			}
 		}
 		if m-i > 1 {
-			if unary, err = rs.recsplit(level+1, bucket[i:], offsets[i:], unary); err != nil {
+			if unary, err = s.recsplit(level+1, bucket[i:], offsets[i:], unary); err != nil {
 				return nil, err
 			}
 		} else if m-i == 1 {
-			binary.BigEndian.PutUint64(rs.numBuf[:], offsets[i])
-			if _, err := rs.indexW.Write(rs.numBuf[8-rs.bytesPerRec:]); err != nil {
+			binary.BigEndian.PutUint64(s.numBuf[:], offsets[i])
+			if _, err := s.w.Write(s.numBuf[8-rs.bytesPerRec:]); err != nil {
 				return nil, err
 			}
 		}
@@ -552,6 +620,9 @@ func (rs *RecSplit) recsplit(level int, bucket []uint64, offsets []uint64, unary
 func (rs *RecSplit) loadFuncBucket(k, v []byte, _ etl.CurrentTableReader, _ etl.LoadNextFunc) error {
 	// k is the BigEndian encoding of the bucket number, and the v is the key that is assigned into that bucket
 	bucketIdx := binary.BigEndian.Uint64(k)
+	if rs.resumeAfter != math.MaxUint64 && bucketIdx <= rs.resumeAfter {
+		return nil // restored from checkpoint
+	}
 	if rs.currentBucketIdx != bucketIdx {
 		if rs.currentBucketIdx != math.MaxUint64 {
 			if err := rs.recsplitCurrentBucket(); err != nil {
@@ -581,30 +652,39 @@ func (rs *RecSplit) Build(ctx context.Context) error {
 		return fmt.Errorf("expected keys %d, got %d", rs.keyExpectedCount, rs.keysAdded)
 	}
 	var err error
-	if rs.indexF, err = os.Create(rs.tmpFilePath); err != nil {
-		return fmt.Errorf("create index file %s: %w", rs.indexFile, err)
+	rs.bytesPerRec = common.BitLenToByteLen(bits.Len64(rs.maxOffset))
+	resumed, err := rs.resumeFromCheckpoint()
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		if rs.indexF, err = os.Create(rs.tmpFilePath); err != nil {
+			return fmt.Errorf("create index file %s: %w", rs.indexFile, err)
+		}
+		rs.logger.Debug("[index] created", "file", rs.tmpFilePath)
 	}
-
-	rs.logger.Debug("[index] created", "file", rs.tmpFilePath)
 
 	defer rs.indexF.Close()
 	rs.indexW = bufio.NewWriterSize(rs.indexF, etl.BufIOSize)
-	// Write minimal app-specific dataID in this index file
-	binary.BigEndian.PutUint64(rs.numBuf[:], rs.baseDataID)
-	if _, err = rs.indexW.Write(rs.numBuf[:]); err != nil {
-		return fmt.Errorf("write number of keys: %w", err)
-	}
+	rs.splitter.w = rs.indexW
+	if !resumed {
+		// Write minimal app-specific dataID in this index file
+		binary.BigEndian.PutUint64(rs.numBuf[:], rs.baseDataID)
+		if _, err = rs.indexW.Write(rs.numBuf[:]); err != nil {
+			return fmt.Errorf("write number of keys: %w", err)
+		}
 
-	// Write number of keys
-	binary.BigEndian.PutUint64(rs.numBuf[:], rs.keysAdded)
-	if _, err = rs.indexW.Write(rs.numBuf[:]); err != nil {
-		return fmt.Errorf("write number of keys: %w", err)
-	}
-	// Write number of bytes per index record
-	rs.bytesPerRec = common.BitLenToByteLen(bits.Len64(rs.maxOffset))
-	if err = rs.indexW.WriteByte(byte(rs.bytesPerRec)); err != nil {
-		return fmt.Errorf("write bytes per record: %w", err)
+		// Write number of keys
+		binary.BigEndian.PutUint64(rs.numBuf[:], rs.keysAdded)
+		if _, err = rs.indexW.Write(rs.numBuf[:]); err != nil {
+			return fmt.Errorf("write number of keys: %w", err)
+		}
+		// Write number of bytes per index record
+		if err = rs.indexW.WriteByte(byte(rs.bytesPerRec)); err != nil {
+			return fmt.Errorf("write bytes per record: %w", err)
+		}
 	}
+	rs.lastCheckpoint = time.Now()
 
 	rs.currentBucketIdx = math.MaxUint64 // To make sure 0 bucket is detected
 	defer rs.bucketCollector.Close()
@@ -619,6 +699,11 @@ func (rs *RecSplit) Build(ctx context.Context) error {
 			return err
 		}
 	}
+	if rs.batch != nil {
+		if err := rs.flushBatch(); err != nil {
+			return err
+		}
+	}
 
 	if assert.Enable {
 		rs.indexW.Flush()
@@ -721,6 +806,11 @@ func (rs *RecSplit) Build(ctx context.Context) error {
 		rs.logger.Warn("[index] rename", "file", rs.tmpFilePath, "err", err)
 		return err
 	}
+	if rs.checkpoint {
+		if err = os.Remove(rs.checkpointFile()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 
 	return nil
 }
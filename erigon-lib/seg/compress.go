@@ -73,6 +73,11 @@ type Compressor struct {
 	trace            bool
 	logger           log.Logger
 	noFsync          bool // fsync is enabled by default, but tests can manually disable
+
+	codec            Codec
+	zstdSamples      [][]byte // words sampled for the zstd dictionary
+	zstdSamplesSize  int
+	zstdSampleStride uint64
 }
 
 func NewCompressor(ctx context.Context, logPrefix, outputFile, tmpDir string, minPatternScore uint64, workers int, lvl log.Lvl, logger log.Logger) (*Compressor, error) {
@@ -129,6 +134,10 @@ func (c *Compressor) Close() {
 func (c *Compressor) SetTrace(trace bool) { c.trace = trace }
 func (c *Compressor) Workers() int        { return c.workers }
 
+// SetCodec - must be called before adding words
+func (c *Compressor) SetCodec(codec Codec) { c.codec = codec }
+func (c *Compressor) Codec() Codec         { return c.codec }
+
 func (c *Compressor) Count() int { return int(c.wordsCount) }
 
 func (c *Compressor) AddWord(word []byte) error {
@@ -139,6 +148,11 @@ func (c *Compressor) AddWord(word []byte) error {
 	}
 
 	c.wordsCount++
+	if c.codec == CodecZstd {
+		c.sampleForZstdDictionary(word)
+		return c.uncompressedFile.Append(word)
+	}
+
 	l := 2*len(word) + 2
 	if c.superstringLen+l > superstringLimit {
 		if c.superstringCount%samplingFactor == 0 {
@@ -184,33 +198,20 @@ func (c *Compressor) Compress() error {
 	close(c.superstrings)
 	c.wg.Wait()
 
-	if c.lvl < log.LvlTrace {
-		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.workers)
-	}
-	t := time.Now()
-	db, err := DictionaryBuilderFromCollectors(c.ctx, compressLogPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger)
-	if err != nil {
-
-		return err
-	}
-	if c.trace {
-		_, fileName := filepath.Split(c.outputFile)
-		if err := PersistDictionary(filepath.Join(c.tmpDir, fileName)+".dictionary.txt", db); err != nil {
-			return err
-		}
-	}
 	defer os.Remove(c.tmpOutFilePath)
-	if c.lvl < log.LvlTrace {
-		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict", c.logPrefix), "took", time.Since(t))
-	}
-
 	cf, err := os.Create(c.tmpOutFilePath)
 	if err != nil {
 		return err
 	}
 	defer cf.Close()
-	t = time.Now()
-	if err := compressWithPatternCandidates(c.ctx, c.trace, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, c.workers, db, c.lvl, c.logger); err != nil {
+	t := time.Now()
+	switch c.codec {
+	case CodecZstd:
+		err = compressWithZstd(c.ctx, c.logPrefix, cf, c.uncompressedFile, c.workers, c.zstdDictionary(), c.lvl, c.logger)
+	default:
+		err = c.compressWithPatterns(cf)
+	}
+	if err != nil {
 		return err
 	}
 	if err = c.fsync(cf); err != nil {
@@ -230,11 +231,32 @@ func (c *Compressor) Compress() error {
 
 	_, fName := filepath.Split(c.outputFile)
 	if c.lvl < log.LvlTrace {
-		c.logger.Log(c.lvl, fmt.Sprintf("[%s] Compress", c.logPrefix), "took", time.Since(t), "ratio", c.Ratio, "file", fName)
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] Compress", c.logPrefix), "took", time.Since(t), "ratio", c.Ratio, "file", fName, "codec", c.codec)
 	}
 	return nil
 }
 
+func (c *Compressor) compressWithPatterns(cf *os.File) error {
+	if c.lvl < log.LvlTrace {
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.workers)
+	}
+	t := time.Now()
+	db, err := DictionaryBuilderFromCollectors(c.ctx, compressLogPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger)
+	if err != nil {
+		return err
+	}
+	if c.trace {
+		_, fileName := filepath.Split(c.outputFile)
+		if err := PersistDictionary(filepath.Join(c.tmpDir, fileName)+".dictionary.txt", db); err != nil {
+			return err
+		}
+	}
+	if c.lvl < log.LvlTrace {
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict", c.logPrefix), "took", time.Since(t))
+	}
+	return compressWithPatternCandidates(c.ctx, c.trace, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, c.workers, db, c.lvl, c.logger)
+}
+
 func (c *Compressor) DisableFsync() { c.noFsync = true }
 
 // fsync - other processes/goroutines must see only "fully-complete" (valid) files. No partial-writes.
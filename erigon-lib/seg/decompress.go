@@ -27,6 +27,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/ledgerwatch/log/v3"
 
 	"github.com/ledgerwatch/erigon-lib/common/dbg"
@@ -113,6 +114,8 @@ type Decompressor struct {
 	modTime         time.Time
 	wordsCount      uint64
 	emptyWordsCount uint64
+	codec           Codec
+	zstd            *zstd.Decoder // only for CodecZstd
 
 	filePath, fileName string
 }
@@ -184,6 +187,17 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 	d.data = d.mmapHandle1[:d.size]
 	defer d.EnableReadAhead().DisableReadAhead() //speedup opening on slow drives
 
+	if codec, ok := readCodecHeader(d.data); ok {
+		if codec != CodecZstd {
+			return nil, fmt.Errorf("unsupported codec: %s", codec)
+		}
+		d.codec = codec
+		if err = d.openZstd(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
 	d.wordsCount = binary.BigEndian.Uint64(d.data[:8])
 	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
 	dictSize := binary.BigEndian.Uint64(d.data[16:24])
@@ -392,10 +406,15 @@ func (d *Decompressor) Close() {
 		}
 		d.f = nil
 	}
+	if d.zstd != nil {
+		d.zstd.Close()
+		d.zstd = nil
+	}
 }
 
 func (d *Decompressor) FilePath() string { return d.filePath }
 func (d *Decompressor) FileName() string { return d.fileName }
+func (d *Decompressor) Codec() Codec     { return d.codec }
 
 // WithReadAhead - Expect read in sequential order. (Hence, pages in the given range can be aggressively read ahead, and may be freed soon after they are accessed.)
 func (d *Decompressor) WithReadAhead(f func() error) error {
@@ -447,6 +466,10 @@ type Getter struct {
 	dataP       uint64
 	dataBit     int // Value 0..7 - position of the bit
 	trace       bool
+
+	zstd      *zstd.Decoder // not nil only for CodecZstd
+	zstdFrame []byte
+	zstdBuf   []byte
 }
 
 func (g *Getter) Trace(t bool)     { g.trace = t }
@@ -551,6 +574,7 @@ func (d *Decompressor) MakeGetter() *Getter {
 		data:        d.data[d.wordsStart:],
 		patternDict: d.dict,
 		fName:       d.fileName,
+		zstd:        d.zstd,
 	}
 }
 
@@ -567,6 +591,9 @@ func (g *Getter) HasNext() bool {
 // and appends it to the given buf, returning the result of appending
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) Next(buf []byte) ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.nextZstd(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -633,6 +660,9 @@ func (g *Getter) Next(buf []byte) ([]byte, uint64) {
 }
 
 func (g *Getter) NextUncompressed() ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.nextUncompressedZstd()
+	}
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -654,6 +684,9 @@ func (g *Getter) NextUncompressed() ([]byte, uint64) {
 
 // Skip moves offset to the next word and returns the new offset and the length of the word.
 func (g *Getter) Skip() (uint64, int) {
+	if g.zstd != nil {
+		return g.skipZstd()
+	}
 	l := g.nextPos(true)
 	l-- // because when create huffman tree we do ++ , because 0 is terminator
 	if l == 0 {
@@ -691,6 +724,9 @@ func (g *Getter) Skip() (uint64, int) {
 }
 
 func (g *Getter) SkipUncompressed() (uint64, int) {
+	if g.zstd != nil {
+		return g.skipZstd()
+	}
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -712,6 +748,9 @@ func (g *Getter) SkipUncompressed() (uint64, int) {
 // Match returns true and next offset if the word at current offset fully matches the buf
 // returns false and current offset otherwise.
 func (g *Getter) Match(buf []byte) (bool, uint64) {
+	if g.zstd != nil {
+		return g.matchZstd(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -777,6 +816,9 @@ func (g *Getter) Match(buf []byte) (bool, uint64) {
 
 // MatchPrefix only checks if the word at the current offset has a buf prefix. Does not move offset to the next word.
 func (g *Getter) MatchPrefix(prefix []byte) bool {
+	if g.zstd != nil {
+		return g.matchPrefixZstd(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -860,6 +902,9 @@ func (g *Getter) MatchPrefix(prefix []byte) bool {
 // MatchCmp lexicographically compares given buf with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchCmp(buf []byte) int {
+	if g.zstd != nil {
+		return g.matchCmpZstd(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -922,6 +967,9 @@ func (g *Getter) MatchCmp(buf []byte) int {
 // MatchPrefixCmp lexicographically compares given prefix with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchPrefixCmp(prefix []byte) int {
+	if g.zstd != nil {
+		return g.matchPrefixCmpZstd(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -988,6 +1036,9 @@ func (g *Getter) MatchPrefixCmp(prefix []byte) int {
 }
 
 func (g *Getter) MatchPrefixUncompressed(prefix []byte) int {
+	if g.zstd != nil {
+		return g.matchPrefixCmpZstd(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -1019,6 +1070,9 @@ func (g *Getter) MatchPrefixUncompressed(prefix []byte) int {
 // It is important to allocate enough buf size. Could throw an error if word in file is larger then the buf size.
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) FastNext(buf []byte) ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.fastNextZstd(buf)
+	}
 	defer func() {
 		if rec := recover(); rec != nil {
 			panic(fmt.Sprintf("file: %s, %s, %s", g.fName, rec, dbg.Stack()))
@@ -0,0 +1,454 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package seg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/etl"
+)
+
+// Codec defines how words are encoded in a .seg file
+type Codec uint8
+
+const (
+	// CodecPatterns - dictionary of patterns, Huffman-coded patterns and positions. Files written before codecs were introduced use it
+	CodecPatterns Codec = iota
+	// CodecZstd - each word is a separate zstd frame, compressed with a dictionary trained on words sampled from the file
+	// (a raw content-only dictionary of the samples, if training fails). Much faster to build than CodecPatterns and needs a fixed amount of RAM, but usually gives a worse ratio
+	CodecZstd
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecPatterns:
+		return "patterns"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+func ParseCodec(s string) (Codec, error) {
+	switch s {
+	case "patterns", "":
+		return CodecPatterns, nil
+	case "zstd":
+		return CodecZstd, nil
+	default:
+		return 0, fmt.Errorf("unknown segment codec: %q, expected one of: patterns, zstd", s)
+	}
+}
+
+// Files of codecs other than CodecPatterns start with codecHeaderMagic followed by the codec byte.
+// CodecPatterns files start with the words count, which never has the highest byte set.
+var codecHeaderMagic = [7]byte{0xff, 'e', 'r', 'i', 's', 'e', 'g'}
+
+const codecHeaderSize = len(codecHeaderMagic) + 1
+
+func readCodecHeader(data []byte) (Codec, bool) {
+	if len(data) < codecHeaderSize || !bytes.Equal(data[:len(codecHeaderMagic)], codecHeaderMagic[:]) {
+		return CodecPatterns, false
+	}
+	return Codec(data[len(codecHeaderMagic)]), true
+}
+
+// zstdFrameMagic is stripped from every frame on write (it's the same for all of them) and restored on read
+var zstdFrameMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// zstdDictMagic - trained dictionaries start with it, raw ones never do
+var zstdDictMagic = []byte{0x37, 0xa4, 0x30, 0xec}
+
+// zstdDictID - id of trained dictionaries. Must not be 0, it's written to every frame
+const zstdDictID = 1
+
+const (
+	// zstdDictSize - max size of the dictionary content
+	zstdDictSize = 110 * 1024
+	// zstdDictRatio - dictionary is not larger than this fraction of words size
+	zstdDictRatio = 16
+	// zstdSamplesLimit - how much of words to keep in memory for building the dictionary
+	zstdSamplesLimit = 64 * zstdDictSize
+	// zstdBatchSize - how much of words are compressed in parallel before written to the file
+	zstdBatchSize = 16 * 1024 * 1024
+)
+
+// sampleForZstdDictionary keeps every zstdSampleStride-th word. When samples don't fit zstdSamplesLimit anymore,
+// every second of them is dropped and stride is doubled - so samples always spread evenly across the words added so far
+func (c *Compressor) sampleForZstdDictionary(word []byte) {
+	if len(word) == 0 {
+		return
+	}
+	if c.zstdSampleStride == 0 {
+		c.zstdSampleStride = 1
+	}
+	if c.wordsCount%c.zstdSampleStride != 0 {
+		return
+	}
+	c.zstdSamples = append(c.zstdSamples, common.Copy(word))
+	c.zstdSamplesSize += len(word)
+	if c.zstdSamplesSize <= zstdSamplesLimit {
+		return
+	}
+	c.zstdSamplesSize = 0
+	kept := c.zstdSamples[:0]
+	for i := 0; i < len(c.zstdSamples); i += 2 {
+		kept = append(kept, c.zstdSamples[i])
+		c.zstdSamplesSize += len(c.zstdSamples[i])
+	}
+	c.zstdSamples = kept
+	c.zstdSampleStride *= 2
+}
+
+// zstdDictionary - dictionary trained on the samples: its content is the samples picked evenly until it's filled, and
+// its entropy tables are built from all samples. Falls back to the content alone (raw dictionary, zstd just uses it as history
+// preceding every word) if training fails. Dictionary is stored in the file, so for small files it's limited to 1/zstdDictRatio of words size
+func (c *Compressor) zstdDictionary() []byte {
+	dictSize := zstdDictSize
+	if estimatedSize := c.zstdSamplesSize * int(c.zstdSampleStride); estimatedSize/zstdDictRatio < dictSize {
+		dictSize = estimatedSize / zstdDictRatio
+	}
+	step := 1
+	if c.zstdSamplesSize > dictSize && dictSize > 0 {
+		step = (c.zstdSamplesSize + dictSize - 1) / dictSize
+	}
+	content := make([]byte, 0, dictSize)
+	for i := 0; i < len(c.zstdSamples) && len(content) < dictSize; i += step {
+		w := c.zstdSamples[i]
+		if len(content)+len(w) > dictSize {
+			w = w[:dictSize-len(content)]
+		}
+		content = append(content, w...)
+	}
+	samples := c.zstdSamples
+	c.zstdSamples, c.zstdSamplesSize = nil, 0
+
+	dict, err := zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       zstdDictID,
+		Contents: samples,
+		History:  content,
+		Offsets:  [3]int{1, 4, 8},
+		Level:    zstd.SpeedDefault,
+	})
+	if err == nil {
+		return dict
+	}
+	c.logger.Debug(fmt.Sprintf("[%s] zstd dictionary training failed, using raw dictionary", c.logPrefix), "err", err)
+	if bytes.HasPrefix(content, zstdDictMagic) {
+		content = content[1:]
+	}
+	return content
+}
+
+// compressWithZstd writes file of CodecZstd:
+//   - codec header
+//   - words count, empty words count, dictionary size (8 bytes each)
+//   - dictionary
+//   - words: uvarint(2*len(word) + isFrame), followed by the word itself, or by uvarint(len(frame)) and the frame.
+//     Words which were added as uncompressed, or which zstd can't make shorter, are stored as-is.
+func compressWithZstd(ctx context.Context, logPrefix string, cf *os.File, uncompressedFile *RawWordsFile, workers int, dict []byte, lvl log.Lvl, logger log.Logger) error {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	dictOpt := zstd.WithEncoderDictRaw(0, dict)
+	if bytes.HasPrefix(dict, zstdDictMagic) {
+		dictOpt = zstd.WithEncoderDict(dict)
+	}
+	enc, err := zstd.NewWriter(nil,
+		dictOpt,
+		zstd.WithEncoderCRC(false),
+		zstd.WithSingleSegment(true),
+		zstd.WithEncoderConcurrency(workers),
+	)
+	if err != nil {
+		return err
+	}
+	defer enc.Close()
+
+	cw := bufio.NewWriterSize(cf, 2*etl.BufIOSize)
+	var numBuf [2 * binary.MaxVarintLen64]byte
+	if _, err = cw.Write(codecHeaderMagic[:]); err != nil {
+		return err
+	}
+	if err = cw.WriteByte(byte(CodecZstd)); err != nil {
+		return err
+	}
+	// words counts are not known yet - will be written when all words are processed
+	var counts [16]byte
+	if _, err = cw.Write(counts[:]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint64(numBuf[:], uint64(len(dict)))
+	if _, err = cw.Write(numBuf[:8]); err != nil {
+		return err
+	}
+	if _, err = cw.Write(dict); err != nil {
+		return err
+	}
+
+	var wordsCount, emptyWordsCount uint64
+	totalWords := uncompressedFile.count
+	batch := make([]byte, 0, zstdBatchSize)
+	var ends []int
+	var compressed []bool
+	var frames [][]byte
+
+	flush := func() error {
+		if len(frames) < len(ends) {
+			frames = append(frames, make([][]byte, len(ends)-len(frames))...)
+		}
+		word := func(i int) []byte {
+			if i == 0 {
+				return batch[:ends[0]]
+			}
+			return batch[ends[i-1]:ends[i]]
+		}
+		perWorker := (len(ends) + workers - 1) / workers
+		wg := sync.WaitGroup{}
+		for from := 0; from < len(ends); from += perWorker {
+			to := from + perWorker
+			if to > len(ends) {
+				to = len(ends)
+			}
+			wg.Add(1)
+			go func(from, to int) {
+				defer wg.Done()
+				for i := from; i < to; i++ {
+					frames[i] = frames[i][:0]
+					if compressed[i] && len(word(i)) > 0 {
+						frames[i] = enc.EncodeAll(word(i), frames[i])
+					}
+				}
+			}(from, to)
+		}
+		wg.Wait()
+
+		for i := range ends {
+			w := word(i)
+			frame := frames[i]
+			if len(frame) > 0 {
+				frame = frame[len(zstdFrameMagic):]
+			}
+			if len(frame) > 0 && len(frame)+binary.PutUvarint(numBuf[:], uint64(len(frame))) < len(w) {
+				n := binary.PutUvarint(numBuf[:], 2*uint64(len(w))+1)
+				n += binary.PutUvarint(numBuf[n:], uint64(len(frame)))
+				if _, err := cw.Write(numBuf[:n]); err != nil {
+					return err
+				}
+				if _, err := cw.Write(frame); err != nil {
+					return err
+				}
+				continue
+			}
+			n := binary.PutUvarint(numBuf[:], 2*uint64(len(w)))
+			if _, err := cw.Write(numBuf[:n]); err != nil {
+				return err
+			}
+			if _, err := cw.Write(w); err != nil {
+				return err
+			}
+		}
+		batch, ends, compressed = batch[:0], ends[:0], compressed[:0]
+		return nil
+	}
+
+	if err = uncompressedFile.ForEach(func(v []byte, toCompress bool) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			if lvl < log.LvlTrace {
+				logger.Log(lvl, fmt.Sprintf("[%s] Compressing with zstd", logPrefix), "processed", fmt.Sprintf("%.2f%%", 100*float64(wordsCount)/float64(totalWords)))
+			}
+		default:
+		}
+		wordsCount++
+		if len(v) == 0 {
+			emptyWordsCount++
+		}
+		if len(batch) > 0 && len(batch)+len(v) > zstdBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		batch = append(batch, v...)
+		ends = append(ends, len(batch))
+		compressed = append(compressed, toCompress)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+	if err = cw.Flush(); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint64(counts[:8], wordsCount)
+	binary.BigEndian.PutUint64(counts[8:], emptyWordsCount)
+	if _, err = cf.WriteAt(counts[:], int64(codecHeaderSize)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *Decompressor) openZstd() (err error) {
+	data := d.data[codecHeaderSize:]
+	d.wordsCount = binary.BigEndian.Uint64(data[:8])
+	d.emptyWordsCount = binary.BigEndian.Uint64(data[8:16])
+	dictSize := binary.BigEndian.Uint64(data[16:24])
+	if 24+dictSize > uint64(len(data)) {
+		return fmt.Errorf("dictionary is invalid: dictSize=%d", dictSize)
+	}
+	dict := data[24 : 24+dictSize]
+	dictOpt := zstd.WithDecoderDictRaw(0, dict)
+	if bytes.HasPrefix(dict, zstdDictMagic) {
+		dictOpt = zstd.WithDecoderDicts(dict)
+	}
+	d.zstd, err = zstd.NewReader(nil, dictOpt)
+	if err != nil {
+		return err
+	}
+	d.wordsStart = uint64(codecHeaderSize) + 24 + dictSize
+	return nil
+}
+
+// zstdRecord parses the word at the current offset. Doesn't move the offset.
+func (g *Getter) zstdRecord() (wordLen uint64, payload []byte, isFrame bool, next uint64) {
+	l, n := binary.Uvarint(g.data[g.dataP:])
+	if n <= 0 {
+		panic(fmt.Sprintf("likely .idx is invalid: %s", g.fName))
+	}
+	p := g.dataP + uint64(n)
+	wordLen = l >> 1
+	if l&1 == 0 {
+		return wordLen, g.data[p : p+wordLen], false, p + wordLen
+	}
+	frameLen, n := binary.Uvarint(g.data[p:])
+	p += uint64(n)
+	return wordLen, g.data[p : p+frameLen], true, p + frameLen
+}
+
+// zstdDecode appends decoded word to buf
+func (g *Getter) zstdDecode(payload []byte, isFrame bool, buf []byte) []byte {
+	if !isFrame {
+		return append(buf, payload...)
+	}
+	g.zstdFrame = append(append(g.zstdFrame[:0], zstdFrameMagic...), payload...)
+	buf, err := g.zstd.DecodeAll(g.zstdFrame, buf)
+	if err != nil {
+		panic(fmt.Sprintf("file: %s, %s", g.fName, err))
+	}
+	return buf
+}
+
+// zstdWord decodes the word at the current offset into the getter's own buffer
+func (g *Getter) zstdWord() (word []byte, next uint64) {
+	_, payload, isFrame, next := g.zstdRecord()
+	if !isFrame {
+		return payload, next
+	}
+	g.zstdBuf = g.zstdDecode(payload, true, g.zstdBuf[:0])
+	return g.zstdBuf, next
+}
+
+func (g *Getter) nextZstd(buf []byte) ([]byte, uint64) {
+	wordLen, payload, isFrame, next := g.zstdRecord()
+	g.dataP = next
+	if wordLen == 0 {
+		if buf == nil { // wordLen == 0, means we have valid record of 0 size. nil - is the marker of "something not found"
+			buf = []byte{}
+		}
+		return buf, next
+	}
+	return g.zstdDecode(payload, isFrame, buf), next
+}
+
+func (g *Getter) nextUncompressedZstd() ([]byte, uint64) {
+	_, payload, isFrame, next := g.zstdRecord()
+	g.dataP = next
+	if isFrame {
+		return g.zstdDecode(payload, true, nil), next
+	}
+	return payload, next
+}
+
+func (g *Getter) skipZstd() (uint64, int) {
+	wordLen, _, _, next := g.zstdRecord()
+	g.dataP = next
+	return next, int(wordLen)
+}
+
+func (g *Getter) matchZstd(buf []byte) (bool, uint64) {
+	wordLen, _, _, _ := g.zstdRecord()
+	if int(wordLen) != len(buf) {
+		return false, g.dataP
+	}
+	word, next := g.zstdWord()
+	if !bytes.Equal(buf, word) {
+		return false, g.dataP
+	}
+	g.dataP = next
+	return true, next
+}
+
+func (g *Getter) matchPrefixZstd(prefix []byte) bool {
+	wordLen, _, _, _ := g.zstdRecord()
+	if int(wordLen) < len(prefix) {
+		return false
+	}
+	word, _ := g.zstdWord()
+	return bytes.HasPrefix(word, prefix)
+}
+
+func (g *Getter) matchCmpZstd(buf []byte) int {
+	word, next := g.zstdWord()
+	cmp := bytes.Compare(buf, word)
+	if cmp == 0 {
+		g.dataP = next
+	}
+	return cmp
+}
+
+func (g *Getter) matchPrefixCmpZstd(prefix []byte) int {
+	if len(prefix) == 0 {
+		return 0
+	}
+	word, _ := g.zstdWord()
+	if len(prefix) > len(word) {
+		return bytes.Compare(prefix, word)
+	}
+	return bytes.Compare(prefix, word[:len(prefix)])
+}
+
+func (g *Getter) fastNextZstd(buf []byte) ([]byte, uint64) {
+	_, payload, isFrame, next := g.zstdRecord()
+	g.dataP = next
+	return g.zstdDecode(payload, isFrame, buf[:0]), next
+}
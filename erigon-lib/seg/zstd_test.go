@@ -0,0 +1,176 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package seg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+// zstdTestWord - every 3rd word is empty and every 5th is added uncompressed
+func zstdTestWord(i int) []byte {
+	if i%3 == 0 {
+		return []byte{}
+	}
+	return []byte(fmt.Sprintf("%s %d %s", loremStrings[i%len(loremStrings)], i, strings.Repeat("x", i%100)))
+}
+
+func prepareZstdDict(t *testing.T, words int) *Decompressor {
+	t.Helper()
+	logger := log.New()
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "compressed")
+	c, err := NewCompressor(context.Background(), t.Name(), file, tmpDir, 1, 2, log.LvlDebug, logger)
+	require.NoError(t, err)
+	defer c.Close()
+	c.SetCodec(CodecZstd)
+	for i := 0; i < words; i++ {
+		if i%5 == 0 {
+			err = c.AddUncompressedWord(zstdTestWord(i))
+		} else {
+			err = c.AddWord(zstdTestWord(i))
+		}
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.Compress())
+	d, err := NewDecompressor(file)
+	require.NoError(t, err)
+	return d
+}
+
+func TestZstdCodec(t *testing.T) {
+	d := prepareZstdDict(t, 1000)
+	defer d.Close()
+	require.Equal(t, CodecZstd, d.Codec())
+	require.Equal(t, 1000, d.Count())
+	require.Equal(t, 334, d.EmptyWordsCount())
+	var rawSize int
+	for i := 0; i < 1000; i++ {
+		rawSize += len(zstdTestWord(i))
+	}
+	require.Less(t, d.Size(), int64(rawSize))
+
+	var offsets []uint64
+	g := d.MakeGetter()
+	var buf, word []byte
+	for i := 0; g.HasNext(); i++ {
+		offsets = append(offsets, g.dataP)
+		if i%5 == 0 {
+			word, _ = g.NextUncompressed()
+		} else {
+			buf, _ = g.Next(buf[:0])
+			word = buf
+		}
+		require.Equal(t, zstdTestWord(i), word)
+	}
+	require.Equal(t, 1000, len(offsets))
+
+	// random access by offsets, as indices do
+	for _, i := range []int{999, 0, 500, 1, 2, 3, 501} {
+		g.Reset(offsets[i])
+		word, _ = g.Next(nil)
+		require.Equal(t, zstdTestWord(i), word)
+
+		g.Reset(offsets[i])
+		_, l := g.Skip()
+		require.Equal(t, len(zstdTestWord(i)), l)
+	}
+}
+
+func TestZstdCodecMatch(t *testing.T) {
+	d := prepareZstdDict(t, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+	for i := 0; g.HasNext(); i++ {
+		w := zstdTestWord(i)
+		savePos := g.dataP
+		require.True(t, g.MatchPrefix(w))
+		if len(w) > 0 {
+			require.True(t, g.MatchPrefix(w[:len(w)/2]))
+			require.False(t, g.MatchPrefix(append(w, 'x')))
+			require.Equal(t, 0, g.MatchPrefixCmp(w[:1]))
+			require.Equal(t, -1, g.MatchCmp(w[:len(w)-1]))
+			ok, _ := g.Match(w[:len(w)-1])
+			require.False(t, ok)
+		}
+		require.Equal(t, 1, g.MatchPrefixCmp(append(w, 'x')))
+		require.Equal(t, savePos, g.dataP)
+
+		if i%2 == 0 {
+			ok, _ := g.Match(w)
+			require.True(t, ok)
+		} else {
+			require.Equal(t, 0, g.MatchCmp(w))
+		}
+	}
+}
+
+func TestZstdCodecFastNext(t *testing.T) {
+	d := prepareZstdDict(t, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+	buf := make([]byte, 1024)
+	for i := 0; g.HasNext(); i++ {
+		word, _ := g.FastNext(buf)
+		require.Equal(t, zstdTestWord(i), word)
+	}
+}
+
+func TestZstdDictionary(t *testing.T) {
+	dictOf := func(d *Decompressor) []byte {
+		data := d.data[codecHeaderSize:]
+		dictSize := binary.BigEndian.Uint64(data[16:24])
+		return data[24 : 24+dictSize]
+	}
+
+	d := prepareZstdDict(t, 1000)
+	defer d.Close()
+	require.True(t, bytes.HasPrefix(dictOf(d), zstdDictMagic), "dictionary must be trained")
+
+	// too few samples to train on - raw dictionary is used
+	d2 := prepareZstdDict(t, 5)
+	defer d2.Close()
+	require.False(t, bytes.HasPrefix(dictOf(d2), zstdDictMagic), "dictionary must be raw")
+	g := d2.MakeGetter()
+	for i := 0; g.HasNext(); i++ {
+		var word []byte
+		if i%5 == 0 {
+			word, _ = g.NextUncompressed()
+		} else {
+			word, _ = g.Next(nil)
+		}
+		require.Equal(t, zstdTestWord(i), word)
+	}
+}
+
+func TestParseCodec(t *testing.T) {
+	for _, c := range []Codec{CodecPatterns, CodecZstd} {
+		parsed, err := ParseCodec(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+	_, err := ParseCodec("lz4")
+	require.Error(t, err)
+}
@@ -0,0 +1,161 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+)
+
+// errNoActiveJournal is returned if a transaction is attempted to be inserted
+// into the journal, but no such file is currently open.
+var errNoActiveJournal = errors.New("no active journal")
+
+// maxJournalEntrySize - entries bigger than this are treated as corruption of the journal
+const maxJournalEntrySize = 16 * 1024 * 1024
+
+// txJournal is an append-only log of local transactions. It lives outside of the txpool db,
+// so locally submitted transactions survive db wipes and schema changes.
+//
+// Every entry is a uvarint-encoded length, followed by the 20-byte sender address and the
+// transaction rlp - the same value as stored in kv.PoolTransaction.
+type txJournal struct {
+	path   string         // Filesystem path to store the transactions at
+	writer io.WriteCloser // Output stream to write new transactions into
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{path: path}
+}
+
+// load parses a transaction journal dump from disk, calling add for every entry.
+// A truncated last entry (e.g. after a crash) is ignored.
+func (j *txJournal) load(add func(sender common.Address, txRlp []byte) error) (loaded int, err error) {
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		size, err := binary.ReadUvarint(r)
+		if errors.Is(err, io.EOF) {
+			return loaded, nil
+		}
+		if err != nil { // truncated entry, e.g. after a crash
+			return loaded, nil //nolint:nilerr
+		}
+		if size < 20 || size > maxJournalEntrySize {
+			return loaded, fmt.Errorf("corrupted journal entry of size %d", size)
+		}
+		entry := make([]byte, size)
+		if _, err := io.ReadFull(r, entry); err != nil { // truncated entry, e.g. after a crash
+			return loaded, nil //nolint:nilerr
+		}
+		if err := add(*(*[20]byte)(entry[:20]), entry[20:]); err != nil {
+			return loaded, err
+		}
+		loaded++
+	}
+}
+
+// insert adds the specified transaction to the local disk journal.
+func (j *txJournal) insert(sender common.Address, txRlp []byte) error {
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+	_, err := j.writer.Write(encodeJournalEntry(nil, sender, txRlp))
+	return err
+}
+
+// rotate regenerates the transaction journal based on the current contents of
+// the transaction pool, which are fed through the forEach iterator.
+func (j *txJournal) rotate(forEach func(f func(sender common.Address, txRlp []byte) error) error) (journaled int, err error) {
+	// Close the current journal (if any is open)
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return 0, err
+		}
+		j.writer = nil
+	}
+	// Generate a new journal with the contents of the current pool
+	replacement, err := os.OpenFile(j.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	w := bufio.NewWriter(replacement)
+	var buf []byte
+	if err := forEach(func(sender common.Address, txRlp []byte) error {
+		buf = encodeJournalEntry(buf[:0], sender, txRlp)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		journaled++
+		return nil
+	}); err != nil {
+		replacement.Close()
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		replacement.Close()
+		return 0, err
+	}
+	if err := replacement.Sync(); err != nil {
+		replacement.Close()
+		return 0, err
+	}
+	if err := replacement.Close(); err != nil {
+		return 0, err
+	}
+
+	// Replace the live journal with the newly generated one
+	if err = os.Rename(j.path+".new", j.path); err != nil {
+		return 0, err
+	}
+	sink, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
+	j.writer = sink
+	return journaled, nil
+}
+
+// close flushes the transaction journal contents to disk and closes the file.
+func (j *txJournal) close() error {
+	var err error
+	if j.writer != nil {
+		err = j.writer.Close()
+		j.writer = nil
+	}
+	return err
+}
+
+func encodeJournalEntry(buf []byte, sender common.Address, txRlp []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(20+len(txRlp)))
+	buf = append(buf, sender[:]...)
+	return append(buf, txRlp...)
+}
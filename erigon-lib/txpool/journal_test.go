@@ -0,0 +1,150 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/fixedgas"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
+	"github.com/ledgerwatch/erigon-lib/types"
+)
+
+type journalEntry struct {
+	sender common.Address
+	txRlp  []byte
+}
+
+func loadJournalEntries(t *testing.T, j *txJournal) []journalEntry {
+	var entries []journalEntry
+	_, err := j.load(func(sender common.Address, txRlp []byte) error {
+		entries = append(entries, journalEntry{sender, common.Copy(txRlp)})
+		return nil
+	})
+	require.NoError(t, err)
+	return entries
+}
+
+func TestTxJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.journal")
+	j := newTxJournal(path)
+
+	// missing journal is not an error
+	require.Empty(t, loadJournalEntries(t, j))
+	require.ErrorIs(t, j.insert(common.Address{1}, []byte{1}), errNoActiveJournal)
+
+	entries := []journalEntry{{common.Address{1}, []byte{1, 2, 3}}, {common.Address{2}, []byte{4}}}
+	journaled, err := j.rotate(func(f func(sender common.Address, txRlp []byte) error) error {
+		return f(entries[0].sender, entries[0].txRlp)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, journaled)
+	require.NoError(t, j.insert(entries[1].sender, entries[1].txRlp))
+	require.Equal(t, entries, loadJournalEntries(t, j))
+
+	// rotation drops everything not in the pool anymore
+	journaled, err = j.rotate(func(f func(sender common.Address, txRlp []byte) error) error {
+		return f(entries[1].sender, entries[1].txRlp)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, journaled)
+	require.Equal(t, entries[1:], loadJournalEntries(t, j))
+	require.NoError(t, j.close())
+
+	// truncated last entry (crash in the middle of write) is ignored
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = f.Write(encodeJournalEntry(nil, common.Address{3}, []byte{5, 6, 7})[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, entries[1:], loadJournalEntries(t, j))
+}
+
+func TestLocalTxsJournal(t *testing.T) {
+	ctx := context.Background()
+	coreDB := memdb.NewTestDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.Journal = filepath.Join(t.TempDir(), "transactions.journal")
+	cfg.RejournalEvery = 0
+
+	// legacy EIP-155 transaction, chainID 1337
+	txRlp := hexutility.MustDecodeHex("f8620101830186a09400000000000000000000000000000000000000006401820a96a04f353451b272c6b183cedf20787dab556db5afadf16733a3c6bffb0d2fcd2563a0773cd45f7cc62250f7ee715b9e19f0489176f8966f75c8eed2fbf1ac861cb50c")
+	sender := common.HexToAddress("67b1d87101671b127f5f8714789c7192f7ad340e")
+	chainID := *uint256.NewInt(1337)
+
+	newPool := func() *TxPool {
+		db := memdb.NewTestPoolDB(t)
+		pool, err := New(make(chan types.Announcements, 100), coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), chainID, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+		require.NoError(t, err)
+
+		v := make([]byte, types.EncodeSenderLengthForStorage(1, *uint256.NewInt(1 * common.Ether)))
+		types.EncodeSender(1, *uint256.NewInt(1 * common.Ether), v)
+		change := &remote.StateChangeBatch{
+			PendingBlockBaseFee: 1,
+			BlockGasLimit:       1000000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: 0,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(sender),
+					Data:    v,
+				}},
+			}},
+		}
+		require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+			return pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx)
+		}))
+		require.NoError(t, pool.Start(ctx, db))
+		return pool
+	}
+
+	pool := newPool()
+	var txs types.TxSlots
+	txs.Resize(1)
+	txs.Txs[0] = &types.TxSlot{}
+	txs.IsLocal[0] = true
+	parseCtx := types.NewTxParseContext(chainID)
+	_, err := parseCtx.ParseTransaction(txRlp, 0, txs.Txs[0], txs.Senders.At(0), false /* hasEnvelope */, true /* wrappedWithBlobs */, nil)
+	require.NoError(t, err)
+	reasons, err := pool.AddLocalTxs(ctx, txs, nil)
+	require.NoError(t, err)
+	require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	require.Equal(t, []journalEntry{{sender, txRlp}}, loadJournalEntries(t, pool.journal))
+
+	// pool with wiped db restores the local transaction from the journal
+	restored := newPool()
+	require.True(t, restored.IsLocal(txs.Txs[0].IDHash[:]))
+	nonce, inPool := restored.NonceFromAddress(sender)
+	require.True(t, inPool)
+	require.Equal(t, uint64(1), nonce)
+	require.Equal(t, []journalEntry{{sender, txRlp}}, loadJournalEntries(t, restored.journal))
+}
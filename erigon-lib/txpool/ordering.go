@@ -0,0 +1,180 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
+)
+
+// txOrdering - decides in which order pending transactions are offered to block building (YieldBest).
+//
+// `best` is the pending sub-pool sorted by metaTx.better (see PendingPool.EnforceBestInvariants).
+// Implementations must not modify it and must yield transactions of the same sender in nonce order.
+type txOrdering interface {
+	order(best []*metaTx, pendingBaseFee uint64) []*metaTx
+}
+
+func newTxOrdering(cfg txpoolcfg.Config) txOrdering {
+	switch cfg.Ordering {
+	case txpoolcfg.FifoOrdering:
+		return fifoOrdering{feeBand: cfg.OrderingFeeBand}
+	case txpoolcfg.RoundRobinOrdering:
+		return roundRobinOrdering{}
+	default:
+		return tipOrdering{}
+	}
+}
+
+// tipOrdering - pending sub-pool order as is: the highest effective tip first
+type tipOrdering struct{}
+
+func (tipOrdering) order(best []*metaTx, _ uint64) []*metaTx { return best }
+
+// fifoOrdering - transactions which pay enough for the pending block go first, then the ones
+// in the higher band of effective tip, and within the same band - the ones seen first
+type fifoOrdering struct {
+	feeBand uint64
+}
+
+func (o fifoOrdering) order(best []*metaTx, pendingBaseFee uint64) []*metaTx {
+	baseFee := uint256.NewInt(pendingBaseFee)
+	heads := &fifoHeads{}
+	for _, txs := range groupBySender(best) {
+		heads.queues = append(heads.queues, fifoQueue{txs: txs})
+	}
+	for i := range heads.queues {
+		heads.queues[i].setKey(baseFee, o.feeBand)
+	}
+	heap.Init(heads)
+
+	res := make([]*metaTx, 0, len(best))
+	for heads.Len() > 0 {
+		q := &heads.queues[0]
+		res = append(res, q.txs[0])
+		q.txs = q.txs[1:]
+		if len(q.txs) == 0 {
+			heap.Pop(heads)
+			continue
+		}
+		q.setKey(baseFee, o.feeBand)
+		heap.Fix(heads, 0)
+	}
+	return res
+}
+
+// fifoQueue - not yet ordered txs of one sender, sorted by nonce
+type fifoQueue struct {
+	txs      []*metaTx
+	enoughFc bool
+	band     uint64
+}
+
+func (q *fifoQueue) setKey(pendingBaseFee *uint256.Int, feeBand uint64) {
+	mt := q.txs[0]
+	q.enoughFc = mt.minFeeCap.Cmp(pendingBaseFee) >= 0
+	q.band = 0
+	if q.enoughFc && feeBand > 0 {
+		q.band = mt.effectiveTip(pendingBaseFee) / feeBand
+	}
+}
+
+// fifoHeads - heap of senders, ordered by their lowest nonce transaction
+type fifoHeads struct {
+	queues []fifoQueue
+}
+
+func (h *fifoHeads) Len() int      { return len(h.queues) }
+func (h *fifoHeads) Swap(i, j int) { h.queues[i], h.queues[j] = h.queues[j], h.queues[i] }
+func (h *fifoHeads) Less(i, j int) bool {
+	a, b := &h.queues[i], &h.queues[j]
+	if a.enoughFc != b.enoughFc {
+		return a.enoughFc
+	}
+	if a.band != b.band {
+		return a.band > b.band
+	}
+	return a.txs[0].seenBefore(b.txs[0])
+}
+func (h *fifoHeads) Push(x interface{}) { h.queues = append(h.queues, x.(fifoQueue)) }
+func (h *fifoHeads) Pop() interface{} {
+	old := h.queues
+	n := len(old)
+	item := old[n-1]
+	h.queues = old[0 : n-1]
+	return item
+}
+
+// roundRobinOrdering - takes one transaction per sender per round, so a single sender
+// can't fill the block. Senders are ordered by their best transaction
+type roundRobinOrdering struct{}
+
+func (roundRobinOrdering) order(best []*metaTx, _ uint64) []*metaTx {
+	bySender := groupBySender(best)
+	res := make([]*metaTx, 0, len(best))
+	for round := 0; len(res) < len(best); round++ {
+		for _, txs := range bySender {
+			if round < len(txs) {
+				res = append(res, txs[round])
+			}
+		}
+	}
+	return res
+}
+
+// groupBySender - splits `best` into per-sender lists sorted by nonce. Senders are returned
+// in order of appearance of their first transaction in `best`
+func groupBySender(best []*metaTx) [][]*metaTx {
+	idx := make(map[uint64]int, len(best))
+	var bySender [][]*metaTx
+	for _, mt := range best {
+		i, ok := idx[mt.Tx.SenderID]
+		if !ok {
+			i = len(bySender)
+			idx[mt.Tx.SenderID] = i
+			bySender = append(bySender, nil)
+		}
+		bySender[i] = append(bySender[i], mt)
+	}
+	for _, txs := range bySender {
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Tx.Nonce < txs[j].Tx.Nonce })
+	}
+	return bySender
+}
+
+// effectiveTip - min(minTip, minFeeCap - pendingBaseFee), or 0 if minFeeCap doesn't cover pendingBaseFee
+func (mt *metaTx) effectiveTip(pendingBaseFee *uint256.Int) uint64 {
+	if mt.minFeeCap.Cmp(pendingBaseFee) < 0 {
+		return 0
+	}
+	difference := uint256.NewInt(0).Sub(&mt.minFeeCap, pendingBaseFee)
+	if difference.CmpUint64(mt.minTip) <= 0 {
+		return difference.Uint64()
+	}
+	return mt.minTip
+}
+
+func (mt *metaTx) seenBefore(than *metaTx) bool {
+	if mt.timestamp != than.timestamp {
+		return mt.timestamp < than.timestamp
+	}
+	return mt.arrival < than.arrival
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright 2024 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package txpool
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
+	"github.com/ledgerwatch/erigon-lib/types"
+)
+
+func TestTxOrdering(t *testing.T) {
+	const baseFee = 10
+	newTx := func(sender, nonce, feeCap, tip, timestamp, arrival uint64) *metaTx {
+		mt := newMetaTx(&types.TxSlot{SenderID: sender, Nonce: nonce}, false, timestamp)
+		mt.minFeeCap = *uint256.NewInt(feeCap)
+		mt.minTip = tip
+		mt.arrival = arrival
+		mt.nonceDistance = nonce
+		mt.subPool = NoNonceGaps | EnoughBalance | NotTooMuchGas
+		mt.currentSubPool = PendingSubPool
+		return mt
+	}
+	txs := []*metaTx{
+		newTx(1, 0, 100, 5, 1, 1), // a0
+		newTx(1, 1, 100, 5, 1, 2), // a1
+		newTx(1, 2, 100, 5, 2, 3), // a2
+		newTx(2, 0, 100, 9, 2, 4), // b0
+		newTx(2, 1, 100, 9, 2, 5), // b1
+		newTx(3, 5, 100, 7, 1, 6), // c5
+		newTx(3, 6, 100, 7, 3, 7), // c6
+		newTx(4, 0, 5, 1, 0, 0),   // d0 - feeCap below baseFee
+	}
+	names := map[*metaTx]string{}
+	for _, mt := range txs {
+		names[mt] = fmt.Sprintf("%c%d", 'a'+mt.Tx.SenderID-1, mt.Tx.Nonce)
+	}
+	best := &bestSlice{pendingBaseFee: baseFee}
+	for _, mt := range txs {
+		best.UnsafeAdd(mt)
+	}
+	sort.Sort(best)
+	order := func(cfg txpoolcfg.Config) []string {
+		var res []string
+		for _, mt := range newTxOrdering(cfg).order(best.ms, baseFee) {
+			res = append(res, names[mt])
+		}
+		return res
+	}
+
+	cfg := txpoolcfg.DefaultConfig
+	require.Equal(t, []string{"b0", "b1", "c5", "c6", "a0", "a1", "a2", "d0"}, order(cfg))
+
+	cfg.Ordering = txpoolcfg.FifoOrdering
+	cfg.OrderingFeeBand = 0
+	require.Equal(t, []string{"a0", "a1", "c5", "a2", "b0", "b1", "c6", "d0"}, order(cfg))
+	cfg.OrderingFeeBand = 8 // bands: [8,16) - b; [0,8) - a, c
+	require.Equal(t, []string{"b0", "b1", "a0", "a1", "c5", "a2", "c6", "d0"}, order(cfg))
+
+	cfg.Ordering = txpoolcfg.RoundRobinOrdering
+	require.Equal(t, []string{"b0", "c5", "a0", "d0", "b1", "c6", "a1", "a2"}, order(cfg))
+
+	// pending sub-pool itself is not re-ordered
+	require.Equal(t, "b0", names[best.ms[0]])
+	for i, mt := range best.ms {
+		require.Equal(t, i, mt.bestIndex)
+	}
+}
+
+func TestParseOrdering(t *testing.T) {
+	for _, o := range []txpoolcfg.Ordering{txpoolcfg.TipOrdering, txpoolcfg.FifoOrdering, txpoolcfg.RoundRobinOrdering} {
+		parsed, err := txpoolcfg.ParseOrdering(o.String())
+		require.NoError(t, err)
+		require.Equal(t, o, parsed)
+	}
+	_, err := txpoolcfg.ParseOrdering("random")
+	require.Error(t, err)
+}
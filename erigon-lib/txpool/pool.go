@@ -128,6 +128,7 @@ type metaTx struct {
 	bestIndex                 int
 	worstIndex                int
 	timestamp                 uint64 // when it was added to pool
+	arrival                   uint64 // sequence number of insertion into the pool, orders txs added at the same timestamp
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
@@ -206,10 +207,17 @@ type TxPool struct {
 	queued                  *SubPool
 	minedBlobTxsByBlock     map[uint64][]*metaTx             // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxsByHash      map[string]*metaTx               // (hash => mt): map of recently mined blobs
+	blobTxsByVersionedHash  map[common.Hash]*metaTx          // (blob versioned hash => mt): pool and recently mined txs carrying the blob
 	isLocalLRU              *simplelru.LRU[string, struct{}] // tx_hash => is_local : to restore isLocal flag of unwinded transactions
 	newPendingTxs           chan types.Announcements         // notifications about new txs in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of tx nonce => *metaTx)
 	deletedTxs              []*metaTx                        // list of discarded txs since last db commit
+	discardedTxs            []*proto_txpool.DiscardedTx      // list of discarded txs not yet sent to OnDiscard subscribers
+	discardedTxsNotify      chan struct{}                    // notifications about new entries in discardedTxs
+	journal                 *txJournal                       // journal of local transactions to back up to disk, nil if disabled
+	lastRejournal           time.Time
+	lastArrival             uint64     // sequence number of the last inserted txn, see metaTx.arrival
+	ordering                txOrdering // order of pending txs for block building
 	promoted                types.Announcements
 	cfg                     txpoolcfg.Config
 	chainID                 uint256.Int
@@ -274,6 +282,7 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		baseFee:                 NewSubPool(BaseFeeSubPool, cfg.BaseFeeSubPoolLimit),
 		queued:                  NewSubPool(QueuedSubPool, cfg.QueuedSubPoolLimit),
 		newPendingTxs:           newTxs,
+		discardedTxsNotify:      make(chan struct{}, 1),
 		_stateCache:             cache,
 		senders:                 newSendersCache(tracedSenders),
 		_chainDB:                coreDB,
@@ -283,11 +292,17 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		unprocessedRemoteByHash: map[string]int{},
 		minedBlobTxsByBlock:     map[uint64][]*metaTx{},
 		minedBlobTxsByHash:      map[string]*metaTx{},
+		blobTxsByVersionedHash:  map[common.Hash]*metaTx{},
+		ordering:                newTxOrdering(cfg),
 		maxBlobsPerBlock:        maxBlobsPerBlock,
 		feeCalculator:           feeCalculator,
 		logger:                  logger,
 	}
 
+	if cfg.Journal != "" {
+		res.journal = newTxJournal(cfg.Journal)
+	}
+
 	if shanghaiTime != nil {
 		if !shanghaiTime.IsUint64() {
 			return nil, errors.New("shanghaiTime overflow")
@@ -318,7 +333,7 @@ func (p *TxPool) Start(ctx context.Context, db kv.RwDB) error {
 		return nil
 	}
 
-	return db.View(ctx, func(tx kv.Tx) error {
+	if err := db.View(ctx, func(tx kv.Tx) error {
 		coreDb, _ := p.coreDBWithCache()
 		coreTx, err := coreDb.BeginRo(ctx)
 
@@ -332,12 +347,93 @@ func (p *TxPool) Start(ctx context.Context, db kv.RwDB) error {
 			return fmt.Errorf("loading pool from DB: %w", err)
 		}
 
-		if p.started.CompareAndSwap(false, true) {
-			p.logger.Info("[txpool] Started")
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if p.journal != nil {
+		if err := db.View(ctx, func(tx kv.Tx) error { return p.loadJournal(ctx, tx) }); err != nil {
+			return fmt.Errorf("loading local transactions journal: %w", err)
 		}
+	}
+
+	if p.started.CompareAndSwap(false, true) {
+		p.logger.Info("[txpool] Started")
+	}
 
+	return nil
+}
+
+// loadJournal - replays local transactions from the journal (they may be missing in db - if it was wiped),
+// then regenerates the journal from the pool content
+func (p *TxPool) loadJournal(ctx context.Context, tx kv.Tx) error {
+	txs := types.TxSlots{}
+	parseCtx := types.NewTxParseContext(p.chainID)
+	parseCtx.WithSender(false)
+	loaded, err := p.journal.load(func(sender common.Address, txRlp []byte) error {
+		txn := &types.TxSlot{}
+		if _, err := parseCtx.ParseTransaction(txRlp, 0, txn, nil, false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+			p.logger.Warn("[txpool] loadJournal: parseTransaction", "err", err, "rlp", hex.EncodeToString(txRlp))
+			return nil
+		}
+		i := len(txs.Txs)
+		txs.Resize(uint(i + 1))
+		txs.Txs[i] = txn
+		txs.IsLocal[i] = true
+		copy(txs.Senders.At(i), sender[:])
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	var imported int
+	if len(txs.Txs) > 0 {
+		reasons, err := p.AddLocalTxs(ctx, txs, tx)
+		if err != nil {
+			return err
+		}
+		for _, reason := range reasons {
+			if reason == txpoolcfg.Success {
+				imported++
+			}
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	journaled, err := p.rejournalLocked(tx)
+	if err != nil {
+		return err
+	}
+	p.logger.Info("[txpool] Loaded local transactions journal", "loaded", loaded, "imported", imported, "journaled", journaled)
+	return nil
+}
+
+// rejournalLocked - regenerates the journal of local transactions from the pool content
+func (p *TxPool) rejournalLocked(tx kv.Tx) (journaled int, err error) {
+	defer func() { p.lastRejournal = time.Now() }()
+	return p.journal.rotate(func(f func(sender common.Address, txRlp []byte) error) error {
+		var err error
+		p.all.ascendAll(func(mt *metaTx) bool {
+			if mt.subPool&IsLocal == 0 {
+				return true
+			}
+			var txRlp []byte
+			var sender common.Address
+			txRlp, sender, _, err = p.getRlpLocked(tx, mt.Tx.IDHash[:])
+			if err != nil {
+				return false
+			}
+			if txRlp == nil {
+				return true
+			}
+			err = f(sender, txRlp)
+			return err == nil
+		})
+		return err
+	})
 }
 
 func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChangeBatch, unwindTxs, unwindBlobTxs, minedTxs types.TxSlots, tx kv.Tx) error {
@@ -712,26 +808,26 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 		p.lastSeenCond.Wait()
 	}
 
-	best := p.pending.best
+	best := p.ordering.order(p.pending.best.ms, p.pending.best.pendingBaseFee)
 
 	isShanghai := p.isShanghai() || p.isAgra()
 
-	txs.Resize(uint(cmp.Min(int(n), len(best.ms))))
+	txs.Resize(uint(cmp.Min(int(n), len(best))))
 	var toRemove []*metaTx
 	count := 0
 	i := 0
 
 	defer func() {
-		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best.ms), "txProcessed", i, "txReturned", count)
+		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best), "txProcessed", i, "txReturned", count)
 	}()
 
-	for ; count < int(n) && i < len(best.ms); i++ {
+	for ; count < int(n) && i < len(best); i++ {
 		// if we wouldn't have enough gas for a standard transaction then quit out early
 		if availableGas < fixedgas.TxGas {
 			break
 		}
 
-		mt := best.ms[i]
+		mt := best[i]
 
 		if yielded.Contains(mt.Tx.IDHash) {
 			continue
@@ -1213,6 +1309,11 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 				p.logger.Info(fmt.Sprintf("TX TRACING: AddLocalTxs promotes idHash=%x, senderId=%d", txn.IDHash, txn.SenderID))
 			}
 			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
+			if p.journal != nil && txn.Rlp != nil {
+				if err := p.journal.insert(p.senders.senderID2Addr[txn.SenderID], txn.Rlp); err != nil && !errors.Is(err, errNoActiveJournal) {
+					p.logger.Warn("[txpool] Failed to journal local transaction", "err", err)
+				}
+			}
 		}
 	}
 	if p.promoted.Len() > 0 {
@@ -1427,6 +1528,9 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 
 	hashStr := string(mt.Tx.IDHash[:])
 	p.byHash[hashStr] = mt
+	p.indexBlobsLocked(mt)
+	p.lastArrival++
+	mt.arrival = p.lastArrival
 
 	if replaced := p.all.replaceOrInsert(mt, p.logger); replaced != nil {
 		if assert.Enable {
@@ -1454,6 +1558,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 	hashStr := string(mt.Tx.IDHash[:])
 	delete(p.byHash, hashStr)
+	p.unindexBlobsLocked(mt)
 	p.deletedTxs = append(p.deletedTxs, mt)
 	p.all.delete(mt, reason, p.logger)
 	p.discardReasonsLRU.Add(hashStr, reason)
@@ -1461,6 +1566,39 @@ func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.Tx.BlobHashes)))
 	}
+	p.notifyDiscardedLocked(mt, reason)
+}
+
+// maxDiscardedTxs - limit of discarded txs buffered between MainLoop iterations, the oldest are dropped first
+const maxDiscardedTxs = 10_000
+
+func (p *TxPool) notifyDiscardedLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
+	switch reason {
+	case txpoolcfg.Mined, txpoolcfg.NotSet, txpoolcfg.Success, txpoolcfg.AlreadyKnown:
+		return // tx is included into block or kept by pool - not dropped
+	}
+	if len(p.discardedTxs) >= maxDiscardedTxs {
+		p.discardedTxs = p.discardedTxs[1:]
+	}
+	p.discardedTxs = append(p.discardedTxs, &proto_txpool.DiscardedTx{
+		Hash:   gointerfaces.ConvertHashToH256(mt.Tx.IDHash),
+		Sender: gointerfaces.ConvertAddressToH160(p.senders.senderID2Addr[mt.Tx.SenderID]),
+		Nonce:  mt.Tx.Nonce,
+		Reason: uint32(reason),
+	})
+	select {
+	case p.discardedTxsNotify <- struct{}{}:
+	default:
+	}
+}
+
+// popDiscarded - returns txs discarded since previous call
+func (p *TxPool) popDiscarded() []*proto_txpool.DiscardedTx {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	discarded := p.discardedTxs
+	p.discardedTxs = nil
+	return discarded
 }
 
 // Cache recently mined blobs in anticipation of reorg, delete finalized ones
@@ -1471,6 +1609,7 @@ func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSl
 		// delete individual hashes
 		for _, mt := range p.minedBlobTxsByBlock[finalizedBlock] {
 			delete(p.minedBlobTxsByHash, string(mt.Tx.IDHash[:]))
+			p.unindexBlobsLocked(mt)
 		}
 		// delete the map entry for this block num
 		delete(p.minedBlobTxsByBlock, finalizedBlock)
@@ -1487,6 +1626,7 @@ func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSl
 			p.minedBlobTxsByBlock[minedBlock] = append(p.minedBlobTxsByBlock[minedBlock], mt)
 			mt.bestIndex = len(p.minedBlobTxsByBlock[minedBlock]) - 1
 			p.minedBlobTxsByHash[string(txn.IDHash[:])] = mt
+			p.indexBlobsLocked(mt)
 		}
 	}
 	return nil
@@ -1504,6 +1644,31 @@ func (p *TxPool) deleteMinedBlobTxn(hash string) {
 	}
 	p.minedBlobTxsByBlock[mt.minedBlockNum] = p.minedBlobTxsByBlock[mt.minedBlockNum][:l-1]
 	delete(p.minedBlobTxsByHash, hash)
+	p.unindexBlobsLocked(mt)
+}
+
+// indexBlobsLocked - makes blobs of the tx available to GetBlobs by their versioned hashes.
+// Txs without blobs and proofs (e.g. mined ones parsed from a block) are not indexed
+func (p *TxPool) indexBlobsLocked(mt *metaTx) {
+	txn := mt.Tx
+	if txn.Type != types.BlobTxType || len(txn.Blobs) != len(txn.BlobHashes) || len(txn.Proofs) != len(txn.BlobHashes) {
+		return
+	}
+	for _, h := range txn.BlobHashes {
+		p.blobTxsByVersionedHash[h] = mt
+	}
+}
+
+// unindexBlobsLocked - removes versioned hashes of the tx, unless they were re-indexed by another tx
+func (p *TxPool) unindexBlobsLocked(mt *metaTx) {
+	if mt.Tx.Type != types.BlobTxType {
+		return
+	}
+	for _, h := range mt.Tx.BlobHashes {
+		if p.blobTxsByVersionedHash[h] == mt {
+			delete(p.blobTxsByVersionedHash, h)
+		}
+	}
 }
 
 func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
@@ -1516,6 +1681,30 @@ func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
 	return p.all.nonce(senderID)
 }
 
+// GetBlobs looks up blobs and their KZG proofs by versioned hash, in the pool and in the cache of
+// recently mined blob transactions. Hashes that are not found get nil entries.
+func (p *TxPool) GetBlobs(blobHashes []common.Hash) (blobs [][]byte, proofs [][]byte) {
+	blobs = make([][]byte, len(blobHashes))
+	proofs = make([][]byte, len(blobHashes))
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, h := range blobHashes {
+		mt, ok := p.blobTxsByVersionedHash[h]
+		if !ok {
+			continue
+		}
+		for j, blobHash := range mt.Tx.BlobHashes {
+			if blobHash == h {
+				blobs[i] = mt.Tx.Blobs[j]
+				proofs[i] = common.Copy(mt.Tx.Proofs[j][:])
+				break
+			}
+		}
+	}
+	return blobs, proofs
+}
+
 // removeMined - apply new highest block (or batch of blocks)
 //
 // 1. New best block arrives, which potentially changes the balance and the nonce of some senders.
@@ -1764,7 +1953,7 @@ const txMaxBroadcastSize = 4 * 1024
 //
 // promote/demote transactions
 // reorgs
-func MainLoop(ctx context.Context, db kv.RwDB, p *TxPool, newTxs chan types.Announcements, send *Send, newSlotsStreams *NewSlotsStreams, notifyMiningAboutNewSlots func()) {
+func MainLoop(ctx context.Context, db kv.RwDB, p *TxPool, newTxs chan types.Announcements, send *Send, newSlotsStreams *NewSlotsStreams, discardedTxsStreams *DiscardedTxsStreams, notifyMiningAboutNewSlots func()) {
 	syncToNewPeersEvery := time.NewTicker(p.cfg.SyncToNewPeersEvery)
 	defer syncToNewPeersEvery.Stop()
 	processRemoteTxsEvery := time.NewTicker(p.cfg.ProcessRemoteTxsEvery)
@@ -1785,9 +1974,19 @@ func MainLoop(ctx context.Context, db kv.RwDB, p *TxPool, newTxs chan types.Anno
 		select {
 		case <-ctx.Done():
 			_, _ = p.flush(ctx, db)
+			if p.journal != nil {
+				p.lock.Lock()
+				_ = p.journal.close()
+				p.lock.Unlock()
+			}
 			return
 		case <-logEvery.C:
 			p.logStats()
+		case <-p.discardedTxsNotify:
+			discarded := p.popDiscarded()
+			if discardedTxsStreams != nil && len(discarded) > 0 {
+				discardedTxsStreams.Broadcast(&proto_txpool.OnDiscardReply{Txs: discarded}, p.logger)
+			}
 		case <-processRemoteTxsEvery.C:
 			if !p.Started() {
 				continue
@@ -2049,6 +2248,14 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		return err
 	}
 
+	if p.journal != nil && p.started.Load() && time.Since(p.lastRejournal) >= p.cfg.RejournalEvery {
+		if journaled, err := p.rejournalLocked(tx); err != nil {
+			p.logger.Warn("[txpool] Failed to rotate local transactions journal", "err", err)
+		} else {
+			p.logger.Debug("[txpool] Regenerated local transactions journal", "transactions", journaled)
+		}
+	}
+
 	// clean - in-memory data structure as later as possible - because if during this Tx will happen error,
 	// DB will stay consistent but some in-memory structures may be already cleaned, and retry will not work
 	// failed write transaction must not create side-effects
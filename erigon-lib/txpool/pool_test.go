@@ -280,9 +280,80 @@ func TestReplaceWithHigherFee(t *testing.T) {
 		nonce, ok := pool.NonceFromAddress(addr)
 		assert.True(ok)
 		assert.Equal(uint64(3), nonce)
+
+		// replaced tx is reported to OnDiscard subscribers
+		discarded := pool.popDiscarded()
+		require.Len(discarded, 1)
+		assert.Equal(byte(1), gointerfaces.ConvertH256ToHash(discarded[0].Hash)[0])
+		assert.Equal(addr, gointerfaces.ConvertH160toAddress(discarded[0].Sender))
+		assert.Equal(uint64(3), discarded[0].Nonce)
+		assert.Equal(txpoolcfg.ReplacedByHigherTip, txpoolcfg.DiscardReason(discarded[0].Reason))
 	}
 }
 
+func TestMinedTxIsNotDiscarded(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.NotEqual(nil, pool)
+	ctx := context.Background()
+	pendingBaseFee := uint64(200000)
+	var addr [20]byte
+	addr[0] = 1
+	senderChange := func(blockHeight, nonce uint64) *remote.StateChangeBatch {
+		v := make([]byte, types.EncodeSenderLengthForStorage(nonce, *uint256.NewInt(1 * common.Ether)))
+		types.EncodeSender(nonce, *uint256.NewInt(1 * common.Ether), v)
+		h := gointerfaces.ConvertHashToH256([32]byte{byte(blockHeight)})
+		return &remote.StateChangeBatch{
+			StateVersionId:      blockHeight,
+			PendingBlockBaseFee: pendingBaseFee,
+			BlockGasLimit:       1000000,
+			ChangeBatch: []*remote.StateChange{{
+				BlockHeight: blockHeight,
+				BlockHash:   h,
+				Changes: []*remote.AccountChange{{
+					Action:  remote.Action_UPSERT,
+					Address: gointerfaces.ConvertAddressToH160(addr),
+					Data:    v,
+				}},
+			}},
+		}
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, senderChange(0, 2), types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	txSlot := &types.TxSlot{
+		Tip:    *uint256.NewInt(300000),
+		FeeCap: *uint256.NewInt(300000),
+		Gas:    100000,
+		Nonce:  2,
+	}
+	txSlot.IDHash[0] = 1
+	var txSlots types.TxSlots
+	txSlots.Append(txSlot, addr[:], true)
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	for _, reason := range reasons {
+		assert.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+
+	var minedTxs types.TxSlots
+	minedTxs.Append(txSlot, addr[:], true)
+	err = pool.OnNewBlock(ctx, senderChange(1, 3), types.TxSlots{}, types.TxSlots{}, minedTxs, tx)
+	assert.NoError(err)
+	_, found := pool.byHash[string(txSlot.IDHash[:])]
+	assert.False(found, "mined tx is removed from pool")
+	assert.Empty(pool.popDiscarded(), "mined tx is not reported as dropped")
+}
+
 func TestReverseNonces(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan types.Announcements, 100)
@@ -934,6 +1005,74 @@ func makeBlobTx() types.TxSlot {
 	return blobTx
 }
 
+func TestGetBlobs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 5)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        1000000,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+
+	v := make([]byte, types.EncodeSenderLengthForStorage(0, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(0, *uint256.NewInt(1 * common.Ether), v)
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    v,
+	})
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	blobTxn := makeBlobTx()
+	blobTxn.Nonce = 0
+	txSlots := types.TxSlots{}
+	txSlots.Append(&blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	for _, reason := range reasons {
+		assert.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+
+	blobs, proofs := pool.GetBlobs([]common.Hash{blobTxn.BlobHashes[1], {0x01}, blobTxn.BlobHashes[0]})
+	require.Equal(3, len(blobs))
+	require.Equal(3, len(proofs))
+	assert.Equal(blobTxn.Blobs[1], blobs[0])
+	assert.Equal(blobTxn.Proofs[1][:], proofs[0])
+	assert.Nil(blobs[1])
+	assert.Nil(proofs[1])
+	assert.Equal(blobTxn.Blobs[0], blobs[2])
+	assert.Equal(blobTxn.Proofs[0][:], proofs[2])
+
+	// discarded txs must not be served
+	pool.lock.Lock()
+	pool.discardLocked(pool.byHash[string(blobTxn.IDHash[:])], txpoolcfg.PendingPoolOverflow)
+	pool.lock.Unlock()
+	blobs, proofs = pool.GetBlobs([]common.Hash{blobTxn.BlobHashes[0], blobTxn.BlobHashes[1]})
+	assert.Nil(blobs[0])
+	assert.Nil(blobs[1])
+	assert.Nil(proofs[0])
+	assert.Nil(proofs[1])
+	assert.Empty(pool.blobTxsByVersionedHash)
+}
+
 func TestDropRemoteAtNoGossip(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan types.Announcements, 100)
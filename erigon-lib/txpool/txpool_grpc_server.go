@@ -59,6 +59,7 @@ type txPool interface {
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
+	GetBlobs(blobHashes []common.Hash) (blobs [][]byte, proofs [][]byte)
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -97,20 +98,27 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) OnDiscard(request *txpool_proto.OnDiscardRequest, server txpool_proto.Txpool_OnDiscardServer) error {
+	return ErrPoolDisabled
+}
+func (*GrpcDisabled) GetBlobs(ctx context.Context, request *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
-	ctx             context.Context
-	txPool          txPool
-	db              kv.RoDB
-	NewSlotsStreams *NewSlotsStreams
+	ctx                 context.Context
+	txPool              txPool
+	db                  kv.RoDB
+	NewSlotsStreams     *NewSlotsStreams
+	DiscardedTxsStreams *DiscardedTxsStreams
 
 	chainID uint256.Int
 	logger  log.Logger
 }
 
 func NewGrpcServer(ctx context.Context, txPool txPool, db kv.RoDB, chainID uint256.Int, logger log.Logger) *GrpcServer {
-	return &GrpcServer{ctx: ctx, txPool: txPool, db: db, NewSlotsStreams: &NewSlotsStreams{}, chainID: chainID, logger: logger}
+	return &GrpcServer{ctx: ctx, txPool: txPool, db: db, NewSlotsStreams: &NewSlotsStreams{}, DiscardedTxsStreams: &DiscardedTxsStreams{}, chainID: chainID, logger: logger}
 }
 
 func (s *GrpcServer) Version(context.Context, *emptypb.Empty) (*types2.VersionReply, error) {
@@ -260,6 +268,19 @@ func (s *GrpcServer) OnAdd(req *txpool_proto.OnAddRequest, stream txpool_proto.T
 	}
 }
 
+func (s *GrpcServer) OnDiscard(req *txpool_proto.OnDiscardRequest, stream txpool_proto.Txpool_OnDiscardServer) error {
+	s.logger.Info("Discarded txs subscriber joined")
+	//txpool.Loop does send messages to this streams
+	remove := s.DiscardedTxsStreams.Add(stream)
+	defer remove()
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
 func (s *GrpcServer) Transactions(ctx context.Context, in *txpool_proto.TransactionsRequest) (*txpool_proto.TransactionsReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -303,6 +324,20 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpool_proto.NonceRequest) (
 	}, nil
 }
 
+// returns blobs and their KZG proofs for the requested versioned hashes; unknown ones are left empty
+func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	hashes := make([]common.Hash, len(in.BlobHashes))
+	for i := range in.BlobHashes {
+		hashes[i] = gointerfaces.ConvertH256ToHash(in.BlobHashes[i])
+	}
+	blobs, proofs := s.txPool.GetBlobs(hashes)
+	reply := &txpool_proto.GetBlobsReply{BlobsAndProofs: make([]*txpool_proto.BlobAndProof, len(hashes))}
+	for i := range hashes {
+		reply.BlobsAndProofs[i] = &txpool_proto.BlobAndProof{Blob: blobs[i], Proof: proofs[i]}
+	}
+	return reply, nil
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpool_proto.Txpool_OnAddServer
@@ -348,6 +383,51 @@ func (s *NewSlotsStreams) remove(id uint) {
 	delete(s.chans, id)
 }
 
+// DiscardedTxsStreams - it's safe to use this class as non-pointer
+type DiscardedTxsStreams struct {
+	chans map[uint]txpool_proto.Txpool_OnDiscardServer
+	mu    sync.Mutex
+	id    uint
+}
+
+func (s *DiscardedTxsStreams) Add(stream txpool_proto.Txpool_OnDiscardServer) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.chans == nil {
+		s.chans = make(map[uint]txpool_proto.Txpool_OnDiscardServer)
+	}
+	s.id++
+	id := s.id
+	s.chans[id] = stream
+	return func() { s.remove(id) }
+}
+
+func (s *DiscardedTxsStreams) Broadcast(reply *txpool_proto.OnDiscardReply, logger log.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, stream := range s.chans {
+		err := stream.Send(reply)
+		if err != nil {
+			logger.Debug("failed send to discarded txs stream", "err", err)
+			select {
+			case <-stream.Context().Done():
+				delete(s.chans, id)
+			default:
+			}
+		}
+	}
+}
+
+func (s *DiscardedTxsStreams) remove(id uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.chans[id]
+	if !ok { // double-unsubscribe support
+		return
+	}
+	delete(s.chans, id)
+}
+
 func StartGrpc(txPoolServer txpool_proto.TxpoolServer, miningServer txpool_proto.MiningServer, addr string, creds *credentials.TransportCredentials, logger log.Logger) (*grpc.Server, error) {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
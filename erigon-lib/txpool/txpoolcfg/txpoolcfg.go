@@ -47,6 +47,13 @@ type Config struct {
 	ProcessRemoteTxsEvery time.Duration
 	CommitEvery           time.Duration
 	LogEvery              time.Duration
+	RejournalEvery        time.Duration // How often the journal of local transactions is regenerated, checked on each commit
+
+	Journal string // Path to the journal of local transactions, which survives txpool db wipes. Empty disables journaling
+
+	// block building
+	Ordering        Ordering // Order in which pending transactions are offered to block building (YieldBest)
+	OrderingFeeBand uint64   // Width (in wei) of the effective tip bands used by FifoOrdering. 0 puts all txs into one band
 
 	//txpool db
 	MdbxPageSize    datasize.ByteSize
@@ -61,6 +68,7 @@ var DefaultConfig = Config{
 	ProcessRemoteTxsEvery: 100 * time.Millisecond,
 	CommitEvery:           15 * time.Second,
 	LogEvery:              30 * time.Second,
+	RejournalEvery:        15 * time.Second,
 
 	PendingSubPoolLimit: 10_000,
 	BaseFeeSubPoolLimit: 10_000,
@@ -73,9 +81,48 @@ var DefaultConfig = Config{
 	PriceBump:          10,  // Price bump percentage to replace an already existing transaction
 	BlobPriceBump:      100,
 
+	Ordering:        TipOrdering,
+	OrderingFeeBand: 1_000_000_000, // 1 gwei
+
 	NoGossip: false,
 }
 
+// Ordering - policy of how pending transactions are ordered for block building.
+// Whatever the policy, transactions of the same sender are always yielded in nonce order.
+type Ordering uint8
+
+const (
+	TipOrdering        Ordering = 0 // highest effective tip first (default)
+	FifoOrdering       Ordering = 1 // first-seen first, within bands of effective tip (see Config.OrderingFeeBand)
+	RoundRobinOrdering Ordering = 2 // one transaction per sender per round, senders ordered by their best transaction
+)
+
+func (o Ordering) String() string {
+	switch o {
+	case TipOrdering:
+		return "tip"
+	case FifoOrdering:
+		return "fifo"
+	case RoundRobinOrdering:
+		return "roundrobin"
+	default:
+		return fmt.Sprintf("unknown ordering: %d", o)
+	}
+}
+
+func ParseOrdering(s string) (Ordering, error) {
+	switch s {
+	case "tip":
+		return TipOrdering, nil
+	case "fifo":
+		return FifoOrdering, nil
+	case "roundrobin":
+		return RoundRobinOrdering, nil
+	default:
+		return 0, fmt.Errorf("unknown txpool ordering %q, expected one of: tip, fifo, roundrobin", s)
+	}
+}
+
 type DiscardReason uint8
 
 const (
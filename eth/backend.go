@@ -94,6 +94,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/ethconsensusconfig"
 	"github.com/ledgerwatch/erigon/eth/ethutils"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
+	snapproto "github.com/ledgerwatch/erigon/eth/protocols/snap"
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/ethdb/privateapi"
@@ -151,6 +152,9 @@ type Ethereum struct {
 	genesisBlock *types.Block
 	genesisHash  libcommon.Hash
 
+	stateCache              kvcache.Cache // state cache of embedded RPC, hottest keys are saved to stateCacheWarmStartFile at Stop
+	stateCacheWarmStartFile string
+
 	eth1ExecutionServer *eth1.EthereumExecutionModule
 
 	ethBackendRPC      *privateapi.EthBackendServer
@@ -343,6 +347,16 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 
 	kvRPC := remotedbserver.NewKvServer(ctx, backend.chainDB, allSnapshots, allBorSnapshots, agg, logger)
+	if stack.Config().PrivateApiACL != "" {
+		if stack.Config().TLSCACert == "" {
+			return nil, fmt.Errorf("--private.api.acl requires client certificates: --tls.cacert")
+		}
+		acl, err := remotedbserver.LoadACL(stack.Config().PrivateApiACL)
+		if err != nil {
+			return nil, err
+		}
+		kvRPC.SetACL(acl)
+	}
 	backend.notifications.StateChangesConsumer = kvRPC
 	backend.kvRPC = kvRPC
 
@@ -423,6 +437,16 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			return nil, err
 		}
 
+		var snapServer *snapproto.Server
+		if refCfg.SnapServer {
+			if config.HistoryV3 {
+				// TODO: serve state of the commitment domain
+				logger.Warn("[p2p] snap/1 protocol is not supported with --history.v3 yet, not serving it")
+			} else {
+				snapServer = snapproto.NewServer(backend.chainDB, config.Dirs, logger)
+			}
+		}
+
 		var pi int // points to next port to be picked from refCfg.AllowedPorts
 		for _, protocol := range refCfg.ProtocolVersion {
 			cfg := refCfg
@@ -456,6 +480,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			cfg.ListenAddr = fmt.Sprintf("%s:%d", listenHost, listenPort)
 
 			server := sentry.NewGrpcServer(backend.sentryCtx, discovery, readNodeInfo, &cfg, protocol, logger)
+			if snapServer != nil {
+				server.AddProtocol(snapServer.MakeProtocol(backend.sentryCtx))
+			}
 			backend.sentryServers = append(backend.sentryServers, server)
 			sentries = append(sentries, direct.NewSentryClientDirect(protocol, server))
 		}
@@ -712,11 +739,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		backend.txPoolFetch.ConnectCore()
 		backend.txPoolFetch.ConnectSentries()
 		var newTxsBroadcaster *txpool.NewSlotsStreams
+		var discardedTxsBroadcaster *txpool.DiscardedTxsStreams
 		if casted, ok := backend.txPoolGrpcServer.(*txpool.GrpcServer); ok {
 			newTxsBroadcaster = casted.NewSlotsStreams
+			discardedTxsBroadcaster = casted.DiscardedTxsStreams
 		}
 		go txpool.MainLoop(backend.sentryCtx,
-			backend.txPoolDB, backend.txPool, backend.newTxs, backend.txPoolSend, newTxsBroadcaster,
+			backend.txPoolDB, backend.txPool, backend.newTxs, backend.txPoolSend, newTxsBroadcaster, discardedTxsBroadcaster,
 			func() {
 				select {
 				case backend.notifyMiningAboutNewTxs <- struct{}{}:
@@ -889,6 +918,17 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 	if err != nil {
 		return err
 	}
+	if warmStartFile := httpRpcCfg.StateCache.WarmStartFile; warmStartFile != "" {
+		s.stateCache, s.stateCacheWarmStartFile = stateCache, warmStartFile
+		go func() {
+			loaded, err := kvcache.WarmUp(ctx, stateCache, chainKv, warmStartFile, s.logger)
+			if err != nil {
+				s.logger.Warn("[rpc] state cache warm up failed", "err", err)
+				return
+			}
+			s.logger.Info("[rpc] state cache warmed up", "keys", loaded)
+		}()
+	}
 
 	//eth.APIBackend.gpo = gasprice.NewOracle(eth.APIBackend, gpoParams)
 	if config.Ethstats != "" {
@@ -1334,6 +1374,36 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemovePeer(ctx, &proto_sentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemovePeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) AddTrustedPeer(ctx context.Context, req *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.AddTrustedPeer(ctx, &proto_sentry.AddPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.AddTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.AddPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) RemoveTrustedPeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemoveTrustedPeer(ctx, &proto_sentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemoveTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -1426,6 +1496,13 @@ func (s *Ethereum) Stop() error {
 		s.agg.Close()
 	}
 	s.chainDB.Close()
+	if s.stateCache != nil {
+		if saved, err := kvcache.SaveHotKeys(s.stateCache, s.stateCacheWarmStartFile); err != nil {
+			s.logger.Warn("[rpc] could not save state cache", "err", err)
+		} else {
+			s.logger.Info("[rpc] state cache saved", "keys", saved)
+		}
+	}
 
 	if s.silkwormRPCDaemonService != nil {
 		if err := s.silkwormRPCDaemonService.Stop(); err != nil {
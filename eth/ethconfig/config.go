@@ -23,6 +23,7 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/chain"
 	"github.com/ledgerwatch/erigon-lib/chain/networkname"
+	"github.com/ledgerwatch/erigon-lib/chain/snapcfg"
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/erigon-lib/downloader/downloadercfg"
@@ -40,6 +42,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethconfig/estimate"
 	"github.com/ledgerwatch/erigon/eth/gasprice/gaspricecfg"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/rpc"
@@ -141,6 +144,11 @@ type BlocksFreezing struct {
 	NoDownloader   bool // possible to use snapshots without calling Downloader
 	Verify         bool // verify snapshots on startup
 	DownloaderAddr string
+	Codecs         SnapshotCodecs         // segment codecs of new snapshots, per snapshot type: "headers=zstd,transactions=zstd"
+	DownloadPolicy snapcfg.DownloadPolicy // download and open only part of block snapshots: "bodies=15000000,transactions=15000000"
+
+	IndexWorkers    int  // goroutines building one .idx file, 0 or 1 - single-threaded
+	IndexCheckpoint bool // save progress of .idx files building, and continue from it after restart
 }
 
 func (s BlocksFreezing) String() string {
@@ -154,12 +162,29 @@ func (s BlocksFreezing) String() string {
 	if !s.Produce {
 		out = append(out, "--"+FlagSnapStop+"=true")
 	}
+	if len(s.Codecs) > 0 {
+		out = append(out, "--"+FlagSnapCodecs+"="+s.Codecs.String())
+	}
+	if len(s.DownloadPolicy) > 0 {
+		out = append(out, "--"+FlagSnapDownload+"="+s.DownloadPolicy.String())
+	}
+	if s.IndexWorkers > 1 {
+		out = append(out, "--"+FlagSnapIndexWorkers+"="+strconv.Itoa(s.IndexWorkers))
+	}
+	if s.IndexCheckpoint {
+		out = append(out, "--"+FlagSnapIndexCheckpoint+"=true")
+	}
 	return strings.Join(out, " ")
 }
 
 var (
 	FlagSnapKeepBlocks = "snap.keepblocks"
 	FlagSnapStop       = "snap.stop"
+	FlagSnapCodecs     = "snap.codecs"
+	FlagSnapDownload   = "snap.download"
+
+	FlagSnapIndexWorkers    = "snap.index.workers"
+	FlagSnapIndexCheckpoint = "snap.index.checkpoint"
 )
 
 func NewSnapCfg(enabled, keepBlocks, produce bool) BlocksFreezing {
@@ -184,6 +209,8 @@ type Config struct {
 	Prune     prune.Mode
 	BatchSize datasize.ByteSize // Batch size for execution stage
 
+	LogIndexes []logindex.Indexer `toml:"-"` // user-defined log indices, built by LogIndex stage
+
 	ImportMode bool
 
 	BadBlockHash common.Hash // hash of the block marked as bad
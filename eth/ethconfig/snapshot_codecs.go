@@ -0,0 +1,58 @@
+package ethconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/seg"
+)
+
+// SnapshotCodecs - codec used to produce new .seg files (by retire and merge) of given snapshot type, seg.CodecPatterns if not set.
+// Existing files of any codec are readable regardless of this setting.
+//
+// Format: "headers=zstd,transactions=zstd"
+type SnapshotCodecs map[snaptype.Enum]seg.Codec
+
+func ParseSnapshotCodecs(s string) (SnapshotCodecs, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	codecs := SnapshotCodecs{}
+	for _, item := range strings.Split(s, ",") {
+		typeName, codecName, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("snapshot codecs: expected <snapshot type>=<codec>, got %q", item)
+		}
+		t, ok := snaptype.ParseEnum(strings.TrimSpace(typeName))
+		if !ok {
+			return nil, fmt.Errorf("snapshot codecs: unknown snapshot type %q", typeName)
+		}
+		codec, err := seg.ParseCodec(strings.TrimSpace(codecName))
+		if err != nil {
+			return nil, fmt.Errorf("snapshot codecs: %s: %w", t, err)
+		}
+		codecs[t] = codec
+	}
+	return codecs, nil
+}
+
+func (c SnapshotCodecs) String() string {
+	types := make([]snaptype.Enum, 0, len(c))
+	for t := range c {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	items := make([]string, 0, len(types))
+	for _, t := range types {
+		items = append(items, fmt.Sprintf("%s=%s", t, c[t]))
+	}
+	return strings.Join(items, ",")
+}
+
+// Codec - codec of new files of given snapshot type
+func (c SnapshotCodecs) Codec(t snaptype.Enum) seg.Codec { return c[t] }
@@ -43,6 +43,9 @@ type DeprecatedTxPoolConfig struct {
 	StartOnInit   bool
 	TracedSenders []string // List of senders for which tx pool should print out debugging info
 	CommitEvery   time.Duration
+
+	Journal   string        // Journal of local transactions to survive txpool db wipes and node restarts
+	Rejournal time.Duration // Time interval to regenerate the local transaction journal
 }
 
 // DeprecatedDefaultTxPoolConfig contains the default configurations for the transaction
@@ -58,6 +61,8 @@ var DeprecatedDefaultTxPoolConfig = DeprecatedTxPoolConfig{
 	GlobalQueue:        30_000,
 
 	Lifetime: 3 * time.Hour,
+
+	Rejournal: txpoolcfg.DefaultConfig.RejournalEvery,
 }
 
 var DefaultTxPool2Config = func(fullCfg *Config) txpoolcfg.Config {
@@ -72,10 +77,14 @@ var DefaultTxPool2Config = func(fullCfg *Config) txpoolcfg.Config {
 	cfg.AccountSlots = pool1Cfg.AccountSlots
 	cfg.BlobSlots = fullCfg.TxPool.BlobSlots
 	cfg.TotalBlobPoolLimit = fullCfg.TxPool.TotalBlobPoolLimit
+	cfg.Ordering = fullCfg.TxPool.Ordering
+	cfg.OrderingFeeBand = fullCfg.TxPool.OrderingFeeBand
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = 5 * time.Minute
 	cfg.TracedSenders = pool1Cfg.TracedSenders
 	cfg.CommitEvery = pool1Cfg.CommitEvery
+	cfg.Journal = pool1Cfg.Journal
+	cfg.RejournalEvery = pool1Cfg.Rejournal
 
 	return cfg
 }
@@ -0,0 +1,206 @@
+// Package logindex - user-defined secondary indices of logs. They are built by LogIndex stage alongside
+// standard address/topic indices and queried by erigon_getLogsByIndex.
+package logindex
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+// IDLength - length of index ID, which prefixes all keys of index in kv.LogCustomIndex
+const IDLength = 8
+
+// Indexer - plugin interface of custom log index. Index is stored in kv.LogCustomIndex:
+// ID + key + block_num_u32 -> bitmap(blockN), same sharded format as kv.LogTopicIndex
+type Indexer interface {
+	Name() string
+	// ID - must change when index definition changes, then index is rebuilt from scratch
+	ID() [IDLength]byte
+	// Key - key of log in this index, false if log is not indexed
+	Key(l *types.Log) ([]byte, bool)
+	// QueryKey - key for RPC lookup by values of indexed fields
+	QueryKey(values []libcommon.Hash) ([]byte, error)
+}
+
+// DBKey - key of index in kv.LogCustomIndex (without shard suffix)
+func DBKey(ix Indexer, key []byte) []byte {
+	id := ix.ID()
+	return append(id[:], key...)
+}
+
+// Progress - last block indexed by given index, false if index is not built yet.
+// Stored in kv.LogCustomIndex by ID - so clearing of table resets it
+func Progress(tx kv.Getter, ix Indexer) (uint64, bool, error) {
+	id := ix.ID()
+	v, err := tx.GetOne(kv.LogCustomIndex, id[:])
+	if err != nil {
+		return 0, false, err
+	}
+	if len(v) < 8 {
+		return 0, false, nil
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+func SaveProgress(tx kv.Putter, ix Indexer, blockNum uint64) error {
+	id := ix.ID()
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, blockNum)
+	return tx.Put(kv.LogCustomIndex, id[:], v)
+}
+
+// Config - declaration of EventIndex, see Load for file format
+type Config struct {
+	Name    string             `json:"name"`
+	Address *libcommon.Address `json:"address,omitempty"` // nil - events of any contract
+	Event   string             `json:"event"`             // event signature: "Transfer(address,address,uint256)"
+	// Keys - fields of event to key on:
+	//   - topic1..topic3 - indexed arguments
+	//   - data0..dataN - 32-byte words of data, for example non-indexed static arguments
+	Keys []string `json:"keys"`
+}
+
+type field struct {
+	topic bool
+	n     int
+}
+
+// EventIndex - indexes logs of one event by values of some of its fields
+type EventIndex struct {
+	cfg    Config
+	id     [IDLength]byte
+	topic0 libcommon.Hash
+	fields []field
+}
+
+func NewEventIndex(cfg Config) (*EventIndex, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("log index: name is required")
+	}
+	if !strings.HasSuffix(cfg.Event, ")") || !strings.Contains(cfg.Event, "(") || strings.ContainsAny(cfg.Event, " \t") {
+		return nil, fmt.Errorf("log index %s: invalid event signature %q, expected canonical form like Transfer(address,address,uint256)", cfg.Name, cfg.Event)
+	}
+	if len(cfg.Keys) == 0 {
+		return nil, fmt.Errorf("log index %s: at least one key field is required", cfg.Name)
+	}
+
+	ix := &EventIndex{cfg: cfg, topic0: crypto.Keccak256Hash([]byte(cfg.Event))}
+	for _, key := range cfg.Keys {
+		var f field
+		var num string
+		if rest, ok := strings.CutPrefix(key, "topic"); ok {
+			f.topic, num = true, rest
+		} else if rest, ok := strings.CutPrefix(key, "data"); ok {
+			num = rest
+		} else {
+			return nil, fmt.Errorf("log index %s: unknown key field %q, expected topic1..topic3 or data0..dataN", cfg.Name, key)
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 0 || (f.topic && (n < 1 || n > 3)) {
+			return nil, fmt.Errorf("log index %s: unknown key field %q, expected topic1..topic3 or data0..dataN", cfg.Name, key)
+		}
+		f.n = n
+		ix.fields = append(ix.fields, f)
+	}
+
+	definition, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	copy(ix.id[:], crypto.Keccak256(definition))
+	return ix, nil
+}
+
+func (ix *EventIndex) Name() string       { return ix.cfg.Name }
+func (ix *EventIndex) ID() [IDLength]byte { return ix.id }
+
+func (ix *EventIndex) Key(l *types.Log) ([]byte, bool) {
+	if len(l.Topics) == 0 || l.Topics[0] != ix.topic0 {
+		return nil, false
+	}
+	if ix.cfg.Address != nil && l.Address != *ix.cfg.Address {
+		return nil, false
+	}
+	key := make([]byte, 0, len(ix.fields)*length.Hash)
+	for _, f := range ix.fields {
+		if f.topic {
+			if f.n >= len(l.Topics) {
+				return nil, false
+			}
+			key = append(key, l.Topics[f.n][:]...)
+			continue
+		}
+		if (f.n+1)*length.Hash > len(l.Data) {
+			return nil, false
+		}
+		key = append(key, l.Data[f.n*length.Hash:(f.n+1)*length.Hash]...)
+	}
+	return key, true
+}
+
+func (ix *EventIndex) QueryKey(values []libcommon.Hash) ([]byte, error) {
+	if len(values) != len(ix.fields) {
+		return nil, fmt.Errorf("log index %s: expected %d values (%s), got %d", ix.cfg.Name, len(ix.fields), strings.Join(ix.cfg.Keys, ","), len(values))
+	}
+	key := make([]byte, 0, len(values)*length.Hash)
+	for _, v := range values {
+		key = append(key, v[:]...)
+	}
+	return key, nil
+}
+
+// Load - reads indices declarations from JSON file, for example:
+//
+//	[{"name": "erc721_by_token", "address": "0x...", "event": "Transfer(address,address,uint256)", "keys": ["topic3"]}]
+func Load(file string) ([]Indexer, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("log index: %w", err)
+	}
+	var cfgs []Config
+	if err := json.Unmarshal(data, &cfgs); err != nil {
+		return nil, fmt.Errorf("log index: parse %s: %w", file, err)
+	}
+	return New(cfgs)
+}
+
+func New(cfgs []Config) ([]Indexer, error) {
+	names := map[string]struct{}{}
+	indexers := make([]Indexer, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if _, ok := names[cfg.Name]; ok {
+			return nil, fmt.Errorf("log index %s: duplicated name", cfg.Name)
+		}
+		names[cfg.Name] = struct{}{}
+		ix, err := NewEventIndex(cfg)
+		if err != nil {
+			return nil, err
+		}
+		indexers = append(indexers, ix)
+	}
+	return indexers, nil
+}
+
+// ByName - index with given name, nil if not found
+func ByName(indexers []Indexer, name string) Indexer {
+	for _, ix := range indexers {
+		if ix.Name() == name {
+			return ix
+		}
+	}
+	return nil
+}
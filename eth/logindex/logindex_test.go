@@ -0,0 +1,64 @@
+package logindex
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+	token := libcommon.HexToAddress("0x06012c8cf97bead5deae237070f9587f8e7a266d")
+	indexers, err := New([]Config{
+		{Name: "nft", Address: &token, Event: "Transfer(address,address,uint256)", Keys: []string{"topic3"}},
+		{Name: "amount", Event: "Transfer(address,address,uint256)", Keys: []string{"topic2", "data0"}},
+	})
+	require.NoError(err)
+	nft, amount := ByName(indexers, "nft"), ByName(indexers, "amount")
+	require.NotEqual(nft.ID(), amount.ID())
+	require.Nil(ByName(indexers, "unknown"))
+
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	from, to, id := libcommon.Hash{1}, libcommon.Hash{2}, libcommon.Hash{3}
+	value := libcommon.Hash{4}
+	nftLog := &types.Log{Address: token, Topics: []libcommon.Hash{transfer, from, to, id}}
+	erc20Log := &types.Log{Address: libcommon.Address{5}, Topics: []libcommon.Hash{transfer, from, to}, Data: value[:]}
+
+	key, ok := nft.Key(nftLog)
+	require.True(ok)
+	queryKey, err := nft.QueryKey([]libcommon.Hash{id})
+	require.NoError(err)
+	require.Equal(queryKey, key)
+	_, ok = nft.Key(erc20Log)
+	require.False(ok)
+
+	key, ok = amount.Key(erc20Log)
+	require.True(ok)
+	require.Equal(append(to[:], value[:]...), key)
+	_, ok = amount.Key(nftLog) // no data
+	require.False(ok)
+	_, err = amount.QueryKey([]libcommon.Hash{to})
+	require.Error(err)
+
+	for _, invalid := range []Config{
+		{Name: "", Event: "Transfer(address,address,uint256)", Keys: []string{"topic1"}},
+		{Name: "a", Event: "Transfer", Keys: []string{"topic1"}},
+		{Name: "a", Event: "Transfer(address, address)", Keys: []string{"topic1"}},
+		{Name: "a", Event: "Transfer(address,address,uint256)"},
+		{Name: "a", Event: "Transfer(address,address,uint256)", Keys: []string{"topic0"}},
+		{Name: "a", Event: "Transfer(address,address,uint256)", Keys: []string{"topic4"}},
+		{Name: "a", Event: "Transfer(address,address,uint256)", Keys: []string{"value"}},
+	} {
+		_, err = NewEventIndex(invalid)
+		require.Error(err, invalid)
+	}
+	_, err = New([]Config{
+		{Name: "a", Event: "Transfer(address,address,uint256)", Keys: []string{"topic1"}},
+		{Name: "a", Event: "Transfer(address,address,uint256)", Keys: []string{"topic2"}},
+	})
+	require.Error(err)
+}
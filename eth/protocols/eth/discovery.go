@@ -22,6 +22,9 @@ import (
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 
 	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/p2p/discover"
+	"github.com/ledgerwatch/erigon/p2p/enode"
 	"github.com/ledgerwatch/erigon/p2p/enr"
 	"github.com/ledgerwatch/erigon/rlp"
 )
@@ -56,3 +59,27 @@ func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	}
 	return &entry.ForkID, nil
 }
+
+// NewNodeFilter returns a dial candidates filter rejecting nodes, which announce a fork ID
+// incompatible with the local chain in the `eth` ENR entry.
+// Nodes without the entry are accepted, because discovery v4 often knows only the node endpoint.
+// forkFilter returns the filter of the current head, it is called for every node and should not rebuild the filter.
+// A nil filter accepts all nodes.
+func NewNodeFilter(forkFilter func() forkid.Filter) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		forkID, err := LoadENRForkID(n.Record())
+		if err != nil {
+			return false
+		}
+		if forkID == nil {
+			return true
+		}
+		filter := forkFilter()
+		return (filter == nil) || (filter(*forkID) == nil)
+	}
+}
+
+// DiscoveryTopic is the discovery v5 topic of the `eth` protocol nodes of the chain with the given genesis.
+func DiscoveryTopic(genesisHash libcommon.Hash) discover.TopicID {
+	return discover.TopicID(crypto.Keccak256Hash([]byte(ProtocolName), genesisHash[:]))
+}
@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/forkid"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/enr"
+)
+
+func TestNodeFilter(t *testing.T) {
+	heightForks := []uint64{100, 200}
+	localGenesis := libcommon.Hash{1}
+
+	makeNode := func(entry enr.Entry) *enode.Node {
+		var r enr.Record
+		if entry != nil {
+			r.Set(entry)
+		}
+		return enode.SignNull(&r, enode.ID{})
+	}
+
+	var status *forkid.Filter
+	filter := NewNodeFilter(func() forkid.Filter {
+		if status == nil {
+			return nil
+		}
+		return *status
+	})
+
+	tests := []struct {
+		name string
+		node *enode.Node
+		want bool
+	}{
+		{"no eth entry", makeNode(nil), true},
+		{"same chain", makeNode(CurrentENREntryFromForks(heightForks, nil, localGenesis, 150, 0)), true},
+		{"same chain, remote ahead", makeNode(CurrentENREntryFromForks(heightForks, nil, localGenesis, 250, 0)), true},
+		{"other chain", makeNode(CurrentENREntryFromForks(heightForks, nil, libcommon.Hash{2}, 150, 0)), false},
+		{"same genesis, other fork", makeNode(CurrentENREntryFromForks([]uint64{120}, nil, localGenesis, 150, 0)), false},
+	}
+
+	// everything passes until the local status is known
+	for _, test := range tests {
+		if !filter(test.node) {
+			t.Errorf("%s: rejected without local status", test.name)
+		}
+	}
+
+	localFilter := forkid.NewFilterFromForks(heightForks, nil, localGenesis, 150, 0)
+	status = &localFilter
+	for _, test := range tests {
+		if got := filter(test.node); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDiscoveryTopic(t *testing.T) {
+	if DiscoveryTopic(libcommon.Hash{1}) == DiscoveryTopic(libcommon.Hash{2}) {
+		t.Error("same topic for different chains")
+	}
+}
@@ -0,0 +1,680 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/membatchwithdb"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/trie"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+
+	// stateLookupSlack defines the ratio by how much a state response can exceed
+	// the requested limit in order to try and avoid breaking up contracts into
+	// multiple packages and proving them.
+	stateLookupSlack = 0.1
+
+	// maxTrieNodeLookups is the maximum number of state trie nodes to serve.
+	maxTrieNodeLookups = 1024
+
+	// proofsPerPeer and proofsPerPeerBurst limit how often one peer can make the node
+	// compute proofs (GetAccountRange, GetStorageRanges, GetTrieNodes not found in cache)
+	proofsPerPeer      = 4
+	proofsPerPeerBurst = 8
+
+	// maxConcurrentProofs is the maximum number of proofs computed at the same time for all peers
+	maxConcurrentProofs = 4
+
+	// responseCacheSize is the number of cached responses with proofs. Responses are capped
+	// by softResponseLimit, so the cache takes up to ~128MB.
+	responseCacheSize = 64
+
+	// stateRootsAge is the number of the latest blocks whose state is served. Peers snap-sync
+	// to a pivot block behind their head (geth - 64 blocks) and keep syncing to newer pivots,
+	// so serving only the latest state would leave them without responses.
+	stateRootsAge = 128
+)
+
+var maxHash = libcommon.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+// Server serves state of the db to snap-syncing peers over snap/1 protocol.
+// Only the serving side is implemented: this node never requests state, so responses are ignored.
+//
+// State is served from HashedAccounts, HashedStorage and Code tables, proofs and storage roots
+// are computed with intermediate hashes of the trie. Requests are answered for the state roots of
+// the last stateRootsAge blocks up to the latest hashed state of the db (the header of IntermediateHashes
+// stage progress). Older states are rewound from the latest one by changesets, the same way as
+// eth_getProof does, so they are served only while changesets of these blocks are not pruned.
+// Other roots get empty responses (same as geth does for roots it doesn't have), and are rejected
+// before any trie work.
+//
+// Computing a proof reads the trie, so responses with proofs are cached per request and state root,
+// every peer is throttled and the number of concurrently computed proofs is capped.
+//
+// TODO: with --history.v3 hashed state and intermediate hashes are not kept in the db, serving
+// would need the commitment domain (and state in snapshot files) - not supported, the server
+// must not be started then.
+type Server struct {
+	db     kv.RoDB
+	dirs   datadir.Dirs
+	logger log.Logger
+
+	rootsLock sync.Mutex
+	roots     stateRoots
+
+	proofs    *semaphore.Weighted
+	responses *lru.Cache[libcommon.Hash, response]
+}
+
+// response - packet of the answer, which can be re-sent to other request by changing its ID
+type response interface {
+	withID(id uint64) interface{}
+}
+
+// stateRoots - blocks of the recent state roots, below the block with hash tip
+type stateRoots struct {
+	tip    libcommon.Hash
+	blocks map[libcommon.Hash]uint64
+}
+
+func NewServer(db kv.RoDB, dirs datadir.Dirs, logger log.Logger) *Server {
+	responses, err := lru.New[libcommon.Hash, response](responseCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &Server{
+		db:        db,
+		dirs:      dirs,
+		logger:    logger,
+		proofs:    semaphore.NewWeighted(maxConcurrentProofs),
+		responses: responses,
+	}
+}
+
+// MakeProtocol creates snap/1 protocol backed by the server. Protocols of all sentries
+// of the node can share one server, and so its cache and limits.
+func (s *Server) MakeProtocol(ctx context.Context) p2p.Protocol {
+	return p2p.Protocol{
+		Name:    ProtocolName,
+		Version: SNAP1,
+		Length:  ProtocolLength,
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+			limiter := rate.NewLimiter(proofsPerPeer, proofsPerPeerBurst)
+			for {
+				if err := s.handleMessage(ctx, limiter, rw); err != nil {
+					s.logger.Trace("[snap] peer disconnected", "name", peer.Name(), "err", err)
+					return err
+				}
+			}
+		},
+		NodeInfo: func() interface{} {
+			return nil
+		},
+		PeerInfo: func(peerID [64]byte) interface{} {
+			return nil
+		},
+	}
+}
+
+func (s *Server) handleMessage(ctx context.Context, limiter *rate.Limiter, rw p2p.MsgReadWriter) *p2p.PeerError {
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		return p2p.NewPeerError(p2p.PeerErrorMessageReceive, p2p.DiscNetworkError, err, "snap: ReadMsg error")
+	}
+	defer msg.Discard()
+	if msg.Size > maxMessageSize {
+		return p2p.NewPeerError(p2p.PeerErrorMessageSizeLimit, p2p.DiscSubprotocolError, nil, fmt.Sprintf("snap: message is too large %d, limit %d", msg.Size, maxMessageSize))
+	}
+
+	var res interface{}
+	var code uint64
+	switch msg.Code {
+	case GetAccountRangeMsg:
+		var req GetAccountRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap: decode GetAccountRange")
+		}
+		key := req
+		key.ID = 0
+		resp, err := s.serveProof(ctx, limiter, req.Root, msg.Code, &key, func(tx kv.Tx, rl *trie.RetainList) (response, error) {
+			accounts, proof, err := AnswerGetAccountRangeQuery(tx, rl, &req)
+			return &AccountRangePacket{Accounts: accounts, Proof: proof}, err
+		})
+		if err != nil {
+			s.logger.Debug("[snap] GetAccountRange", "err", err)
+		}
+		if resp == nil {
+			resp = &AccountRangePacket{}
+		}
+		res, code = resp.withID(req.ID), AccountRangeMsg
+	case GetStorageRangesMsg:
+		var req GetStorageRangesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap: decode GetStorageRanges")
+		}
+		key := req
+		key.ID = 0
+		resp, err := s.serveProof(ctx, limiter, req.Root, msg.Code, &key, func(tx kv.Tx, rl *trie.RetainList) (response, error) {
+			slots, proof, err := AnswerGetStorageRangesQuery(tx, rl, &req)
+			return &StorageRangesPacket{Slots: slots, Proof: proof}, err
+		})
+		if err != nil {
+			s.logger.Debug("[snap] GetStorageRanges", "err", err)
+		}
+		if resp == nil {
+			resp = &StorageRangesPacket{}
+		}
+		res, code = resp.withID(req.ID), StorageRangesMsg
+	case GetByteCodesMsg:
+		var req GetByteCodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap: decode GetByteCodes")
+		}
+		resp := &ByteCodesPacket{ID: req.ID}
+		if err := s.db.View(ctx, func(tx kv.Tx) (err error) {
+			resp.Codes, err = AnswerGetByteCodesQuery(tx, &req)
+			return err
+		}); err != nil {
+			s.logger.Debug("[snap] GetByteCodes", "err", err)
+		}
+		res, code = resp, ByteCodesMsg
+	case GetTrieNodesMsg:
+		var req GetTrieNodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap: decode GetTrieNodes")
+		}
+		key := req
+		key.ID = 0
+		resp, err := s.serveProof(ctx, limiter, req.Root, msg.Code, &key, func(tx kv.Tx, rl *trie.RetainList) (response, error) {
+			nodes, err := AnswerGetTrieNodesQuery(tx, rl, &req)
+			return &TrieNodesPacket{Nodes: nodes}, err
+		})
+		if err != nil {
+			s.logger.Debug("[snap] GetTrieNodes", "err", err)
+		}
+		if resp == nil {
+			resp = &TrieNodesPacket{}
+		}
+		res, code = resp.withID(req.ID), TrieNodesMsg
+	case AccountRangeMsg, StorageRangesMsg, ByteCodesMsg, TrieNodesMsg:
+		// state is never requested by this node
+		return nil
+	default:
+		return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscProtocolError, nil, fmt.Sprintf("snap: unknown message code %d", msg.Code))
+	}
+	if err := p2p.Send(rw, code, res); err != nil {
+		return p2p.NewPeerError(p2p.PeerErrorMessageSend, p2p.DiscNetworkError, err, "snap: Send error")
+	}
+	return nil
+}
+
+// serveProof answers request which needs trie of the state root: from cache, or by answer under
+// the peer's limiter and the cap of concurrent proofs. answer gets the state of the root, and the retain
+// list to load its trie with. Returns nil response if root is not a recent state root of the db.
+// key - the request without ID.
+func (s *Server) serveProof(ctx context.Context, limiter *rate.Limiter, root libcommon.Hash, code uint64, key interface{}, answer func(tx kv.Tx, rl *trie.RetainList) (response, error)) (response, error) {
+	enc, err := rlp.EncodeToBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := crypto.Keccak256Hash([]byte{byte(code)}, enc)
+	if resp, ok := s.responses.Get(cacheKey); ok {
+		return resp, nil
+	}
+
+	// root is checked without waiting, to not hold read transaction while the peer is throttled
+	var known bool
+	if err := s.db.View(ctx, func(tx kv.Tx) (err error) {
+		_, _, known, err = s.stateRootBlock(tx, root)
+		return err
+	}); err != nil || !known {
+		return nil, err
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.proofs.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	defer s.proofs.Release(1)
+
+	var resp response
+	if err := s.db.View(ctx, func(tx kv.Tx) error {
+		// state could move on while waiting
+		block, latest, known, err := s.stateRootBlock(tx, root)
+		if err != nil || !known {
+			return err
+		}
+		if block == latest {
+			resp, err = answer(tx, trie.NewRetainList(0))
+			return err
+		}
+		batch := membatchwithdb.NewMemoryBatch(tx, s.dirs.Tmp, s.logger)
+		defer batch.Rollback()
+		rl, err := s.rewindState(ctx, batch, block, latest)
+		if err != nil {
+			return err
+		}
+		resp, err = answer(batch, rl)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		// root is part of the request, so the response stays valid after the state moves on
+		s.responses.Add(cacheKey, resp)
+	}
+	return resp, nil
+}
+
+// rewindState unwinds hashed state from the latest block to the given one by changesets, and returns
+// the retain list of the keys changed since the block: intermediate hashes of these keys are stale
+// and the trie must be loaded with this list.
+func (s *Server) rewindState(ctx context.Context, batch kv.RwTx, block, latest uint64) (*trie.RetainList, error) {
+	u := &stagedsync.UnwindState{UnwindPoint: block}
+	st := &stagedsync.StageState{BlockNumber: latest}
+	if err := stagedsync.UnwindHashStateStage(u, st, batch, stagedsync.StageHashStateCfg(nil, s.dirs, false), ctx, s.logger); err != nil {
+		return nil, err
+	}
+	rl := trie.NewRetainList(0)
+	trieCfg := stagedsync.StageTrieCfg(nil, false, false, false, s.dirs.Tmp, nil, nil, false, nil)
+	if err := stagedsync.UnwindIntermediateHashes("snap", rl, u, st, batch, trieCfg, ctx.Done(), s.logger); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// stateRootBlock returns the block of the state root, if it's one of the last stateRootsAge
+// blocks up to latest - the block of the latest state. Roots are read once per latest block.
+func (s *Server) stateRootBlock(tx kv.Tx, root libcommon.Hash) (block, latest uint64, ok bool, err error) {
+	latest, ok, err = latestStateBlock(tx)
+	if err != nil || !ok {
+		return 0, 0, false, err
+	}
+	tip, err := rawdb.ReadCanonicalHash(tx, latest)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	s.rootsLock.Lock()
+	defer s.rootsLock.Unlock()
+	if s.roots.tip != tip || s.roots.blocks == nil {
+		s.roots = stateRoots{tip: tip, blocks: make(map[libcommon.Hash]uint64, stateRootsAge)}
+		for i := uint64(0); i < stateRootsAge && i <= latest; i++ {
+			header := rawdb.ReadHeaderByNumber(tx, latest-i)
+			if header == nil {
+				break
+			}
+			// the same root can be the state of consecutive blocks - the latest one is cheaper to serve
+			if _, ok := s.roots.blocks[header.Root]; !ok {
+				s.roots.blocks[header.Root] = latest - i
+			}
+		}
+	}
+	block, ok = s.roots.blocks[root]
+	return block, latest, ok, nil
+}
+
+// latestStateBlock returns the block of the hashed state and intermediate hashes of the db,
+// false if they are not at the same block (hashed state is promoted, but the trie is not yet).
+func latestStateBlock(tx kv.Tx) (uint64, bool, error) {
+	hashStateProgress, err := stages.GetStageProgress(tx, stages.HashState)
+	if err != nil {
+		return 0, false, err
+	}
+	trieProgress, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return 0, false, err
+	}
+	return trieProgress, hashStateProgress == trieProgress, nil
+}
+
+// AnswerGetAccountRangeQuery returns consecutive accounts starting from the origin of the request,
+// and proofs of the origin and the last returned account. rl - the retain list to load the trie with
+// (keys with stale intermediate hashes), empty for the latest state.
+func AnswerGetAccountRangeQuery(tx kv.Tx, rl *trie.RetainList, req *GetAccountRangePacket) ([]*AccountData, [][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	c, err := tx.Cursor(kv.HashedAccounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+
+	pr := trie.NewRangeProofRetainer(rl)
+	originHex := pr.AddKey(req.Origin[:])
+	var (
+		keys  [][]byte
+		accs  []*accounts.Account
+		hexes [][]byte
+		size  uint64
+	)
+	for k, v, err := c.Seek(req.Origin[:]); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return nil, nil, err
+		}
+		acc := &accounts.Account{}
+		if err := acc.DecodeForStorage(v); err != nil {
+			return nil, nil, fmt.Errorf("decode account %x: %w", k, err)
+		}
+		keys = append(keys, libcommon.Copy(k))
+		accs = append(accs, acc)
+		hexes = append(hexes, pr.AddKey(k))
+		// storage root is not known yet, so estimate size of the slim account by its storage encoding
+		size += uint64(length.Hash + len(v) + length.Hash)
+		if bytes.Compare(k, req.Limit[:]) >= 0 {
+			break
+		}
+		if size > req.Bytes {
+			break
+		}
+	}
+
+	root, err := loadTrie(tx, rl, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root != req.Root {
+		return nil, nil, nil
+	}
+	data := make([]*AccountData, len(keys))
+	for i, k := range keys {
+		storageRoot, ok := pr.StorageRoot(hexes[i])
+		if !ok {
+			return nil, nil, fmt.Errorf("storage root of account %x is not found", k)
+		}
+		body, err := slimAccountRLP(accs[i], storageRoot)
+		if err != nil {
+			return nil, nil, err
+		}
+		data[i] = &AccountData{Hash: libcommon.BytesToHash(k), Body: body}
+	}
+	var proof [][]byte
+	if len(hexes) > 0 {
+		proof = pr.Proof(originHex, hexes[len(hexes)-1])
+	} else {
+		proof = pr.Proof(originHex)
+	}
+	return data, proof, nil
+}
+
+// AnswerGetStorageRangesQuery returns storage slots of the requested accounts. Origin and limit of
+// the request apply only to the first account. If slots of the last account are returned only partially
+// (or from non-zero origin) - the response has proofs of its origin and the last returned slot.
+func AnswerGetStorageRangesQuery(tx kv.Tx, rl *trie.RetainList, req *GetStorageRangesPacket) ([][]*StorageData, [][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	// Calculate the hard limit at which to abort, even if mid storage trie
+	hardLimit := uint64(float64(req.Bytes) * (1 + stateLookupSlack))
+
+	c, err := tx.CursorDupSort(kv.HashedStorage)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Close()
+
+	pr := trie.NewRangeProofRetainer(rl)
+	var (
+		slots              [][]*StorageData
+		size               uint64
+		proveFrom, proveTo []byte
+		accAndInc          [length.Hash + length.Incarnation]byte
+		storageKey         [length.Hash + length.Incarnation + length.Hash]byte
+	)
+	for _, accHash := range req.Accounts {
+		// If we've exceeded the requested data limit, abort without opening
+		// a new storage range (that we'd need to prove due to exceeded size)
+		if size >= req.Bytes {
+			break
+		}
+		// The first account might start from a different origin and end sooner
+		var origin libcommon.Hash
+		if len(req.Origin) > 0 {
+			origin, req.Origin = libcommon.BytesToHash(req.Origin), nil
+		}
+		limit := maxHash
+		if len(req.Limit) > 0 {
+			limit, req.Limit = libcommon.BytesToHash(req.Limit), nil
+		}
+		enc, err := tx.GetOne(kv.HashedAccounts, accHash[:])
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(enc) == 0 {
+			break
+		}
+		acc := &accounts.Account{}
+		if err := acc.DecodeForStorage(enc); err != nil {
+			return nil, nil, fmt.Errorf("decode account %x: %w", accHash, err)
+		}
+		copy(accAndInc[:], accHash[:])
+		binary.BigEndian.PutUint64(accAndInc[length.Hash:], acc.Incarnation)
+
+		var (
+			storage []*StorageData
+			last    libcommon.Hash
+			abort   bool
+		)
+		for v, err := c.SeekBothRange(accAndInc[:], origin[:]); v != nil; _, v, err = c.NextDup() {
+			if err != nil {
+				return nil, nil, err
+			}
+			if size >= hardLimit {
+				abort = true
+				break
+			}
+			body, err := rlp.EncodeToBytes(v[length.Hash:])
+			if err != nil {
+				return nil, nil, err
+			}
+			last = libcommon.BytesToHash(v[:length.Hash])
+			storage = append(storage, &StorageData{Hash: last, Body: body})
+			size += uint64(length.Hash + len(body))
+			if bytes.Compare(last[:], limit[:]) >= 0 {
+				break
+			}
+		}
+		if len(storage) > 0 {
+			slots = append(slots, storage)
+		}
+		// If the request was capped or started not from the beginning, the range of
+		// the last account needs proving
+		if origin != (libcommon.Hash{}) || (abort && len(storage) > 0) {
+			copy(storageKey[:], accAndInc[:])
+			copy(storageKey[length.Hash+length.Incarnation:], origin[:])
+			proveFrom = pr.AddKey(storageKey[:])
+			if len(storage) > 0 {
+				copy(storageKey[length.Hash+length.Incarnation:], last[:])
+				proveTo = pr.AddKey(storageKey[:])
+			}
+			break
+		}
+	}
+
+	root, err := loadTrie(tx, rl, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root != req.Root {
+		return nil, nil, nil
+	}
+	var proof [][]byte
+	if proveFrom != nil {
+		if proveTo != nil {
+			proof = pr.Proof(proveFrom, proveTo)
+		} else {
+			proof = pr.Proof(proveFrom)
+		}
+	}
+	return slots, proof, nil
+}
+
+// AnswerGetByteCodesQuery returns requested bytecodes, skipping unknown ones.
+func AnswerGetByteCodesQuery(tx kv.Getter, req *GetByteCodesPacket) ([][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+	var (
+		codes [][]byte
+		size  uint64
+	)
+	for _, hash := range req.Hashes {
+		if hash == trie.EmptyCodeHash {
+			// Peers should not request the empty code, but if they do, at
+			// least sent them back a correct response without db lookups
+			codes = append(codes, []byte{})
+		} else {
+			code, err := tx.GetOne(kv.Code, hash[:])
+			if err != nil {
+				return nil, err
+			}
+			if len(code) > 0 {
+				codes = append(codes, libcommon.Copy(code))
+				size += uint64(len(code))
+			}
+		}
+		if size > req.Bytes {
+			break
+		}
+	}
+	return codes, nil
+}
+
+// AnswerGetTrieNodesQuery returns trie nodes by their paths. Nodes are built from intermediate hashes
+// of the trie, because the db doesn't store nodes. Serving stops at the first unknown node.
+func AnswerGetTrieNodesQuery(tx kv.Tx, rl *trie.RetainList, req *GetTrieNodesPacket) ([][]byte, error) {
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	pr := trie.NewRangeProofRetainer(rl)
+	var paths [][]byte
+	var accAndInc [length.Hash + length.Incarnation]byte
+Paths:
+	for _, pathset := range req.Paths {
+		switch len(pathset) {
+		case 0:
+			// Ensure we penalize invalid requests
+			return nil, fmt.Errorf("zero-item pathset requested")
+		case 1:
+			// If we're only retrieving an account trie node, fetch it directly
+			paths = append(paths, compactToHex(pathset[0]))
+		default:
+			// Storage slots requested, open the storage trie and retrieve from there
+			if len(pathset[0]) != length.Hash {
+				break Paths
+			}
+			enc, err := tx.GetOne(kv.HashedAccounts, pathset[0])
+			if err != nil {
+				return nil, err
+			}
+			if len(enc) == 0 {
+				break Paths
+			}
+			acc := &accounts.Account{}
+			if err := acc.DecodeForStorage(enc); err != nil {
+				return nil, fmt.Errorf("decode account %x: %w", pathset[0], err)
+			}
+			copy(accAndInc[:], pathset[0])
+			binary.BigEndian.PutUint64(accAndInc[length.Hash:], acc.Incarnation)
+			prefix := pr.AddKey(accAndInc[:])
+			for _, path := range pathset[1:] {
+				paths = append(paths, append(libcommon.Copy(prefix), compactToHex(path)...))
+			}
+		}
+		if len(paths) >= maxTrieNodeLookups {
+			paths = paths[:maxTrieNodeLookups]
+			break
+		}
+	}
+	for _, path := range paths {
+		pr.AddHex(path)
+	}
+
+	root, err := loadTrie(tx, rl, pr)
+	if err != nil {
+		return nil, err
+	}
+	if root != req.Root {
+		return nil, nil
+	}
+	var (
+		nodes [][]byte
+		size  uint64
+	)
+	for _, path := range paths {
+		node := pr.Node(path)
+		if node == nil {
+			break
+		}
+		nodes = append(nodes, node)
+		size += uint64(len(node))
+		if size > req.Bytes {
+			break
+		}
+	}
+	return nodes, nil
+}
+
+// loadTrie computes the state root of the db, retaining nodes on the paths to the keys added to pr
+func loadTrie(tx kv.Tx, rl *trie.RetainList, pr *trie.RangeProofRetainer) (libcommon.Hash, error) {
+	loader := trie.NewFlatDBTrieLoader[libcommon.Hash]("snap", rl, nil, nil, false, trie.NewRootHashAggregator(nil, nil, false))
+	loader.Receiver().(*trie.RootHashAggregator).SetProofRetainer(pr)
+	return loader.Result(tx, nil)
+}
+
+// compactToHex converts a node path in COMPACT encoding to HEX encoding without terminator
+func compactToHex(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	kb := trie.CompactToKeybytes(compact)
+	kb.Terminating = false
+	return kb.ToHex()
+}
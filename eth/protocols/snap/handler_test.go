@@ -0,0 +1,354 @@
+package snap
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/dbutils"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/turbo/stages/mock"
+	"github.com/ledgerwatch/erigon/turbo/trie"
+)
+
+type testState struct {
+	tx       kv.RwTx
+	root     libcommon.Hash
+	accTrie  *trie.Trie
+	accounts []libcommon.Hash // sorted
+	storage  map[libcommon.Hash]*trie.Trie
+	slots    map[libcommon.Hash]int
+	roots    map[libcommon.Hash]libcommon.Hash
+	codes    []libcommon.Hash
+}
+
+func newTestState(t *testing.T) *testState {
+	t.Helper()
+	_, tx := memdb.NewTestTx(t)
+	s := &testState{
+		tx:      tx,
+		accTrie: trie.New(trie.EmptyRoot),
+		storage: map[libcommon.Hash]*trie.Trie{},
+		slots:   map[libcommon.Hash]int{},
+		roots:   map[libcommon.Hash]libcommon.Hash{},
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var addrHash libcommon.Hash
+		r.Read(addrHash[:])
+		acc := accounts.NewAccount()
+		acc.Nonce = uint64(i)
+		acc.Balance.SetUint64(uint64(r.Intn(1_000_000)))
+		if i%3 == 0 {
+			code := make([]byte, 1+r.Intn(100))
+			r.Read(code)
+			acc.CodeHash = crypto.Keccak256Hash(code)
+			require.NoError(t, tx.Put(kv.Code, acc.CodeHash[:], code))
+			s.codes = append(s.codes, acc.CodeHash)
+		}
+		if i%10 == 0 {
+			acc.Incarnation = 1
+			st := trie.New(trie.EmptyRoot)
+			slots := 1 + r.Intn(10*i+1)
+			for j := 0; j < slots; j++ {
+				var loc libcommon.Hash
+				r.Read(loc[:])
+				val := make([]byte, 1+r.Intn(32))
+				r.Read(val)
+				val[0] |= 1
+				require.NoError(t, tx.Put(kv.HashedStorage, dbutils.GenerateCompositeStorageKey(addrHash, acc.Incarnation, loc), val))
+				st.Update(loc[:], val)
+			}
+			acc.Root = st.Hash()
+			s.storage[addrHash] = st
+			s.slots[addrHash] = slots
+		}
+		s.roots[addrHash] = acc.Root
+		enc := make([]byte, acc.EncodingLengthForStorage())
+		acc.EncodeForStorage(enc)
+		require.NoError(t, tx.Put(kv.HashedAccounts, addrHash[:], enc))
+		s.accTrie.UpdateAccount(addrHash[:], &acc)
+		s.accounts = append(s.accounts, addrHash)
+	}
+	sort.Slice(s.accounts, func(i, j int) bool { return bytes.Compare(s.accounts[i][:], s.accounts[j][:]) < 0 })
+
+	var err error
+	s.root, err = trie.CalcRoot("test", tx)
+	require.NoError(t, err)
+	require.Equal(t, s.accTrie.Hash(), s.root)
+	return s
+}
+
+// requireProves checks that proof has all nodes of the proof of key made by in-memory trie
+func requireProves(t *testing.T, tr *trie.Trie, proof [][]byte, key libcommon.Hash) {
+	t.Helper()
+	expected, err := tr.Prove(key[:], 0, false)
+	require.NoError(t, err)
+	for _, node := range expected {
+		require.Contains(t, proof, node, "key %x", key)
+	}
+}
+
+func TestAnswerGetAccountRangeQuery(t *testing.T) {
+	s := newTestState(t)
+
+	var served []libcommon.Hash
+	origin := libcommon.Hash{}
+	for i := 0; ; i++ {
+		require.Less(t, i, len(s.accounts), "too many requests")
+		accs, proof, err := AnswerGetAccountRangeQuery(s.tx, trie.NewRetainList(0), &GetAccountRangePacket{Root: s.root, Origin: origin, Limit: maxHash, Bytes: 1000})
+		require.NoError(t, err)
+		if len(accs) == 0 {
+			break
+		}
+		requireProves(t, s.accTrie, proof, origin)
+		requireProves(t, s.accTrie, proof, accs[len(accs)-1].Hash)
+		for _, acc := range accs {
+			var slim slimAccount
+			require.NoError(t, rlp.DecodeBytes(acc.Body, &slim))
+			if root := s.roots[acc.Hash]; root == trie.EmptyRoot {
+				require.Empty(t, slim.Root)
+			} else {
+				require.Equal(t, root[:], slim.Root)
+			}
+			served = append(served, acc.Hash)
+		}
+		next := new(uint256.Int).SetBytes(accs[len(accs)-1].Hash[:])
+		origin = next.AddUint64(next, 1).Bytes32()
+	}
+	require.Equal(t, s.accounts, served)
+
+	// limit is included into the range
+	accs, _, err := AnswerGetAccountRangeQuery(s.tx, trie.NewRetainList(0), &GetAccountRangePacket{Root: s.root, Limit: s.accounts[9], Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, 10, len(accs))
+
+	// unknown root
+	accs, proof, err := AnswerGetAccountRangeQuery(s.tx, trie.NewRetainList(0), &GetAccountRangePacket{Root: libcommon.Hash{1}, Limit: maxHash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, accs)
+	require.Empty(t, proof)
+}
+
+func TestAnswerGetStorageRangesQuery(t *testing.T) {
+	s := newTestState(t)
+	var accounts []libcommon.Hash
+	var biggest libcommon.Hash
+	for _, acc := range s.accounts {
+		if s.slots[acc] == 0 {
+			continue
+		}
+		accounts = append(accounts, acc)
+		if s.slots[acc] > s.slots[biggest] {
+			biggest = acc
+		}
+	}
+
+	// whole storage of few accounts - no proofs
+	slots, proof, err := AnswerGetStorageRangesQuery(s.tx, trie.NewRetainList(0), &GetStorageRangesPacket{Root: s.root, Accounts: accounts[:3], Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(slots))
+	for i := range slots {
+		require.Equal(t, s.slots[accounts[i]], len(slots[i]))
+	}
+	require.Empty(t, proof)
+
+	// large contract - served by parts with proofs
+	var served int
+	var origin []byte
+	for i := 0; ; i++ {
+		require.Less(t, i, s.slots[biggest], "too many requests")
+		slots, proof, err := AnswerGetStorageRangesQuery(s.tx, trie.NewRetainList(0), &GetStorageRangesPacket{Root: s.root, Accounts: []libcommon.Hash{biggest}, Origin: origin, Bytes: 5000})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(slots))
+		served += len(slots[0])
+		last := slots[0][len(slots[0])-1].Hash
+		if served == s.slots[biggest] {
+			if len(origin) > 0 {
+				requireProves(t, s.storage[biggest], proof, libcommon.BytesToHash(origin))
+			}
+			break
+		}
+		require.NotEmpty(t, proof)
+		requireProves(t, s.storage[biggest], proof, libcommon.BytesToHash(origin))
+		requireProves(t, s.storage[biggest], proof, last)
+		for _, slot := range slots[0] {
+			var val []byte
+			require.NoError(t, rlp.DecodeBytes(slot.Body, &val))
+			expected, _ := s.storage[biggest].Get(slot.Hash[:])
+			require.Equal(t, expected, val)
+		}
+		next := new(uint256.Int).SetBytes(last[:])
+		next = next.AddUint64(next, 1)
+		origin = next.Bytes()
+		origin = append(make([]byte, 32-len(origin)), origin...)
+	}
+
+	// unknown root
+	slots, _, err = AnswerGetStorageRangesQuery(s.tx, trie.NewRetainList(0), &GetStorageRangesPacket{Root: libcommon.Hash{1}, Accounts: accounts[:3], Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, slots)
+}
+
+func TestAnswerGetByteCodesQuery(t *testing.T) {
+	s := newTestState(t)
+	codes, err := AnswerGetByteCodesQuery(s.tx, &GetByteCodesPacket{Hashes: []libcommon.Hash{s.codes[0], trie.EmptyCodeHash, {1}, s.codes[1]}, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(codes))
+	require.Equal(t, s.codes[0], crypto.Keccak256Hash(codes[0]))
+	require.Empty(t, codes[1])
+	require.Equal(t, s.codes[1], crypto.Keccak256Hash(codes[2]))
+
+	codes, err = AnswerGetByteCodesQuery(s.tx, &GetByteCodesPacket{Hashes: s.codes, Bytes: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(codes))
+}
+
+func TestAnswerGetTrieNodesQuery(t *testing.T) {
+	s := newTestState(t)
+	var contract libcommon.Hash
+	for _, acc := range s.accounts {
+		if s.slots[acc] > 1 {
+			contract = acc
+			break
+		}
+	}
+	proof, err := s.accTrie.Prove(s.accounts[0][:], 0, false)
+	require.NoError(t, err)
+	require.Greater(t, len(proof), 2)
+
+	nodes, err := AnswerGetTrieNodesQuery(s.tx, trie.NewRetainList(0), &GetTrieNodesPacket{
+		Root: s.root,
+		Paths: []TrieNodePathSet{
+			{{}},                           // root
+			{{0x10 | s.accounts[0][0]>>4}}, // child of root branch on the path to the first account
+			{contract[:], {}},              // storage root
+			{append([]byte{0}, s.accounts[0][:20]...)}, // inside of the leaf of the first account - no such node
+			{{}},
+		},
+		Bytes: softResponseLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(nodes))
+	require.Equal(t, s.root, crypto.Keccak256Hash(nodes[0]))
+	require.Equal(t, proof[1], nodes[1])
+	require.Equal(t, s.roots[contract], crypto.Keccak256Hash(nodes[2]))
+
+	_, err = AnswerGetTrieNodesQuery(s.tx, trie.NewRetainList(0), &GetTrieNodesPacket{Root: s.root, Paths: []TrieNodePathSet{{}}, Bytes: softResponseLimit})
+	require.Error(t, err)
+}
+
+func TestServerServeProof(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	ctx := context.Background()
+	header := &types.Header{Number: big.NewInt(1), Root: libcommon.Hash{1}}
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		if err := rawdb.WriteHeader(tx, header); err != nil {
+			return err
+		}
+		if err := rawdb.WriteCanonicalHash(tx, header.Hash(), 1); err != nil {
+			return err
+		}
+		return stages.SaveStageProgress(tx, stages.HashState, 1)
+	}))
+
+	s := NewServer(db, datadir.New(t.TempDir()), log.New())
+	limiter := rate.NewLimiter(proofsPerPeer, proofsPerPeerBurst)
+	var answered int
+	answer := func(tx kv.Tx, rl *trie.RetainList) (response, error) {
+		answered++
+		return &TrieNodesPacket{Nodes: [][]byte{{1}}}, nil
+	}
+	req := &GetTrieNodesPacket{Root: header.Root, Paths: []TrieNodePathSet{{{1}}}, Bytes: softResponseLimit}
+
+	// trie is behind hashed state - no state root
+	resp, err := s.serveProof(ctx, limiter, header.Root, GetTrieNodesMsg, req, answer)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return stages.SaveStageProgress(tx, stages.IntermediateHashes, 1)
+	}))
+
+	// unknown root is rejected without answering
+	resp, err = s.serveProof(ctx, limiter, libcommon.Hash{2}, GetTrieNodesMsg, req, answer)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Equal(t, 0, answered)
+
+	for i := 0; i < 3; i++ {
+		resp, err = s.serveProof(ctx, limiter, header.Root, GetTrieNodesMsg, req, answer)
+		require.NoError(t, err)
+		require.Equal(t, &TrieNodesPacket{ID: 5, Nodes: [][]byte{{1}}}, resp.withID(5))
+	}
+	require.Equal(t, 1, answered, "response must be cached")
+}
+
+func TestServerServeRecentStates(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		signer = types.LatestSignerForChainID(nil)
+		blocks = 5
+	)
+	m := mock.MockWithGenesis(t, &types.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(1e18)}},
+	}, key, false)
+	if m.HistoryV3 {
+		t.Skip("hashed state is not kept with --history.v3")
+	}
+	// every block creates a new account
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, blocks, func(i int, b *core.BlockGen) {
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), libcommon.Address{byte(i + 1)}, uint256.NewInt(1000), params.TxGas, new(uint256.Int), nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	ctx := context.Background()
+	s := NewServer(m.DB, m.Dirs, log.New())
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	for n := 0; n <= blocks; n++ {
+		var header *types.Header
+		require.NoError(t, m.DB.View(ctx, func(tx kv.Tx) error {
+			header = rawdb.ReadHeaderByNumber(tx, uint64(n))
+			return nil
+		}))
+		req := &GetAccountRangePacket{Root: header.Root, Limit: maxHash, Bytes: softResponseLimit}
+		resp, err := s.serveProof(ctx, limiter, req.Root, GetAccountRangeMsg, req, func(tx kv.Tx, rl *trie.RetainList) (response, error) {
+			accs, proof, err := AnswerGetAccountRangeQuery(tx, rl, req)
+			return &AccountRangePacket{Accounts: accs, Proof: proof}, err
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp, "block %d", n)
+		served := map[libcommon.Hash]struct{}{}
+		for _, acc := range resp.(*AccountRangePacket).Accounts {
+			served[acc.Hash] = struct{}{}
+		}
+		require.NotEmpty(t, served, "state of block %d must be served", n)
+		for i := 1; i <= blocks; i++ {
+			_, ok := served[crypto.Keccak256Hash(libcommon.Address{byte(i)}.Bytes())]
+			require.Equal(t, i <= n, ok, "account created in block %d, state of block %d", i, n)
+		}
+	}
+}
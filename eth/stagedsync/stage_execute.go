@@ -14,6 +14,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ledgerwatch/erigon-lib/chain"
+	"github.com/ledgerwatch/erigon-lib/chain/snapcfg"
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/cmp"
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
@@ -294,6 +295,9 @@ func ExecBlockV3(s *StageState, u Unwinder, txc wrap.TxContainer, toBlock uint64
 	if to <= s.BlockNumber {
 		return nil
 	}
+	if err := checkDownloadPolicy(logPrefix, cfg.blockReader.FreezingCfg().DownloadPolicy, true, s.BlockNumber+1); err != nil {
+		return err
+	}
 	if to > s.BlockNumber+16 {
 		logger.Info(fmt.Sprintf("[%s] Blocks execution", logPrefix), "from", s.BlockNumber, "to", to)
 	}
@@ -375,6 +379,21 @@ func senderStageProgress(tx kv.Tx, db kv.RoDB) (prevStageProgress uint64, err er
 
 // ================ Erigon3 End ================
 
+// checkDownloadPolicy - blocks starting from `from` are going to be executed, but snapcfg.DownloadPolicy may have skipped their bodies.
+// Without history v3 the state is not downloaded, so all blocks are executed from genesis
+func checkDownloadPolicy(logPrefix string, policy snapcfg.DownloadPolicy, historyV3 bool, from uint64) error {
+	if len(policy) == 0 {
+		return nil
+	}
+	if !historyV3 {
+		return fmt.Errorf("[%s] snapshots download policy %q requires history v3", logPrefix, policy)
+	}
+	if first := policy.FirstFullBlock(); from < first {
+		return fmt.Errorf("[%s] can't execute block %d: snapshots download policy %q skipped blocks below %d", logPrefix, from, policy, first)
+	}
+	return nil
+}
+
 func SpawnExecuteBlocksStage(s *StageState, u Unwinder, txc wrap.TxContainer, toBlock uint64, ctx context.Context, cfg ExecuteBlockCfg, initialCycle bool, logger log.Logger) (err error) {
 	if cfg.historyV3 {
 		if err = ExecBlockV3(s, u, txc, toBlock, ctx, cfg, initialCycle, logger); err != nil {
@@ -413,6 +432,9 @@ func SpawnExecuteBlocksStage(s *StageState, u Unwinder, txc wrap.TxContainer, to
 	if to <= s.BlockNumber {
 		return nil
 	}
+	if err := checkDownloadPolicy(logPrefix, cfg.blockReader.FreezingCfg().DownloadPolicy, false, s.BlockNumber+1); err != nil {
+		return err
+	}
 
 	if to > s.BlockNumber+16 {
 		logger.Info(fmt.Sprintf("[%s] Blocks execution", logPrefix), "from", s.BlockNumber, "to", to)
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ledgerwatch/erigon-lib/chain/snapcfg"
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/turbo/snapshotsync/freezeblocks"
 )
 
 func TestExec(t *testing.T) {
@@ -249,3 +251,42 @@ func TestExec22(t *testing.T) {
 		compareCurrentState(t, newAgg(t, logger), tx1, tx2, kv.PlainState, kv.PlainContractCode)
 	})
 }
+
+func TestExecWithDownloadPolicy(t *testing.T) {
+	logger := log.New()
+	ctx := context.Background()
+	policy, err := snapcfg.ParseDownloadPolicy("bodies=600000,transactions=600000")
+	require.NoError(t, err)
+	snapshots := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true, DownloadPolicy: policy}, t.TempDir(), 0, logger)
+	defer snapshots.Close()
+	blockReader := freezeblocks.NewBlockReader(snapshots, nil)
+
+	t.Run("NoHistoryV3", func(t *testing.T) {
+		require, tx := require.New(t), memdb.BeginRw(t, memdb.NewTestDB(t))
+		require.NoError(stages.SaveStageProgress(tx, stages.Senders, 700_000))
+
+		cfg := ExecuteBlockCfg{blockReader: blockReader}
+		s := &StageState{ID: stages.Execution, BlockNumber: 650_000}
+		err := SpawnExecuteBlocksStage(s, nil, wrap.TxContainer{Tx: tx}, 0, ctx, cfg, true, logger)
+		require.ErrorContains(err, "requires history v3")
+	})
+	t.Run("HistoryV3", func(t *testing.T) {
+		_, db, _ := temporal.NewTestDB(t, datadir.New(t.TempDir()), nil)
+		require, tx := require.New(t), memdb.BeginRw(t, db)
+		require.NoError(stages.SaveStageProgress(tx, stages.Senders, 700_000))
+
+		// the state is downloaded below the skipped blocks: they would have to be executed
+		cfg := ExecuteBlockCfg{historyV3: true, agg: newAgg(t, logger), db: db, blockReader: blockReader}
+		s := &StageState{ID: stages.Execution, BlockNumber: 500_000}
+		err := SpawnExecuteBlocksStage(s, nil, wrap.TxContainer{Tx: tx}, 0, ctx, cfg, false, logger)
+		require.ErrorContains(err, "skipped blocks below 600000")
+	})
+	t.Run("Check", func(t *testing.T) {
+		require := require.New(t)
+		require.NoError(checkDownloadPolicy("", nil, false, 1))
+		require.ErrorContains(checkDownloadPolicy("", policy, false, 600_000), "requires history v3")
+		require.ErrorContains(checkDownloadPolicy("", policy, true, 599_999), "skipped blocks below 600000")
+		require.NoError(checkDownloadPolicy("", policy, true, 600_000))
+		require.NoError(checkDownloadPolicy("", policy, true, 650_001))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"runtime"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/ethdb/cbor"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 )
@@ -38,9 +40,10 @@ type LogIndexCfg struct {
 	bufLimit         datasize.ByteSize
 	flushEvery       time.Duration
 	noPruneContracts map[libcommon.Address]bool
+	indexers         []logindex.Indexer // user-defined indices, stored in kv.LogCustomIndex
 }
 
-func StageLogIndexCfg(db kv.RwDB, prune prune.Mode, tmpDir string, noPruneContracts map[libcommon.Address]bool) LogIndexCfg {
+func StageLogIndexCfg(db kv.RwDB, prune prune.Mode, tmpDir string, noPruneContracts map[libcommon.Address]bool, indexers []logindex.Indexer) LogIndexCfg {
 	return LogIndexCfg{
 		db:               db,
 		prune:            prune,
@@ -48,6 +51,7 @@ func StageLogIndexCfg(db kv.RwDB, prune prune.Mode, tmpDir string, noPruneContra
 		flushEvery:       bitmapsFlushEvery,
 		tmpdir:           tmpDir,
 		noPruneContracts: noPruneContracts,
+		indexers:         indexers,
 	}
 }
 
@@ -125,6 +129,12 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 	defer collectorTopics.Close()
 	collectorAddrs := etl.NewCollector(logPrefix, cfg.tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), logger)
 	defer collectorAddrs.Close()
+	custom, err := newCustomLogIndices(tx, cfg.indexers)
+	if err != nil {
+		return err
+	}
+	collectorCustom := etl.NewCollector(logPrefix, cfg.tmpdir, etl.NewSortableBuffer(etl.BufferOptimalSize), logger)
+	defer collectorCustom.Close()
 
 	reader := bytes.NewReader(nil)
 
@@ -132,7 +142,15 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 		logger.Info(fmt.Sprintf("[%s] processing", logPrefix), "from", start, "to", endBlock)
 	}
 
-	for k, v, err := logs.Seek(dbutils.LogKey(start, 0)); k != nil; k, v, err = logs.Next() {
+	// newly added custom indices start from older blocks
+	seekFrom := start
+	if customStart := custom.startBlock(); customStart < seekFrom {
+		logger.Info(fmt.Sprintf("[%s] building custom log indices", logPrefix), "from", customStart, "to", endBlock)
+		seekFrom = customStart
+	}
+	lastBlockNum := start
+
+	for k, v, err := logs.Seek(dbutils.LogKey(seekFrom, 0)); k != nil; k, v, err = logs.Next() {
 		if err != nil {
 			return err
 		}
@@ -169,7 +187,15 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 				}
 				addresses = map[string]*roaring.Bitmap{}
 			}
+
+			if needFlush(custom.bitmaps, cfg.bufLimit) {
+				if err := flushBitmaps(collectorCustom, custom.bitmaps); err != nil {
+					return err
+				}
+				custom.bitmaps = map[string]*roaring.Bitmap{}
+			}
 		}
+		lastBlockNum = blockNum
 
 		var ll types.Logs
 		reader.Reset(v)
@@ -181,6 +207,10 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 			if l.BlockNumber < pruneBlock && cfg.noPruneContracts != nil && !cfg.noPruneContracts[l.Address] {
 				continue
 			}
+			custom.add(blockNum, l)
+			if blockNum < start {
+				continue
+			}
 			for _, topic := range l.Topics {
 				topicStr := string(topic.Bytes())
 				m, ok := topics[topicStr]
@@ -207,6 +237,9 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 	if err := flushBitmaps(collectorAddrs, addresses); err != nil {
 		return err
 	}
+	if err := flushBitmaps(collectorCustom, custom.bitmaps); err != nil {
+		return err
+	}
 
 	var currentBitmap = roaring.New()
 	var buf = bytes.NewBuffer(nil)
@@ -250,6 +283,75 @@ func promoteLogIndex(logPrefix string, tx kv.RwTx, start uint64, endBlock uint64
 		return err
 	}
 
+	if err := collectorCustom.Load(tx, kv.LogCustomIndex, loaderFunc, etl.TransformArgs{Quit: quit}); err != nil {
+		return err
+	}
+	if endBlock == 0 {
+		endBlock = lastBlockNum
+	}
+	return custom.saveProgress(tx, endBlock)
+}
+
+// customLogIndices - state of user-defined indices while LogIndex stage builds them
+type customLogIndices struct {
+	indexers []logindex.Indexer
+	from     []uint64 // first not indexed block of each index
+	bitmaps  map[string]*roaring.Bitmap
+}
+
+func newCustomLogIndices(tx kv.Getter, indexers []logindex.Indexer) (*customLogIndices, error) {
+	c := &customLogIndices{indexers: indexers, from: make([]uint64, len(indexers)), bitmaps: map[string]*roaring.Bitmap{}}
+	for i, ix := range indexers {
+		progress, ok, err := logindex.Progress(tx, ix)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.from[i] = progress + 1
+		}
+	}
+	return c, nil
+}
+
+// startBlock - first block which some of indices didn't see yet
+func (c *customLogIndices) startBlock() uint64 {
+	start := uint64(math.MaxUint64)
+	for _, from := range c.from {
+		if from < start {
+			start = from
+		}
+	}
+	return start
+}
+
+func (c *customLogIndices) add(blockNum uint64, l *types.Log) {
+	for i, ix := range c.indexers {
+		if blockNum < c.from[i] {
+			continue
+		}
+		key, ok := ix.Key(l)
+		if !ok {
+			continue
+		}
+		k := string(logindex.DBKey(ix, key))
+		m, ok := c.bitmaps[k]
+		if !ok {
+			m = roaring.New()
+			c.bitmaps[k] = m
+		}
+		m.Add(uint32(blockNum))
+	}
+}
+
+func (c *customLogIndices) saveProgress(tx kv.RwTx, blockNum uint64) error {
+	for i, ix := range c.indexers {
+		if blockNum < c.from[i] {
+			continue
+		}
+		if err := logindex.SaveProgress(tx, ix, blockNum); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -283,6 +385,7 @@ func UnwindLogIndex(u *UnwindState, s *StageState, tx kv.RwTx, cfg LogIndexCfg,
 func unwindLogIndex(logPrefix string, db kv.RwTx, to uint64, cfg LogIndexCfg, quitCh <-chan struct{}) error {
 	topics := map[string]struct{}{}
 	addrs := map[string]struct{}{}
+	custom := map[string]struct{}{}
 
 	reader := bytes.NewReader(nil)
 	c, err := db.Cursor(kv.Log)
@@ -309,6 +412,11 @@ func unwindLogIndex(logPrefix string, db kv.RwTx, to uint64, cfg LogIndexCfg, qu
 				topics[string(topic.Bytes())] = struct{}{}
 			}
 			addrs[string(l.Address.Bytes())] = struct{}{}
+			for _, ix := range cfg.indexers {
+				if key, ok := ix.Key(l); ok {
+					custom[string(logindex.DBKey(ix, key))] = struct{}{}
+				}
+			}
 		}
 	}
 
@@ -318,6 +426,20 @@ func unwindLogIndex(logPrefix string, db kv.RwTx, to uint64, cfg LogIndexCfg, qu
 	if err := truncateBitmaps(db, kv.LogAddressIndex, addrs, to); err != nil {
 		return err
 	}
+	if err := truncateBitmaps(db, kv.LogCustomIndex, custom, to); err != nil {
+		return err
+	}
+	for _, ix := range cfg.indexers {
+		progress, ok, err := logindex.Progress(db, ix)
+		if err != nil {
+			return err
+		}
+		if ok && progress > to {
+			if err := logindex.SaveProgress(db, ix, to); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -412,7 +534,7 @@ func PruneLogIndex(s *PruneState, tx kv.RwTx, cfg LogIndexCfg, ctx context.Conte
 
 	pruneTo := cfg.prune.Receipts.PruneTo(s.ForwardProgress)
 	// s.PruneProgress
-	if err = pruneLogIndex(logPrefix, tx, cfg.tmpdir, s.PruneProgress, pruneTo, ctx, logger, cfg.noPruneContracts); err != nil {
+	if err = pruneLogIndex(logPrefix, tx, cfg.tmpdir, s.PruneProgress, pruneTo, ctx, logger, cfg.noPruneContracts, cfg.indexers); err != nil {
 		return err
 	}
 	if err = s.Done(tx); err != nil {
@@ -427,7 +549,7 @@ func PruneLogIndex(s *PruneState, tx kv.RwTx, cfg LogIndexCfg, ctx context.Conte
 	return nil
 }
 
-func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneFrom, pruneTo uint64, ctx context.Context, logger log.Logger, noPruneContracts map[libcommon.Address]bool) error {
+func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneFrom, pruneTo uint64, ctx context.Context, logger log.Logger, noPruneContracts map[libcommon.Address]bool, indexers []logindex.Indexer) error {
 	logEvery := time.NewTicker(logInterval)
 	defer logEvery.Stop()
 
@@ -436,6 +558,8 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneFrom, prune
 	defer topics.Close()
 	addrs := etl.NewCollector(logPrefix, tmpDir, etl.NewOldestEntryBuffer(bufferSize), logger)
 	defer addrs.Close()
+	custom := etl.NewCollector(logPrefix, tmpDir, etl.NewOldestEntryBuffer(bufferSize), logger)
+	defer custom.Close()
 
 	reader := bytes.NewReader(nil)
 	{
@@ -479,6 +603,13 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneFrom, prune
 				if err := addrs.Collect(l.Address.Bytes(), nil); err != nil {
 					return err
 				}
+				for _, ix := range indexers {
+					if key, ok := ix.Key(l); ok {
+						if err := custom.Collect(logindex.DBKey(ix, key), nil); err != nil {
+							return err
+						}
+					}
+				}
 			}
 		}
 	}
@@ -489,5 +620,8 @@ func pruneLogIndex(logPrefix string, tx kv.RwTx, tmpDir string, pruneFrom, prune
 	if err := pruneOldLogChunks(tx, kv.LogAddressIndex, addrs, pruneTo, ctx); err != nil {
 		return err
 	}
+	if err := pruneOldLogChunks(tx, kv.LogCustomIndex, custom, pruneTo, ctx); err != nil {
+		return err
+	}
 	return nil
 }
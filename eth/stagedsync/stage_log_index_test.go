@@ -15,6 +15,7 @@ import (
 
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 
 	"github.com/stretchr/testify/require"
@@ -99,7 +100,7 @@ func TestPromoteLogIndex(t *testing.T) {
 
 	expectAddrs, expectTopics := genReceipts(t, tx, 100)
 
-	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil)
+	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil, nil)
 	cfgCopy := cfg
 	cfgCopy.bufLimit = 10
 	cfgCopy.flushEvery = time.Nanosecond
@@ -127,7 +128,7 @@ func TestPruneLogIndex(t *testing.T) {
 
 	_, _ = genReceipts(t, tx, 100)
 
-	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil)
+	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil, nil)
 	cfgCopy := cfg
 	cfgCopy.bufLimit = 10
 	cfgCopy.flushEvery = time.Nanosecond
@@ -135,7 +136,7 @@ func TestPruneLogIndex(t *testing.T) {
 	require.NoError(err)
 
 	// Mode test
-	err = pruneLogIndex("", tx, tmpDir, 0, 50, ctx, logger, nil)
+	err = pruneLogIndex("", tx, tmpDir, 0, 50, ctx, logger, nil, nil)
 	require.NoError(err)
 
 	{
@@ -167,7 +168,7 @@ func TestUnwindLogIndex(t *testing.T) {
 
 	expectAddrs, expectTopics := genReceipts(t, tx, 100)
 
-	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil)
+	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil, nil)
 	cfgCopy := cfg
 	cfgCopy.bufLimit = 10
 	cfgCopy.flushEvery = time.Nanosecond
@@ -175,7 +176,7 @@ func TestUnwindLogIndex(t *testing.T) {
 	require.NoError(err)
 
 	// Mode test
-	err = pruneLogIndex("", tx, tmpDir, 0, 50, ctx, logger, nil)
+	err = pruneLogIndex("", tx, tmpDir, 0, 50, ctx, logger, nil, nil)
 	require.NoError(err)
 
 	// Unwind test
@@ -193,3 +194,55 @@ func TestUnwindLogIndex(t *testing.T) {
 		require.True(m.Maximum() <= 700)
 	}
 }
+
+// addrLogIndex - custom index of logs by contract address, must match kv.LogAddressIndex
+type addrLogIndex struct{ id byte }
+
+func (ix addrLogIndex) Name() string                              { return "by_address" }
+func (ix addrLogIndex) ID() [logindex.IDLength]byte               { return [logindex.IDLength]byte{ix.id} }
+func (ix addrLogIndex) Key(l *types.Log) ([]byte, bool)           { return l.Address.Bytes(), true }
+func (ix addrLogIndex) QueryKey([]libcommon.Hash) ([]byte, error) { return nil, nil }
+
+func TestCustomLogIndex(t *testing.T) {
+	logger := log.New()
+	require, ctx := require.New(t), context.Background()
+	_, tx := memdb.NewTestTx(t)
+
+	expectAddrs, _ := genReceipts(t, tx, 100)
+
+	requireSameAsAddressIndex := func(ix logindex.Indexer, progress uint64) {
+		p, ok, err := logindex.Progress(tx, ix)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(progress, p)
+		for addr := range expectAddrs {
+			expect, err := bitmapdb.Get(tx, kv.LogAddressIndex, addr[:], 0, 10_000_000)
+			require.NoError(err)
+			m, err := bitmapdb.Get(tx, kv.LogCustomIndex, logindex.DBKey(ix, addr[:]), 0, 10_000_000)
+			require.NoError(err)
+			require.Equal(expect.ToArray(), m.ToArray())
+		}
+	}
+
+	first, second := addrLogIndex{1}, addrLogIndex{2}
+	cfg := StageLogIndexCfg(nil, prune.DefaultMode, "", nil, []logindex.Indexer{first})
+	cfg.bufLimit = 10
+	cfg.flushEvery = time.Nanosecond
+	require.NoError(promoteLogIndex("logPrefix", tx, 0, 50, 0, cfg, ctx, logger))
+	requireSameAsAddressIndex(first, 50)
+
+	// new index is built from genesis, existing one continues
+	cfg.indexers = []logindex.Indexer{first, second}
+	require.NoError(promoteLogIndex("logPrefix", tx, 51, 0, 0, cfg, ctx, logger))
+	requireSameAsAddressIndex(first, 99)
+	requireSameAsAddressIndex(second, 99)
+	for addr, expect := range expectAddrs {
+		m, err := bitmapdb.Get(tx, kv.LogAddressIndex, addr[:], 0, 10_000_000)
+		require.NoError(err)
+		require.Equal(expect, m.GetCardinality())
+	}
+
+	require.NoError(unwindLogIndex("logPrefix", tx, 70, cfg, nil))
+	requireSameAsAddressIndex(first, 70)
+	requireSameAsAddressIndex(second, 70)
+}
@@ -510,7 +510,7 @@ type snapshotUploader struct {
 	files           map[string]*uploadState
 	uploadFs        string
 	rclone          *downloader.RCloneClient
-	uploadSession   *downloader.RCloneSession
+	uploadSession   downloader.RemoteSession
 	uploadScheduled atomic.Bool
 	uploading       atomic.Bool
 	manifestMutex   sync.Mutex
@@ -903,38 +903,55 @@ func isLocalFs(ctx context.Context, rclient *downloader.RCloneClient, fs string)
 	return true
 }
 
-func (u *snapshotUploader) start(ctx context.Context, logger log.Logger) {
+func (u *snapshotUploader) startRClone(ctx context.Context, uploadFs string, logger log.Logger) (downloader.RemoteSession, error) {
 	var err error
 
 	u.rclone, err = downloader.NewRCloneClient(logger)
 
 	if err != nil {
-		logger.Warn("[uploader] Uploading disabled: rclone start failed", "err", err)
-		return
+		return nil, fmt.Errorf("rclone start failed: %w", err)
 	}
 
-	uploadFs := u.uploadFs
-
 	if isLocalFs(ctx, u.rclone, uploadFs) {
 		uploadFs = expandHomeDir(filepath.Clean(uploadFs))
 
 		uploadFs, err = filepath.Abs(uploadFs)
 
 		if err != nil {
-			logger.Warn("[uploader] Uploading disabled: invalid upload fs", "err", err, "fs", u.uploadFs)
-			return
+			return nil, fmt.Errorf("invalid upload fs: %s: %w", u.uploadFs, err)
 		}
 
 		if err := os.MkdirAll(uploadFs, 0755); err != nil {
-			logger.Warn("[uploader] Uploading disabled: can't create upload fs", "err", err, "fs", u.uploadFs)
-			return
+			return nil, fmt.Errorf("can't create upload fs: %s: %w", u.uploadFs, err)
 		}
 	}
 
-	u.uploadSession, err = u.rclone.NewSession(ctx, u.cfg.dirs.Snap, uploadFs)
+	session, err := u.rclone.NewSession(ctx, u.cfg.dirs.Snap, uploadFs)
 
 	if err != nil {
-		logger.Warn("[uploader] Uploading disabled: rclone session failed", "err", err)
+		return nil, fmt.Errorf("rclone session failed: %w", err)
+	}
+
+	return session, nil
+}
+
+func (u *snapshotUploader) start(ctx context.Context, logger log.Logger) {
+	var err error
+
+	uploadFs := u.uploadFs
+
+	if downloader.IsS3Location(uploadFs) {
+		// native s3 client - rclone is not required
+		session, err := downloader.NewS3Client(downloader.S3ConfigFromEnv(), logger).NewSession(ctx, u.cfg.dirs.Snap, uploadFs)
+
+		if err != nil {
+			logger.Warn("[uploader] Uploading disabled: s3 session failed", "err", err)
+			return
+		}
+
+		u.uploadSession = session
+	} else if u.uploadSession, err = u.startRClone(ctx, uploadFs, logger); err != nil {
+		logger.Warn("[uploader] Uploading disabled", "err", err)
 		return
 	}
 
@@ -1192,7 +1209,7 @@ func (u *snapshotUploader) upload(ctx context.Context, logger log.Logger) {
 			err := func() error {
 				state.Lock()
 				defer state.Unlock()
-				if !state.remote && state.torrent != nil && len(state.uploads) == 0 && u.rclone != nil {
+				if !state.remote && state.torrent != nil && len(state.uploads) == 0 && u.uploadSession != nil {
 					state.uploads = []string{state.file, state.file + ".torrent"}
 					uploadList = append(uploadList, state)
 				}
@@ -0,0 +1,275 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/eth/tracers"
+)
+
+func init() {
+	register("flatCallTracer", newFlatCallTracer)
+}
+
+// parityErrorMapping maps EVM errors to the error strings reported by Parity/OpenEthereum.
+var parityErrorMapping = map[string]string{
+	"contract creation code storage out of gas": "Out of gas",
+	"out of gas":                      "Out of gas",
+	"gas uint64 overflow":             "Out of gas",
+	"max code size exceeded":          "Out of gas",
+	"invalid jump destination":        "Bad jump destination",
+	"return data out of bounds":       "Out of bounds",
+	"stack limit reached 1024 (1023)": "Out of stack",
+	"precompiled failed":              "Built-in failed",
+	"invalid input length":            "Built-in failed",
+}
+
+var parityErrorMappingStartingWith = map[string]string{
+	"invalid opcode:": "Bad instruction",
+	"stack underflow": "Stack underflow",
+}
+
+// flatCallFrame is a single call frame in the Parity/OpenEthereum trace format, as returned by trace_transaction.
+type flatCallFrame struct {
+	Action              interface{}       `json:"action"` // One of flatCallAction, flatCreateAction or flatSuicideAction
+	BlockHash           *libcommon.Hash   `json:"blockHash,omitempty"`
+	BlockNumber         *uint64           `json:"blockNumber,omitempty"`
+	Error               string            `json:"error,omitempty"`
+	Result              interface{}       `json:"result"` // One of flatCallResult or flatCreateResult, nil on failure
+	Subtraces           int               `json:"subtraces"`
+	TraceAddress        []int             `json:"traceAddress"`
+	TransactionHash     *libcommon.Hash   `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64           `json:"transactionPosition,omitempty"`
+	Type                string            `json:"type"`
+	value               *uint256.Int      // Value of the frame, inherited by delegate calls
+	to                  libcommon.Address // Address of the created contract
+}
+
+type flatCallAction struct {
+	From     libcommon.Address `json:"from"`
+	CallType string            `json:"callType"`
+	Gas      hexutil.Uint64    `json:"gas"`
+	Input    hexutility.Bytes  `json:"input"`
+	To       libcommon.Address `json:"to"`
+	Value    *hexutil.Big      `json:"value"`
+}
+
+type flatCreateAction struct {
+	From  libcommon.Address `json:"from"`
+	Gas   hexutil.Uint64    `json:"gas"`
+	Init  hexutility.Bytes  `json:"init"`
+	Value *hexutil.Big      `json:"value"`
+}
+
+type flatSuicideAction struct {
+	Address       libcommon.Address `json:"address"`
+	RefundAddress libcommon.Address `json:"refundAddress"`
+	Balance       *hexutil.Big      `json:"balance"`
+}
+
+type flatCallResult struct {
+	GasUsed hexutil.Uint64   `json:"gasUsed"`
+	Output  hexutility.Bytes `json:"output"`
+}
+
+type flatCreateResult struct {
+	Address *libcommon.Address `json:"address,omitempty"`
+	Code    hexutility.Bytes   `json:"code"`
+	GasUsed hexutil.Uint64     `json:"gasUsed"`
+}
+
+// flatCallTracer reports the call frames of a tx in the flat Parity format, i.e. as
+// opposed to the nested format of `callTracer`. The output matches trace_transaction.
+type flatCallTracer struct {
+	noopTracer
+	ctx       *tracers.Context
+	config    flatCallTracerConfig
+	traces    []*flatCallFrame
+	callstack []*flatCallFrame // Frames being executed, nil for skipped precompile calls
+	traceAddr []int
+	reason    error // Textual reason for the interruption
+}
+
+type flatCallTracerConfig struct {
+	ConvertParityErrors bool `json:"convertParityErrors"` // If true, EVM errors are converted to the Parity error strings
+	IncludePrecompiles  bool `json:"includePrecompiles"`  // If true, calls to precompiles without value are reported
+}
+
+// newFlatCallTracer returns a native go tracer which reports the call
+// frames of a tx in the Parity format, and implements vm.EVMLogger.
+func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config flatCallTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return &flatCallTracer{ctx: ctx, config: config}, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *flatCallTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.captureStartOrEnter(false /* deep */, vm.CALL, from, to, precompile, create, input, gas, value)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *flatCallTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.captureEndOrExit(false /* deep */, output, gasUsed, err)
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *flatCallTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.captureStartOrEnter(true /* deep */, typ, from, to, precompile, create, input, gas, value)
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *flatCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.captureEndOrExit(true /* deep */, output, gasUsed, err)
+}
+
+func (t *flatCallTracer) captureStartOrEnter(deep bool, typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int) {
+	// Parity traces don't include calls to precompiles, unless they transfer value
+	if deep && precompile && (value == nil || value.IsZero()) && !t.config.IncludePrecompiles {
+		t.callstack = append(t.callstack, nil)
+		return
+	}
+
+	frame := &flatCallFrame{value: value, to: to}
+	if deep {
+		parent := t.callstack[len(t.callstack)-1]
+		t.traceAddr = append(t.traceAddr, parent.Subtraces)
+		parent.Subtraces++
+		switch typ {
+		case vm.DELEGATECALL:
+			frame.value = parent.value
+		case vm.STATICCALL:
+			frame.value = nil
+		}
+	}
+	if frame.value == nil {
+		frame.value = new(uint256.Int)
+	}
+	frame.TraceAddress = make([]int, len(t.traceAddr))
+	copy(frame.TraceAddress, t.traceAddr)
+
+	switch {
+	case create:
+		frame.Type = "create"
+		frame.Action = &flatCreateAction{
+			From:  from,
+			Gas:   hexutil.Uint64(gas),
+			Init:  libcommon.CopyBytes(input),
+			Value: (*hexutil.Big)(frame.value.ToBig()),
+		}
+		frame.Result = &flatCreateResult{Address: &frame.to}
+	case typ == vm.SELFDESTRUCT:
+		frame.Type = "suicide"
+		frame.Action = &flatSuicideAction{
+			Address:       from,
+			RefundAddress: to,
+			Balance:       (*hexutil.Big)(frame.value.ToBig()),
+		}
+	default:
+		frame.Type = "call"
+		frame.Action = &flatCallAction{
+			From:     from,
+			CallType: strings.ToLower(typ.String()),
+			Gas:      hexutil.Uint64(gas),
+			Input:    libcommon.CopyBytes(input),
+			To:       to,
+			Value:    (*hexutil.Big)(frame.value.ToBig()),
+		}
+		frame.Result = &flatCallResult{}
+	}
+	t.traces = append(t.traces, frame)
+	t.callstack = append(t.callstack, frame)
+}
+
+func (t *flatCallTracer) captureEndOrExit(deep bool, output []byte, gasUsed uint64, err error) {
+	size := len(t.callstack)
+	if size == 0 {
+		return
+	}
+	frame := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	if frame == nil {
+		return
+	}
+	if deep {
+		t.traceAddr = t.traceAddr[:len(t.traceAddr)-1]
+	}
+
+	// Revert output contains useful information (revert reason), otherwise the result is discarded.
+	if err != nil && !errors.Is(err, vm.ErrExecutionReverted) {
+		frame.Result = nil
+		frame.Error = err.Error()
+		if t.config.ConvertParityErrors {
+			frame.Error = convertErrorToParity(frame.Error)
+		}
+		return
+	}
+	if err != nil {
+		frame.Error = "Reverted"
+	}
+	switch result := frame.Result.(type) {
+	case *flatCallResult:
+		result.GasUsed = hexutil.Uint64(gasUsed)
+		result.Output = libcommon.CopyBytes(output)
+	case *flatCreateResult:
+		result.GasUsed = hexutil.Uint64(gasUsed)
+		result.Code = libcommon.CopyBytes(output)
+	}
+}
+
+// GetResult returns the json-encoded list of flat call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *flatCallTracer) GetResult() (json.RawMessage, error) {
+	if len(t.traces) == 0 {
+		return nil, errors.New("invalid number of calls")
+	}
+	if t.ctx != nil && t.ctx.BlockHash != (libcommon.Hash{}) {
+		var (
+			blockHash   = t.ctx.BlockHash
+			blockNumber = t.ctx.BlockNum
+			txHash      = t.ctx.TxHash
+			txIndex     = uint64(t.ctx.TxIndex)
+		)
+		for _, frame := range t.traces {
+			frame.BlockHash = &blockHash
+			frame.BlockNumber = &blockNumber
+			frame.TransactionHash = &txHash
+			frame.TransactionPosition = &txIndex
+		}
+	}
+	res, err := json.Marshal(t.traces)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *flatCallTracer) Stop(err error) {
+	t.reason = err
+}
+
+func convertErrorToParity(err string) string {
+	if parityError, ok := parityErrorMapping[err]; ok {
+		return parityError
+	}
+	for gethError, parityError := range parityErrorMappingStartingWith {
+		if strings.HasPrefix(err, gethError) {
+			return parityError
+		}
+	}
+	return err
+}
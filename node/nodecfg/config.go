@@ -147,6 +147,8 @@ type Config struct {
 	// empty string means not to start the listener
 	PrivateApiAddr      string
 	PrivateApiRateLimit uint32
+	// PrivateApiACL - file with allow-list of kv tables per client, see remotedbserver.ACL
+	PrivateApiACL string
 
 	staticNodesWarning  bool
 	trustedNodesWarning bool
@@ -0,0 +1,468 @@
+package discover
+
+import (
+	"context"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ledgerwatch/erigon/common/debug"
+	"github.com/ledgerwatch/erigon/common/mclock"
+	"github.com/ledgerwatch/erigon/p2p/discover/v5wire"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/p2p/netutil"
+)
+
+// Topic advertisement.
+//
+// A node advertises itself in a topic by placing ads on the nodes closest to the topic ID
+// (registrars). Registration takes two steps: REQUESTTICKET returns a ticket with
+// the time to wait until the topic queue of the registrar has room, and REGTOPIC
+// with the ticket places the ad after the wait time. Ads expire after topicAdLifetime,
+// so registration is refreshed periodically. Topic search looks up the registrars
+// the same way, and asks them for the ads using TOPICQUERY.
+const (
+	topicAdLifetime       = 15 * time.Minute // ads are removed from the topic queue after this time
+	topicQueueLimit       = 100              // max number of ads in a single topic queue
+	topicTableLimit       = 5000             // max number of ads in all topic queues
+	ticketValidity        = 30 * time.Second // time after the wait time when the ticket can be used
+	maxTicketWait         = 5 * time.Minute  // registration is skipped if the wait time is longer
+	topicRegistrarCount   = 8                // number of nodes closest to the topic used as registrars
+	topicRegisterInterval = topicAdLifetime / 3
+	topicRegisterRetry    = 30 * time.Second // registration interval when no registrar accepted the ad
+	topicSearchInterval   = 10 * time.Second // min time between topic search rounds
+
+	topicQueryResultLimit = totalNodesResponseLimit * nodesResponseItemLimit
+
+	// ticket layout: topic | issue time | wait time | MAC
+	ticketSize = len(TopicID{}) + 8 + 8 + sha256.Size
+)
+
+var (
+	errInvalidTicket    = errors.New("invalid ticket")
+	errRegistrationFull = errors.New("topic registration rejected")
+)
+
+// TopicID is the identifier of a topic, nodes advertising the topic are registered
+// at the nodes closest to the topic ID.
+type TopicID [32]byte
+
+func (topic TopicID) String() string {
+	return hex.EncodeToString(topic[:])
+}
+
+type topicAd struct {
+	node    *enode.Node
+	expires mclock.AbsTime
+}
+
+// topicTable keeps ads registered at the local node.
+type topicTable struct {
+	mu     sync.Mutex
+	queues map[TopicID][]topicAd
+	count  int
+	secret [32]byte
+	clock  mclock.Clock
+}
+
+func newTopicTable(clock mclock.Clock) *topicTable {
+	table := &topicTable{
+		queues: make(map[TopicID][]topicAd),
+		clock:  clock,
+	}
+	crand.Read(table.secret[:])
+	return table
+}
+
+// expire removes the expired ads, the caller must hold mu
+func (table *topicTable) expire(now mclock.AbsTime) {
+	for topic, queue := range table.queues {
+		i := 0
+		for i < len(queue) && queue[i].expires <= now {
+			i++
+		}
+		table.count -= i
+		if i == len(queue) {
+			delete(table.queues, topic)
+		} else if i > 0 {
+			table.queues[topic] = append([]topicAd(nil), queue[i:]...)
+		}
+	}
+}
+
+// waitTime returns the time after which the node can place the ad, the caller must hold mu
+func (table *topicTable) waitTime(id enode.ID, topic TopicID, now mclock.AbsTime) time.Duration {
+	queue := table.queues[topic]
+	for _, ad := range queue {
+		if ad.node.ID() == id {
+			return 0
+		}
+	}
+	if len(queue) >= topicQueueLimit {
+		return time.Duration(queue[0].expires - now)
+	}
+	if table.count >= topicTableLimit {
+		earliest := now.Add(topicAdLifetime)
+		for _, queue := range table.queues {
+			if queue[0].expires < earliest {
+				earliest = queue[0].expires
+			}
+		}
+		return time.Duration(earliest - now)
+	}
+	return 0
+}
+
+func (table *topicTable) ticketMAC(id enode.ID, ticket []byte) []byte {
+	mac := hmac.New(sha256.New, table.secret[:])
+	mac.Write(id[:])
+	mac.Write(ticket)
+	return mac.Sum(nil)
+}
+
+// ticket issues a ticket for the node to register in the topic
+func (table *topicTable) ticket(id enode.ID, topic TopicID) []byte {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	now := table.clock.Now()
+	table.expire(now)
+	ticket := make([]byte, 0, ticketSize)
+	ticket = append(ticket, topic[:]...)
+	ticket = binary.BigEndian.AppendUint64(ticket, uint64(now))
+	ticket = binary.BigEndian.AppendUint64(ticket, uint64(table.waitTime(id, topic, now)))
+	return append(ticket, table.ticketMAC(id, ticket)...)
+}
+
+// register places the ad of the node if the ticket is valid and there is room in the topic queue
+func (table *topicTable) register(node *enode.Node, ticket []byte) (TopicID, error) {
+	var topic TopicID
+	if len(ticket) != ticketSize {
+		return topic, errInvalidTicket
+	}
+	data, mac := ticket[:ticketSize-sha256.Size], ticket[ticketSize-sha256.Size:]
+	if !hmac.Equal(mac, table.ticketMAC(node.ID(), data)) {
+		return topic, errInvalidTicket
+	}
+	copy(topic[:], data)
+	issued := mclock.AbsTime(binary.BigEndian.Uint64(data[len(topic):]))
+	readyAt := issued.Add(time.Duration(binary.BigEndian.Uint64(data[len(topic)+8:])))
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	now := table.clock.Now()
+	if (now < readyAt) || (now > readyAt.Add(ticketValidity)) {
+		return topic, errInvalidTicket
+	}
+	table.expire(now)
+
+	queue := table.queues[topic]
+	for i, ad := range queue {
+		if ad.node.ID() == node.ID() {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			table.count--
+			break
+		}
+	}
+	if (len(queue) >= topicQueueLimit) || (table.count >= topicTableLimit) {
+		return topic, errRegistrationFull
+	}
+	table.queues[topic] = append(queue, topicAd{node, now.Add(topicAdLifetime)})
+	table.count++
+	return topic, nil
+}
+
+// nodes returns random ads of the topic
+func (table *topicTable) nodes(topic TopicID, limit int) []*enode.Node {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	table.expire(table.clock.Now())
+	queue := table.queues[topic]
+	nodes := make([]*enode.Node, 0, len(queue))
+	for _, i := range rand.Perm(len(queue)) {
+		if len(nodes) >= limit {
+			break
+		}
+		nodes = append(nodes, queue[i].node)
+	}
+	return nodes
+}
+
+func ticketWaitTime(ticket []byte) (time.Duration, error) {
+	if len(ticket) != ticketSize {
+		return 0, errInvalidTicket
+	}
+	return time.Duration(binary.BigEndian.Uint64(ticket[len(TopicID{})+8:])), nil
+}
+
+// RegisterTopic starts advertising the local node in the topic.
+// The advertisement is refreshed until StopRegisterTopic is called or the transport is closed.
+func (t *UDPv5) RegisterTopic(topic TopicID) {
+	t.topicRegLock.Lock()
+	defer t.topicRegLock.Unlock()
+
+	if _, ok := t.topicRegs[topic]; ok || (t.closeCtx.Err() != nil) {
+		return
+	}
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	t.topicRegs[topic] = cancel
+	t.wg.Add(1)
+	go t.topicRegisterLoop(ctx, topic)
+}
+
+// StopRegisterTopic stops advertising the local node in the topic.
+// Already placed ads are not removed, they expire on their own.
+func (t *UDPv5) StopRegisterTopic(topic TopicID) {
+	t.topicRegLock.Lock()
+	defer t.topicRegLock.Unlock()
+
+	if cancel, ok := t.topicRegs[topic]; ok {
+		cancel()
+		delete(t.topicRegs, topic)
+	}
+}
+
+// TopicSearch returns an iterator over the nodes advertising the topic.
+func (t *UDPv5) TopicSearch(topic TopicID) enode.Iterator {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	return &topicIterator{t: t, topic: topic, ctx: ctx, cancel: cancel}
+}
+
+func (t *UDPv5) topicRegisterLoop(ctx context.Context, topic TopicID) {
+	defer debug.LogPanic()
+	defer t.wg.Done()
+
+	for {
+		delay := topicRegisterInterval
+		if t.registerTopic(ctx, topic) == 0 {
+			delay = topicRegisterRetry
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.clock.After(delay):
+		}
+	}
+}
+
+// registerTopic places the ads at the registrars of the topic, it returns the number of placed ads
+func (t *UDPv5) registerTopic(ctx context.Context, topic TopicID) int {
+	registrars := t.topicRegistrars(ctx, topic)
+	var registered atomic.Int32
+	var wg sync.WaitGroup
+	for _, n := range registrars {
+		wg.Add(1)
+		go func(n *enode.Node) {
+			defer debug.LogPanic()
+			defer wg.Done()
+			if err := t.registerTopicAt(ctx, n, topic); err != nil {
+				t.log.Trace("Topic registration failed", "topic", topic, "id", n.ID(), "err", err)
+				return
+			}
+			registered.Add(1)
+		}(n)
+	}
+	wg.Wait()
+	t.log.Debug("Topic registration", "topic", topic, "registrars", len(registrars), "registered", registered.Load())
+	return int(registered.Load())
+}
+
+func (t *UDPv5) registerTopicAt(ctx context.Context, n *enode.Node, topic TopicID) error {
+	ticket, wait, err := t.requestTicket(n, topic)
+	if err != nil {
+		return err
+	}
+	if wait > maxTicketWait {
+		return fmt.Errorf("ticket wait time %v is too long", wait)
+	}
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.clock.After(wait):
+		}
+	}
+	registered, err := t.regtopic(n, ticket)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		return errRegistrationFull
+	}
+	return nil
+}
+
+// searchTopic queries the registrars of the topic for the ads
+func (t *UDPv5) searchTopic(ctx context.Context, topic TopicID) []*enode.Node {
+	registrars := t.topicRegistrars(ctx, topic)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[enode.ID]struct{})
+	var result []*enode.Node
+	for _, n := range registrars {
+		wg.Add(1)
+		go func(n *enode.Node) {
+			defer debug.LogPanic()
+			defer wg.Done()
+			nodes, err := t.topicQuery(n, topic)
+			if err != nil {
+				t.log.Trace("Topic query failed", "topic", topic, "id", n.ID(), "err", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, node := range nodes {
+				if _, ok := seen[node.ID()]; ok || (node.ID() == t.Self().ID()) {
+					continue
+				}
+				seen[node.ID()] = struct{}{}
+				result = append(result, node)
+			}
+		}(n)
+	}
+	wg.Wait()
+	return result
+}
+
+func (t *UDPv5) topicRegistrars(ctx context.Context, topic TopicID) []*enode.Node {
+	nodes := t.newLookup(ctx, enode.ID(topic)).run()
+	if len(nodes) > topicRegistrarCount {
+		nodes = nodes[:topicRegistrarCount]
+	}
+	return nodes
+}
+
+// requestTicket calls REQUESTTICKET on a node and waits for a TICKET response.
+func (t *UDPv5) requestTicket(n *enode.Node, topic TopicID) ([]byte, time.Duration, error) {
+	resp := t.call(n, v5wire.TicketMsg, &v5wire.RequestTicket{Topic: topic[:]})
+	defer t.callDone(resp)
+
+	select {
+	case respMsg := <-resp.ch:
+		ticket := respMsg.(*v5wire.Ticket).Ticket
+		wait, err := ticketWaitTime(ticket)
+		return ticket, wait, err
+	case err := <-resp.err:
+		return nil, 0, err
+	}
+}
+
+// regtopic calls REGTOPIC on a node and waits for a REGCONFIRMATION response.
+func (t *UDPv5) regtopic(n *enode.Node, ticket []byte) (bool, error) {
+	resp := t.call(n, v5wire.RegconfirmationMsg, &v5wire.Regtopic{Ticket: ticket, ENR: t.Self().Record()})
+	defer t.callDone(resp)
+
+	select {
+	case respMsg := <-resp.ch:
+		return respMsg.(*v5wire.Regconfirmation).Registered, nil
+	case err := <-resp.err:
+		return false, err
+	}
+}
+
+// topicQuery calls TOPICQUERY on a node and waits for NODES responses.
+func (t *UDPv5) topicQuery(n *enode.Node, topic TopicID) ([]*enode.Node, error) {
+	resp := t.call(n, v5wire.NodesMsg, &v5wire.TopicQuery{Topic: topic[:]})
+	return t.waitForNodes(resp, nil)
+}
+
+// handleRequestTicket issues a ticket to the requester.
+func (t *UDPv5) handleRequestTicket(p *v5wire.RequestTicket, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var topic TopicID
+	if len(p.Topic) != len(topic) {
+		return
+	}
+	copy(topic[:], p.Topic)
+	t.sendResponse(fromID, fromAddr, &v5wire.Ticket{ReqID: p.ReqID, Ticket: t.topics.ticket(fromID, topic)}) //nolint:errcheck
+}
+
+// handleRegtopic places the ad of the requester.
+func (t *UDPv5) handleRegtopic(p *v5wire.Regtopic, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var err error
+	var node *enode.Node
+	if p.ENR == nil {
+		err = errors.New("missing record")
+	} else if node, err = enode.New(t.validSchemes, p.ENR); err == nil && node.ID() != fromID {
+		err = errors.New("record of another node")
+	}
+	if err == nil {
+		_, err = t.topics.register(node, p.Ticket)
+	}
+	if err != nil {
+		t.log.Trace("Topic registration rejected", "id", fromID, "addr", fromAddr, "err", err)
+	}
+	t.sendResponse(fromID, fromAddr, &v5wire.Regconfirmation{ReqID: p.ReqID, Registered: err == nil}) //nolint:errcheck
+}
+
+// handleTopicQuery returns the ads of the topic to the requester.
+func (t *UDPv5) handleTopicQuery(p *v5wire.TopicQuery, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var topic TopicID
+	if len(p.Topic) != len(topic) {
+		return
+	}
+	copy(topic[:], p.Topic)
+	var nodes []*enode.Node
+	for _, n := range t.topics.nodes(topic, topicQueryResultLimit) {
+		if netutil.CheckRelayIP(fromAddr.IP, n.IP()) == nil {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, resp := range packNodes(p.ReqID, nodes) {
+		t.sendResponse(fromID, fromAddr, resp) //nolint:errcheck
+	}
+}
+
+// topicIterator runs topic search rounds and iterates over the found nodes.
+type topicIterator struct {
+	t         *UDPv5
+	topic     TopicID
+	ctx       context.Context
+	cancel    func()
+	buffer    []*enode.Node
+	nextRound mclock.AbsTime
+}
+
+// Node returns the current node.
+func (it *topicIterator) Node() *enode.Node {
+	if len(it.buffer) == 0 {
+		return nil
+	}
+	return it.buffer[0]
+}
+
+// Next moves to the next node.
+func (it *topicIterator) Next() bool {
+	if len(it.buffer) > 0 {
+		it.buffer = it.buffer[1:]
+	}
+	for len(it.buffer) == 0 {
+		if wait := time.Duration(it.nextRound - it.t.clock.Now()); wait > 0 {
+			select {
+			case <-it.ctx.Done():
+			case <-it.t.clock.After(wait):
+			}
+		}
+		if it.ctx.Err() != nil {
+			it.buffer = nil
+			return false
+		}
+		it.nextRound = it.t.clock.Now().Add(topicSearchInterval)
+		it.buffer = it.t.searchTopic(it.ctx, it.topic)
+	}
+	return true
+}
+
+// Close ends the iterator.
+func (it *topicIterator) Close() {
+	it.cancel()
+}
@@ -0,0 +1,163 @@
+package discover
+
+import (
+	"bytes"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ledgerwatch/erigon/common/mclock"
+	"github.com/ledgerwatch/erigon/p2p/discover/v5wire"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/log/v3"
+)
+
+func TestTopicTable(t *testing.T) {
+	var clock mclock.Simulated
+	table := newTopicTable(&clock)
+	topic := TopicID{1}
+	nodes := nodesAtDistance(enode.ID{}, 256, topicQueueLimit+1)
+
+	// Fill the queue, the registration is possible right after getting the ticket.
+	for i, n := range nodes[:topicQueueLimit] {
+		ticket := table.ticket(n.ID(), topic)
+		if wait, _ := ticketWaitTime(ticket); wait != 0 {
+			t.Fatalf("node %d: unexpected wait time %v", i, wait)
+		}
+		if _, err := table.register(n, ticket); err != nil {
+			t.Fatalf("node %d: %v", i, err)
+		}
+		clock.Run(time.Second)
+	}
+	if got := table.nodes(topic, 10); len(got) != 10 {
+		t.Fatalf("got %d nodes, want 10", len(got))
+	}
+
+	// Ticket is bound to the node.
+	last := nodes[topicQueueLimit]
+	if _, err := table.register(last, table.ticket(nodes[0].ID(), topic)); err != errInvalidTicket {
+		t.Fatalf("got %v, want errInvalidTicket", err)
+	}
+
+	// The queue is full, the ticket waits until the first ad expires.
+	ticket := table.ticket(last.ID(), topic)
+	wait, _ := ticketWaitTime(ticket)
+	if want := topicAdLifetime - topicQueueLimit*time.Second; wait != want {
+		t.Fatalf("wait time %v, want %v", wait, want)
+	}
+	if _, err := table.register(last, ticket); err != errInvalidTicket {
+		t.Fatalf("early registration: got %v, want errInvalidTicket", err)
+	}
+	clock.Run(wait)
+	if _, err := table.register(last, ticket); err != nil {
+		t.Fatal(err)
+	}
+	if got := table.nodes(topic, topicQueueLimit+1); len(got) != topicQueueLimit {
+		t.Fatalf("got %d nodes, want %d", len(got), topicQueueLimit)
+	}
+
+	// Registered nodes can refresh the ad without waiting, but expired tickets are rejected.
+	ticket = table.ticket(last.ID(), topic)
+	clock.Run(ticketValidity + time.Second)
+	if _, err := table.register(last, ticket); err != errInvalidTicket {
+		t.Fatalf("expired ticket: got %v, want errInvalidTicket", err)
+	}
+	if _, err := table.register(last, table.ticket(last.ID(), topic)); err != nil {
+		t.Fatal(err)
+	}
+
+	// All ads expire.
+	clock.Run(topicAdLifetime)
+	if got := table.nodes(topic, topicQueueLimit); len(got) != 0 {
+		t.Fatalf("got %d nodes after expiration", len(got))
+	}
+	if table.count != 0 {
+		t.Fatalf("table count %d after expiration", table.count)
+	}
+}
+
+// This test checks that incoming topic messages are handled correctly.
+func TestUDPv5_topicHandling(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fix me on win please")
+	}
+	t.Parallel()
+	logger := log.New()
+	test := newUDPV5Test(t, logger)
+	t.Cleanup(test.close)
+
+	topic := TopicID{1, 2, 3}
+	remote := test.getNode(test.remotekey, test.remoteaddr, logger).Node()
+
+	var ticket []byte
+	test.packetIn(&v5wire.RequestTicket{ReqID: []byte("1"), Topic: topic[:]}, logger)
+	test.waitPacketOut(func(p *v5wire.Ticket, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if !bytes.Equal(p.ReqID, []byte("1")) {
+			t.Error("wrong request ID in response:", p.ReqID)
+		}
+		ticket = p.Ticket
+	})
+
+	// The record must belong to the sender.
+	other := nodesAtDistance(remote.ID(), 256, 1)[0]
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte("2"), Ticket: ticket, ENR: other.Record()}, logger)
+	test.waitPacketOut(func(p *v5wire.Regconfirmation, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if p.Registered {
+			t.Error("registered a record of another node")
+		}
+	})
+
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte("3"), Ticket: ticket, ENR: remote.Record()}, logger)
+	test.waitPacketOut(func(p *v5wire.Regconfirmation, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if !bytes.Equal(p.ReqID, []byte("3")) {
+			t.Error("wrong request ID in response:", p.ReqID)
+		}
+		if !p.Registered {
+			t.Error("registration rejected")
+		}
+	})
+
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte("4"), Topic: topic[:]}, logger)
+	test.expectNodes([]byte("4"), 1, []*enode.Node{remote})
+
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte("5"), Topic: make([]byte, len(topic))}, logger)
+	test.expectNodes([]byte("5"), 1, nil)
+}
+
+// This test checks that nodes can find each other using topic advertisement.
+func TestUDPv5_topicE2E(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fix me on win please")
+	}
+	t.Parallel()
+	logger := log.New()
+
+	bootNode := startLocalhostV5(t, Config{}, logger)
+	nodes := []*UDPv5{bootNode}
+	for len(nodes) < 4 {
+		nodes = append(nodes, startLocalhostV5(t, Config{Bootnodes: []*enode.Node{bootNode.Self()}}, logger))
+	}
+	defer func() {
+		for _, node := range nodes {
+			node.Close()
+		}
+	}()
+
+	topic := TopicID{1, 2, 3}
+	advertiser, searcher := nodes[1], nodes[2]
+	if n := advertiser.registerTopic(advertiser.closeCtx, topic); n == 0 {
+		t.Fatal("topic is not registered")
+	}
+
+	found := searcher.searchTopic(searcher.closeCtx, topic)
+	if len(found) != 1 || found[0].ID() != advertiser.Self().ID() {
+		t.Fatalf("topic search found %v, want %v", found, advertiser.Self())
+	}
+
+	it := searcher.TopicSearch(topic)
+	defer it.Close()
+	if !it.Next() || it.Node().ID() != advertiser.Self().ID() {
+		t.Fatalf("topic iterator found %v, want %v", it.Node(), advertiser.Self())
+	}
+}
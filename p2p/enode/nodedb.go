@@ -44,6 +44,7 @@ const (
 	dbVersionKey   = "version" // Version of the database to flush if changes
 	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
 	dbLocalPrefix  = "local:"
+	dbBanPrefix    = "ban:" // Identifier to prefix temporary bans with, the full key is "ban:<ID>"
 	dbDiscoverRoot = "v4"
 	dbDiscv5Root   = "v5"
 
@@ -57,6 +58,11 @@ const (
 	// Local information is keyed by ID only, the full key is "local:<ID>:seq".
 	// Use localItemKey to create those keys.
 	dbLocalSeq = "seq"
+
+	// Node sets managed at runtime, the full key is "<set>:<ID>".
+	// The value is the node URL, or dbNodeSetRemoved if the node was removed from the set.
+	StaticNodeSet  = "static"
+	TrustedNodeSet = "trusted"
 )
 
 const (
@@ -67,6 +73,8 @@ const (
 
 var (
 	errInvalidIP = errors.New("invalid IP")
+
+	dbNodeSetRemoved = []byte{0}
 )
 
 var zeroIP = make(net.IP, 16)
@@ -228,6 +236,17 @@ func v5Key(id ID, ip net.IP, field string) []byte {
 	}, []byte{':'})
 }
 
+// banKey returns the database key for a temporary ban of a node.
+// Bans are not stored under the node prefix, so they outlive the expiration of node records.
+func banKey(id ID) []byte {
+	return append([]byte(dbBanPrefix), id[:]...)
+}
+
+// nodeSetKey returns the database key for a node in the runtime managed node set.
+func nodeSetKey(set string, id ID) []byte {
+	return append([]byte(set+":"), id[:]...)
+}
+
 // localItemKey returns the key of a local node item.
 func localItemKey(id ID, field string) []byte {
 	key := append([]byte(dbLocalPrefix), id[:]...)
@@ -469,6 +488,33 @@ func (db *DB) expireNodes() {
 	for _, td := range toDelete {
 		db.deleteRange(td)
 	}
+	db.expireBans()
+}
+
+// expireBans deletes all bans which are already lifted.
+func (db *DB) expireBans() {
+	now := time.Now().Unix()
+	if err := db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		c, err := tx.RwCursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		p := []byte(dbBanPrefix)
+		for k, v, err := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			if until, _ := binary.Varint(v); until < now {
+				if err := c.DeleteCurrent(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Warn("nodeDB.expireBans failed", "err", err)
+	}
 }
 
 // LastPingReceived retrieves the time of the last ping packet received from
@@ -538,6 +584,94 @@ func (db *DB) UpdateFindFailsV5(id ID, ip net.IP, fails int) error {
 	return db.storeInt64(v5Key(id, ip, dbNodeFindFails), int64(fails))
 }
 
+// BannedUntil retrieves the time until which the node is banned.
+// Zero or past time means that the node is not banned.
+func (db *DB) BannedUntil(id ID) time.Time {
+	until := db.fetchInt64(banKey(id))
+	if until == 0 {
+		return time.Time{}
+	}
+	return time.Unix(until, 0)
+}
+
+// BannedNodes retrieves all bans which are not lifted yet.
+func (db *DB) BannedNodes() (map[ID]time.Time, error) {
+	now := time.Now().Unix()
+	bans := map[ID]time.Time{}
+	err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		c, err := tx.Cursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		p := []byte(dbBanPrefix)
+		for k, v, err := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			if until, _ := binary.Varint(v); until >= now {
+				var id ID
+				copy(id[:], k[len(p):])
+				bans[id] = time.Unix(until, 0)
+			}
+		}
+		return nil
+	})
+	return bans, err
+}
+
+// UpdateBannedUntil bans the node until the given time. Zero time lifts the ban.
+func (db *DB) UpdateBannedUntil(id ID, until time.Time) error {
+	if until.IsZero() {
+		return db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+			return tx.Delete(kv.Inodes, banKey(id))
+		})
+	}
+	return db.storeInt64(banKey(id), until.Unix())
+}
+
+// NodeSet retrieves the nodes added to the given node set (StaticNodeSet or TrustedNodeSet)
+// at runtime and the IDs of the nodes removed from it. Removals override nodes configured at startup.
+func (db *DB) NodeSet(set string) (added []*Node, removed []ID, err error) {
+	err = db.kv.View(db.ctx, func(tx kv.Tx) error {
+		c, err := tx.Cursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		p := []byte(set + ":")
+		for k, v, err := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			var id ID
+			copy(id[:], k[len(p):])
+			if bytes.Equal(v, dbNodeSetRemoved) {
+				removed = append(removed, id)
+				continue
+			}
+			n, err := Parse(ValidSchemes, string(v))
+			if err != nil {
+				return fmt.Errorf("invalid node %x in %s set: %w", id, set, err)
+			}
+			added = append(added, n)
+		}
+		return nil
+	})
+	return added, removed, err
+}
+
+// UpdateNodeSet stores addition of the node to the given node set, or its removal from the set.
+func (db *DB) UpdateNodeSet(set string, n *Node, add bool) error {
+	v := dbNodeSetRemoved
+	if add {
+		v = []byte(n.String())
+	}
+	return db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.Inodes, nodeSetKey(set, n.ID()), v)
+	})
+}
+
 // LocalSeq retrieves the local record sequence counter.
 func (db *DB) localSeq(id ID) uint64 {
 	return db.fetchUint64(localItemKey(id, dbLocalSeq))
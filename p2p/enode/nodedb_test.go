@@ -491,3 +491,46 @@ func TestDBExpireV5(t *testing.T) {
 	db.UpdateFindFailsV5(ID{}, ip, 4)
 	db.expireNodes()
 }
+
+// This test checks that bans survive node expiration and are removed once lifted.
+func TestDBBans(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(context.Background(), "", tmpDir, log.Root())
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	active, lifted := ID{1}, ID{2}
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := db.UpdateBannedUntil(active, until); err != nil {
+		t.Fatalf("failed to ban node: %v", err)
+	}
+	if err := db.UpdateBannedUntil(lifted, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to ban node: %v", err)
+	}
+	// Stale pong makes the node record of the banned node expire.
+	if err := db.UpdateLastPongReceived(active, net.IP{127, 0, 0, 1}, time.Now().Add(-dbNodeExpiration-time.Minute)); err != nil {
+		t.Fatalf("failed to update pong: %v", err)
+	}
+
+	db.expireNodes()
+
+	if got := db.BannedUntil(active); !got.Equal(until) {
+		t.Errorf("active ban mismatch: have %v, want %v", got, until)
+	}
+	if got := db.BannedUntil(lifted); !got.IsZero() {
+		t.Errorf("lifted ban should be removed, have %v", got)
+	}
+	if bans, err := db.BannedNodes(); err != nil {
+		t.Fatalf("failed to load bans: %v", err)
+	} else if len(bans) != 1 || !bans[active].Equal(until) {
+		t.Errorf("banned nodes mismatch: have %v, want only %v until %v", bans, active, until)
+	}
+	if err := db.UpdateBannedUntil(active, time.Time{}); err != nil {
+		t.Fatalf("failed to lift ban: %v", err)
+	}
+	if got := db.BannedUntil(active); !got.IsZero() {
+		t.Errorf("ban should be lifted, have %v", got)
+	}
+}
@@ -535,7 +535,8 @@ type PeerInfo struct {
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
 	} `json:"network"`
-	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
+	Protocols  map[string]interface{} `json:"protocols"`            // Sub-protocol specific metadata fields
+	Reputation float64                `json:"reputation,omitempty"` // Score of the peer kept by sentry, negative for misbehaving peers
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -0,0 +1,169 @@
+package sentry
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// reputationHalfLife is the time in which both rewards and penalties of a peer lose half of their weight.
+	reputationHalfLife = 10 * time.Minute
+
+	responseReward     = 1.0  // Reward for a response to our request
+	bandwidthReward    = 0.5  // Additional reward for each MiB of response payload
+	maxBandwidthReward = 4.0  // Cap of the bandwidth reward of a single response
+	timeoutPenalty     = -5.0 // Penalty for a request not answered before its deadline
+	invalidPenalty     = -50  // Penalty for invalid data (bad blocks or headers), reported via PenalizePeer
+
+	// banThreshold is the score at which the peer is disconnected and banned for banDuration.
+	banThreshold = -100.0
+	banDuration  = 6 * time.Hour
+
+	// scoreForgetThreshold is the absolute score below which disconnected peers are not tracked anymore.
+	scoreForgetThreshold = 1.0
+	// pruneInterval is how often scores of all disconnected peers are checked against scoreForgetThreshold.
+	pruneInterval = reputationHalfLife
+)
+
+type peerScore struct {
+	value     float64
+	updated   time.Time
+	connected bool
+}
+
+// decay brings the score to the moment now
+func (s *peerScore) decay(now time.Time) {
+	if elapsed := now.Sub(s.updated); elapsed > 0 {
+		s.value *= math.Exp2(-float64(elapsed) / float64(reputationHalfLife))
+	}
+	s.updated = now
+}
+
+// Reputation keeps score of every known peer: useful responses increase it,
+// timeouts and invalid data decrease it. Score decays to zero over time, so
+// old offences are forgiven. Peer whose score reaches banThreshold is passed to onBan.
+// Scores are not persistent, persistence of bans is the responsibility of onBan.
+// Nil Reputation is valid and doesn't track anything.
+type Reputation struct {
+	lock       sync.Mutex
+	scores     map[[64]byte]*peerScore
+	lastPruned time.Time
+	onBan      func(peerID [64]byte, until time.Time)
+	now        func() time.Time
+}
+
+func NewReputation(onBan func(peerID [64]byte, until time.Time)) *Reputation {
+	return &Reputation{
+		scores: map[[64]byte]*peerScore{},
+		onBan:  onBan,
+		now:    time.Now,
+	}
+}
+
+// Score returns current score of the peer, 0 for unknown peers
+func (r *Reputation) Score(peerID [64]byte) float64 {
+	if r == nil {
+		return 0
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	s, ok := r.scores[peerID]
+	if !ok {
+		return 0
+	}
+	s.decay(r.now())
+	return s.value
+}
+
+// Connected marks the peer as connected, so its score is kept even when it is close to zero
+func (r *Reputation) Connected(peerID [64]byte) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.get(peerID).connected = true
+}
+
+// Disconnected marks the peer as disconnected and forgets it if it is not worth remembering.
+// Other disconnected peers are forgotten once per pruneInterval, when their penalties have decayed.
+func (r *Reputation) Disconnected(peerID [64]byte) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.now()
+	if s, ok := r.scores[peerID]; ok {
+		s.connected = false
+		r.forgetIfNeutral(peerID, s, now)
+	}
+	if now.Sub(r.lastPruned) < pruneInterval {
+		return
+	}
+	r.lastPruned = now
+	for id, s := range r.scores {
+		if !s.connected {
+			r.forgetIfNeutral(id, s, now)
+		}
+	}
+}
+
+// forgetIfNeutral deletes score of a disconnected peer, unless the peer is still penalized:
+// positive score only matters for connected peers
+func (r *Reputation) forgetIfNeutral(peerID [64]byte, s *peerScore, now time.Time) {
+	s.decay(now)
+	if s.value > -scoreForgetThreshold {
+		delete(r.scores, peerID)
+	}
+}
+
+// UsefulResponse rewards the peer for a response of given size to our request
+func (r *Reputation) UsefulResponse(peerID [64]byte, size uint32) {
+	reward := responseReward + math.Min(bandwidthReward*float64(size)/(1<<20), maxBandwidthReward)
+	r.add(peerID, reward)
+}
+
+// Timeouts penalizes the peer for the given number of requests left unanswered
+func (r *Reputation) Timeouts(peerID [64]byte, count int) {
+	if count > 0 {
+		r.add(peerID, timeoutPenalty*float64(count))
+	}
+}
+
+// InvalidData penalizes the peer for sending invalid blocks or headers
+func (r *Reputation) InvalidData(peerID [64]byte) {
+	r.add(peerID, invalidPenalty)
+}
+
+func (r *Reputation) get(peerID [64]byte) *peerScore {
+	s, ok := r.scores[peerID]
+	if !ok {
+		s = &peerScore{updated: r.now()}
+		r.scores[peerID] = s
+	}
+	return s
+}
+
+func (r *Reputation) add(peerID [64]byte, delta float64) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	now := r.now()
+	s := r.get(peerID)
+	s.decay(now)
+	s.value += delta
+	ban := s.value <= banThreshold
+	if ban {
+		// ban is the punishment, after it the peer starts from half of the threshold,
+		// so that one more offence is enough to be banned again
+		s.value = banThreshold / 2
+	}
+	r.lock.Unlock()
+
+	if ban && r.onBan != nil {
+		r.onBan(peerID, now.Add(banDuration))
+	}
+}
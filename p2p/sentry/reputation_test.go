@@ -0,0 +1,77 @@
+package sentry
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputation(t *testing.T) {
+	var banned [][64]byte
+	r := NewReputation(func(peerID [64]byte, until time.Time) { banned = append(banned, peerID) })
+	now := time.Unix(1_000_000, 0)
+	r.now = func() time.Time { return now }
+
+	good, bad := [64]byte{1}, [64]byte{2}
+	r.Connected(good)
+	r.Connected(bad)
+	r.UsefulResponse(good, 2<<20)
+	require.Equal(t, responseReward+2*bandwidthReward, r.Score(good))
+
+	r.Timeouts(bad, 2)
+	require.Equal(t, 2*timeoutPenalty, r.Score(bad))
+
+	// decay
+	now = now.Add(reputationHalfLife)
+	require.InDelta(t, (responseReward+2*bandwidthReward)/2, r.Score(good), 1e-9)
+	require.InDelta(t, timeoutPenalty, r.Score(bad), 1e-9)
+
+	// ban
+	r.InvalidData(bad)
+	require.Empty(t, banned)
+	r.InvalidData(bad)
+	require.Equal(t, [][64]byte{bad}, banned)
+	require.Equal(t, banThreshold/2, r.Score(bad))
+
+	// disconnected peers are forgotten when their score is not negative
+	r.Disconnected(good)
+	r.Disconnected(bad)
+	require.Zero(t, r.Score(good))
+	require.Equal(t, banThreshold/2, r.Score(bad))
+	now = now.Add(10 * reputationHalfLife)
+	r.Disconnected(bad)
+	require.Zero(t, r.Score(bad))
+	require.Empty(t, r.scores)
+
+	// penalties of other disconnected peers are checked once per pruneInterval
+	r.Connected(bad)
+	r.InvalidData(bad)
+	r.Disconnected(bad)
+	require.Equal(t, float64(invalidPenalty), r.Score(bad))
+	now = now.Add(10 * reputationHalfLife)
+	r.Connected(good)
+	r.Disconnected(good)
+	require.Empty(t, r.scores)
+
+	var nilReputation *Reputation
+	nilReputation.InvalidData(bad)
+	require.Zero(t, nilReputation.Score(bad))
+}
+
+func TestPeersByMinBlockReputation(t *testing.T) {
+	peers := PeersByMinBlock{
+		{height: 10, score: 5},
+		{height: 20, score: -1},
+		{height: 10, score: 1},
+		{height: 5, score: 0},
+	}
+	heap.Init(&peers)
+	var order []PeerRef
+	for peers.Len() > 0 {
+		order = append(order, heap.Pop(&peers).(PeerRef))
+	}
+	// worst peers are popped first
+	require.Equal(t, []PeerRef{{height: 20, score: -1}, {height: 5, score: 0}, {height: 10, score: 1}, {height: 10, score: 5}}, order)
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/forkid"
 	"github.com/ledgerwatch/erigon/eth/protocols/eth"
 	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/p2p/discover"
 	"github.com/ledgerwatch/erigon/p2p/dnsdisc"
 	"github.com/ledgerwatch/erigon/p2p/enode"
 	"github.com/ledgerwatch/erigon/params"
@@ -62,6 +63,7 @@ type PeerInfo struct {
 	height        uint64
 	rw            p2p.MsgReadWriter
 	protocol      uint
+	reputation    *Reputation
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
@@ -80,6 +82,7 @@ type PeerInfo struct {
 type PeerRef struct {
 	pi     *PeerInfo
 	height uint64
+	score  float64
 }
 
 // PeersByMinBlock is the priority queue of peers. Used to select certain number of peers considered to be "best available"
@@ -90,9 +93,16 @@ func (bp PeersByMinBlock) Len() int {
 	return len(bp)
 }
 
-// Less (part of heap.Interface) compares two peers
+// Less (part of heap.Interface) compares two peers.
+// Peers with negative reputation are worse than any other, then peers are compared by height and by reputation.
 func (bp PeersByMinBlock) Less(i, j int) bool {
-	return bp[i].height < bp[j].height
+	if (bp[i].score < 0) != (bp[j].score < 0) {
+		return bp[i].score < 0
+	}
+	if bp[i].height != bp[j].height {
+		return bp[i].height < bp[j].height
+	}
+	return bp[i].score < bp[j].score
 }
 
 // Swap (part of heap.Interface) moves two peers in the queue into each other's places.
@@ -183,6 +193,7 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
+// Passed deadlines are counted as timeouts in the peer's reputation
 // It returns the number of deadlines left
 func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	pi.lock.Lock()
@@ -191,6 +202,7 @@ func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	firstNotPassed := sort.Search(len(pi.deadlines), func(i int) bool {
 		return pi.deadlines[i].After(now)
 	})
+	pi.reputation.Timeouts(pi.ID(), firstNotPassed)
 	cutOff := firstNotPassed
 	if cutOff < len(pi.deadlines) && givePermit {
 		cutOff++
@@ -274,6 +286,7 @@ func makeP2PServer(
 		p2pConfig.BootstrapNodes = bootstrapNodes
 		p2pConfig.BootstrapNodesV5 = bootstrapNodes
 	}
+	p2pConfig.DiscoveryV5Topics = []discover.TopicID{eth.DiscoveryTopic(genesisHash)}
 	p2pConfig.Protocols = protocols
 	return &p2p.Server{Config: p2pConfig}, nil
 }
@@ -544,6 +557,9 @@ func runPeer(
 
 		msg.Discard()
 		peerInfo.ClearDeadlines(time.Now(), givePermit)
+		if givePermit {
+			peerInfo.reputation.UsefulResponse(peerID, msg.Size)
+		}
 	}
 }
 
@@ -602,6 +618,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		peersStreams: NewPeersStreams(),
 		logger:       logger,
 	}
+	ss.reputation = NewReputation(ss.banPeer)
 
 	var disc enode.Iterator
 	if dialCandidates != nil {
@@ -628,6 +645,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 
 				peerInfo := NewPeerInfo(peer, rw)
 				peerInfo.protocol = protocol
+				peerInfo.reputation = ss.reputation
 				defer peerInfo.Close()
 
 				defer ss.GoodPeers.Delete(peerID)
@@ -647,6 +665,8 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 				logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
 
 				ss.GoodPeers.Store(peerID, peerInfo)
+				ss.reputation.Connected(peerID)
+				defer ss.reputation.Disconnected(peerID)
 				ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
 				getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
 				if getBlockHeadersErr != nil {
@@ -682,6 +702,12 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 	return ss
 }
 
+// AddProtocol registers one more protocol (for example snap/1) to run next to eth on the same peers.
+// Must be called before the p2p server is started by SetStatus.
+func (ss *GrpcServer) AddProtocol(protocol p2p.Protocol) {
+	ss.Protocols = append(ss.Protocols, protocol)
+}
+
 // Sentry creates and runs standalone sentry
 func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, healthCheck bool, logger log.Logger) error {
 	dir.MustExist(dirs.DataDir)
@@ -714,6 +740,7 @@ type GrpcServer struct {
 	discoveryDNS         []string
 	GoodPeers            sync.Map
 	statusData           *proto_sentry.StatusData
+	dialForkFilter       forkid.Filter // built from statusData, checks fork IDs of the dial candidates
 	P2pServer            *p2p.Server
 	TxSubscribed         uint32 // Set to non-zero if downloader is subscribed to transaction messages
 	lock                 sync.RWMutex
@@ -722,6 +749,7 @@ type GrpcServer struct {
 	messageStreamsLock   sync.RWMutex
 	peersStreams         *PeersStreams
 	p2p                  *p2p.Config
+	reputation           *Reputation
 	logger               log.Logger
 }
 
@@ -802,6 +830,7 @@ func (ss *GrpcServer) getBlockHeaders(ctx context.Context, bestHash libcommon.Ha
 func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.PenalizePeerRequest) (*emptypb.Empty, error) {
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
+	ss.reputation.InvalidData(peerID)
 	peerInfo := ss.getPeer(peerID)
 	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
@@ -809,6 +838,22 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	return &emptypb.Empty{}, nil
 }
 
+// banPeer is called by reputation when the score of the peer drops too low.
+// Ban is stored in the node database of p2p server, so it is kept after restart.
+func (ss *GrpcServer) banPeer(peerID [64]byte, until time.Time) {
+	peerInfo := ss.getPeer(peerID)
+	if peerInfo != nil && (peerInfo.peer.Info().Network.Static || peerInfo.peer.Info().Network.Trusted) {
+		return
+	}
+	if ss.P2pServer != nil {
+		if err := ss.P2pServer.BanNode(enode.PubkeyEncoded(peerID).ID(), until); err != nil {
+			ss.logger.Warn("[sentry] failed to store peer ban", "peerId", hex.EncodeToString(peerID[:])[:20], "err", err)
+		}
+	}
+	ss.logger.Debug("[sentry] peer banned for low reputation", "peerId", hex.EncodeToString(peerID[:])[:20], "until", until)
+	ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscUselessPeer, nil, "peer banned for low reputation"))
+}
+
 func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	if peerInfo := ss.getPeer(peerID); peerInfo != nil {
@@ -828,7 +873,7 @@ func (ss *GrpcServer) findBestPeersWithPermit(peerCount int) []*PeerInfo {
 		height := peerInfo.Height()
 		//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 		if deadlines < maxPermitsPerPeer {
-			heap.Push(&byMinBlock, PeerRef{pi: peerInfo, height: height})
+			heap.Push(&byMinBlock, PeerRef{pi: peerInfo, height: height, score: ss.reputation.Score(peerInfo.ID())})
 			if byMinBlock.Len() > peerCount {
 				// Remove the worst peer
 				peerRef := heap.Pop(&byMinBlock).(PeerRef)
@@ -1004,22 +1049,30 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 	reply := &proto_sentry.SetStatusReply{}
 
 	if ss.P2pServer == nil {
-		var err error
 		if !ss.p2p.NoDiscovery {
 			if len(ss.discoveryDNS) == 0 {
 				if url := params.KnownDNSNetwork(genesisHash, "all"); url != "" {
 					ss.discoveryDNS = []string{url}
 				}
 			}
-			for _, p := range ss.Protocols {
-				p.DialCandidates, err = setupDiscovery(ss.discoveryDNS)
-				if err != nil {
-					return nil, err
+			dnsCandidates, err := setupDiscovery(ss.discoveryDNS)
+			if err != nil {
+				return nil, err
+			}
+			// one iterator can feed only one protocol: p2p server mixes dial candidates of all protocols,
+			// and concurrent reads of the same iterator race. Peers from DNS are dialed for eth,
+			// other protocols (snap) run on the same connections.
+			for i := range ss.Protocols {
+				if ss.Protocols[i].Name == eth.ProtocolName {
+					ss.Protocols[i].DialCandidates = dnsCandidates
+					break
 				}
 			}
 		}
 
-		srv, err := makeP2PServer(*ss.p2p, genesisHash, ss.Protocols)
+		p2pConfig := *ss.p2p
+		p2pConfig.DialFilter = eth.NewNodeFilter(ss.forkFilter)
+		srv, err := makeP2PServer(p2pConfig, genesisHash, ss.Protocols)
 		if err != nil {
 			return reply, err
 		}
@@ -1037,6 +1090,7 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 	if ss.statusData == nil || statusData.MaxBlockHeight != 0 {
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
+		ss.dialForkFilter = forkid.NewFilterFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime)
 	}
 	return reply, nil
 }
@@ -1047,6 +1101,11 @@ func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.
 	}
 
 	peers := ss.P2pServer.PeersInfo()
+	scores := map[string]float64{}
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		scores[peerInfo.peer.ID().String()] = ss.reputation.Score(peerInfo.ID())
+		return true
+	})
 
 	var reply proto_sentry.PeersReply
 	reply.Peers = make([]*proto_types.PeerInfo, 0, len(peers))
@@ -1063,6 +1122,7 @@ func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.
 			ConnIsInbound:  peer.Network.Inbound,
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
+			Reputation:     scores[peer.ID],
 		}
 		reply.Peers = append(reply.Peers, &rpcPeer)
 	}
@@ -1108,6 +1168,7 @@ func (ss *GrpcServer) PeerById(_ context.Context, req *proto_sentry.PeerByIdRequ
 			ConnIsInbound:  peer.Network.Inbound,
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
+			Reputation:     ss.reputation.Score(peerID),
 		}
 	}
 
@@ -1124,6 +1185,13 @@ func setupDiscovery(urls []string) (enode.Iterator, error) {
 	return client.NewIterator(urls...)
 }
 
+// forkFilter returns the filter built on the last status update, it returns nil if the status is unknown
+func (ss *GrpcServer) forkFilter() forkid.Filter {
+	ss.lock.RLock()
+	defer ss.lock.RUnlock()
+	return ss.dialForkFilter
+}
+
 func (ss *GrpcServer) GetStatus() *proto_sentry.StatusData {
 	ss.lock.RLock()
 	defer ss.lock.RUnlock()
@@ -1250,6 +1318,44 @@ func (ss *GrpcServer) AddPeer(_ context.Context, req *proto_sentry.AddPeerReques
 	return &proto_sentry.AddPeerReply{Success: true}, nil
 }
 
+// RemovePeer removes the node from static nodes and disconnects it
+func (ss *GrpcServer) RemovePeer(_ context.Context, req *proto_sentry.RemovePeerRequest) (*proto_sentry.RemovePeerReply, error) {
+	if ss.P2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+	ss.P2pServer.RemovePeer(node)
+	return &proto_sentry.RemovePeerReply{Success: true}, nil
+}
+
+// AddTrustedPeer allows the node to always connect, even if all peer slots are taken
+func (ss *GrpcServer) AddTrustedPeer(_ context.Context, req *proto_sentry.AddPeerRequest) (*proto_sentry.AddPeerReply, error) {
+	if ss.P2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+	ss.P2pServer.AddTrustedPeer(node)
+	return &proto_sentry.AddPeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) RemoveTrustedPeer(_ context.Context, req *proto_sentry.RemovePeerRequest) (*proto_sentry.RemovePeerReply, error) {
+	if ss.P2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+	ss.P2pServer.RemoveTrustedPeer(node)
+	return &proto_sentry.RemovePeerReply{Success: true}, nil
+}
+
 func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_types.NodeInfoReply, error) {
 	if ss.P2pServer == nil {
 		return nil, errors.New("p2p server was not started")
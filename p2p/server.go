@@ -151,6 +151,9 @@ type Config struct {
 	// eth/66, eth/67, etc
 	ProtocolVersion []uint
 
+	// SnapServer enables snap/1 protocol on in-process sentries, to serve state to snap-syncing peers
+	SnapServer bool
+
 	SentryAddr []string
 
 	// If set to a non-nil value, the given NAT port mapper
@@ -168,6 +171,18 @@ type Config struct {
 	// If NoDial is true, the server will not dial any peers.
 	NoDial bool `toml:",omitempty"`
 
+	// If DialFilter is set, the nodes found by discovery are dialed only if it returns true.
+	// It allows to skip nodes of other networks before connecting. Static nodes are not filtered.
+	DialFilter func(*enode.Node) bool `toml:"-"`
+
+	// DiscoveryV5Topics are advertised and searched using the V5 discovery,
+	// nodes found by the topic search are dialed.
+	DiscoveryV5Topics []discover.TopicID `toml:"-"`
+
+	// If DiscoveryV5Dial is set, random nodes found by the V5 discovery are dialed too.
+	// By default the V5 discovery only provides the topic search results.
+	DiscoveryV5Dial bool `toml:",omitempty"`
+
 	// If EnableMsgEvents is set then the server will emit PeerEvents
 	// whenever a message is sent to or received from a peer
 	EnableMsgEvents bool
@@ -213,6 +228,10 @@ type Server struct {
 	logger       log.Logger
 
 	nodedb             *enode.DB
+	staticNodes        []*enode.Node // StaticNodes with changes made at runtime and persisted in nodedb
+	trustedNodes       []*enode.Node // TrustedNodes with changes made at runtime and persisted in nodedb
+	bansLock           sync.RWMutex
+	bans               map[enode.ID]time.Time // bans persisted in nodedb, kept in memory for dial and handshake checks
 	localnode          *enode.LocalNode
 	localnodeAddrCache atomic.Pointer[string]
 	ntab               *discover.UDPv4
@@ -360,8 +379,9 @@ func (srv *Server) PeerCount() int {
 
 // AddPeer adds the given node to the static node set. When there is room in the peer set,
 // the server will connect to the node. If the connection fails for any reason, the server
-// will attempt to reconnect the peer.
+// will attempt to reconnect the peer. The change of the static node set is kept after restart.
 func (srv *Server) AddPeer(node *enode.Node) {
+	srv.persistNodeSet(enode.StaticNodeSet, node, true)
 	srv.dialsched.addStatic(node)
 }
 
@@ -375,6 +395,7 @@ func (srv *Server) RemovePeer(node *enode.Node) {
 		ch  chan *PeerEvent
 		sub event.Subscription
 	)
+	srv.persistNodeSet(enode.StaticNodeSet, node, false)
 	// Disconnect the peer on the main loop.
 	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
 		srv.dialsched.removeStatic(node)
@@ -396,8 +417,10 @@ func (srv *Server) RemovePeer(node *enode.Node) {
 }
 
 // AddTrustedPeer adds the given node to a reserved whitelist which allows the
-// node to always connect, even if the slot are full.
+// node to always connect, even if the slot are full. The change of the trusted
+// node set is kept after restart.
 func (srv *Server) AddTrustedPeer(node *enode.Node) {
+	srv.persistNodeSet(enode.TrustedNodeSet, node, true)
 	select {
 	case srv.addtrusted <- node:
 	case <-srv.quit:
@@ -406,12 +429,89 @@ func (srv *Server) AddTrustedPeer(node *enode.Node) {
 
 // RemoveTrustedPeer removes the given node from the trusted peer set.
 func (srv *Server) RemoveTrustedPeer(node *enode.Node) {
+	srv.persistNodeSet(enode.TrustedNodeSet, node, false)
 	select {
 	case srv.removetrusted <- node:
 	case <-srv.quit:
 	}
 }
 
+// BanNode keeps the node from connecting until the given time, zero time lifts the ban.
+// Bans are stored in the node database, so they survive restarts. Trusted and static
+// nodes are not affected. BanNode doesn't disconnect the node if it is connected.
+func (srv *Server) BanNode(id enode.ID, until time.Time) error {
+	if srv.nodedb == nil {
+		return errServerStopped
+	}
+	if err := srv.nodedb.UpdateBannedUntil(id, until); err != nil {
+		return err
+	}
+	srv.bansLock.Lock()
+	defer srv.bansLock.Unlock()
+	if until.After(time.Now()) {
+		srv.bans[id] = until
+	} else {
+		delete(srv.bans, id)
+	}
+	return nil
+}
+
+// BannedUntil returns the time until which the node is banned.
+func (srv *Server) BannedUntil(id enode.ID) time.Time {
+	srv.bansLock.RLock()
+	defer srv.bansLock.RUnlock()
+	return srv.bans[id]
+}
+
+// loadBans reads bans made before restart from the node database.
+func (srv *Server) loadBans() {
+	bans, err := srv.nodedb.BannedNodes()
+	if err != nil {
+		srv.logger.Warn("Failed to load banned nodes", "err", err)
+		bans = map[enode.ID]time.Time{}
+	}
+	srv.bansLock.Lock()
+	defer srv.bansLock.Unlock()
+	srv.bans = bans
+}
+
+func (srv *Server) isBanned(id enode.ID) bool {
+	return srv.BannedUntil(id).After(time.Now())
+}
+
+// persistNodeSet stores the change of static or trusted node set in the node database.
+func (srv *Server) persistNodeSet(set string, node *enode.Node, add bool) {
+	if srv.nodedb == nil {
+		return
+	}
+	if err := srv.nodedb.UpdateNodeSet(set, node, add); err != nil {
+		srv.logger.Warn("Failed to persist node set change", "set", set, "node", node, "add", add, "err", err)
+	}
+}
+
+// loadNodeSet applies changes of the node set, made at runtime, to the configured nodes.
+func (srv *Server) loadNodeSet(set string, configured []*enode.Node) []*enode.Node {
+	added, removed, err := srv.nodedb.NodeSet(set)
+	if err != nil {
+		srv.logger.Warn("Failed to load node set", "set", set, "err", err)
+		return configured
+	}
+	skip := make(map[enode.ID]bool, len(added)+len(removed))
+	for _, id := range removed {
+		skip[id] = true
+	}
+	for _, n := range added {
+		skip[n.ID()] = true
+	}
+	nodes := make([]*enode.Node, 0, len(configured)+len(added))
+	for _, n := range configured {
+		if !skip[n.ID()] {
+			nodes = append(nodes, n)
+		}
+	}
+	return append(nodes, added...)
+}
+
 // SubscribeEvents subscribes the given channel to peer events.
 func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
@@ -527,6 +627,9 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 	if err := srv.setupLocalNode(); err != nil {
 		return err
 	}
+	srv.staticNodes = srv.loadNodeSet(enode.StaticNodeSet, srv.StaticNodes)
+	srv.trustedNodes = srv.loadNodeSet(enode.TrustedNodeSet, srv.TrustedNodes)
+	srv.loadBans()
 	if srv.ListenAddr != "" {
 		if err := srv.setupListening(srv.quitCtx); err != nil {
 			return err
@@ -682,6 +785,13 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if srv.DiscoveryV5Dial {
+			srv.discmix.AddSource(srv.DiscV5.RandomNodes())
+		}
+		for _, topic := range srv.DiscoveryV5Topics {
+			srv.DiscV5.RegisterTopic(topic)
+			srv.discmix.AddSource(srv.DiscV5.TopicSearch(topic))
+		}
 	}
 	return nil
 }
@@ -693,6 +803,7 @@ func (srv *Server) setupDialScheduler() {
 		maxActiveDials: srv.MaxPendingPeers,
 		log:            srv.logger,
 		netRestrict:    srv.NetRestrict,
+		banned:         srv.isBanned,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
 	}
@@ -706,8 +817,12 @@ func (srv *Server) setupDialScheduler() {
 	if len(srv.Protocols) > 0 {
 		subProtocolVersion = srv.Protocols[0].Version
 	}
-	srv.dialsched = newDialScheduler(config, srv.discmix, srv.SetupConn, subProtocolVersion)
-	for _, n := range srv.StaticNodes {
+	var candidates enode.Iterator = srv.discmix
+	if srv.DialFilter != nil {
+		candidates = enode.Filter(candidates, srv.DialFilter)
+	}
+	srv.dialsched = newDialScheduler(config, candidates, srv.SetupConn, subProtocolVersion)
+	for _, n := range srv.staticNodes {
 		srv.dialsched.addStatic(n)
 	}
 }
@@ -787,11 +902,11 @@ func (srv *Server) run() {
 	var (
 		peers        = make(map[enode.ID]*Peer)
 		inboundCount = 0
-		trusted      = make(map[enode.ID]bool, len(srv.TrustedNodes))
+		trusted      = make(map[enode.ID]bool, len(srv.trustedNodes))
 	)
 	// Put trusted nodes into a map to speed up checks.
 	// Trusted peers are loaded on startup or added via AddTrustedPeer RPC.
-	for _, n := range srv.TrustedNodes {
+	for _, n := range srv.trustedNodes {
 		trusted[n.ID()] = true
 	}
 
@@ -905,6 +1020,8 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
 		return DiscTooManyPeers
+	case !c.is(trustedConn) && !c.is(staticDialedConn) && srv.isBanned(c.node.ID()):
+		return DiscUselessPeer
 	case peers[c.node.ID()] != nil:
 		return DiscAlreadyConnected
 	case c.node.ID() == srv.localnode.ID():
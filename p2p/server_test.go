@@ -245,6 +245,83 @@ func TestServerRemovePeerDisconnect(t *testing.T) {
 	}
 }
 
+// This test checks that static and trusted nodes added or removed at runtime are kept after restart.
+func TestServerNodeSetsPersist(t *testing.T) {
+	logger := log.New()
+	newNode := func() *enode.Node { return enode.NewV4(&newkey().PublicKey, net.IP{127, 0, 0, 1}, 30303, 30303) }
+	a, b, c, d, e := newNode(), newNode(), newNode(), newNode(), newNode()
+	config := Config{
+		PrivateKey:      newkey(),
+		MaxPeers:        10,
+		MaxPendingPeers: 1,
+		NoDiscovery:     true,
+		NoDial:          true,
+		NodeDatabase:    t.TempDir(),
+		StaticNodes:     []*enode.Node{a, b},
+		TrustedNodes:    []*enode.Node{c},
+	}
+	ids := func(nodes []*enode.Node) (res []enode.ID) {
+		for _, n := range nodes {
+			res = append(res, n.ID())
+		}
+		return res
+	}
+
+	srv := &Server{Config: config}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't start server", err)
+	}
+	srv.AddPeer(d)
+	srv.RemovePeer(a)
+	srv.AddTrustedPeer(e)
+	srv.RemoveTrustedPeer(c)
+	srv.Stop()
+
+	srv = &Server{Config: config}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't restart server", err)
+	}
+	defer srv.Stop()
+	if have, want := ids(srv.staticNodes), ids([]*enode.Node{b, d}); !reflect.DeepEqual(have, want) {
+		t.Errorf("static nodes mismatch: have %v, want %v", have, want)
+	}
+	if have, want := ids(srv.trustedNodes), ids([]*enode.Node{e}); !reflect.DeepEqual(have, want) {
+		t.Errorf("trusted nodes mismatch: have %v, want %v", have, want)
+	}
+}
+
+// This test checks that discovered nodes rejected by DialFilter are not dialed.
+func TestServerDialFilter(t *testing.T) {
+	logger := log.New()
+	newNode := func() *enode.Node { return enode.NewV4(&newkey().PublicKey, net.IP{127, 0, 0, 1}, 30303, 30303) }
+	rejected, a, b := newNode(), newNode(), newNode()
+	dialer := newDialTestDialer()
+	srv := &Server{Config: Config{
+		PrivateKey:      newkey(),
+		MaxPeers:        10,
+		MaxPendingPeers: 10,
+		NoDiscovery:     true,
+		Dialer:          dialer,
+		Protocols: []Protocol{{
+			Name:           "test",
+			Version:        1,
+			DialCandidates: enode.IterNodes([]*enode.Node{rejected, a, b}),
+		}},
+		DialFilter: func(n *enode.Node) bool { return n.ID() != rejected.ID() },
+	}}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't start server", err)
+	}
+	defer srv.Stop()
+
+	if err := dialer.waitForDials([]*enode.Node{a, b}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dialer.completeDials([]enode.ID{a.ID(), b.ID()}, errors.New("dial failed")); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // This test checks that connections are disconnected just after the encryption handshake
 // when the server is at capacity. Trusted connections should still be accepted.
 func TestServerAtCap(t *testing.T) {
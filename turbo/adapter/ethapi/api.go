@@ -175,6 +175,8 @@ type Account struct {
 	Balance   **hexutil.Big                   `json:"balance"`
 	State     *map[libcommon.Hash]uint256.Int `json:"state"`
 	StateDiff *map[libcommon.Hash]uint256.Int `json:"stateDiff"`
+
+	MovePrecompileTo *libcommon.Address `json:"movePrecompileToAddress"`
 }
 
 func NewRevertError(result *core.ExecutionResult) *RevertError {
@@ -319,6 +321,9 @@ func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	if head.ParentBeaconBlockRoot != nil {
 		result["parentBeaconBlockRoot"] = head.ParentBeaconBlockRoot
 	}
+	if head.RequestsRoot != nil {
+		result["requestsRoot"] = head.RequestsRoot
+	}
 
 	return result
 }
@@ -8,6 +8,7 @@ import (
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 
 	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/vm"
 )
 
 type StateOverrides map[libcommon.Address]Account
@@ -49,3 +50,43 @@ func (overrides *StateOverrides) Override(state *state.IntraBlockState) error {
 
 	return nil
 }
+
+// OverridePrecompiles returns a copy of the given precompiled contracts with every
+// account that has movePrecompileToAddress set relocated to its new address.
+// It returns the original set if no precompile is moved.
+func (overrides *StateOverrides) OverridePrecompiles(precompiles map[libcommon.Address]vm.PrecompiledContract) (map[libcommon.Address]vm.PrecompiledContract, error) {
+	moved := false
+	for _, account := range *overrides {
+		if account.MovePrecompileTo != nil {
+			moved = true
+			break
+		}
+	}
+	if !moved {
+		return precompiles, nil
+	}
+
+	result := make(map[libcommon.Address]vm.PrecompiledContract, len(precompiles))
+	for addr, p := range precompiles {
+		result[addr] = p
+	}
+	moves := make(map[libcommon.Address]vm.PrecompiledContract)
+	for addr, account := range *overrides {
+		if account.MovePrecompileTo == nil {
+			continue
+		}
+		p, ok := precompiles[addr]
+		if !ok {
+			return nil, fmt.Errorf("account %s is not a precompile", addr.Hex())
+		}
+		if _, ok := moves[*account.MovePrecompileTo]; ok {
+			return nil, fmt.Errorf("account %s is already overridden", account.MovePrecompileTo.Hex())
+		}
+		moves[*account.MovePrecompileTo] = p
+		delete(result, addr)
+	}
+	for addr, p := range moves {
+		result[addr] = p
+	}
+	return result, nil
+}
@@ -47,7 +47,57 @@ TODO:
 	}),
 }
 
+var incrementalBackupCommand = cli.Command{
+	Name: "backup",
+	Description: `Incremental backup of databases and snapshots - can run without stopping of Erigon.
+Each run creates new backup in the --to directory, copying only db tables and snapshot files which changed since previous backup.
+Interrupted backup continues from where it stopped on next run. Any of backups can be restored by "erigon restore".
+
+Example: erigon backup --datadir=<your_datadir> --to=<backup_dir>
+`,
+	Action: doIncrementalBackup,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&BackupDirFlag,
+		&BackupLabelsFlag,
+		&WarmupThreadsFlag,
+	}),
+}
+
+var restoreCommand = cli.Command{
+	Name: "restore",
+	Description: `Restore datadir from backup made by "erigon backup". Hashes of all files are checked before anything is written.
+
+Example: erigon restore --from=<backup_dir> --datadir=<new_datadir> --at=2024-03-01T00:00:00Z
+`,
+	Action: doRestore,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&RestoreFromFlag,
+		&RestoreAtFlag,
+		&RestoreVerifyOnlyFlag,
+	}),
+}
+
 var (
+	BackupDirFlag = flags.DirectoryFlag{
+		Name:     "to",
+		Usage:    "Backup directory",
+		Required: true,
+	}
+	RestoreFromFlag = flags.DirectoryFlag{
+		Name:     "from",
+		Usage:    "Backup directory",
+		Required: true,
+	}
+	RestoreAtFlag = cli.StringFlag{
+		Name:  "at",
+		Usage: "Backup to restore: its number, or time (RFC3339) - then the latest backup made before it. Default: the latest backup",
+	}
+	RestoreVerifyOnlyFlag = cli.BoolFlag{
+		Name:  "verify.only",
+		Usage: "Only check hashes of files of the backup",
+	}
 	ToDatadirFlag = flags.DirectoryFlag{
 		Name:     "to.datadir",
 		Usage:    "Target datadir",
@@ -146,3 +196,58 @@ func doBackup(cliCtx *cli.Context) error {
 
 	return nil
 }
+
+func doIncrementalBackup(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	lables := backup.IncrementalLabels
+	if cliCtx.IsSet(BackupLabelsFlag.Name) {
+		lables = nil
+		for _, l := range common.CliString2Array(cliCtx.String(BackupLabelsFlag.Name)) {
+			lables = append(lables, kv.UnmarshalLabel(l))
+		}
+	}
+	readAheadThreads := backup.ReadAheadThreads
+	if cliCtx.IsSet(WarmupThreadsFlag.Name) {
+		readAheadThreads = int(cliCtx.Uint64(WarmupThreadsFlag.Name))
+	}
+
+	m, err := backup.Incremental(cliCtx.Context, dirs, cliCtx.String(BackupDirFlag.Name), lables, readAheadThreads, logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("backup done", "backup", m.Seq, "tables", len(m.Tables), "files", len(m.Files))
+	return nil
+}
+
+func doRestore(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+
+	from := cliCtx.String(RestoreFromFlag.Name)
+	m, err := backup.FindManifest(from, cliCtx.String(RestoreAtFlag.Name))
+	if err != nil {
+		return err
+	}
+	logger.Info("[restore] start", "backup", m.Seq, "time", m.Time)
+	if cliCtx.Bool(RestoreVerifyOnlyFlag.Name) {
+		if err := backup.Verify(cliCtx.Context, from, m, logger); err != nil {
+			return err
+		}
+		logger.Info("backup is valid", "backup", m.Seq)
+		return nil
+	}
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	if err := backup.Restore(cliCtx.Context, from, m, dirs, logger); err != nil {
+		return err
+	}
+	logger.Info("restore done", "backup", m.Seq)
+	return nil
+}
@@ -64,6 +64,8 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 		&snapshotCommand,
 		&supportCommand,
 		//&backupCommand,
+		&incrementalBackupCommand,
+		&restoreCommand,
 	}
 	return app
 }
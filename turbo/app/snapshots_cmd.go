@@ -75,6 +75,8 @@ var snapshotCommand = cli.Command{
 				&utils.DataDirFlag,
 				&SnapshotFromFlag,
 				&SnapshotRebuildFlag,
+				&utils.SnapIndexWorkersFlag,
+				&utils.SnapIndexCheckpointFlag,
 			}),
 		},
 		{
@@ -86,6 +88,7 @@ var snapshotCommand = cli.Command{
 				&SnapshotFromFlag,
 				&SnapshotToFlag,
 				&SnapshotEveryFlag,
+				&utils.SnapCodecsFlag,
 			}),
 		},
 		{
@@ -128,7 +131,7 @@ var snapshotCommand = cli.Command{
 		{
 			Name:   "compress",
 			Action: doCompress,
-			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag}),
+			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag, &SnapshotCodecFlag}),
 		},
 		{
 			Name:   "ram",
@@ -138,7 +141,8 @@ var snapshotCommand = cli.Command{
 		{
 			Name:   "decompress_speed",
 			Action: doDecompressSpeed,
-			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag}),
+			Usage:  "erigon snapshots decompress_speed a.seg [--codec=zstd] - with --codec, also re-compresses a.seg by given codec and compares",
+			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag, &SnapshotCodecFlag}),
 		},
 		{
 			Name:   "diff",
@@ -191,6 +195,10 @@ var (
 		Name:  "rebuild",
 		Usage: "Force rebuild",
 	}
+	SnapshotCodecFlag = cli.StringFlag{
+		Name:  "codec",
+		Usage: "Codec of .seg file: patterns, zstd",
+	}
 )
 
 func doIntegrity(cliCtx *cli.Context) error {
@@ -279,6 +287,60 @@ func doDecompressSpeed(cliCtx *cli.Context) error {
 		return err
 	}
 	defer decompressor.Close()
+	decompressSpeed(decompressor, logger)
+
+	if !cliCtx.IsSet(SnapshotCodecFlag.Name) {
+		return nil
+	}
+	codec, err := seg.ParseCodec(cliCtx.String(SnapshotCodecFlag.Name))
+	if err != nil {
+		return err
+	}
+	if codec == decompressor.Codec() {
+		return nil
+	}
+
+	// re-compress the file by another codec and compare
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	_, fName := filepath.Split(f)
+	recompressed := filepath.Join(dirs.Tmp, fName+"."+codec.String())
+	defer os.Remove(recompressed)
+	c, err := seg.NewCompressor(cliCtx.Context, "decompress_speed", recompressed, dirs.Tmp, seg.MinPatternScore, estimate.CompressSnapshot.Workers(), log.LvlInfo, logger)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	c.SetCodec(codec)
+	if err := decompressor.WithReadAhead(func() error {
+		g := decompressor.MakeGetter()
+		buf := make([]byte, 0, 16*etl.BufIOSize)
+		for g.HasNext() {
+			buf, _ = g.Next(buf[:0])
+			if err := c.AddWord(buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	t := time.Now()
+	if err := c.Compress(); err != nil {
+		return err
+	}
+	logger.Info("compress speed", "codec", codec, "took", time.Since(t))
+
+	other, err := seg.NewDecompressor(recompressed)
+	if err != nil {
+		return err
+	}
+	defer other.Close()
+	logger.Info("size", decompressor.Codec().String(), datasize.ByteSize(decompressor.Size()).HR(), other.Codec().String(), datasize.ByteSize(other.Size()).HR())
+	decompressSpeed(other, logger)
+	return nil
+}
+
+func decompressSpeed(decompressor *seg.Decompressor, logger log.Logger) {
 	func() {
 		defer decompressor.EnableReadAhead().DisableReadAhead()
 
@@ -288,7 +350,7 @@ func doDecompressSpeed(cliCtx *cli.Context) error {
 		for g.HasNext() {
 			buf, _ = g.Next(buf[:0])
 		}
-		logger.Info("decompress speed", "took", time.Since(t))
+		logger.Info("decompress speed", "codec", decompressor.Codec(), "took", time.Since(t))
 	}()
 	func() {
 		defer decompressor.EnableReadAhead().DisableReadAhead()
@@ -298,9 +360,8 @@ func doDecompressSpeed(cliCtx *cli.Context) error {
 		for g.HasNext() {
 			_, _ = g.Skip()
 		}
-		log.Info("decompress skip speed", "took", time.Since(t))
+		logger.Info("decompress skip speed", "codec", decompressor.Codec(), "took", time.Since(t))
 	}()
-	return nil
 }
 
 func doRam(cliCtx *cli.Context) error {
@@ -353,6 +414,8 @@ func doIndicesCommand(cliCtx *cli.Context) error {
 	}
 
 	cfg := ethconfig.NewSnapCfg(true, false, true)
+	cfg.IndexWorkers = cliCtx.Int(utils.SnapIndexWorkersFlag.Name)
+	cfg.IndexCheckpoint = cliCtx.Bool(utils.SnapIndexCheckpointFlag.Name)
 	chainConfig := fromdb.ChainConfig(chainDB)
 	blockSnaps, borSnaps, br, agg, err := openSnaps(ctx, cfg, dirs, chainDB, logger)
 
@@ -476,11 +539,16 @@ func doCompress(cliCtx *cli.Context) error {
 	f := args.First()
 	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
 	logger.Info("file", "datadir", dirs.DataDir, "f", f)
+	codec, err := seg.ParseCodec(cliCtx.String(SnapshotCodecFlag.Name))
+	if err != nil {
+		return err
+	}
 	c, err := seg.NewCompressor(ctx, "compress", f, dirs.Tmp, seg.MinPatternScore, estimate.CompressSnapshot.Workers(), log.LvlInfo, logger)
 	if err != nil {
 		return err
 	}
 	defer c.Close()
+	c.SetCodec(codec)
 	r := bufio.NewReaderSize(os.Stdin, int(128*datasize.MB))
 	buf := make([]byte, 0, int(1*datasize.MB))
 	var l uint64
@@ -529,6 +597,9 @@ func doRetireCommand(cliCtx *cli.Context) error {
 	defer db.Close()
 
 	cfg := ethconfig.NewSnapCfg(true, false, true)
+	if cfg.Codecs, err = ethconfig.ParseSnapshotCodecs(cliCtx.String(utils.SnapCodecsFlag.Name)); err != nil {
+		return err
+	}
 	blockSnaps, borSnaps, br, agg, err := openSnaps(ctx, cfg, dirs, db, logger)
 	if err != nil {
 		return err
@@ -0,0 +1,345 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/erigontech/mdbx-go/mdbx"
+	"github.com/ledgerwatch/erigon-lib/common/dir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Compaction writes compacted copy of db table by table, then swaps it with the db:
+//
+//	<db>-compact               - compacted copy
+//	<db>-compact/compact.json  - tables already copied, next run continues from them
+//	<db>-old                   - original db during swap, removed after swap
+//
+// Compaction is offline: node must be stopped - copy is valid only while source db is not modified
+// (progress remembers txID of source db, and copy starts from scratch if db was modified between runs),
+// and swap replaces files of db. Only FragmentationReport can run on live node.
+const (
+	compactSuffix       = "-compact"
+	oldSuffix           = "-old"
+	compactProgressFile = "compact.json"
+
+	mdbxMetaPages = 3
+)
+
+// compactStater - bucketStater which also reports size of table, to log size of table before and after compaction
+type compactStater interface {
+	bucketStater
+	BucketSize(name string) (uint64, error)
+}
+
+type compactProgress struct {
+	SrcTxID uint64   `json:"srcTxID"`
+	Tables  []string `json:"tables"`
+	Done    bool     `json:"done"` // all tables copied, copy can replace db
+}
+
+type TableFragmentation struct {
+	Table   string
+	Entries uint64
+	Size    uint64 // all pages of table
+	Payload uint64 // keys, values and their headers - estimation of table size after compaction (without branch pages)
+}
+
+// Fill - share of table pages used by data
+func (t TableFragmentation) Fill() float64 {
+	if t.Size == 0 {
+		return 1
+	}
+	if t.Payload >= t.Size {
+		return 1
+	}
+	return float64(t.Payload) / float64(t.Size)
+}
+
+// Reclaimable - estimated amount of space compaction will free
+func (t TableFragmentation) Reclaimable() uint64 {
+	if t.Payload >= t.Size {
+		return 0
+	}
+	return t.Size - t.Payload
+}
+
+type Fragmentation struct {
+	FileSize uint64 // size of mdbx.dat
+	Free     uint64 // pages which are not used by any table - reusable by db, but never returned to filesystem
+	Tables   []TableFragmentation
+}
+
+// mdbx node header and its pointer in page
+const nodeOverhead = 8 + 2
+
+// FragmentationReport - reads stats of all tables and scans them to estimate fill of pages.
+// Opens db read-only - can run on live node.
+func FragmentationReport(ctx context.Context, path string, label kv.Label, logger log.Logger) (*Fragmentation, error) {
+	db, err := mdbx2.NewMDBX(logger).Path(path).
+		Label(label).
+		Readonly().
+		Accede().
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	stater, ok := tx.(bucketStater)
+	if !ok {
+		return nil, fmt.Errorf("compaction is supported only for mdbx, got %T", tx)
+	}
+	info, err := db.(*mdbx2.MdbxKV).Env().Info(nil)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := uint64(info.PageSize)
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	report := &Fragmentation{FileSize: info.Geo.Current}
+	usedPages := uint64(mdbxMetaPages)
+	for _, name := range []string{"gc", "root"} {
+		stat, err := stater.BucketStat(name)
+		if err != nil {
+			return nil, err
+		}
+		usedPages += stat.BranchPages + stat.LeafPages + stat.OverflowPages
+	}
+	for _, table := range compactTables(label) {
+		if exists, err := stater.ExistsBucket(table); err != nil {
+			return nil, err
+		} else if !exists {
+			continue
+		}
+		stat, err := stater.BucketStat(table)
+		if err != nil {
+			return nil, err
+		}
+		usedPages += stat.BranchPages + stat.LeafPages + stat.OverflowPages
+		t := TableFragmentation{Table: table, Entries: stat.Entries, Size: (stat.BranchPages + stat.LeafPages + stat.OverflowPages) * pageSize}
+		if t.Payload, err = tablePayload(ctx, tx, table, logEvery, logger); err != nil {
+			return nil, fmt.Errorf("table %s: %w", table, err)
+		}
+		report.Tables = append(report.Tables, t)
+		logger.Info("[compact] table", "table", table, "entries", t.Entries, "size", datasize.ByteSize(t.Size).HR(),
+			"fill", fmt.Sprintf("%.1f%%", t.Fill()*100), "reclaimable", datasize.ByteSize(t.Reclaimable()).HR())
+	}
+	if allocated := uint64(info.LastPNO) + 1; allocated > usedPages {
+		report.Free = (allocated - usedPages) * pageSize
+	}
+	var reclaimable uint64
+	for _, t := range report.Tables {
+		reclaimable += t.Reclaimable()
+	}
+	logger.Info("[compact] db", "path", path, "file", datasize.ByteSize(report.FileSize).HR(), "free", datasize.ByteSize(report.Free).HR(),
+		"reclaimable_from_tables", datasize.ByteSize(reclaimable).HR())
+	return report, nil
+}
+
+func tablePayload(ctx context.Context, tx kv.Tx, table string, logEvery *time.Ticker, logger log.Logger) (payload uint64, err error) {
+	c, err := tx.Cursor(table)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	var i uint64
+	for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return 0, err
+		}
+		payload += uint64(len(k)+len(v)) + nodeOverhead
+		i++
+		if i%100_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-logEvery.C:
+				logger.Info("[compact] scanning", "table", table, "entries", i)
+			default:
+			}
+		}
+	}
+	return payload, nil
+}
+
+func compactTables(label kv.Label) []string {
+	tablesCfg := kv.TablesCfgByLabel(label)
+	tables := maps.Keys(tablesCfg)
+	sort.Strings(tables)
+	return slices.DeleteFunc(tables, func(table string) bool { return tablesCfg[table].IsDeprecated })
+}
+
+// Compact - replaces db by its compacted copy. Offline: db must not be used by other processes, node must be stopped.
+// Continues interrupted compaction: copy of tables or swap of directories.
+func Compact(ctx context.Context, path string, label kv.Label, readAheadThreads int, logger log.Logger) error {
+	if swapped, err := finishSwap(path, logger); err != nil || swapped {
+		return err
+	}
+	if err := compactCopy(ctx, path, label, readAheadThreads, logger); err != nil {
+		return err
+	}
+	return swap(path, logger)
+}
+
+func compactCopy(ctx context.Context, path string, label kv.Label, readAheadThreads int, logger log.Logger) error {
+	src, err := mdbx2.NewMDBX(logger).Path(path).
+		Label(label).
+		Exclusive().
+		Accede().
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return fmt.Errorf("open %s exclusively (is node stopped?): %w", path, err)
+	}
+	defer src.Close()
+	srcTx, err := src.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcTx.Rollback()
+	stater, ok := srcTx.(compactStater)
+	if !ok {
+		return fmt.Errorf("compaction is supported only for mdbx, got %T", srcTx)
+	}
+
+	to := path + compactSuffix
+	progressPath := filepath.Join(to, compactProgressFile)
+	progress := &compactProgress{}
+	if dir.FileExist(progressPath) {
+		if err := readJSON(progressPath, progress); err != nil {
+			return err
+		}
+	}
+	if progress.SrcTxID != srcTx.ViewID() {
+		if len(progress.Tables) > 0 {
+			logger.Info("[compact] db was modified since previous run, starting from scratch", "path", path)
+		}
+		if err := os.RemoveAll(to); err != nil {
+			return err
+		}
+		progress = &compactProgress{SrcTxID: srcTx.ViewID()}
+	} else if progress.Done {
+		return nil
+	} else if len(progress.Tables) > 0 {
+		logger.Info("[compact] resuming", "path", path, "tables", len(progress.Tables))
+	}
+
+	info, err := src.(*mdbx2.MdbxKV).Env().Info(nil)
+	if err != nil {
+		return err
+	}
+	dst, err := mdbx2.NewMDBX(logger).Path(to).
+		Label(label).
+		PageSize(src.(*mdbx2.MdbxKV).PageSize()).
+		MapSize(datasize.ByteSize(info.Geo.Upper)).
+		GrowthStep(8 * datasize.GB).
+		Flags(func(flags uint) uint { return flags | mdbx.WriteMap }).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for _, table := range compactTables(label) {
+		if slices.Contains(progress.Tables, table) {
+			continue
+		}
+		if exists, err := stater.ExistsBucket(table); err != nil {
+			return err
+		} else if !exists {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := backupTable(ctx, src, srcTx, dst, table, readAheadThreads, logEvery, logger); err != nil {
+			return fmt.Errorf("table %s: %w", table, err)
+		}
+		before, err := stater.BucketSize(table)
+		if err != nil {
+			return err
+		}
+		var after uint64
+		if err := dst.View(ctx, func(tx kv.Tx) (err error) {
+			after, err = tx.(compactStater).BucketSize(table)
+			return err
+		}); err != nil {
+			return err
+		}
+		progress.Tables = append(progress.Tables, table)
+		if err := writeJSON(progressPath, progress); err != nil {
+			return err
+		}
+		logger.Info("[compact] table", "table", table, "before", datasize.ByteSize(before).HR(), "after", datasize.ByteSize(after).HR())
+	}
+	progress.Done = true
+	return writeJSON(progressPath, progress)
+}
+
+// swap - replaces db by compacted copy, in steps which finishSwap can continue after crash
+func swap(path string, logger log.Logger) error {
+	if err := os.Rename(path, path+oldSuffix); err != nil {
+		return err
+	}
+	if _, err := finishSwap(path, logger); err != nil {
+		return err
+	}
+	return nil
+}
+
+// finishSwap - continues swap if db was already moved to <db>-old. Returns true if swap is done.
+func finishSwap(path string, logger log.Logger) (bool, error) {
+	old := path + oldSuffix
+	progressPath := filepath.Join(path, compactProgressFile)
+	if !dir.Exist(old) {
+		if !dir.FileExist(progressPath) {
+			return false, nil
+		}
+		// crashed after removal of old db
+		return true, os.Remove(progressPath)
+	}
+	if !dir.Exist(path) {
+		progress := &compactProgress{}
+		if err := readJSON(filepath.Join(path+compactSuffix, compactProgressFile), progress); err != nil {
+			return false, fmt.Errorf("%s exists, but compacted copy is not found: %w", old, err)
+		}
+		if !progress.Done {
+			return false, fmt.Errorf("%s exists, but compacted copy is incomplete", old)
+		}
+		if err := os.Rename(path+compactSuffix, path); err != nil {
+			return false, err
+		}
+	}
+	if !dir.FileExist(progressPath) {
+		return false, fmt.Errorf("both %s and %s exist, don't know which one to keep", path, old)
+	}
+	if err := os.RemoveAll(old); err != nil {
+		return false, err
+	}
+	if err := os.Remove(progressPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	logger.Info("[compact] db replaced by compacted copy", "path", path)
+	return true, nil
+}
@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func fragmentedDB(t *testing.T, path string) {
+	t.Helper()
+	db := mdbx2.NewMDBX(log.New()).Path(path).Label(kv.ChainDB).MustOpen()
+	defer db.Close()
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		var k [8]byte
+		for i := uint64(0); i < 50_000; i++ {
+			binary.BigEndian.PutUint64(k[:], i)
+			if err := tx.Put(kv.Headers, k[:], make([]byte, 100)); err != nil {
+				return err
+			}
+			if err := tx.Put(kv.AccountChangeSet, k[:4], k[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	// delete 9 of 10 keys - most of pages stay in db, but almost empty
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		var k [8]byte
+		for i := uint64(0); i < 50_000; i++ {
+			if i%10 == 0 {
+				continue
+			}
+			binary.BigEndian.PutUint64(k[:], i)
+			if err := tx.Delete(kv.Headers, k[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func readCompactedDB(t *testing.T, path string) (headers, changes int) {
+	t.Helper()
+	db := mdbx2.NewMDBX(log.New()).Path(path).Label(kv.ChainDB).MustOpen()
+	defer db.Close()
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) error {
+		if err := tx.ForEach(kv.Headers, nil, func(k, v []byte) error {
+			require.Zero(t, binary.BigEndian.Uint64(k)%10)
+			require.Equal(t, 100, len(v))
+			headers++
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.ForEach(kv.AccountChangeSet, nil, func(k, v []byte) error {
+			changes++
+			return nil
+		})
+	}))
+	return headers, changes
+}
+
+func TestFragmentationReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chaindata")
+	fragmentedDB(t, path)
+
+	report, err := FragmentationReport(context.Background(), path, kv.ChainDB, log.New())
+	require.NoError(t, err)
+	var headers *TableFragmentation
+	for i := range report.Tables {
+		if report.Tables[i].Table == kv.Headers {
+			headers = &report.Tables[i]
+		}
+	}
+	require.NotNil(t, headers)
+	require.Equal(t, uint64(5_000), headers.Entries)
+	require.Less(t, headers.Fill(), 0.5)
+	require.Greater(t, headers.Reclaimable(), uint64(0))
+}
+
+func TestCompact(t *testing.T) {
+	logger := log.New()
+	path := filepath.Join(t.TempDir(), "chaindata")
+	fragmentedDB(t, path)
+
+	before, err := FragmentationReport(context.Background(), path, kv.ChainDB, logger)
+	require.NoError(t, err)
+	require.NoError(t, Compact(context.Background(), path, kv.ChainDB, 1, logger))
+	require.NoDirExists(t, path+compactSuffix)
+	require.NoDirExists(t, path+oldSuffix)
+	require.NoFileExists(t, filepath.Join(path, compactProgressFile))
+
+	headers, changes := readCompactedDB(t, path)
+	require.Equal(t, 5_000, headers)
+	require.Equal(t, 50_000, changes)
+
+	after, err := FragmentationReport(context.Background(), path, kv.ChainDB, logger)
+	require.NoError(t, err)
+	size := func(f *Fragmentation, table string) uint64 {
+		for _, t := range f.Tables {
+			if t.Table == table {
+				return t.Size
+			}
+		}
+		return 0
+	}
+	require.Less(t, size(after, kv.Headers), size(before, kv.Headers)/2)
+}
+
+func TestCompactResume(t *testing.T) {
+	logger := log.New()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "chaindata")
+	fragmentedDB(t, path)
+
+	// progress of other db state is ignored
+	require.NoError(t, os.MkdirAll(path+compactSuffix, 0740))
+	require.NoError(t, writeJSON(filepath.Join(path+compactSuffix, compactProgressFile), &compactProgress{SrcTxID: 1, Tables: []string{kv.Headers}}))
+	require.NoError(t, compactCopy(ctx, path, kv.ChainDB, 1, logger))
+	progress := &compactProgress{}
+	require.NoError(t, readJSON(filepath.Join(path+compactSuffix, compactProgressFile), progress))
+	require.True(t, progress.Done)
+	require.Contains(t, progress.Tables, kv.Headers)
+	require.NotEqual(t, uint64(1), progress.SrcTxID)
+
+	// copy of not modified db is reused
+	require.NoError(t, compactCopy(ctx, path, kv.ChainDB, 1, logger))
+	progress2 := &compactProgress{}
+	require.NoError(t, readJSON(filepath.Join(path+compactSuffix, compactProgressFile), progress2))
+	require.Equal(t, progress, progress2)
+
+	// crash in the middle of swap
+	require.NoError(t, os.Rename(path, path+oldSuffix))
+	require.NoError(t, Compact(ctx, path, kv.ChainDB, 1, logger))
+	require.NoDirExists(t, path+oldSuffix)
+	require.NoDirExists(t, path+compactSuffix)
+	headers, changes := readCompactedDB(t, path)
+	require.Equal(t, 5_000, headers)
+	require.Equal(t, 50_000, changes)
+}
@@ -0,0 +1,706 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/erigontech/mdbx-go/mdbx"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/common/dir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/maps"
+)
+
+// Incremental backup keeps all backups made into one directory:
+//
+//	objects/<hh>/<sha256> - content-addressed files: dumps of db tables and copies of snapshot files
+//	manifests/<seq>.json  - one per completed backup. Restore of any of them gives datadir as it was at that backup
+//	progress.json         - objects done by unfinished backup, next run continues from them
+//
+// Table is dumped only if it was modified since the previous dump (mdbx tracks txID of the last modification of each table).
+// Snapshot file is copied only if its size or modification time changed.
+// All tables of a db are read in one read-only transaction - so backup is consistent and can run on live node.
+const (
+	manifestsDir = "manifests"
+	objectsDir   = "objects"
+	progressFile = "progress.json"
+)
+
+// IncrementalLabels - databases included into incremental backup
+var IncrementalLabels = []kv.Label{kv.ChainDB, kv.TxPoolDB, kv.DownloaderDB}
+
+type Manifest struct {
+	Seq    uint64       `json:"seq"`
+	Time   time.Time    `json:"time"`
+	Tables []TableEntry `json:"tables"`
+	Files  []FileEntry  `json:"files"`
+}
+
+type TableEntry struct {
+	Label   string `json:"label"`
+	Table   string `json:"table"`
+	ModTxID uint64 `json:"modTxID"` // txID of the last modification of the table
+	Entries uint64 `json:"entries"`
+	Object  string `json:"object"`
+	Size    int64  `json:"size"`
+}
+
+type FileEntry struct {
+	Path    string    `json:"path"` // relative to datadir
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Object  string    `json:"object"`
+}
+
+func (e TableEntry) key() string {
+	return e.Label + "/" + e.Table + "/" + strconv.FormatUint(e.ModTxID, 10) + "/" + strconv.FormatUint(e.Entries, 10)
+}
+func (e FileEntry) key() string {
+	return e.Path + "/" + strconv.FormatInt(e.Size, 10) + "/" + strconv.FormatInt(e.ModTime.UnixNano(), 10)
+}
+
+func dbPath(dirs datadir.Dirs, label kv.Label) string {
+	switch label {
+	case kv.ChainDB:
+		return dirs.Chaindata
+	case kv.TxPoolDB:
+		return dirs.TxPool
+	case kv.DownloaderDB:
+		return dirs.Downloader
+	default:
+		panic(fmt.Sprintf("unexpected: %+v", label))
+	}
+}
+
+func objectPath(backupDir, object string) string {
+	return filepath.Join(backupDir, objectsDir, object[:2], object)
+}
+
+// LoadManifests returns manifests of all completed backups, ordered by Seq
+func LoadManifests(backupDir string) ([]*Manifest, error) {
+	entries, err := os.ReadDir(filepath.Join(backupDir, manifestsDir))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var manifests []*Manifest
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		m := &Manifest{}
+		if err := readJSON(filepath.Join(backupDir, manifestsDir, e.Name()), m); err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Seq < manifests[j].Seq })
+	return manifests, nil
+}
+
+// FindManifest - by seq number, or the latest backup made at or before given time (RFC3339). Empty `at` - the latest backup
+func FindManifest(backupDir, at string) (*Manifest, error) {
+	manifests, err := LoadManifests(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no backups in %s", backupDir)
+	}
+	if at == "" {
+		return manifests[len(manifests)-1], nil
+	}
+	if seq, err := strconv.ParseUint(at, 10, 64); err == nil {
+		for _, m := range manifests {
+			if m.Seq == seq {
+				return m, nil
+			}
+		}
+		return nil, fmt.Errorf("backup %d not found", seq)
+	}
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return nil, fmt.Errorf("expected backup number or time in RFC3339 format, got: %s", at)
+	}
+	var found *Manifest
+	for _, m := range manifests {
+		if !m.Time.After(t) {
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no backups made before %s", at)
+	}
+	return found, nil
+}
+
+// Incremental - makes new backup of datadir into backupDir. Continues unfinished backup if there is one.
+func Incremental(ctx context.Context, dirs datadir.Dirs, backupDir string, labels []kv.Label, readAheadThreads int, logger log.Logger) (*Manifest, error) {
+	if err := os.MkdirAll(filepath.Join(backupDir, manifestsDir), 0740); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(backupDir, objectsDir), 0740); err != nil {
+		return nil, err
+	}
+	manifests, err := LoadManifests(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	progress := &Manifest{}
+	if dir.FileExist(filepath.Join(backupDir, progressFile)) {
+		if err := readJSON(filepath.Join(backupDir, progressFile), progress); err != nil {
+			return nil, err
+		}
+		logger.Info("[backup] resuming", "tables", len(progress.Tables), "files", len(progress.Files))
+	}
+
+	// everything what was already backed up, and is still in backup dir
+	knownTables, knownFiles := map[string]TableEntry{}, map[string]FileEntry{}
+	for _, m := range append(manifests, progress) {
+		for _, e := range m.Tables {
+			if dir.FileExist(objectPath(backupDir, e.Object)) {
+				knownTables[e.key()] = e
+			}
+		}
+		for _, e := range m.Files {
+			if dir.FileExist(objectPath(backupDir, e.Object)) {
+				knownFiles[e.key()] = e
+			}
+		}
+	}
+
+	b := &incrementalBackup{backupDir: backupDir, progress: progress, readAheadThreads: readAheadThreads, logger: logger}
+	m := &Manifest{Time: time.Now().UTC()}
+	if len(manifests) > 0 {
+		m.Seq = manifests[len(manifests)-1].Seq + 1
+	}
+
+	// databases first: snapshot files which they reference may only be added after that, never removed
+	for _, label := range labels {
+		tables, err := b.backupDB(ctx, dbPath(dirs, label), label, knownTables)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+		m.Tables = append(m.Tables, tables...)
+	}
+	if m.Files, err = b.backupFiles(ctx, dirs, knownFiles); err != nil {
+		return nil, err
+	}
+
+	if err := writeJSON(filepath.Join(backupDir, manifestsDir, fmt.Sprintf("%06d.json", m.Seq)), m); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filepath.Join(backupDir, progressFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return m, nil
+}
+
+type incrementalBackup struct {
+	backupDir        string
+	progress         *Manifest
+	readAheadThreads int
+	logger           log.Logger
+}
+
+// bucketStater - kv.Tx of mdbx
+type bucketStater interface {
+	BucketStat(name string) (*mdbx.Stat, error)
+	ExistsBucket(name string) (bool, error)
+}
+
+func (b *incrementalBackup) backupDB(ctx context.Context, path string, label kv.Label, known map[string]TableEntry) (entries []TableEntry, err error) {
+	if !dir.FileExist(filepath.Join(path, "mdbx.dat")) {
+		return nil, nil
+	}
+	db, err := mdbx2.NewMDBX(b.logger).Path(path).
+		Label(label).
+		Readonly().
+		Accede().
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	stater, ok := tx.(bucketStater)
+	if !ok {
+		return nil, fmt.Errorf("incremental backup is supported only for mdbx, got %T", tx)
+	}
+
+	tablesCfg := kv.TablesCfgByLabel(label)
+	tables := maps.Keys(tablesCfg)
+	sort.Strings(tables)
+	var skipped int
+	for _, table := range tables {
+		if tablesCfg[table].IsDeprecated {
+			continue
+		}
+		if exists, err := stater.ExistsBucket(table); err != nil {
+			return nil, err
+		} else if !exists {
+			continue
+		}
+		stat, err := stater.BucketStat(table)
+		if err != nil {
+			return nil, err
+		}
+		e := TableEntry{Label: label.String(), Table: table, ModTxID: stat.LastTxId, Entries: stat.Entries}
+		if prev, ok := known[e.key()]; ok {
+			entries = append(entries, prev)
+			skipped++
+			continue
+		}
+		if e.Object, e.Size, err = b.dumpTable(ctx, db, tx, table); err != nil {
+			return nil, fmt.Errorf("table %s: %w", table, err)
+		}
+		entries = append(entries, e)
+		b.progress.Tables = append(b.progress.Tables, e)
+		if err := b.saveProgress(); err != nil {
+			return nil, err
+		}
+		b.logger.Info("[backup] table", "db", label, "table", table, "entries", e.Entries, "size", datasize.ByteSize(e.Size).HR())
+	}
+	b.logger.Info("[backup] db done", "db", label, "txID", tx.ViewID(), "tables", len(entries), "unchanged", skipped)
+	return entries, nil
+}
+
+// dumpTable - zstd stream of uvarint(len(k)), k, uvarint(len(v)), v - in order of the table
+func (b *incrementalBackup) dumpTable(ctx context.Context, db kv.RoDB, tx kv.Tx, table string) (object string, size int64, err error) {
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	warmupCtx, warmupCancel := context.WithCancel(ctx)
+	defer warmupCancel()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		WarmupTable(warmupCtx, db, table, log.LvlTrace, b.readAheadThreads)
+	}()
+
+	w, err := newObjectWriter(b.backupDir)
+	if err != nil {
+		return "", 0, err
+	}
+	defer w.abort()
+	zw, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		return "", 0, err
+	}
+	defer zw.Close()
+	bw := bufio.NewWriterSize(zw, 1024*1024)
+
+	c, err := tx.Cursor(table)
+	if err != nil {
+		return "", 0, err
+	}
+	defer c.Close()
+	var numBuf [binary.MaxVarintLen64]byte
+	var i uint64
+	for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return "", 0, err
+		}
+		for _, part := range [][]byte{k, v} {
+			n := binary.PutUvarint(numBuf[:], uint64(len(part)))
+			if _, err := bw.Write(numBuf[:n]); err != nil {
+				return "", 0, err
+			}
+			if _, err := bw.Write(part); err != nil {
+				return "", 0, err
+			}
+		}
+		i++
+		if i%100_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return "", 0, ctx.Err()
+			default:
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return "", 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return "", 0, err
+	}
+	return w.commit()
+}
+
+func (b *incrementalBackup) backupFiles(ctx context.Context, dirs datadir.Dirs, known map[string]FileEntry) (entries []FileEntry, err error) {
+	var copied int
+	err = filepath.WalkDir(dirs.Snap, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "db" { // downloader db of old versions
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".tmp") || !d.Type().IsRegular() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) { // removed by merge
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dirs.DataDir, path)
+		if err != nil {
+			return err
+		}
+		e := FileEntry{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime().UTC()}
+		if prev, ok := known[e.key()]; ok {
+			entries = append(entries, prev)
+			return nil
+		}
+		if e.Object, err = b.copyFile(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		entries = append(entries, e)
+		copied++
+		b.progress.Files = append(b.progress.Files, e)
+		return b.saveProgress()
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.logger.Info("[backup] files done", "files", len(entries), "copied", copied)
+	return entries, nil
+}
+
+func (b *incrementalBackup) copyFile(path string) (object string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	w, err := newObjectWriter(b.backupDir)
+	if err != nil {
+		return "", err
+	}
+	defer w.abort()
+	if _, err = io.Copy(w, bufio.NewReaderSize(f, 1024*1024)); err != nil {
+		return "", err
+	}
+	object, _, err = w.commit()
+	return object, err
+}
+
+func (b *incrementalBackup) saveProgress() error {
+	return writeJSON(filepath.Join(b.backupDir, progressFile), b.progress)
+}
+
+// objectWriter - writes to temporary file, and moves it to objects/ under name of its hash
+type objectWriter struct {
+	f       *os.File
+	w       *bufio.Writer
+	h       hash.Hash
+	size    int64
+	dir     string
+	aborted bool
+}
+
+func newObjectWriter(backupDir string) (*objectWriter, error) {
+	f, err := os.CreateTemp(filepath.Join(backupDir, objectsDir), "*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &objectWriter{f: f, w: bufio.NewWriterSize(f, 1024*1024), h: sha256.New(), dir: backupDir}, nil
+}
+
+func (w *objectWriter) Write(p []byte) (int, error) {
+	w.h.Write(p)
+	w.size += int64(len(p))
+	return w.w.Write(p)
+}
+
+func (w *objectWriter) commit() (object string, size int64, err error) {
+	if err = w.w.Flush(); err != nil {
+		return "", 0, err
+	}
+	if err = w.f.Sync(); err != nil {
+		return "", 0, err
+	}
+	if err = w.f.Close(); err != nil {
+		return "", 0, err
+	}
+	object = hex.EncodeToString(w.h.Sum(nil))
+	to := objectPath(w.dir, object)
+	if err = os.MkdirAll(filepath.Dir(to), 0740); err != nil {
+		return "", 0, err
+	}
+	if err = os.Rename(w.f.Name(), to); err != nil {
+		return "", 0, err
+	}
+	w.aborted = true
+	return object, w.size, nil
+}
+
+// abort - removes temporary file if commit didn't happen
+func (w *objectWriter) abort() {
+	if w.aborted {
+		return
+	}
+	w.aborted = true
+	w.f.Close()
+	_ = os.Remove(w.f.Name())
+}
+
+// Verify - checks that all objects of the manifest exist and their hashes match
+func Verify(ctx context.Context, backupDir string, m *Manifest, logger log.Logger) error {
+	sizes := map[string]int64{}
+	for _, e := range m.Tables {
+		sizes[e.Object] = e.Size
+	}
+	for _, e := range m.Files {
+		sizes[e.Object] = e.Size
+	}
+	objects := make([]string, 0, len(sizes))
+	for object := range sizes {
+		objects = append(objects, object)
+	}
+	sort.Strings(objects)
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for i, object := range objects {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[restore] verifying", "progress", fmt.Sprintf("%d/%d", i, len(objects)))
+		default:
+		}
+		h, size, err := hashFile(objectPath(backupDir, object))
+		if err != nil {
+			return err
+		}
+		if h != object || size != sizes[object] {
+			return fmt.Errorf("object %s is corrupted: hash %s, size %d, expected size %d", object, h, size, sizes[object])
+		}
+	}
+	return nil
+}
+
+// Restore - creates datadir from the backup. Target databases must not exist.
+func Restore(ctx context.Context, backupDir string, m *Manifest, dirs datadir.Dirs, logger log.Logger) error {
+	byLabel := map[string][]TableEntry{}
+	for _, e := range m.Tables {
+		byLabel[e.Label] = append(byLabel[e.Label], e)
+	}
+	for l := range byLabel {
+		if path := dbPath(dirs, kv.UnmarshalLabel(l)); dir.FileExist(filepath.Join(path, "mdbx.dat")) {
+			return fmt.Errorf("db already exists: %s", path)
+		}
+	}
+	if err := Verify(ctx, backupDir, m, logger); err != nil {
+		return err
+	}
+
+	for _, e := range m.Files {
+		to := filepath.Join(dirs.DataDir, filepath.FromSlash(e.Path))
+		if err := restoreFile(objectPath(backupDir, e.Object), to, e.ModTime); err != nil {
+			return err
+		}
+	}
+	logger.Info("[restore] files done", "files", len(m.Files))
+
+	for _, label := range IncrementalLabels {
+		tables := byLabel[label.String()]
+		if len(tables) == 0 {
+			continue
+		}
+		if err := restoreDB(ctx, backupDir, dbPath(dirs, label), label, tables, logger); err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+	}
+	return nil
+}
+
+func restoreFile(from, to string, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0740); err != nil {
+		return err
+	}
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if _, err = io.Copy(dst, src); err != nil {
+		return err
+	}
+	if err = dst.Sync(); err != nil {
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(to, modTime, modTime)
+}
+
+func restoreDB(ctx context.Context, backupDir, path string, label kv.Label, tables []TableEntry, logger log.Logger) error {
+	if err := os.MkdirAll(path, 0740); err != nil {
+		return err
+	}
+	db, err := mdbx2.NewMDBX(logger).Path(path).
+		Label(label).
+		GrowthStep(8 * datasize.GB).
+		Flags(func(flags uint) uint { return flags | mdbx.WriteMap }).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
+		Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	for _, e := range tables {
+		if err := db.Update(ctx, func(tx kv.RwTx) error {
+			return restoreTable(ctx, objectPath(backupDir, e.Object), tx, e.Table)
+		}); err != nil {
+			return fmt.Errorf("table %s: %w", e.Table, err)
+		}
+		logger.Info("[restore] table", "db", label, "table", e.Table, "entries", e.Entries)
+	}
+	return nil
+}
+
+func restoreTable(ctx context.Context, object string, tx kv.RwTx, table string) error {
+	f, err := os.Open(object)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	r := bufio.NewReaderSize(zr, 1024*1024)
+
+	c, err := tx.RwCursor(table)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	casted, isDupsort := c.(kv.RwCursorDupSort)
+
+	readPart := func() ([]byte, error) {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		part := make([]byte, l)
+		_, err = io.ReadFull(r, part)
+		return part, err
+	}
+	for i := 0; ; i++ {
+		k, err := readPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		v, err := readPart()
+		if err != nil {
+			return err
+		}
+		if isDupsort {
+			err = casted.AppendDup(k, v)
+		} else {
+			err = c.Append(k, v)
+		}
+		if err != nil {
+			return err
+		}
+		if i%100_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+}
+
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, bufio.NewReaderSize(f, 1024*1024))
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON - atomically replaces file
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := dir.WriteFileWithFsync(path+".tmp", data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
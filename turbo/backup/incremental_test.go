@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalBackupRestore(t *testing.T) {
+	logger := log.New()
+	ctx := context.Background()
+	dirs, backupDir := datadir.New(t.TempDir()), t.TempDir()
+
+	// mdbx doesn't allow to open same db twice in one process - so it's closed while backup is running
+	put := func(table string, k, v string) {
+		db := mdbx2.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).MustOpen()
+		defer db.Close()
+		require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error { return tx.Put(table, []byte(k), []byte(v)) }))
+	}
+	put(kv.Headers, "1", "header1")
+	put(kv.AccountChangeSet, "1", "a")
+	put(kv.AccountChangeSet, "1", "b")
+	segFile := filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg")
+	require.NoError(t, os.WriteFile(segFile, []byte("segment"), 0644))
+
+	first, err := Incremental(ctx, dirs, backupDir, []kv.Label{kv.ChainDB}, 1, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), first.Seq)
+	require.Equal(t, 1, len(first.Files))
+
+	put(kv.Headers, "2", "header2")
+	require.NoError(t, os.WriteFile(filepath.Join(dirs.Snap, "v1-000500-001000-headers.seg"), []byte("segment2"), 0644))
+	second, err := Incremental(ctx, dirs, backupDir, []kv.Label{kv.ChainDB}, 1, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), second.Seq)
+	require.Equal(t, 2, len(second.Files))
+
+	objects := func(m *Manifest) map[string]string {
+		res := map[string]string{}
+		for _, e := range m.Tables {
+			res[e.Table] = e.Object
+		}
+		for _, e := range m.Files {
+			res[e.Path] = e.Object
+		}
+		return res
+	}
+	firstObjects, secondObjects := objects(first), objects(second)
+	require.NotEqual(t, firstObjects[kv.Headers], secondObjects[kv.Headers])
+	require.Equal(t, firstObjects[kv.AccountChangeSet], secondObjects[kv.AccountChangeSet])
+	require.Equal(t, firstObjects["snapshots/v1-000000-000500-headers.seg"], secondObjects["snapshots/v1-000000-000500-headers.seg"])
+
+	latest, err := FindManifest(backupDir, "")
+	require.NoError(t, err)
+	require.Equal(t, second.Seq, latest.Seq)
+
+	restore := func(m *Manifest) (headers map[string]string, changes []string, restoredDirs datadir.Dirs) {
+		restoredDirs = datadir.New(t.TempDir())
+		require.NoError(t, Restore(ctx, backupDir, m, restoredDirs, logger))
+		restored := mdbx2.NewMDBX(logger).Path(restoredDirs.Chaindata).Label(kv.ChainDB).MustOpen()
+		defer restored.Close()
+		headers = map[string]string{}
+		require.NoError(t, restored.View(ctx, func(tx kv.Tx) error {
+			if err := tx.ForEach(kv.Headers, nil, func(k, v []byte) error {
+				headers[string(k)] = string(v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return tx.ForEach(kv.AccountChangeSet, nil, func(k, v []byte) error {
+				changes = append(changes, string(v))
+				return nil
+			})
+		}))
+		return headers, changes, restoredDirs
+	}
+
+	headers, changes, restoredDirs := restore(first)
+	require.Equal(t, map[string]string{"1": "header1"}, headers)
+	require.Equal(t, []string{"a", "b"}, changes)
+	seg, err := os.ReadFile(filepath.Join(restoredDirs.Snap, "v1-000000-000500-headers.seg"))
+	require.NoError(t, err)
+	require.Equal(t, "segment", string(seg))
+	require.NoFileExists(t, filepath.Join(restoredDirs.Snap, "v1-000500-001000-headers.seg"))
+
+	headers, _, restoredDirs = restore(second)
+	require.Equal(t, map[string]string{"1": "header1", "2": "header2"}, headers)
+	require.FileExists(t, filepath.Join(restoredDirs.Snap, "v1-000500-001000-headers.seg"))
+
+	// restore refuses corrupted backup
+	require.NoError(t, os.WriteFile(objectPath(backupDir, secondObjects["snapshots/v1-000000-000500-headers.seg"]), []byte("segmenT"), 0644))
+	require.ErrorContains(t, Verify(ctx, backupDir, second, logger), "corrupted")
+	require.Error(t, Restore(ctx, backupDir, second, datadir.New(t.TempDir()), logger))
+}
+
+func TestIncrementalBackupResume(t *testing.T) {
+	logger := log.New()
+	dirs, backupDir := datadir.New(t.TempDir()), t.TempDir()
+
+	db := mdbx2.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).MustOpen()
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error { return tx.Put(kv.Headers, []byte("1"), []byte("header1")) }))
+	db.Close()
+	require.NoError(t, os.WriteFile(filepath.Join(dirs.Snap, "v1-000000-000500-headers.seg"), []byte("segment"), 0644))
+
+	// interrupted after the db
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &incrementalBackup{backupDir: backupDir, progress: &Manifest{}, readAheadThreads: 1, logger: logger}
+	require.NoError(t, os.MkdirAll(filepath.Join(backupDir, objectsDir), 0740))
+	tables, err := b.backupDB(ctx, dirs.Chaindata, kv.ChainDB, nil)
+	require.NoError(t, err)
+	cancel()
+	_, err = Incremental(ctx, dirs, backupDir, []kv.Label{kv.ChainDB}, 1, logger)
+	require.ErrorIs(t, err, context.Canceled)
+	require.FileExists(t, filepath.Join(backupDir, progressFile))
+
+	m, err := Incremental(context.Background(), dirs, backupDir, []kv.Label{kv.ChainDB}, 1, logger)
+	require.NoError(t, err)
+	require.Equal(t, tables, m.Tables)
+	require.Equal(t, 1, len(m.Files))
+	require.NoFileExists(t, filepath.Join(backupDir, progressFile))
+}
@@ -28,6 +28,10 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolLifetimeFlag,
 	&utils.TxPoolTraceSendersFlag,
 	&utils.TxPoolCommitEveryFlag,
+	&utils.TxPoolJournalFlag,
+	&utils.TxPoolRejournalFlag,
+	&utils.TxPoolOrderingFlag,
+	&utils.TxPoolOrderingFeeBandFlag,
 	&PruneFlag,
 	&PruneHistoryFlag,
 	&PruneReceiptFlag,
@@ -42,6 +46,7 @@ var DefaultFlags = []cli.Flag{
 	&DatabaseVerbosityFlag,
 	&PrivateApiAddr,
 	&PrivateApiRateLimit,
+	&PrivateApiACLFlag,
 	&EtlBufferSizeFlag,
 	&TLSFlag,
 	&TLSCertFlag,
@@ -69,6 +74,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.WsCompressionFlag,
 	&utils.HTTPTraceFlag,
 	&utils.StateCacheFlag,
+	&utils.StateCacheWarmStartFileFlag,
 	&utils.RpcBatchConcurrencyFlag,
 	&utils.RpcStreamingDisableFlag,
 	&utils.DBReadConcurrencyFlag,
@@ -92,6 +98,10 @@ var DefaultFlags = []cli.Flag{
 
 	&utils.SnapKeepBlocksFlag,
 	&utils.SnapStopFlag,
+	&utils.SnapCodecsFlag,
+	&utils.SnapDownloadFlag,
+	&utils.SnapIndexWorkersFlag,
+	&utils.SnapIndexCheckpointFlag,
 	&utils.DbPageSizeFlag,
 	&utils.DbSizeLimitFlag,
 	&utils.ForcePartialCommitFlag,
@@ -106,6 +116,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.ListenPortFlag,
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
+	&utils.P2pSnapServerFlag,
 	&utils.NATFlag,
 	&utils.NoDiscoverFlag,
 	&utils.DiscoveryV5Flag,
@@ -149,6 +160,7 @@ var DefaultFlags = []cli.Flag{
 	&HealthCheckFlag,
 	&utils.HeimdallURLFlag,
 	&utils.WebSeedsFlag,
+	&utils.WebSeedServerAddrFlag,
 	&utils.WithoutHeimdallFlag,
 	&utils.BorBlockPeriodFlag,
 	&utils.BorBlockSizeFlag,
@@ -163,6 +175,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SentinelPortFlag,
 
 	&utils.OtsSearchMaxCapFlag,
+	&utils.LogIndexesFlag,
 
 	&utils.SilkwormExecutionFlag,
 	&utils.SilkwormRpcDaemonFlag,
@@ -24,6 +24,7 @@ import (
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/cli/httpcfg"
 	"github.com/ledgerwatch/erigon/cmd/utils"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/ethdb/prune"
 	"github.com/ledgerwatch/erigon/node/nodecfg"
 )
@@ -62,6 +63,11 @@ var (
 		Value: kv.ReadersLimit - 128,
 	}
 
+	PrivateApiACLFlag = cli.StringFlag{
+		Name:  "private.api.acl",
+		Usage: `Path to JSON file with allow-list of kv tables and snapshot types per client of remote database interface. Client identity is Common Name of client TLS certificate (requires --tls.cacert), clients without own rule use "*" rule. Example: {"rpc-team-a": {"tables": ["*"], "snapshots": ["*"]}, "*": {"tables": ["Header", "CanonicalHeader"], "snapshots": ["headers"]}}`,
+	}
+
 	PruneFlag = cli.StringFlag{
 		Name: "prune",
 		Usage: `Choose which ancient data delete from DB:
@@ -169,7 +175,7 @@ var (
 
 	UploadLocationFlag = cli.StringFlag{
 		Name:  "upload.location",
-		Usage: "Location to upload snapshot segments to",
+		Usage: "Location to upload snapshot segments to: rclone remote, local dir or s3://bucket/prefix (native s3 client, configured by AWS_* env variables)",
 		Value: "",
 	}
 
@@ -264,6 +270,9 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		utils.Fatalf(fmt.Sprintf("error while parsing mode: %v", err))
 	}
 	cfg.Prune = mode
+	if cfg.LogIndexes, err = logindex.Load(ctx.String(utils.LogIndexesFlag.Name)); err != nil {
+		utils.Fatalf("Option %s: %v", utils.LogIndexesFlag.Name, err)
+	}
 	if ctx.String(BatchSizeFlag.Name) != "" {
 		err := cfg.BatchSize.UnmarshalText([]byte(ctx.String(BatchSizeFlag.Name)))
 		if err != nil {
@@ -428,6 +437,11 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 
 	apis := ctx.String(utils.HTTPApiFlag.Name)
 
+	logIndexes, err := logindex.Load(ctx.String(utils.LogIndexesFlag.Name))
+	if err != nil {
+		utils.Fatalf("Option %s: %v", utils.LogIndexesFlag.Name, err)
+	}
+
 	c := &httpcfg.HttpCfg{
 		Enabled: func() bool {
 			if ctx.IsSet(utils.HTTPEnabledFlag.Name) {
@@ -480,6 +494,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		MaxGetProofRewindBlockCount: ctx.Int(utils.RpcMaxGetProofRewindBlockCount.Name),
 
 		OtsMaxPageSize: ctx.Uint64(utils.OtsSearchMaxCapFlag.Name),
+		LogIndexes:     logIndexes,
 
 		TxPoolApiAddr: ctx.String(utils.TxpoolApiAddrFlag.Name),
 
@@ -502,7 +517,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		c.WebsocketCompression = true
 	}
 
-	err := c.StateCache.CacheSize.UnmarshalText([]byte(ctx.String(utils.StateCacheFlag.Name)))
+	err = c.StateCache.CacheSize.UnmarshalText([]byte(ctx.String(utils.StateCacheFlag.Name)))
 	if err != nil {
 		utils.Fatalf("Invalid state.cache value provided")
 	}
@@ -511,6 +526,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 	if err != nil {
 		utils.Fatalf("Invalid state.cache value provided")
 	}
+	c.StateCache.WarmStartFile = ctx.String(utils.StateCacheWarmStartFileFlag.Name)
 
 	/*
 		rootCmd.PersistentFlags().BoolVar(&cfg.GRPCServerEnabled, "grpc", false, "Enable GRPC server")
@@ -546,5 +562,6 @@ func setPrivateApi(ctx *cli.Context, cfg *nodecfg.Config) {
 		cfg.TLSKeyFile = keyFile
 		cfg.TLSCACert = ctx.String(TLSCACertFlag.Name)
 	}
+	cfg.PrivateApiACL = ctx.String(PrivateApiACLFlag.Name)
 	cfg.HealthCheck = ctx.Bool(HealthCheckFlag.Name)
 }
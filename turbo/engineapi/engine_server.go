@@ -21,6 +21,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/execution"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
+	types2 "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
 	libstate "github.com/ledgerwatch/erigon-lib/state"
@@ -51,6 +52,7 @@ type EngineServer struct {
 	proposing        bool
 	test             bool
 	executionService execution.ExecutionClient
+	txpool           txpool.TxpoolClient // needed for getBlobs
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
 	lock    sync.Mutex
@@ -87,6 +89,7 @@ func (e *EngineServer) Start(
 	txPool txpool.TxpoolClient,
 	mining txpool.MiningClient,
 ) {
+	e.txpool = txPool
 	base := jsonrpc.NewBaseApi(filters, stateCache, blockReader, agg, httpConfig.WithDatadir, httpConfig.EvmCallTimeout, engineReader, httpConfig.Dirs)
 
 	ethImpl := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, httpConfig.Gascap, httpConfig.ReturnDataLimit, httpConfig.AllowUnprotectedTxs, httpConfig.MaxGetProofRewindBlockCount, e.logger)
@@ -184,10 +187,22 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 	}
 
 	if (!s.config.IsCancun(header.Time) && version >= clparams.DenebVersion) ||
-		(s.config.IsCancun(header.Time) && version < clparams.DenebVersion) {
+		(s.config.IsCancun(header.Time) && version < clparams.DenebVersion) ||
+		(!s.config.IsPrague(header.Time) && version >= clparams.ElectraVersion) ||
+		(s.config.IsPrague(header.Time) && version < clparams.ElectraVersion) {
 		return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
 	}
 
+	var requests types.Requests
+	if version >= clparams.ElectraVersion {
+		if req.DepositRequests == nil || req.WithdrawalRequests == nil || req.ConsolidationRequests == nil {
+			return nil, &rpc.InvalidParamsError{Message: "depositRequests/withdrawalRequests/consolidationRequests missing"}
+		}
+		requests = req.Requests()
+		rh := types.DeriveSha(requests)
+		header.RequestsRoot = &rh
+	}
+
 	blockHash := req.BlockHash
 	if header.Hash() != blockHash {
 		s.logger.Error("[NewPayload] invalid block hash", "stated", blockHash, "actual", header.Hash())
@@ -252,7 +267,7 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 	defer s.lock.Unlock()
 
 	s.logger.Debug("[NewPayload] sending block", "height", header.Number, "hash", blockHash)
-	block := types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals)
+	block := types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals, requests)
 
 	payloadStatus, err := s.HandleNewPayload(ctx, "NewPayload", block, expectedBlobHashes)
 	if err != nil {
@@ -424,12 +439,18 @@ func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, version
 
 	ts := data.ExecutionPayload.Timestamp
 	if (!s.config.IsCancun(ts) && version >= clparams.DenebVersion) ||
-		(s.config.IsCancun(ts) && version < clparams.DenebVersion) {
+		(s.config.IsCancun(ts) && version < clparams.DenebVersion) ||
+		(!s.config.IsPrague(ts) && version >= clparams.ElectraVersion) ||
+		(s.config.IsPrague(ts) && version < clparams.ElectraVersion) {
 		return nil, &rpc.UnsupportedForkError{Message: "Unsupported fork"}
 	}
 
+	payload, err := engine_types.ConvertPayloadFromRpc(data.ExecutionPayload)
+	if err != nil {
+		return nil, err
+	}
 	return &engine_types.GetPayloadResponse{
-		ExecutionPayload: engine_types.ConvertPayloadFromRpc(data.ExecutionPayload),
+		ExecutionPayload: payload,
 		BlockValue:       (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(data.BlockValue).ToBig()),
 		BlobsBundle:      engine_types.ConvertBlobsFromRpc(data.BlobsBundle),
 	}, nil
@@ -528,20 +549,26 @@ func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *e
 	}, nil
 }
 
-func (s *EngineServer) getPayloadBodiesByHash(ctx context.Context, request []libcommon.Hash, _ clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV1, error) {
+func (s *EngineServer) getPayloadBodiesByHash(ctx context.Context, request []libcommon.Hash, version clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV2, error) {
 	bodies, err := s.chainRW.GetBodiesByHashes(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := make([]*engine_types.ExecutionPayloadBodyV1, len(bodies))
+	resp := make([]*engine_types.ExecutionPayloadBodyV2, len(bodies))
 	for idx, body := range bodies {
-		resp[idx] = extractPayloadBodyFromBody(body)
+		var prague bool
+		if body != nil && version >= clparams.ElectraVersion {
+			header := s.chainRW.GetHeaderByHash(ctx, request[idx])
+			prague = header != nil && s.config.IsPrague(header.Time)
+		}
+		resp[idx] = extractPayloadBodyFromBody(body, prague)
 	}
 	return resp, nil
 }
 
-func extractPayloadBodyFromBody(body *types.RawBody) *engine_types.ExecutionPayloadBodyV1 {
+// extractPayloadBodyFromBody converts the body, request lists are set (possibly empty) only for Prague blocks
+func extractPayloadBodyFromBody(body *types.RawBody, prague bool) *engine_types.ExecutionPayloadBodyV2 {
 	if body == nil {
 		return nil
 	}
@@ -551,22 +578,45 @@ func extractPayloadBodyFromBody(body *types.RawBody) *engine_types.ExecutionPayl
 		bdTxs[idx] = body.Transactions[idx]
 	}
 
-	return &engine_types.ExecutionPayloadBodyV1{Transactions: bdTxs, Withdrawals: body.Withdrawals}
+	ret := &engine_types.ExecutionPayloadBodyV2{Transactions: bdTxs, Withdrawals: body.Withdrawals}
+	if prague {
+		ret.DepositRequests = body.Requests.Deposits()
+		ret.WithdrawalRequests = body.Requests.Withdrawals()
+		ret.ConsolidationRequests = body.Requests.Consolidations()
+	}
+	return ret
 }
 
-func (s *EngineServer) getPayloadBodiesByRange(ctx context.Context, start, count uint64, _ clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV1, error) {
+func (s *EngineServer) getPayloadBodiesByRange(ctx context.Context, start, count uint64, version clparams.StateVersion) ([]*engine_types.ExecutionPayloadBodyV2, error) {
 	bodies, err := s.chainRW.GetBodiesByRange(ctx, start, count)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := make([]*engine_types.ExecutionPayloadBodyV1, len(bodies))
+	resp := make([]*engine_types.ExecutionPayloadBodyV2, len(bodies))
 	for idx, body := range bodies {
-		resp[idx] = extractPayloadBodyFromBody(body)
+		var prague bool
+		if body != nil && version >= clparams.ElectraVersion {
+			header := s.chainRW.GetHeaderByNumber(ctx, start+uint64(idx))
+			prague = header != nil && s.config.IsPrague(header.Time)
+		}
+		resp[idx] = extractPayloadBodyFromBody(body, prague)
 	}
 	return resp, nil
 }
 
+// payloadBodiesToV1 drops the Prague request lists for the V1 methods.
+func payloadBodiesToV1(bodies []*engine_types.ExecutionPayloadBodyV2) []*engine_types.ExecutionPayloadBodyV1 {
+	resp := make([]*engine_types.ExecutionPayloadBodyV1, len(bodies))
+	for idx, body := range bodies {
+		if body == nil {
+			continue
+		}
+		resp[idx] = &engine_types.ExecutionPayloadBodyV1{Transactions: body.Transactions, Withdrawals: body.Withdrawals}
+	}
+	return resp
+}
+
 // Returns the most recent version of the payload(for the payloadID) at the time of receiving the call
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/paris.md#engine_getpayloadv1
 func (e *EngineServer) GetPayloadV1(ctx context.Context, payloadId hexutility.Bytes) (*engine_types.ExecutionPayload, error) {
@@ -598,6 +648,14 @@ func (e *EngineServer) GetPayloadV3(ctx context.Context, payloadID hexutility.By
 	return e.getPayload(ctx, decodedPayloadId, clparams.DenebVersion)
 }
 
+// Same as [GetPayloadV3], with the execution layer requests (deposits, withdrawal and consolidation requests) in the payload
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_getpayloadv4
+func (e *EngineServer) GetPayloadV4(ctx context.Context, payloadID hexutility.Bytes) (*engine_types.GetPayloadResponse, error) {
+	decodedPayloadId := binary.BigEndian.Uint64(payloadID)
+	e.logger.Info("Received GetPayloadV4", "payloadId", decodedPayloadId)
+	return e.getPayload(ctx, decodedPayloadId, clparams.ElectraVersion)
+}
+
 // Updates the forkchoice state after validating the headBlockHash
 // Additionally, builds and returns a unique identifier for an initial version of a payload
 // (asynchronously updated with transactions), if payloadAttributes is not nil and passes validation
@@ -637,6 +695,13 @@ func (e *EngineServer) NewPayloadV3(ctx context.Context, payload *engine_types.E
 	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, clparams.DenebVersion)
 }
 
+// NewPayloadV4 processes new payloads (blocks) from the beacon chain with withdrawals, blob gas and execution layer requests.
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_newpayloadv4
+func (e *EngineServer) NewPayloadV4(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []libcommon.Hash, parentBeaconBlockRoot *libcommon.Hash) (*engine_types.PayloadStatus, error) {
+	return e.newPayload(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, clparams.ElectraVersion)
+}
+
 // Receives consensus layer's transition configuration and checks if the execution layer has the correct configuration.
 // Can also be used to ping the execution layer (heartbeats).
 // See https://github.com/ethereum/execution-apis/blob/v1.0.0-beta.1/src/engine/specification.md#engine_exchangetransitionconfigurationv1
@@ -665,7 +730,11 @@ func (e *EngineServer) GetPayloadBodiesByHashV1(ctx context.Context, hashes []li
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
 
-	return e.getPayloadBodiesByHash(ctx, hashes, clparams.DenebVersion)
+	bodies, err := e.getPayloadBodiesByHash(ctx, hashes, clparams.DenebVersion)
+	if err != nil {
+		return nil, err
+	}
+	return payloadBodiesToV1(bodies), nil
 }
 
 // Returns an ordered (as per canonical chain) array of execution payload bodies, with corresponding execution block numbers from "start", up to "count"
@@ -678,7 +747,66 @@ func (e *EngineServer) GetPayloadBodiesByRangeV1(ctx context.Context, start, cou
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
 
-	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.CapellaVersion)
+	bodies, err := e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.CapellaVersion)
+	if err != nil {
+		return nil, err
+	}
+	return payloadBodiesToV1(bodies), nil
+}
+
+// Same as [GetPayloadBodiesByHashV1], with the execution layer requests included in each body
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_getpayloadbodiesbyhashv2
+func (e *EngineServer) GetPayloadBodiesByHashV2(ctx context.Context, hashes []libcommon.Hash) ([]*engine_types.ExecutionPayloadBodyV2, error) {
+	if len(hashes) > 1024 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+
+	return e.getPayloadBodiesByHash(ctx, hashes, clparams.ElectraVersion)
+}
+
+// Same as [GetPayloadBodiesByRangeV1], with the execution layer requests included in each body
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/prague.md#engine_getpayloadbodiesbyrangev2
+func (e *EngineServer) GetPayloadBodiesByRangeV2(ctx context.Context, start, count hexutil.Uint64) ([]*engine_types.ExecutionPayloadBodyV2, error) {
+	if start == 0 || count == 0 {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("invalid start or count, start: %v count: %v", start, count)}
+	}
+	if count > 1024 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+
+	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.ElectraVersion)
+}
+
+// Returns the blobs and KZG proofs for the given versioned hashes from the txpool, null for the ones it doesn't have
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_getblobsv1
+func (e *EngineServer) GetBlobsV1(ctx context.Context, blobHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	if len(blobHashes) > 128 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+	if e.txpool == nil {
+		return nil, fmt.Errorf("txpool is not available")
+	}
+
+	req := &txpool.GetBlobsRequest{BlobHashes: make([]*types2.H256, len(blobHashes))}
+	for i := range blobHashes {
+		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(blobHashes[i])
+	}
+	res, err := e.txpool.GetBlobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*engine_types.BlobAndProofV1, len(blobHashes))
+	for i, bp := range res.BlobsAndProofs {
+		if i >= len(ret) {
+			break
+		}
+		if len(bp.Blob) == 0 {
+			continue
+		}
+		ret[i] = &engine_types.BlobAndProofV1{Blob: bp.Blob, Proof: bp.Proof}
+	}
+	return ret, nil
 }
 
 var ourCapabilities = []string{
@@ -688,12 +816,17 @@ var ourCapabilities = []string{
 	"engine_newPayloadV1",
 	"engine_newPayloadV2",
 	"engine_newPayloadV3",
+	"engine_newPayloadV4",
 	"engine_getPayloadV1",
 	"engine_getPayloadV2",
 	"engine_getPayloadV3",
+	"engine_getPayloadV4",
 	"engine_exchangeTransitionConfigurationV1",
 	"engine_getPayloadBodiesByHashV1",
 	"engine_getPayloadBodiesByRangeV1",
+	"engine_getPayloadBodiesByHashV2",
+	"engine_getPayloadBodiesByRangeV2",
+	"engine_getBlobsV1",
 }
 
 func (e *EngineServer) ExchangeCapabilities(fromCl []string) []string {
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 
@@ -34,6 +35,10 @@ type ExecutionPayload struct {
 	Withdrawals   []*types.Withdrawal `json:"withdrawals"`
 	BlobGasUsed   *hexutil.Uint64     `json:"blobGasUsed"`
 	ExcessBlobGas *hexutil.Uint64     `json:"excessBlobGas"`
+
+	DepositRequests       []*types.DepositRequest       `json:"depositRequests"`       // added in V4
+	WithdrawalRequests    []*types.WithdrawalRequest    `json:"withdrawalRequests"`    // added in V4
+	ConsolidationRequests []*types.ConsolidationRequest `json:"consolidationRequests"` // added in V4
 }
 
 // PayloadAttributes represent the attributes required to start assembling a payload
@@ -66,11 +71,25 @@ type BlobsBundleV1 struct {
 	Blobs       []hexutility.Bytes `json:"blobs"       gencodec:"required"`
 }
 
+// BlobAndProofV1 holds a blob and its KZG proof, as returned by engine_getBlobsV1
+type BlobAndProofV1 struct {
+	Blob  hexutility.Bytes `json:"blob"  gencodec:"required"`
+	Proof hexutility.Bytes `json:"proof" gencodec:"required"`
+}
+
 type ExecutionPayloadBodyV1 struct {
 	Transactions []hexutility.Bytes  `json:"transactions" gencodec:"required"`
 	Withdrawals  []*types.Withdrawal `json:"withdrawals"  gencodec:"required"`
 }
 
+type ExecutionPayloadBodyV2 struct {
+	Transactions          []hexutility.Bytes            `json:"transactions"          gencodec:"required"`
+	Withdrawals           []*types.Withdrawal           `json:"withdrawals"           gencodec:"required"`
+	DepositRequests       []*types.DepositRequest       `json:"depositRequests"       gencodec:"required"`
+	WithdrawalRequests    []*types.WithdrawalRequest    `json:"withdrawalRequests"    gencodec:"required"`
+	ConsolidationRequests []*types.ConsolidationRequest `json:"consolidationRequests" gencodec:"required"`
+}
+
 type PayloadStatus struct {
 	Status          EngineStatus      `json:"status" gencodec:"required"`
 	ValidationError *StringifiedError `json:"validationError"`
@@ -111,7 +130,7 @@ func (e StringifiedError) Error() error {
 	return e.err
 }
 
-func ConvertRpcBlockToExecutionPayload(payload *execution.Block) *ExecutionPayload {
+func ConvertRpcBlockToExecutionPayload(payload *execution.Block) (*ExecutionPayload, error) {
 	header := payload.Header
 	body := payload.Body
 
@@ -149,10 +168,17 @@ func ConvertRpcBlockToExecutionPayload(payload *execution.Block) *ExecutionPaylo
 		excessBlobGas := *header.ExcessBlobGas
 		res.ExcessBlobGas = (*hexutil.Uint64)(&excessBlobGas)
 	}
-	return res
+	if header.RequestsRoot != nil {
+		requests, err := convertRequestsFromRpc(body.Requests)
+		if err != nil {
+			return nil, err
+		}
+		res.setRequests(requests)
+	}
+	return res, nil
 }
 
-func ConvertPayloadFromRpc(payload *types2.ExecutionPayload) *ExecutionPayload {
+func ConvertPayloadFromRpc(payload *types2.ExecutionPayload) (*ExecutionPayload, error) {
 	var bloom types.Bloom = gointerfaces.ConvertH2048ToBloom(payload.LogsBloom)
 	baseFee := gointerfaces.ConvertH256ToUint256Int(payload.BaseFeePerGas).ToBig()
 
@@ -187,7 +213,52 @@ func ConvertPayloadFromRpc(payload *types2.ExecutionPayload) *ExecutionPayload {
 		excessBlobGas := *payload.ExcessBlobGas
 		res.ExcessBlobGas = (*hexutil.Uint64)(&excessBlobGas)
 	}
-	return res
+	if payload.Version >= 4 {
+		requests, err := convertRequestsFromRpc(payload.Requests)
+		if err != nil {
+			return nil, err
+		}
+		res.setRequests(requests)
+	}
+	return res, nil
+}
+
+// Requests reassembles the EIP-7685 requests carried by the payload in their canonical order:
+// deposits, then withdrawal requests, then consolidation requests.
+func (p *ExecutionPayload) Requests() types.Requests {
+	if p.DepositRequests == nil && p.WithdrawalRequests == nil && p.ConsolidationRequests == nil {
+		return nil
+	}
+	reqs := make(types.Requests, 0, len(p.DepositRequests)+len(p.WithdrawalRequests)+len(p.ConsolidationRequests))
+	for _, r := range p.DepositRequests {
+		reqs = append(reqs, r)
+	}
+	for _, r := range p.WithdrawalRequests {
+		reqs = append(reqs, r)
+	}
+	for _, r := range p.ConsolidationRequests {
+		reqs = append(reqs, r)
+	}
+	return reqs
+}
+
+func (p *ExecutionPayload) setRequests(reqs types.Requests) {
+	p.DepositRequests = reqs.Deposits()
+	p.WithdrawalRequests = reqs.Withdrawals()
+	p.ConsolidationRequests = reqs.Consolidations()
+}
+
+// convertRequestsFromRpc decodes the EIP-7685 encoded requests
+func convertRequestsFromRpc(in [][]byte) (types.Requests, error) {
+	out := make(types.Requests, 0, len(in))
+	for _, data := range in {
+		r, err := types.UnmarshalRequest(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		out = append(out, r)
+	}
+	return out, nil
 }
 
 func ConvertBlobsFromRpc(bundle *types2.BlobsBundleV1) *BlobsBundleV1 {
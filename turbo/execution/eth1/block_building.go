@@ -165,6 +165,14 @@ func (e *EthereumExecutionModule) GetAssembledBlock(ctx context.Context, req *ex
 		payload.ExcessBlobGas = header.ExcessBlobGas
 	}
 
+	if block.Requests() != nil {
+		payload.Version = 4
+		payload.Requests, err = eth1_utils.ConvertRequestsToRpc(block.Requests())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	blockValue := blockValue(blockWithReceipts, baseFee)
 
 	blobsBundle := &types2.BlobsBundleV1{}
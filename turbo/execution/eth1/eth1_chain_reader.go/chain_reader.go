@@ -15,6 +15,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/execution"
 	types2 "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
 	"github.com/ledgerwatch/erigon/turbo/execution/eth1/eth1_utils"
 )
 
@@ -102,7 +103,7 @@ func (c ChainReaderWriterEth1) GetBlockByHash(ctx context.Context, hash libcommo
 		log.Warn("[engine] GetBlockByHash", "err", err)
 		return nil
 	}
-	return types.NewBlock(header, txs, nil, nil, body.Withdrawals)
+	return types.NewBlockWithRequests(header, txs, nil, nil, body.Withdrawals, body.Requests)
 }
 
 func (c ChainReaderWriterEth1) GetBlockByNumber(ctx context.Context, number uint64) *types.Block {
@@ -131,7 +132,7 @@ func (c ChainReaderWriterEth1) GetBlockByNumber(ctx context.Context, number uint
 		log.Warn("[engine] GetBlockByNumber", "err", err)
 		return nil
 	}
-	return types.NewBlock(header, txs, nil, nil, body.Withdrawals)
+	return types.NewBlockWithRequests(header, txs, nil, nil, body.Withdrawals, body.Requests)
 }
 
 func (c ChainReaderWriterEth1) GetHeaderByHash(ctx context.Context, hash libcommon.Hash) *types.Header {
@@ -269,8 +270,12 @@ func (c ChainReaderWriterEth1) FrozenBlocks(ctx context.Context) uint64 {
 const retryTimeout = 10 * time.Millisecond
 
 func (c ChainReaderWriterEth1) InsertBlocksAndWait(ctx context.Context, blocks []*types.Block) error {
+	rpcBlocks, err := eth1_utils.ConvertBlocksToRPC(blocks)
+	if err != nil {
+		return err
+	}
 	request := &execution.InsertBlocksRequest{
-		Blocks: eth1_utils.ConvertBlocksToRPC(blocks),
+		Blocks: rpcBlocks,
 	}
 	response, err := c.executionModule.InsertBlocks(ctx, request)
 	if err != nil {
@@ -297,8 +302,12 @@ func (c ChainReaderWriterEth1) InsertBlocksAndWait(ctx context.Context, blocks [
 }
 
 func (c ChainReaderWriterEth1) InsertBlocks(ctx context.Context, blocks []*types.Block) error {
+	rpcBlocks, err := eth1_utils.ConvertBlocksToRPC(blocks)
+	if err != nil {
+		return err
+	}
 	request := &execution.InsertBlocksRequest{
-		Blocks: eth1_utils.ConvertBlocksToRPC(blocks),
+		Blocks: rpcBlocks,
 	}
 	response, err := c.executionModule.InsertBlocks(ctx, request)
 	if err != nil {
@@ -316,8 +325,12 @@ func (c ChainReaderWriterEth1) InsertBlocks(ctx context.Context, blocks []*types
 
 func (c ChainReaderWriterEth1) InsertBlockAndWait(ctx context.Context, block *types.Block) error {
 	blocks := []*types.Block{block}
+	rpcBlocks, err := eth1_utils.ConvertBlocksToRPC(blocks)
+	if err != nil {
+		return err
+	}
 	request := &execution.InsertBlocksRequest{
-		Blocks: eth1_utils.ConvertBlocksToRPC(blocks),
+		Blocks: rpcBlocks,
 	}
 
 	response, err := c.executionModule.InsertBlocks(ctx, request)
@@ -395,3 +408,41 @@ func (c ChainReaderWriterEth1) HasBlock(ctx context.Context, hash libcommon.Hash
 	}
 	return resp.HasBlock, nil
 }
+
+func (c ChainReaderWriterEth1) AssembleBlock(ctx context.Context, baseHash libcommon.Hash, attributes *engine_types.PayloadAttributes) (id uint64, busy bool, err error) {
+	request := &execution.AssembleBlockRequest{
+		ParentHash:            gointerfaces.ConvertHashToH256(baseHash),
+		Timestamp:             uint64(attributes.Timestamp),
+		PrevRandao:            gointerfaces.ConvertHashToH256(attributes.PrevRandao),
+		SuggestedFeeRecipient: gointerfaces.ConvertAddressToH160(attributes.SuggestedFeeRecipient),
+		Withdrawals:           eth1_utils.ConvertWithdrawalsToRpc(attributes.Withdrawals),
+	}
+	if attributes.ParentBeaconBlockRoot != nil {
+		request.ParentBeaconBlockRoot = gointerfaces.ConvertHashToH256(*attributes.ParentBeaconBlockRoot)
+	}
+	resp, err := c.executionModule.AssembleBlock(ctx, request)
+	if err != nil {
+		return 0, false, err
+	}
+	return resp.Id, resp.Busy, nil
+}
+
+func (c ChainReaderWriterEth1) GetAssembledBlock(ctx context.Context, id uint64) (*engine_types.ExecutionPayload, *engine_types.BlobsBundleV1, *big.Int, bool, error) {
+	resp, err := c.executionModule.GetAssembledBlock(ctx, &execution.GetAssembledBlockRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	if resp.Busy {
+		return nil, nil, nil, true, nil
+	}
+	if resp.Data == nil {
+		return nil, nil, nil, false, fmt.Errorf("GetAssembledBlock: no block assembled with id %d", id)
+	}
+	payload, err := engine_types.ConvertPayloadFromRpc(resp.Data.ExecutionPayload)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	return payload, engine_types.ConvertBlobsFromRpc(resp.Data.BlobsBundle), eth1_utils.ConvertBigIntFromRpc(resp.Data.BlockValue), false, nil
+}
@@ -1,6 +1,7 @@
 package eth1_utils
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math/big"
@@ -57,6 +58,10 @@ func HeaderToHeaderRPC(header *types.Header) *execution.Header {
 		h.ParentBeaconBlockRoot = gointerfaces.ConvertHashToH256(*header.ParentBeaconBlockRoot)
 	}
 
+	if header.RequestsRoot != nil {
+		h.RequestsRoot = gointerfaces.ConvertHashToH256(*header.RequestsRoot)
+	}
+
 	if len(header.AuRaSeal) > 0 {
 		h.AuraSeal = header.AuRaSeal
 		h.AuraStep = &header.AuRaStep
@@ -75,23 +80,31 @@ func HeadersToHeadersRPC(headers []*types.Header) []*execution.Header {
 	return ret
 }
 
-func ConvertBlocksToRPC(blocks []*types.Block) []*execution.Block {
+func ConvertBlocksToRPC(blocks []*types.Block) ([]*execution.Block, error) {
 	ret := []*execution.Block{}
 	for _, block := range blocks {
-		ret = append(ret, ConvertBlockToRPC(block))
+		rpcBlock, err := ConvertBlockToRPC(block)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, rpcBlock)
 	}
-	return ret
+	return ret, nil
 }
 
-func ConvertBlockToRPC(block *types.Block) *execution.Block {
+func ConvertBlockToRPC(block *types.Block) (*execution.Block, error) {
 	h := HeaderToHeaderRPC(block.Header())
 	blockHash := block.Hash()
 	h.BlockHash = gointerfaces.ConvertHashToH256(blockHash)
 
+	body, err := ConvertRawBlockBodyToRpc(block.RawBody(), h.BlockNumber, blockHash)
+	if err != nil {
+		return nil, err
+	}
 	return &execution.Block{
 		Header: h,
-		Body:   ConvertRawBlockBodyToRpc(block.RawBody(), h.BlockNumber, blockHash),
-	}
+		Body:   body,
+	}, nil
 }
 
 func HeaderRpcToHeader(header *execution.Header) (*types.Header, error) {
@@ -131,6 +144,10 @@ func HeaderRpcToHeader(header *execution.Header) (*types.Header, error) {
 		h.ParentBeaconBlockRoot = new(libcommon.Hash)
 		*h.ParentBeaconBlockRoot = gointerfaces.ConvertH256ToHash(header.ParentBeaconBlockRoot)
 	}
+	if header.RequestsRoot != nil {
+		h.RequestsRoot = new(libcommon.Hash)
+		*h.RequestsRoot = gointerfaces.ConvertH256ToHash(header.RequestsRoot)
+	}
 	blockHash := gointerfaces.ConvertH256ToHash(header.BlockHash)
 	if blockHash != h.Hash() {
 		return nil, fmt.Errorf("block %d, %x has invalid hash. expected: %x", header.BlockNumber, h.Hash(), blockHash)
@@ -186,27 +203,67 @@ func ConvertWithdrawalsToRpc(in []*types.Withdrawal) []*types2.Withdrawal {
 	return out
 }
 
-func ConvertRawBlockBodyToRpc(in *types.RawBody, blockNumber uint64, blockHash libcommon.Hash) *execution.BlockBody {
+// ConvertRequestsToRpc encodes the requests with EIP-7685 encoding
+func ConvertRequestsToRpc(in types.Requests) ([][]byte, error) {
 	if in == nil {
-		return nil
+		return nil, nil
+	}
+	out := make([][]byte, 0, len(in))
+	for _, r := range in {
+		var buf bytes.Buffer
+		if err := r.MarshalBinary(&buf); err != nil {
+			return nil, err
+		}
+		out = append(out, buf.Bytes())
 	}
+	return out, nil
+}
 
+func ConvertRequestsFromRpc(in [][]byte) (types.Requests, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make(types.Requests, 0, len(in))
+	for _, b := range in {
+		r, err := types.UnmarshalRequest(b)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func ConvertRawBlockBodyToRpc(in *types.RawBody, blockNumber uint64, blockHash libcommon.Hash) (*execution.BlockBody, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	requests, err := ConvertRequestsToRpc(in.Requests)
+	if err != nil {
+		return nil, err
+	}
 	return &execution.BlockBody{
 		BlockNumber:  blockNumber,
 		BlockHash:    gointerfaces.ConvertHashToH256(blockHash),
 		Transactions: in.Transactions,
 		Uncles:       HeadersToHeadersRPC(in.Uncles),
 		Withdrawals:  ConvertWithdrawalsToRpc(in.Withdrawals),
-	}
+		Requests:     requests,
+	}, nil
 }
 
-func ConvertRawBlockBodiesToRpc(in []*types.RawBody, blockNumbers []uint64, blockHashes []libcommon.Hash) []*execution.BlockBody {
+func ConvertRawBlockBodiesToRpc(in []*types.RawBody, blockNumbers []uint64, blockHashes []libcommon.Hash) ([]*execution.BlockBody, error) {
 	ret := []*execution.BlockBody{}
 
 	for i, body := range in {
-		ret = append(ret, ConvertRawBlockBodyToRpc(body, blockNumbers[i], blockHashes[i]))
+		rpcBody, err := ConvertRawBlockBodyToRpc(body, blockNumbers[i], blockHashes[i])
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, rpcBody)
 	}
-	return ret
+	return ret, nil
 }
 
 func ConvertRawBlockBodyFromRpc(in *execution.BlockBody) (*types.RawBody, error) {
@@ -217,10 +274,15 @@ func ConvertRawBlockBodyFromRpc(in *execution.BlockBody) (*types.RawBody, error)
 	if err != nil {
 		return nil, err
 	}
+	requests, err := ConvertRequestsFromRpc(in.Requests)
+	if err != nil {
+		return nil, err
+	}
 	return &types.RawBody{
 		Transactions: in.Transactions,
 		Uncles:       uncles,
 		Withdrawals:  ConvertWithdrawalsFromRpc(in.Withdrawals),
+		Requests:     requests,
 	}, nil
 }
 
@@ -75,7 +75,10 @@ func TestBlockRpcConversion(t *testing.T) {
 	require.Equal(testBlock.Header(), roundTripHeader)
 
 	// body conversions
-	rpcBlock := ConvertBlockToRPC(testBlock)
+	rpcBlock, err := ConvertBlockToRPC(testBlock)
+	if err != nil {
+		panic(err)
+	}
 	roundTripBody, err := ConvertRawBlockBodyFromRpc(rpcBlock.Body)
 	if err != nil {
 		panic(err)
@@ -79,7 +79,11 @@ func (e *EthereumExecutionModule) GetBody(ctx context.Context, req *execution.Ge
 	}
 	rawBody := body.RawBody()
 
-	return &execution.GetBodyResponse{Body: eth1_utils.ConvertRawBlockBodyToRpc(rawBody, blockNumber, blockHash)}, nil
+	rpcBody, err := eth1_utils.ConvertRawBlockBodyToRpc(rawBody, blockNumber, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("ethereumExecutionModule.GetBody: could not convert body: %w", err)
+	}
+	return &execution.GetBodyResponse{Body: rpcBody}, nil
 }
 
 func (e *EthereumExecutionModule) GetHeader(ctx context.Context, req *execution.GetSegmentRequest) (*execution.GetHeaderResponse, error) {
@@ -143,9 +147,14 @@ func (e *EthereumExecutionModule) GetBodiesByHashes(ctx context.Context, req *ex
 		if err != nil {
 			return nil, err
 		}
+		requests, err := eth1_utils.ConvertRequestsToRpc(body.Requests)
+		if err != nil {
+			return nil, err
+		}
 		bodies = append(bodies, &execution.BlockBody{
 			Transactions: txs,
 			Withdrawals:  eth1_utils.ConvertWithdrawalsToRpc(body.Withdrawals),
+			Requests:     requests,
 		})
 	}
 
@@ -185,9 +194,14 @@ func (e *EthereumExecutionModule) GetBodiesByRange(ctx context.Context, req *exe
 		if err != nil {
 			return nil, err
 		}
+		requests, err := eth1_utils.ConvertRequestsToRpc(body.Requests)
+		if err != nil {
+			return nil, err
+		}
 		bodies = append(bodies, &execution.BlockBody{
 			Transactions: txs,
 			Withdrawals:  eth1_utils.ConvertWithdrawalsToRpc(body.Withdrawals),
+			Requests:     requests,
 		})
 	}
 	// Remove trailing nil values as per spec
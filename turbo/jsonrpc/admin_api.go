@@ -22,6 +22,15 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// RemovePeer disconnects from a remote node and removes it from static nodes.
+	RemovePeer(ctx context.Context, url string) (bool, error)
+
+	// AddTrustedPeer allows a remote node to always connect, even if all peer slots are taken.
+	AddTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// RemoveTrustedPeer removes a remote node from trusted nodes. It doesn't disconnect the node.
+	RemoveTrustedPeer(ctx context.Context, url string) (bool, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -63,3 +72,36 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) RemovePeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemovePeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removePeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) AddTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.AddTrustedPeer(ctx, &remote.AddPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil addTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) RemoveTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemoveTrustedPeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removeTrustedPeer response")
+	}
+	return result.Success, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common"
 
 	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 
 	"github.com/ledgerwatch/erigon-lib/kv"
 
@@ -33,6 +34,7 @@ type ErigonAPI interface {
 	//GetLogsByNumber(ctx context.Context, number rpc.BlockNumber) ([][]*types.Log, error)
 	GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.ErigonLogs, error)
 	GetLatestLogs(ctx context.Context, crit filters.FilterCriteria, logOptions filters.LogFilterOptions) (types.ErigonLogs, error)
+	GetLogsByIndex(ctx context.Context, name string, values []common.Hash, fromBlock, toBlock *rpc.BlockNumber) (types.ErigonLogs, error)
 	// Gets cannonical block receipt through hash. If the block is not cannonical returns error
 	GetBlockReceiptsByBlockHash(ctx context.Context, cannonicalBlockHash common.Hash) ([]map[string]interface{}, error)
 
@@ -45,13 +47,15 @@ type ErigonImpl struct {
 	*BaseAPI
 	db         kv.RoDB
 	ethBackend rpchelper.ApiBackend
+	logIndexes []logindex.Indexer
 }
 
 // NewErigonAPI returns ErigonImpl instance
-func NewErigonAPI(base *BaseAPI, db kv.RoDB, eth rpchelper.ApiBackend) *ErigonImpl {
+func NewErigonAPI(base *BaseAPI, db kv.RoDB, eth rpchelper.ApiBackend, logIndexes []logindex.Indexer) *ErigonImpl {
 	return &ErigonImpl{
 		BaseAPI:    base,
 		db:         db,
 		ethBackend: eth,
+		logIndexes: logIndexes,
 	}
 }
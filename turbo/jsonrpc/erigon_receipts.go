@@ -9,6 +9,7 @@ import (
 	"github.com/RoaringBitmap/roaring"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/cmp"
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/bitmapdb"
@@ -16,6 +17,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethutils"
 	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/eth/logindex"
 	"github.com/ledgerwatch/erigon/ethdb/cbor"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
@@ -121,81 +123,183 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 			return nil, err
 		}
 
-		blockNumber := uint64(iter.Next())
-		var logIndex uint
-		var txIndex uint
-		var blockLogs []*types.Log
-		it, err := tx.Prefix(kv.Log, hexutility.EncodeTs(blockNumber))
+		blockLogs, err := api.blockErigonLogs(ctx, tx, uint64(iter.Next()), func(logs types.Logs) types.Logs {
+			return logs.Filter(addrMap, crit.Topics)
+		})
 		if err != nil {
 			return nil, err
 		}
-		for it.HasNext() {
-			k, v, err := it.Next()
-			if err != nil {
-				return erigonLogs, err
-			}
-			var logs types.Logs
-			if err := cbor.Unmarshal(&logs, bytes.NewReader(v)); err != nil {
-				return erigonLogs, fmt.Errorf("receipt unmarshal failed:  %w", err)
-			}
-			for _, log := range logs {
-				log.Index = logIndex
-				logIndex++
-			}
-			filtered := logs.Filter(addrMap, crit.Topics)
-			if len(filtered) == 0 {
-				continue
-			}
-			txIndex = uint(binary.BigEndian.Uint32(k[8:]))
-			for _, log := range filtered {
-				log.TxIndex = txIndex
-			}
-			blockLogs = append(blockLogs, filtered...)
+		erigonLogs = append(erigonLogs, blockLogs...)
+	}
+
+	return erigonLogs, nil
+}
+
+// GetLogsByIndex implements erigon_getLogsByIndex. Returns logs found by custom log index (see --logindex.custom)
+// by values of its key fields, in given block range.
+func (api *ErigonImpl) GetLogsByIndex(ctx context.Context, name string, values []common.Hash, fromBlock, toBlock *rpc.BlockNumber) (types.ErigonLogs, error) {
+	ix := logindex.ByName(api.logIndexes, name)
+	if ix == nil {
+		return nil, fmt.Errorf("unknown log index: %s", name)
+	}
+	key, err := ix.QueryKey(values)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	progress, ok, err := logindex.Progress(tx, ix)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("log index %s is not built yet", name)
+	}
+	var begin, end uint64 = 0, cmp.Min(latest, progress)
+	if fromBlock != nil {
+		if begin, err = api.logsBlockNumber(tx, *fromBlock); err != nil {
+			return nil, err
 		}
-		if casted, ok := it.(kv.Closer); ok {
-			casted.Close()
+	}
+	if toBlock != nil {
+		if end, err = api.logsBlockNumber(tx, *toBlock); err != nil {
+			return nil, err
 		}
-		if len(blockLogs) == 0 {
-			continue
+		if end > progress {
+			if *toBlock >= 0 {
+				return nil, fmt.Errorf("log index %s is built up to block %d", name, progress)
+			}
+			end = progress // tag: serve what is indexed
 		}
+	}
+	if end < begin {
+		return nil, fmt.Errorf("end (%d) < begin (%d)", end, begin)
+	}
+	if end > roaring.MaxUint32 {
+		return nil, fmt.Errorf("end (%d) > MaxUint32", end)
+	}
 
-		header, err := api._blockReader.HeaderByNumber(ctx, tx, blockNumber)
-		if err != nil {
+	blockNumbers, err := bitmapdb.Get(tx, kv.LogCustomIndex, logindex.DBKey(ix, key), uint32(begin), uint32(end))
+	if err != nil {
+		return nil, err
+	}
+	erigonLogs := types.ErigonLogs{}
+	iter := blockNumbers.Iterator()
+	for iter.HasNext() {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-		if header == nil {
-			return nil, fmt.Errorf("block header not found: %d", blockNumber)
+
+		blockLogs, err := api.blockErigonLogs(ctx, tx, uint64(iter.Next()), func(logs types.Logs) types.Logs {
+			var filtered types.Logs
+			for _, l := range logs {
+				if k, ok := ix.Key(l); ok && bytes.Equal(k, key) {
+					filtered = append(filtered, l)
+				}
+			}
+			return filtered
+		})
+		if err != nil {
+			return nil, err
 		}
-		timestamp := header.Time
+		erigonLogs = append(erigonLogs, blockLogs...)
+	}
+	return erigonLogs, nil
+}
 
-		blockHash := header.Hash()
-		body, err := api._blockReader.BodyWithTransactions(ctx, tx, blockHash, blockNumber)
+// logsBlockNumber - resolves block number tags (latest, pending, finalized, ...) same way as eth_getLogs
+func (api *ErigonImpl) logsBlockNumber(tx kv.Tx, number rpc.BlockNumber) (uint64, error) {
+	if number >= 0 {
+		return uint64(number), nil
+	}
+	blockNum, _, _, err := rpchelper.GetBlockNumber(rpc.BlockNumberOrHashWithNumber(number), tx, api.filters)
+	return blockNum, err
+}
+
+// blockErigonLogs - logs of given block, selected by filter, with block and transaction details
+func (api *ErigonImpl) blockErigonLogs(ctx context.Context, tx kv.Tx, blockNumber uint64, filter func(types.Logs) types.Logs) (types.ErigonLogs, error) {
+	var logIndex uint
+	var txIndex uint
+	var blockLogs []*types.Log
+	it, err := tx.Prefix(kv.Log, hexutility.EncodeTs(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	for it.HasNext() {
+		k, v, err := it.Next()
 		if err != nil {
 			return nil, err
 		}
-		if body == nil {
-			return nil, fmt.Errorf("block not found %d", blockNumber)
+		var logs types.Logs
+		if err := cbor.Unmarshal(&logs, bytes.NewReader(v)); err != nil {
+			return nil, fmt.Errorf("receipt unmarshal failed:  %w", err)
 		}
-		for _, log := range blockLogs {
-			erigonLog := &types.ErigonLog{}
-			erigonLog.BlockNumber = blockNumber
-			erigonLog.BlockHash = blockHash
-			if log.TxIndex == uint(len(body.Transactions)) {
-				erigonLog.TxHash = types.ComputeBorTxHash(blockNumber, blockHash)
-			} else {
-				erigonLog.TxHash = body.Transactions[log.TxIndex].Hash()
-			}
-			erigonLog.Timestamp = timestamp
-			erigonLog.Address = log.Address
-			erigonLog.Topics = log.Topics
-			erigonLog.Data = log.Data
-			erigonLog.Index = log.Index
-			erigonLog.Removed = log.Removed
-			erigonLog.TxIndex = log.TxIndex
-			erigonLogs = append(erigonLogs, erigonLog)
+		for _, log := range logs {
+			log.Index = logIndex
+			logIndex++
 		}
+		filtered := filter(logs)
+		if len(filtered) == 0 {
+			continue
+		}
+		txIndex = uint(binary.BigEndian.Uint32(k[8:]))
+		for _, log := range filtered {
+			log.TxIndex = txIndex
+		}
+		blockLogs = append(blockLogs, filtered...)
+	}
+	if casted, ok := it.(kv.Closer); ok {
+		casted.Close()
+	}
+	if len(blockLogs) == 0 {
+		return nil, nil
 	}
 
+	header, err := api._blockReader.HeaderByNumber(ctx, tx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block header not found: %d", blockNumber)
+	}
+	timestamp := header.Time
+
+	blockHash := header.Hash()
+	body, err := api._blockReader.BodyWithTransactions(ctx, tx, blockHash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, fmt.Errorf("block not found %d", blockNumber)
+	}
+	erigonLogs := make(types.ErigonLogs, 0, len(blockLogs))
+	for _, log := range blockLogs {
+		erigonLog := &types.ErigonLog{}
+		erigonLog.BlockNumber = blockNumber
+		erigonLog.BlockHash = blockHash
+		if log.TxIndex == uint(len(body.Transactions)) {
+			erigonLog.TxHash = types.ComputeBorTxHash(blockNumber, blockHash)
+		} else {
+			erigonLog.TxHash = body.Transactions[log.TxIndex].Hash()
+		}
+		erigonLog.Timestamp = timestamp
+		erigonLog.Address = log.Address
+		erigonLog.Topics = log.Topics
+		erigonLog.Data = log.Data
+		erigonLog.Index = log.Index
+		erigonLog.Removed = log.Removed
+		erigonLog.TxIndex = log.TxIndex
+		erigonLogs = append(erigonLogs, erigonLog)
+	}
 	return erigonLogs, nil
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/filters"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
 	"github.com/ledgerwatch/erigon/turbo/stages/mock"
 	"github.com/ledgerwatch/log/v3"
 )
@@ -59,7 +60,7 @@ func TestErigonGetLatestLogs(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, nil)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make([]*types.ErigonLog, 0)
@@ -91,7 +92,7 @@ func TestErigonGetLatestLogsIgnoreTopics(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, nil)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make([]*types.ErigonLog, 0)
@@ -178,7 +179,7 @@ func TestGetBlockReceiptsByBlockHash(t *testing.T) {
 	}
 	// Assemble the test environment
 	m := mockWithGenerator(t, 4, generator)
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	expect := map[uint64]string{
 		0: `[]`,
@@ -219,3 +220,23 @@ func mockWithGenerator(t *testing.T, blocks int, generator func(int, *core.Block
 	}
 	return m
 }
+
+func TestGetLogsByIndexBlockTags(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	require.NoError(t, err)
+	require.NotZero(t, latest)
+
+	from, err := api.logsBlockNumber(tx, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, latest, from)
+
+	from, err = api.logsBlockNumber(tx, rpc.BlockNumber(5))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), from)
+}
@@ -224,7 +224,7 @@ func TestGetBlockByTimestampLatestTime(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -259,7 +259,7 @@ func TestGetBlockByTimestampOldestTime(t *testing.T) {
 		t.Errorf("failed at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	oldestBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, 0)
 	if err != nil {
@@ -297,7 +297,7 @@ func TestGetBlockByTimeHigherThanLatestBlock(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -333,7 +333,7 @@ func TestGetBlockByTimeMiddle(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	currentHeader := rawdb.ReadCurrentHeader(tx)
 	oldestHeader, err := api._blockReader.HeaderByNumber(ctx, tx, 0)
@@ -380,7 +380,7 @@ func TestGetBlockByTimestamp(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	highestBlockNumber := rawdb.ReadCurrentHeader(tx).Number
 	pickedBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, highestBlockNumber.Uint64()/3)
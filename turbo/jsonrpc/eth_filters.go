@@ -243,6 +243,48 @@ func (api *APIImpl) NewPendingTransactionsWithBody(ctx context.Context) (*rpc.Su
 	return rpcSub, nil
 }
 
+// DroppedTransactions send a notification each time when a transaction is evicted or replaced in the mempool,
+// together with the reason of discarding.
+func (api *APIImpl) DroppedTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	if api.filters == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		txsCh, id := api.filters.SubscribeDroppedTxs(256)
+		defer api.filters.UnsubscribeDroppedTxs(id)
+
+		for {
+			select {
+			case txs, ok := <-txsCh:
+				for _, t := range txs {
+					if t != nil {
+						err := notifier.Notify(rpcSub.ID, t)
+						if err != nil {
+							log.Warn("[rpc] error while notifying subscription", "err", err)
+						}
+					}
+				}
+				if !ok {
+					log.Warn("[rpc] dropped transactions channel was closed")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs send a notification each time a new log appears.
 func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
 	if api.filters == nil {
@@ -0,0 +1,527 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+
+	"github.com/ledgerwatch/erigon/common/math"
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+// maxSimulateBlocks is the maximum number of blocks a single eth_simulateV1 request can simulate
+const maxSimulateBlocks = 256
+
+// simulateBlockTimeIncrement is the default timestamp increment between simulated blocks
+const simulateBlockTimeIncrement = 12
+
+var (
+	// transferLogAddress is the pseudo-address ERC-7528 assigns to the native currency
+	transferLogAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	// transferTopic is keccak256("Transfer(address,address,uint256)")
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+)
+
+// SimulationOptions is the request object of eth_simulateV1
+type SimulationOptions struct {
+	BlockStateCalls        []SimulatedBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+}
+
+// SimulatedBlock is a batch of calls executed in one simulated block on top of the given overrides
+type SimulatedBlock struct {
+	BlockOverrides *SimulatedBlockOverrides `json:"blockOverrides"`
+	StateOverrides *ethapi.StateOverrides   `json:"stateOverrides"`
+	Calls          []ethapi.CallArgs        `json:"calls"`
+}
+
+// SimulatedBlockOverrides are the header fields of a simulated block that can be set by the caller
+type SimulatedBlockOverrides struct {
+	Number        *hexutil.Big    `json:"number"`
+	Difficulty    *hexutil.Big    `json:"difficulty"`
+	Time          *hexutil.Uint64 `json:"time"`
+	GasLimit      *hexutil.Uint64 `json:"gasLimit"`
+	FeeRecipient  *common.Address `json:"feeRecipient"`
+	PrevRandao    *common.Hash    `json:"prevRandao"`
+	BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas"`
+}
+
+// SimulatedCallResult is the outcome of a single call in a simulated block
+type SimulatedCallResult struct {
+	ReturnValue hexutility.Bytes    `json:"returnData"`
+	Logs        []*types.Log        `json:"logs"`
+	GasUsed     hexutil.Uint64      `json:"gasUsed"`
+	Status      hexutil.Uint64      `json:"status"`
+	Error       *SimulatedCallError `json:"error,omitempty"`
+}
+
+// SimulatedCallError describes why a simulated call failed
+type SimulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+const (
+	simulateErrCodeReverted         = 3
+	simulateErrCodeVMError          = -32015
+	simulateErrCodeInvalidTx        = -38014
+	simulateErrCodeBlockNumberOrder = -38020
+	simulateErrCodeBlockTimeOrder   = -38021
+	simulateErrCodeClientLimit      = -38026
+)
+
+// simulateError is a JSON-RPC error with one of the eth_simulateV1 error codes
+type simulateError struct {
+	code int
+	msg  string
+}
+
+func (e *simulateError) Error() string  { return e.msg }
+func (e *simulateError) ErrorCode() int { return e.code }
+
+// SimulateV1 implements eth_simulateV1. Executes a sequence of calls grouped into blocks on top of the given block,
+// applying the per-block header and state overrides, and returns each simulated block together with its call results.
+func (api *APIImpl) SimulateV1(ctx context.Context, opts SimulationOptions, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, &rpc.InvalidParamsError{Message: "empty input"}
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &simulateError{code: simulateErrCodeClientLimit, msg: fmt.Sprintf("too many blocks: %d > %d", len(opts.BlockStateCalls), maxSimulateBlocks)}
+	}
+	if blockNrOrHash == nil {
+		blockNrOrHash = &latestNumOrHash
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(start time.Time) { log.Trace("Executing EVM simulateV1 finished", "runtime", time.Since(start)) }(time.Now())
+
+	blockNum, hash, _, err := rpchelper.GetCanonicalBlockNumber(*blockNrOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := api._blockReader.Header(ctx, tx, hash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("block %d(%x) not found", blockNum, hash)
+	}
+
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, *blockNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	ibs := state.New(stateReader)
+
+	// Setup context so it may be cancelled the call has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if api.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// simulatedHashes makes the BLOCKHASH opcode see the blocks simulated so far
+	simulatedHashes := make(map[uint64]common.Hash, len(opts.BlockStateCalls))
+	getHash := func(i uint64) common.Hash {
+		if h, ok := simulatedHashes[i]; ok {
+			return h
+		}
+		h, err := api._blockReader.CanonicalHash(ctx, tx, i)
+		if err != nil {
+			log.Debug("Can't get block hash by number", "number", i, "only-canonical", true)
+		}
+		return h
+	}
+
+	blocks, err := fillSimulatedBlockGaps(parent.Number.Uint64(), opts.BlockStateCalls)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]map[string]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		header, err := makeSimulatedHeader(chainConfig, parent, block.BlockOverrides, opts.Validation)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := api.simulateBlock(ctx, chainConfig, ibs, header, getHash, block, &opts)
+		if err != nil {
+			return nil, err
+		}
+		simulatedHashes[header.Number.Uint64()] = header.Hash()
+		results = append(results, fields)
+		parent = header
+	}
+	return results, nil
+}
+
+// fillSimulatedBlockGaps inserts empty blocks where the block number overrides skip numbers,
+// so that every number between the base block and the last simulated block is simulated, as geth does.
+func fillSimulatedBlockGaps(base uint64, blocks []SimulatedBlock) ([]SimulatedBlock, error) {
+	filled := make([]SimulatedBlock, 0, len(blocks))
+	prev := base
+	for _, block := range blocks {
+		if block.BlockOverrides == nil || block.BlockOverrides.Number == nil {
+			filled = append(filled, block)
+			prev++
+			continue
+		}
+		number := block.BlockOverrides.Number.ToInt()
+		if !number.IsUint64() || number.Uint64() <= prev {
+			return nil, &simulateError{code: simulateErrCodeBlockNumberOrder, msg: fmt.Sprintf("block numbers must be in order: %d <= %d", number, prev)}
+		}
+		if number.Uint64()-base > maxSimulateBlocks {
+			return nil, &simulateError{code: simulateErrCodeClientLimit, msg: fmt.Sprintf("too many blocks: %d > %d", number.Uint64()-base, maxSimulateBlocks)}
+		}
+		for n := prev + 1; n < number.Uint64(); n++ {
+			filled = append(filled, SimulatedBlock{})
+		}
+		filled = append(filled, block)
+		prev = number.Uint64()
+	}
+	if len(filled) > maxSimulateBlocks {
+		return nil, &simulateError{code: simulateErrCodeClientLimit, msg: fmt.Sprintf("too many blocks: %d > %d", len(filled), maxSimulateBlocks)}
+	}
+	return filled, nil
+}
+
+// makeSimulatedHeader derives the header of the next simulated block from its parent and the caller-supplied overrides
+func makeSimulatedHeader(chainConfig *chain.Config, parent *types.Header, overrides *SimulatedBlockOverrides, validation bool) (*types.Header, error) {
+	if overrides == nil {
+		overrides = &SimulatedBlockOverrides{}
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + simulateBlockTimeIncrement,
+		MixDigest:  parent.MixDigest,
+	}
+	if overrides.Number != nil {
+		if overrides.Number.ToInt().Cmp(parent.Number) <= 0 {
+			return nil, &simulateError{code: simulateErrCodeBlockNumberOrder, msg: fmt.Sprintf("block numbers must be in order: %d <= %d", overrides.Number.ToInt(), parent.Number)}
+		}
+		header.Number = new(big.Int).Set(overrides.Number.ToInt())
+	}
+	if overrides.Time != nil {
+		if uint64(*overrides.Time) <= parent.Time {
+			return nil, &simulateError{code: simulateErrCodeBlockTimeOrder, msg: fmt.Sprintf("block timestamps must be in order: %d <= %d", uint64(*overrides.Time), parent.Time)}
+		}
+		header.Time = uint64(*overrides.Time)
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.FeeRecipient != nil {
+		header.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.Difficulty != nil {
+		header.Difficulty = new(big.Int).Set(overrides.Difficulty.ToInt())
+	}
+	if overrides.PrevRandao != nil {
+		header.MixDigest = *overrides.PrevRandao
+	}
+
+	number := header.Number.Uint64()
+	if chainConfig.IsLondon(number) {
+		switch {
+		case overrides.BaseFeePerGas != nil:
+			header.BaseFee = new(big.Int).Set(overrides.BaseFeePerGas.ToInt())
+		case validation:
+			header.BaseFee = misc.CalcBaseFee(chainConfig, parent)
+		default:
+			// Without validation calls are free to use a zero gas price
+			header.BaseFee = new(big.Int)
+		}
+	}
+	if chainConfig.IsShanghai(header.Time) {
+		header.WithdrawalsHash = &types.EmptyRootHash
+	}
+	if chainConfig.IsCancun(header.Time) {
+		excessBlobGas := misc.CalcExcessBlobGas(chainConfig, parent)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+		header.ParentBeaconBlockRoot = new(common.Hash)
+	}
+	return header, nil
+}
+
+// simulateBlock executes the calls of a single simulated block against ibs and marshals the resulting block.
+// header is completed in place with the gas used and the transaction and receipt roots.
+func (api *APIImpl) simulateBlock(ctx context.Context, chainConfig *chain.Config, ibs *state.IntraBlockState, header *types.Header,
+	getHash func(uint64) common.Hash, block SimulatedBlock, opts *SimulationOptions) (map[string]interface{}, error) {
+	blockNum := header.Number.Uint64()
+	rules := chainConfig.Rules(blockNum, header.Time)
+
+	if block.StateOverrides != nil {
+		if err := block.StateOverrides.Override(ibs); err != nil {
+			return nil, err
+		}
+	}
+	precompiles := vm.ActivePrecompiledContracts(rules)
+	if block.StateOverrides != nil {
+		var err error
+		if precompiles, err = block.StateOverrides.OverridePrecompiles(precompiles); err != nil {
+			return nil, err
+		}
+	}
+
+	vmConfig := vm.Config{NoBaseFee: !opts.Validation}
+	var tracer *transferTracer
+	if opts.TraceTransfers {
+		tracer = &transferTracer{ibs: ibs}
+		vmConfig.Debug = true
+		vmConfig.Tracer = tracer
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, getHash, api.engine(), &header.Coinbase)
+	evm := vm.NewEVM(blockCtx, evmtypes.TxContext{}, ibs, chainConfig, vmConfig)
+	evm.SetPrecompiles(precompiles)
+
+	// Wait for the context to be done and cancel the evm. Even if the
+	// EVM has finished, cancelling may be done (repeatedly)
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+
+	var (
+		txs         = make(types.Transactions, 0, len(block.Calls))
+		receipts    = make(types.Receipts, 0, len(block.Calls))
+		callResults = make([]SimulatedCallResult, 0, len(block.Calls))
+		gp          = new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(math.MaxUint64)
+		gasUsed     uint64
+	)
+	for i, args := range block.Calls {
+		if args.Gas == nil {
+			remaining := hexutil.Uint64(header.GasLimit - gasUsed)
+			args.Gas = &remaining
+		}
+		txn, msg, err := api.simulatedTransaction(chainConfig, ibs, header, args, opts.Validation)
+		if err != nil {
+			return nil, &simulateError{code: simulateErrCodeInvalidTx, msg: fmt.Sprintf("call %d: %v", i, err)}
+		}
+		ibs.SetTxContext(txn.Hash(), common.Hash{}, i)
+		evm.Reset(core.NewEVMTxContext(msg), ibs)
+
+		result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
+		if err != nil {
+			return nil, &simulateError{code: simulateErrCodeInvalidTx, msg: fmt.Sprintf("call %d: %v", i, err)}
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", api.evmCallTimeout)
+		}
+		if err = ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, err
+		}
+		gasUsed += result.UsedGas
+
+		receipt := &types.Receipt{
+			Type:              txn.Type(),
+			CumulativeGasUsed: gasUsed,
+			TxHash:            txn.Hash(),
+			GasUsed:           result.UsedGas,
+			BlockNumber:       new(big.Int).Set(header.Number),
+			TransactionIndex:  uint(i),
+			Logs:              ibs.GetLogs(txn.Hash()),
+		}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+		}
+		if msg.To() == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From(), msg.Nonce())
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		callResult := SimulatedCallResult{
+			ReturnValue: result.Return(),
+			Logs:        receipt.Logs,
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			Status:      hexutil.Uint64(receipt.Status),
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+		if result.Err != nil {
+			if len(result.Revert()) > 0 {
+				revertErr := ethapi.NewRevertError(result)
+				callResult.Error = &SimulatedCallError{Code: simulateErrCodeReverted, Message: revertErr.Error(), Data: hexutility.Encode(result.Revert())}
+			} else {
+				callResult.Error = &SimulatedCallError{Code: simulateErrCodeVMError, Message: result.Err.Error()}
+			}
+		}
+
+		txs = append(txs, txn)
+		receipts = append(receipts, receipt)
+		callResults = append(callResults, callResult)
+	}
+
+	header.GasUsed = gasUsed
+	var withdrawals []*types.Withdrawal
+	if header.WithdrawalsHash != nil {
+		withdrawals = []*types.Withdrawal{}
+	}
+	simulated := types.NewBlock(header, txs, nil, receipts, withdrawals)
+	*header = *simulated.HeaderNoCopy()
+
+	blockHash := simulated.Hash()
+	for _, receipt := range receipts {
+		receipt.BlockHash = blockHash
+		for _, l := range receipt.Logs {
+			l.BlockHash = blockHash
+			l.BlockNumber = blockNum
+		}
+	}
+
+	return ethapi.RPCMarshalBlock(simulated, true, opts.ReturnFullTransactions, map[string]interface{}{"calls": callResults})
+}
+
+// simulatedTransaction converts the call arguments into the unsigned transaction included in a simulated block
+// and the message executed for it. In validation mode the message is subject to the nonce check.
+func (api *APIImpl) simulatedTransaction(chainConfig *chain.Config, ibs *state.IntraBlockState, header *types.Header, args ethapi.CallArgs, validation bool) (types.Transaction, types.Message, error) {
+	var baseFee *uint256.Int
+	if header.BaseFee != nil {
+		baseFee = uint256.MustFromBig(header.BaseFee)
+	}
+	if args.From == nil {
+		args.From = new(common.Address)
+	}
+	nonce := ibs.GetNonce(*args.From)
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+	msg, err := args.ToMessage(api.GasCap, baseFee)
+	if err != nil {
+		return nil, types.Message{}, err
+	}
+	if validation && baseFee != nil && msg.FeeCap().Lt(baseFee) {
+		return nil, types.Message{}, fmt.Errorf("%w: address %v, maxFeePerGas: %v baseFee: %v", core.ErrFeeCapTooLow, msg.From().Hex(), msg.FeeCap(), baseFee)
+	}
+	msg = types.NewMessage(msg.From(), msg.To(), nonce, msg.Value(), msg.Gas(), msg.GasPrice(), msg.FeeCap(), msg.Tip(),
+		msg.Data(), msg.AccessList(), validation /* checkNonce */, false /* isFree */, msg.MaxFeePerBlobGas())
+
+	commonTx := types.CommonTx{
+		Nonce: nonce,
+		Gas:   msg.Gas(),
+		To:    msg.To(),
+		Value: msg.Value(),
+		Data:  msg.Data(),
+	}
+	var txn types.Transaction
+	if baseFee == nil {
+		txn = &types.LegacyTx{CommonTx: commonTx, GasPrice: msg.GasPrice()}
+	} else {
+		txn = &types.DynamicFeeTransaction{
+			CommonTx:   commonTx,
+			ChainID:    uint256.MustFromBig(chainConfig.ChainID),
+			Tip:        msg.Tip(),
+			FeeCap:     msg.FeeCap(),
+			AccessList: msg.AccessList(),
+		}
+	}
+	txn.SetSender(msg.From())
+	return txn, msg, nil
+}
+
+// transferTracer records native value transfers as ERC-7528 Transfer logs.
+// The capture hooks of a frame fire before the value transfer is checked and the state snapshot is taken,
+// so the log of each frame is buffered until the frame executes its first opcode or returns without an error.
+// Frames which fail before that (e.g. insufficient balance, address collision) leave no log,
+// and the flushed logs are reverted together with their frame.
+type transferTracer struct {
+	ibs    *state.IntraBlockState
+	frames []*types.Log // pending transfer log of each call frame, nil if there is none or it is flushed
+}
+
+func (t *transferTracer) enterFrame(from, to common.Address, value *uint256.Int) {
+	if value == nil || value.IsZero() {
+		t.frames = append(t.frames, nil)
+		return
+	}
+	data := value.Bytes32()
+	t.frames = append(t.frames, &types.Log{
+		Address: transferLogAddress,
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    data[:],
+	})
+}
+
+func (t *transferTracer) exitFrame(err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	pending := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if pending != nil && err == nil {
+		t.ibs.AddLog(pending)
+	}
+}
+
+func (t *transferTracer) CaptureTxStart(gasLimit uint64) {}
+func (t *transferTracer) CaptureTxEnd(restGas uint64)    {}
+func (t *transferTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.frames = t.frames[:0]
+	t.enterFrame(from, to, value)
+}
+func (t *transferTracer) CaptureEnd(output []byte, usedGas uint64, err error) {
+	t.exitFrame(err)
+}
+func (t *transferTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	// CALLCODE and DELEGATECALL execute foreign code in the context of the caller, the value stays with it
+	if typ == vm.CALLCODE || typ == vm.DELEGATECALL || typ == vm.STATICCALL {
+		value = nil
+	}
+	t.enterFrame(from, to, value)
+}
+func (t *transferTracer) CaptureExit(output []byte, usedGas uint64, err error) {
+	t.exitFrame(err)
+}
+func (t *transferTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	// the frame runs its code, so the value is transferred and the state snapshot is taken
+	if n := len(t.frames); n > 0 && t.frames[n-1] != nil {
+		t.ibs.AddLog(t.frames[n-1])
+		t.frames[n-1] = nil
+	}
+}
+func (t *transferTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+var _ vm.EVMLogger = (*transferTracer)(nil)
@@ -0,0 +1,177 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+)
+
+func TestSimulateV1(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	var (
+		from      = libcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+		to        = libcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+		moved     = libcommon.HexToAddress("0x000000000000000000000000000000000000beef")
+		sha256Pre = libcommon.BytesToAddress([]byte{0x02})
+		balance   = (*hexutil.Big)(big.NewInt(1e18))
+		value     = (*hexutil.Big)(big.NewInt(1000))
+		input     = hexutility.Bytes("abc")
+		blockTime = hexutil.Uint64(1_900_000_000)
+	)
+
+	opts := SimulationOptions{
+		TraceTransfers: true,
+		BlockStateCalls: []SimulatedBlock{
+			{
+				StateOverrides: &ethapi.StateOverrides{from: {Balance: &balance}},
+				Calls:          []ethapi.CallArgs{{From: &from, To: &to, Value: value}},
+			},
+			{
+				BlockOverrides: &SimulatedBlockOverrides{Time: &blockTime},
+				StateOverrides: &ethapi.StateOverrides{sha256Pre: {MovePrecompileTo: &moved}},
+				Calls:          []ethapi.CallArgs{{From: &from, To: &moved, Input: &input}},
+			},
+		},
+	}
+	latest := latestNumOrHash
+	res, err := api.SimulateV1(context.Background(), opts, &latest)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	transfer := res[0]["calls"].([]SimulatedCallResult)
+	require.Len(t, transfer, 1)
+	require.Nil(t, transfer[0].Error)
+	require.Equal(t, hexutil.Uint64(1), transfer[0].Status)
+	require.Len(t, transfer[0].Logs, 1)
+	require.Equal(t, transferLogAddress, transfer[0].Logs[0].Address)
+	require.Equal(t, []libcommon.Hash{transferTopic, libcommon.BytesToHash(from.Bytes()), libcommon.BytesToHash(to.Bytes())}, transfer[0].Logs[0].Topics)
+	require.Equal(t, res[0]["hash"], transfer[0].Logs[0].BlockHash)
+
+	require.Equal(t, res[0]["hash"], res[1]["parentHash"])
+	require.Equal(t, blockTime, res[1]["timestamp"])
+
+	precompile := res[1]["calls"].([]SimulatedCallResult)
+	require.Len(t, precompile, 1)
+	require.Nil(t, precompile[0].Error)
+	expected := sha256.Sum256(input)
+	require.Equal(t, hexutility.Bytes(expected[:]), precompile[0].ReturnValue)
+
+	// Skipped block numbers are filled with empty blocks
+	head := res[0]["number"].(*hexutil.Big).ToInt().Int64()
+	gapNumber := (*hexutil.Big)(big.NewInt(head + 3))
+	res, err = api.SimulateV1(context.Background(), SimulationOptions{
+		BlockStateCalls: []SimulatedBlock{
+			{},
+			{BlockOverrides: &SimulatedBlockOverrides{Number: gapNumber}},
+		},
+	}, &latest)
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	for i, block := range res {
+		require.Equal(t, big.NewInt(head+int64(i)), block["number"].(*hexutil.Big).ToInt())
+		require.Empty(t, block["calls"])
+		if i > 0 {
+			require.Equal(t, res[i-1]["hash"], block["parentHash"])
+			require.Equal(t, res[i-1]["timestamp"].(hexutil.Uint64)+simulateBlockTimeIncrement, block["timestamp"])
+		}
+	}
+
+	// Block numbers must strictly increase
+	number := (*hexutil.Big)(big.NewInt(1))
+	_, err = api.SimulateV1(context.Background(), SimulationOptions{
+		BlockStateCalls: []SimulatedBlock{{BlockOverrides: &SimulatedBlockOverrides{Number: number}}},
+	}, &latest)
+	require.Error(t, err)
+	require.Equal(t, simulateErrCodeBlockNumberOrder, err.(*simulateError).ErrorCode())
+}
+
+func TestSimulateV1TraceTransfersFailedFrames(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	var (
+		from      = libcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+		reverter  = libcommon.HexToAddress("0x3000000000000000000000000000000000000003")
+		forwarder = libcommon.HexToAddress("0x4000000000000000000000000000000000000004")
+		creator   = libcommon.HexToAddress("0x5000000000000000000000000000000000000005")
+		callcoder = libcommon.HexToAddress("0x6000000000000000000000000000000000000006")
+		balance   = (*hexutil.Big)(big.NewInt(1e18))
+		value     = (*hexutil.Big)(big.NewInt(1000))
+
+		// PUSH1 0 PUSH1 0 REVERT
+		revertCode = hexutility.Bytes{0x60, 0x00, 0x60, 0x00, 0xfd}
+		// CALL(GAS, reverter, 1, 0, 0, 0, 0) STOP
+		forwarderCode = append(append(hexutility.Bytes{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x73}, reverter.Bytes()...), 0x5a, 0xf1, 0x00)
+		// CREATE(0xffff, 0, 0) STOP, the value exceeds the balance of the creator
+		creatorCode = hexutility.Bytes{0x60, 0x00, 0x60, 0x00, 0x61, 0xff, 0xff, 0xf0, 0x00}
+		// CALLCODE(GAS, from, 1, 0, 0, 0, 0) STOP
+		callcoderCode = append(append(hexutility.Bytes{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x73}, from.Bytes()...), 0x5a, 0xf2, 0x00)
+	)
+
+	opts := SimulationOptions{
+		TraceTransfers: true,
+		BlockStateCalls: []SimulatedBlock{
+			{
+				StateOverrides: &ethapi.StateOverrides{
+					from:      {Balance: &balance},
+					reverter:  {Code: &revertCode},
+					forwarder: {Code: &forwarderCode},
+					creator:   {Code: &creatorCode},
+					callcoder: {Code: &callcoderCode},
+				},
+				Calls: []ethapi.CallArgs{
+					{From: &from, To: &reverter, Value: value},
+					{From: &from, Input: &revertCode, Value: value},
+					{From: &from, To: &forwarder, Value: value},
+					{From: &from, To: &creator},
+					{From: &from, To: &callcoder, Value: value},
+				},
+			},
+		},
+	}
+	latest := latestNumOrHash
+	res, err := api.SimulateV1(context.Background(), opts, &latest)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	calls := res[0]["calls"].([]SimulatedCallResult)
+	require.Len(t, calls, 5)
+	transferLog := func(from, to libcommon.Address) []libcommon.Hash {
+		return []libcommon.Hash{transferTopic, libcommon.BytesToHash(from.Bytes()), libcommon.BytesToHash(to.Bytes())}
+	}
+
+	// reverted value call
+	require.Equal(t, hexutil.Uint64(0), calls[0].Status)
+	require.Empty(t, calls[0].Logs)
+
+	// reverted CREATE
+	require.Equal(t, hexutil.Uint64(0), calls[1].Status)
+	require.Empty(t, calls[1].Logs)
+
+	// the reverted inner call leaves only the outer transfer
+	require.Equal(t, hexutil.Uint64(1), calls[2].Status)
+	require.Len(t, calls[2].Logs, 1)
+	require.Equal(t, transferLog(from, forwarder), calls[2].Logs[0].Topics)
+
+	// CREATE failing the balance check
+	require.Equal(t, hexutil.Uint64(1), calls[3].Status)
+	require.Empty(t, calls[3].Logs)
+
+	// CALLCODE keeps the value with the caller
+	require.Equal(t, hexutil.Uint64(1), calls[4].Status)
+	require.Len(t, calls[4].Logs, 1)
+	require.Equal(t, transferLog(from, callcoder), calls[4].Logs[0].Topics)
+}
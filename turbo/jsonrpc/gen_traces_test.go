@@ -8,14 +8,17 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/cli/httpcfg"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/eth/tracers"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+	"github.com/ledgerwatch/erigon/turbo/stages/mock"
 
 	// Force-load native and js packages, to trigger registration
 	_ "github.com/ledgerwatch/erigon/eth/tracers/js"
@@ -339,3 +342,62 @@ func TestGeneratedTraceApiCollision(t *testing.T) {
 		t.Fatalf("not equal")
 	}
 }
+
+func TestGeneratedFlatCallTracer(t *testing.T) {
+	for name, m := range map[string]*mock.MockSentry{
+		"traces":    rpcdaemontest.CreateTestSentryForTraces(t),
+		"collision": rpcdaemontest.CreateTestSentryForTracesCollision(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			checkFlatCallTracer(t, m)
+		})
+	}
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	checkFlatCallTracer(t, m)
+}
+
+// checkFlatCallTracer checks that the flatCallTracer output of debug_traceBlockByNumber and
+// debug_traceTransaction matches trace_transaction, for every transaction of the chain.
+func checkFlatCallTracer(t *testing.T, m *mock.MockSentry) {
+	t.Helper()
+	baseApi := newBaseApiForTest(m)
+	debugApi := NewPrivateDebugAPI(baseApi, m.DB, 0)
+	traceApi := NewTraceAPI(baseApi, m.DB, &httpcfg.HttpCfg{})
+	flatCallTracer := "flatCallTracer"
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	for blockNum := uint64(1); blockNum <= *rawdb.ReadCurrentBlockNumber(tx); blockNum++ {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, blockNum)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+		require.NoError(t, debugApi.TraceBlockByNumber(m.Ctx, rpc.BlockNumber(blockNum), &tracers.TraceConfig{Tracer: &flatCallTracer}, stream))
+		require.NoError(t, stream.Flush())
+		var results []struct {
+			TxHash common.Hash     `json:"txHash"`
+			Result json.RawMessage `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		require.Len(t, results, block.Transactions().Len())
+
+		for i, txn := range block.Transactions() {
+			traces, err := traceApi.Transaction(m.Ctx, txn.Hash(), new(bool))
+			require.NoError(t, err)
+			expected, err := json.Marshal(traces)
+			require.NoError(t, err)
+
+			require.Equal(t, txn.Hash(), results[i].TxHash)
+			require.JSONEq(t, string(expected), string(results[i].Result), "block %d tx %d", blockNum, i)
+
+			buf.Reset()
+			stream = jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+			require.NoError(t, debugApi.TraceTransaction(m.Ctx, txn.Hash(), &tracers.TraceConfig{Tracer: &flatCallTracer}, stream))
+			require.NoError(t, stream.Flush())
+			require.JSONEq(t, string(expected), buf.String(), "tx %x", txn.Hash())
+		}
+	}
+}
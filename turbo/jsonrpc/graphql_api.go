@@ -1,35 +1,64 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/chain"
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethutils"
+	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/rlp"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
 )
 
 type GraphQLAPI interface {
-	GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error)
+	GetBlockDetails(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error)
 	GetChainID(ctx context.Context) (*big.Int, error)
+	GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error)
+	GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error)
+	GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error)
+	GetStorageAt(ctx context.Context, address common.Address, index string, blockNrOrHash rpc.BlockNumberOrHash) (string, error)
+	Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (*core.ExecutionResult, error)
+	EstimateGas(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error)
+	GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error)
+	GetTransactionByHash(ctx context.Context, hash common.Hash) (*RPCTransaction, error)
+	GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error)
+	GetPendingTransactions(ctx context.Context) ([]*RPCTransaction, error)
+	SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error)
+	GasPrice(ctx context.Context) (*hexutil.Big, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error)
+	Syncing(ctx context.Context) (interface{}, error)
 }
 
+// GraphQLAPIImpl backs the GraphQL resolvers. State, calls, logs and the transaction pool are served by the
+// eth and txpool implementations, so that GraphQL shares their dataset guarantees.
 type GraphQLAPIImpl struct {
 	*BaseAPI
-	db kv.RoDB
+	db     kv.RoDB
+	eth    *APIImpl
+	txPool *TxPoolAPIImpl
 }
 
-func NewGraphQLAPI(base *BaseAPI, db kv.RoDB) *GraphQLAPIImpl {
+func NewGraphQLAPI(base *BaseAPI, db kv.RoDB, eth *APIImpl, txPool *TxPoolAPIImpl) *GraphQLAPIImpl {
 	return &GraphQLAPIImpl{
 		BaseAPI: base,
 		db:      db,
+		eth:     eth,
+		txPool:  txPool,
 	}
 }
 
@@ -48,14 +77,14 @@ func (api *GraphQLAPIImpl) GetChainID(ctx context.Context) (*big.Int, error) {
 	return response.ChainID, nil
 }
 
-func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.BlockNumber) (map[string]interface{}, error) {
+func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error) {
 	tx, err := api.db.BeginRo(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	block, senders, err := api.getBlockWithSenders(ctx, blockNumber, tx)
+	block, senders, err := api.getBlockWithSenders(ctx, blockNrOrHash, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +92,10 @@ func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.
 		return nil, nil
 	}
 
+	blockNumber, isNumber := blockNrOrHash.Number()
+	if !isNumber {
+		blockNumber = rpc.BlockNumber(block.NumberU64())
+	}
 	getBlockRes, err := api.delegateGetBlockByNumber(tx, block, blockNumber, false)
 	if err != nil {
 		return nil, err
@@ -78,31 +111,132 @@ func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.
 		return nil, fmt.Errorf("getReceipts error: %w", err)
 	}
 
+	var baseFee *uint256.Int
+	if block.BaseFee() != nil {
+		baseFee, _ = uint256.FromBig(block.BaseFee())
+	}
+	if chainConfig.IsLondon(block.NumberU64() + 1) {
+		getBlockRes["nextBaseFeePerGas"] = (*hexutil.Big)(misc.CalcBaseFee(chainConfig, block.HeaderNoCopy()))
+	}
+
+	rawHeader, err := rlp.EncodeToBytes(block.HeaderNoCopy())
+	if err != nil {
+		return nil, err
+	}
+	getBlockRes["rawHeader"] = hexutility.Bytes(rawHeader)
+	rawBlock, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+	getBlockRes["raw"] = hexutility.Bytes(rawBlock)
+
+	ommers := make([]map[string]interface{}, 0, len(block.Uncles()))
+	for _, uncle := range block.Uncles() {
+		ommers = append(ommers, ethapi.RPCMarshalHeader(uncle))
+	}
+
 	result := make([]map[string]interface{}, 0, len(receipts))
 	for _, receipt := range receipts {
 		txn := block.Transactions()[receipt.TransactionIndex]
-
-		transaction := ethutils.MarshalReceipt(receipt, txn, chainConfig, block.HeaderNoCopy(), txn.Hash(), true)
-		transaction["nonce"] = txn.GetNonce()
-		transaction["value"] = txn.GetValue()
-		transaction["data"] = txn.GetData()
-		transaction["logs"] = receipt.Logs
+		transaction, err := marshalTransactionDetails(receipt, txn, chainConfig, block.HeaderNoCopy(), baseFee)
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, transaction)
 	}
 
 	response := map[string]interface{}{}
 	response["block"] = getBlockRes
 	response["receipts"] = result
+	response["ommers"] = ommers
 
 	return response, nil
 }
 
-func (api *GraphQLAPIImpl) getBlockWithSenders(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, []common.Address, error) {
-	if number == rpc.PendingBlockNumber {
+// GetTransactionDetails returns a mined transaction merged with its receipt, in the format of the GetBlockDetails
+// receipts. It returns nil if the transaction is not in the canonical chain.
+// Like eth_getTransactionReceipt it reads the whole block and gets the receipts of all its transactions,
+// because cumulative gas and log indices of the receipt depend on the preceding transactions;
+// it only skips marshalling of the block, its ommers and the other receipts.
+func (api *GraphQLAPIImpl) GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, ok, err := api.txnLookup(tx, hash)
+	if err != nil || !ok {
+		return nil, err
+	}
+	block, err := api.blockByNumberWithSenders(tx, blockNum)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	txnIndex := -1
+	for i, txn := range block.Transactions() {
+		if txn.Hash() == hash {
+			txnIndex = i
+			break
+		}
+	}
+	if txnIndex < 0 {
+		return nil, nil
+	}
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := api.getReceipts(ctx, tx, chainConfig, block, block.Body().SendersFromTxs())
+	if err != nil {
+		return nil, fmt.Errorf("getReceipts error: %w", err)
+	}
+	if len(receipts) <= txnIndex {
+		return nil, fmt.Errorf("block has less receipts than expected: %d <= %d, block: %d", len(receipts), txnIndex, blockNum)
+	}
+
+	var baseFee *uint256.Int
+	if block.BaseFee() != nil {
+		baseFee, _ = uint256.FromBig(block.BaseFee())
+	}
+	return marshalTransactionDetails(receipts[txnIndex], block.Transactions()[txnIndex], chainConfig, block.HeaderNoCopy(), baseFee)
+}
+
+func marshalTransactionDetails(receipt *types.Receipt, txn types.Transaction, chainConfig *chain.Config, header *types.Header, baseFee *uint256.Int) (map[string]interface{}, error) {
+	transaction := ethutils.MarshalReceipt(receipt, txn, chainConfig, header, txn.Hash(), true)
+	transaction["nonce"] = txn.GetNonce()
+	transaction["value"] = txn.GetValue()
+	transaction["data"] = txn.GetData()
+	transaction["logs"] = receipt.Logs
+	transaction["gas"] = hexutil.Uint64(txn.GetGas())
+	if txn.Type() >= types.DynamicFeeTxType {
+		transaction["maxFeePerGas"] = txn.GetFeeCap()
+		transaction["maxPriorityFeePerGas"] = txn.GetTip()
+	}
+	if baseFee != nil {
+		transaction["effectiveTip"] = txn.GetEffectiveGasTip(baseFee)
+	}
+	v, r, s := txn.RawSignatureValues()
+	transaction["v"], transaction["r"], transaction["s"] = v, r, s
+	transaction["accessList"] = txn.GetAccessList()
+	var raw bytes.Buffer
+	if err := txn.MarshalBinary(&raw); err != nil {
+		return nil, err
+	}
+	transaction["raw"] = hexutility.Bytes(raw.Bytes())
+	var rawReceipt bytes.Buffer
+	types.Receipts{receipt}.EncodeIndex(0, &rawReceipt)
+	transaction["rawReceipt"] = hexutility.Bytes(rawReceipt.Bytes())
+	return transaction, nil
+}
+
+func (api *GraphQLAPIImpl) getBlockWithSenders(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, tx kv.Tx) (*types.Block, []common.Address, error) {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
 		return api.pendingBlock(), nil, nil
 	}
 
-	blockHeight, blockHash, _, err := rpchelper.GetBlockNumber(rpc.BlockNumberOrHashWithNumber(number), tx, api.filters)
+	blockHeight, blockHash, _, err := rpchelper.GetBlockNumber(blockNrOrHash, tx, api.filters)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -133,3 +267,88 @@ func (api *GraphQLAPIImpl) delegateGetBlockByNumber(tx kv.Tx, b *types.Block, nu
 
 	return response, err
 }
+
+func (api *GraphQLAPIImpl) GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	return api.eth.GetBalance(ctx, address, blockNrOrHash)
+}
+
+func (api *GraphQLAPIImpl) GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	return api.eth.GetTransactionCount(ctx, address, blockNrOrHash)
+}
+
+func (api *GraphQLAPIImpl) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error) {
+	return api.eth.GetCode(ctx, address, blockNrOrHash)
+}
+
+func (api *GraphQLAPIImpl) GetStorageAt(ctx context.Context, address common.Address, index string, blockNrOrHash rpc.BlockNumberOrHash) (string, error) {
+	return api.eth.GetStorageAt(ctx, address, index, blockNrOrHash)
+}
+
+// Call executes a message call and returns the execution result, unlike eth_call a revert is not an error
+// since GraphQL reports it through the status of the call result.
+func (api *GraphQLAPIImpl) Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (*core.ExecutionResult, error) {
+	result, err := api.eth.doCall(ctx, args, blockNrOrHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	if len(result.ReturnData) > api.eth.ReturnDataLimit {
+		return nil, fmt.Errorf("call returned result on length %d exceeding --rpc.returndata.limit %d", len(result.ReturnData), api.eth.ReturnDataLimit)
+	}
+	return result, nil
+}
+
+func (api *GraphQLAPIImpl) EstimateGas(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	return api.eth.EstimateGas(ctx, &args, &blockNrOrHash)
+}
+
+func (api *GraphQLAPIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error) {
+	return api.eth.GetLogs(ctx, crit)
+}
+
+func (api *GraphQLAPIImpl) GetTransactionByHash(ctx context.Context, hash common.Hash) (*RPCTransaction, error) {
+	return api.eth.GetTransactionByHash(ctx, hash)
+}
+
+// GetPendingTransactions returns the executable transactions of the pool, ordered by sender and nonce.
+func (api *GraphQLAPIImpl) GetPendingTransactions(ctx context.Context) ([]*RPCTransaction, error) {
+	content, err := api.txPool.Content(ctx)
+	if err != nil {
+		return nil, err
+	}
+	senders := make([]string, 0, len(content["pending"]))
+	for sender := range content["pending"] {
+		senders = append(senders, sender)
+	}
+	sort.Strings(senders)
+
+	var pending []*RPCTransaction
+	for _, sender := range senders {
+		byNonce := content["pending"][sender]
+		txs := make([]*RPCTransaction, 0, len(byNonce))
+		for _, txn := range byNonce {
+			txs = append(txs, txn)
+		}
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+		pending = append(pending, txs...)
+	}
+	return pending, nil
+}
+
+func (api *GraphQLAPIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	return api.eth.SendRawTransaction(ctx, encodedTx)
+}
+
+func (api *GraphQLAPIImpl) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.GasPrice(ctx)
+}
+
+func (api *GraphQLAPIImpl) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.MaxPriorityFeePerGas(ctx)
+}
+
+func (api *GraphQLAPIImpl) Syncing(ctx context.Context) (interface{}, error) {
+	return api.eth.Syncing(ctx)
+}
@@ -0,0 +1,89 @@
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+)
+
+func TestGraphQLGetBlockDetails(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	eth := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+	api := NewGraphQLAPI(base, m.DB, eth, nil)
+
+	block := chain.Blocks[0]
+	for _, blockNrOrHash := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.NumberU64())),
+		rpc.BlockNumberOrHashWithHash(block.Hash(), false),
+	} {
+		res, err := api.GetBlockDetails(context.Background(), blockNrOrHash)
+		require.NoError(t, err)
+
+		details := res["block"].(map[string]interface{})
+		require.Equal(t, block.Hash(), details["hash"])
+		require.Equal(t, block.Hash(), crypto.Keccak256Hash(details["rawHeader"].(hexutility.Bytes)))
+
+		receipts := res["receipts"].([]map[string]interface{})
+		require.Len(t, receipts, block.Transactions().Len())
+		for i, receipt := range receipts {
+			require.Equal(t, block.Transactions()[i].Hash(), receipt["transactionHash"])
+			require.NotEmpty(t, receipt["raw"])
+			require.NotEmpty(t, receipt["rawReceipt"])
+		}
+		require.Len(t, res["ommers"], len(block.Uncles()))
+	}
+
+	res, err := api.GetBlockDetails(context.Background(), rpc.BlockNumberOrHashWithHash(libcommon.HexToHash("0x1234"), false))
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestGraphQLGetTransactionDetails(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	eth := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+	api := NewGraphQLAPI(base, m.DB, eth, nil)
+
+	block := chain.Blocks[1]
+	require.NotZero(t, block.Transactions().Len())
+	blockDetails, err := api.GetBlockDetails(context.Background(), rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	require.NoError(t, err)
+	for i, txn := range block.Transactions() {
+		res, err := api.GetTransactionDetails(context.Background(), txn.Hash())
+		require.NoError(t, err)
+		require.Equal(t, block.Hash(), res["blockHash"])
+		require.Equal(t, blockDetails["receipts"].([]map[string]interface{})[i], res)
+	}
+
+	res, err := api.GetTransactionDetails(context.Background(), libcommon.HexToHash("0x1234"))
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestGraphQLCall(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	eth := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+	api := NewGraphQLAPI(base, m.DB, eth, nil)
+
+	var from = libcommon.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+	var to = libcommon.HexToAddress("0x0d3ab14bbad3d99f4203bd7a11acb94882050e7e")
+	result, err := api.Call(context.Background(), ethapi.CallArgs{
+		From: &from,
+		To:   &to,
+	}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	require.False(t, result.Failed())
+	require.NotZero(t, result.UsedGas)
+}
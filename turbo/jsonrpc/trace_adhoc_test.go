@@ -4,15 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/holiman/uint256"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/cli/httpcfg"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/common/math"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/eth/tracers"
 	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/tests"
+	"github.com/ledgerwatch/erigon/turbo/stages/mock"
 )
 
 func TestEmptyQuery(t *testing.T) {
@@ -100,3 +113,79 @@ func TestReplayBlockTransactions(t *testing.T) {
 	v := addrDiff.Balance.(map[string]*hexutil.Big)["+"].ToInt().Uint64()
 	require.Equal(t, uint64(1_000_000_000_000_000), v)
 }
+
+// TestFlatCallTracerMatchesOeTracer runs the call tracer test suite through both the trace_* tracer
+// and the native flatCallTracer, and checks that they produce the same Parity traces.
+func TestFlatCallTracerMatchesOeTracer(t *testing.T) {
+	dir := filepath.Join("..", "..", "eth", "tracers", "internal", "tracetest", "testdata", "call_tracer")
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") || strings.HasSuffix(file.Name(), "_onlytop.json") {
+			continue
+		}
+		file := file
+		t.Run(strings.TrimSuffix(file.Name(), ".json"), func(t *testing.T) {
+			var test struct {
+				Genesis *types.Genesis `json:"genesis"`
+				Context struct {
+					Number     math.HexOrDecimal64   `json:"number"`
+					Difficulty *math.HexOrDecimal256 `json:"difficulty"`
+					Time       math.HexOrDecimal64   `json:"timestamp"`
+					GasLimit   math.HexOrDecimal64   `json:"gasLimit"`
+					Miner      libcommon.Address     `json:"miner"`
+				} `json:"context"`
+				Input string `json:"input"`
+			}
+			blob, err := os.ReadFile(filepath.Join(dir, file.Name()))
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(blob, &test))
+			txn, err := types.UnmarshalTransactionFromBinary(libcommon.FromHex(test.Input), false /* blobTxnsAreWrappedWithBlobs */)
+			require.NoError(t, err)
+
+			trace := func(tracer vm.EVMLogger) {
+				var (
+					signer    = types.MakeSigner(test.Genesis.Config, uint64(test.Context.Number), uint64(test.Context.Time))
+					origin, _ = signer.Sender(txn)
+					txCtx     = evmtypes.TxContext{Origin: origin, GasPrice: txn.GetPrice()}
+					blockCtx  = evmtypes.BlockContext{
+						CanTransfer: core.CanTransfer,
+						Transfer:    core.Transfer,
+						Coinbase:    test.Context.Miner,
+						BlockNumber: uint64(test.Context.Number),
+						Time:        uint64(test.Context.Time),
+						Difficulty:  (*big.Int)(test.Context.Difficulty),
+						GasLimit:    uint64(test.Context.GasLimit),
+					}
+					rules = test.Genesis.Config.Rules(blockCtx.BlockNumber, blockCtx.Time)
+				)
+				if test.Genesis.BaseFee != nil {
+					blockCtx.BaseFee, _ = uint256.FromBig(test.Genesis.BaseFee)
+				}
+				m := mock.Mock(t)
+				dbTx, err := m.DB.BeginRw(m.Ctx)
+				require.NoError(t, err)
+				defer dbTx.Rollback()
+				ibs, err := tests.MakePreState(rules, dbTx, test.Genesis.Alloc, uint64(test.Context.Number))
+				require.NoError(t, err)
+				evm := vm.NewEVM(blockCtx, txCtx, ibs, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
+				msg, err := txn.AsMessage(*signer, test.Genesis.BaseFee, rules)
+				require.NoError(t, err)
+				_, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(txn.GetGas()).AddBlobGas(txn.GetBlobGas()), true /* refunds */, false /* gasBailout */)
+				require.NoError(t, err)
+			}
+
+			ot := &OeTracer{r: &TraceCallResult{Trace: []*ParityTrace{}}, traceAddr: []int{}}
+			trace(ot)
+			expected, err := json.Marshal(ot.r.Trace)
+			require.NoError(t, err)
+
+			flatCallTracer, err := tracers.New("flatCallTracer", new(tracers.Context), nil)
+			require.NoError(t, err)
+			trace(flatCallTracer)
+			res, err := flatCallTracer.GetResult()
+			require.NoError(t, err)
+			require.JSONEq(t, string(expected), string(res))
+		})
+	}
+}
@@ -159,6 +159,8 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 			BlobHashes:        msg.BlobHashes(),
 			Txn:               txn,
 			CumulativeGasUsed: &cumulativeGas,
+			BlockHash:         block.Hash(),
+			TxIndex:           idx,
 		}
 
 		if isBorStateSyncTxn {
@@ -19,6 +19,7 @@ import (
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*RPCTransaction, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -201,40 +202,48 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
-
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.All(ctx, &proto_txpool.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
+
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
 
 	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	var format = func(txn types.Transaction) string {
+		if to := txn.GetTo(); to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
 	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
-		content["pending"][account.Hex()] = dump
-	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+		var subPool string
+		switch reply.Txs[i].TxnType {
+		case proto_txpool.AllReply_PENDING:
+			subPool = "pending"
+		case proto_txpool.AllReply_BASE_FEE:
+			subPool = "baseFee"
+		case proto_txpool.AllReply_QUEUED:
+			subPool = "queued"
+		default:
+			continue
 		}
-		content["queued"][account.Hex()] = dump
+		account := libcommon.Address(gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)).Hex()
+		if _, ok := content[subPool][account]; !ok {
+			content[subPool][account] = make(map[string]string)
+		}
+		content[subPool][account][fmt.Sprintf("%d", txn.GetNonce())] = format(txn)
 	}
-	return content
+	return content, nil
 }
-*/
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"testing"
+	"time"
 
 	"github.com/holiman/uint256"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	txPoolProto "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
+	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
@@ -58,9 +60,59 @@ func TestTxPoolContent(t *testing.T) {
 	require.Equal(1, len(content["pending"][sender]))
 	require.Equal(expectValue, content["pending"][sender]["0"].Value.ToInt().Uint64())
 
+	inspect, err := api.Inspect(ctx)
+	require.NoError(err)
+	require.Equal(1, len(inspect["pending"][sender]))
+	require.Equal(fmt.Sprintf("%s: %d wei + %d gas × %d wei", libcommon.Address{1}.Hex(), expectValue, params.TxGas, uint64(10*params.GWei)), inspect["pending"][sender]["0"])
+
 	status, err := api.Status(ctx)
 	require.NoError(err)
 	require.Len(status, 3)
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
 }
+
+func TestTxPoolDroppedTransactions(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(libcommon.Address{1})
+	})
+	require.NoError(err)
+	err = m.InsertChain(chain)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+
+	txsCh, id := ff.SubscribeDroppedTxs(16)
+	defer ff.UnsubscribeDroppedTxs(id)
+
+	// every replacement by a higher tip discards the previous tx, repeat until the subscription is established
+	replaced := map[libcommon.Hash]struct{}{}
+	for i := uint64(1); i <= 10; i++ {
+		txn, err := types.SignTx(types.NewTransaction(0, libcommon.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(i*10*params.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		err = txn.MarshalBinary(buf)
+		require.NoError(err)
+		reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+		require.NoError(err)
+		require.Equal(txPoolProto.ImportResult_SUCCESS, reply.Imported[0], fmt.Sprintf("%s", reply.Errors))
+
+		if i > 1 {
+			select {
+			case got := <-txsCh:
+				require.Len(got, 1)
+				require.Equal(m.Address, got[0].From)
+				require.Equal(hexutil.Uint64(0), got[0].Nonce)
+				require.Equal(txpoolcfg.ReplacedByHigherTip.String(), got[0].Reason)
+				require.Contains(replaced, got[0].Hash)
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		replaced[txn.Hash()] = struct{}{}
+	}
+	t.Fatal("no notification about dropped transaction")
+}
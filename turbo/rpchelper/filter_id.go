@@ -14,6 +14,7 @@ type (
 	PendingLogsSubID  SubscriptionID
 	PendingBlockSubID SubscriptionID
 	PendingTxsSubID   SubscriptionID
+	DroppedTxsSubID   SubscriptionID
 	LogsSubID         SubscriptionID
 )
 
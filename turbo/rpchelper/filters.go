@@ -13,11 +13,13 @@ import (
 	"time"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/grpcutil"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	txpool2 "github.com/ledgerwatch/erigon-lib/txpool"
+	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
 	"github.com/ledgerwatch/log/v3"
 	"google.golang.org/grpc"
 
@@ -35,6 +37,7 @@ type Filters struct {
 	pendingLogsSubs  *SyncMap[PendingLogsSubID, Sub[types.Logs]]
 	pendingBlockSubs *SyncMap[PendingBlockSubID, Sub[*types.Block]]
 	pendingTxsSubs   *SyncMap[PendingTxsSubID, Sub[[]types.Transaction]]
+	droppedTxsSubs   *SyncMap[DroppedTxsSubID, Sub[[]*DroppedTx]]
 	logsSubs         *LogsFilterAggregator
 	logsRequestor    atomic.Value
 	onNewSnapshot    func()
@@ -52,6 +55,7 @@ func New(ctx context.Context, ethBackend ApiBackend, txPool txpool.TxpoolClient,
 	ff := &Filters{
 		headsSubs:          NewSyncMap[HeadsSubID, Sub[*types.Header]](),
 		pendingTxsSubs:     NewSyncMap[PendingTxsSubID, Sub[[]types.Transaction]](),
+		droppedTxsSubs:     NewSyncMap[DroppedTxsSubID, Sub[[]*DroppedTx]](),
 		pendingLogsSubs:    NewSyncMap[PendingLogsSubID, Sub[types.Logs]](),
 		pendingBlockSubs:   NewSyncMap[PendingBlockSubID, Sub[*types.Block]](),
 		logsSubs:           NewLogsFilterAggregator(),
@@ -135,6 +139,27 @@ func New(ctx context.Context, ethBackend ApiBackend, txPool txpool.TxpoolClient,
 				}
 			}
 		}()
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				if err := ff.subscribeToDroppedTransactions(ctx, txPool); err != nil {
+					select {
+					case <-ctx.Done():
+						return
+					default:
+					}
+					if grpcutil.IsEndOfStream(err) || grpcutil.IsRetryLater(err) || grpcutil.ErrIs(err, txpool2.ErrPoolDisabled) {
+						time.Sleep(3 * time.Second)
+						continue
+					}
+					logger.Warn("rpc filters: error subscribing to dropped transactions", "err", err)
+				}
+			}
+		}()
 
 		if !reflect.ValueOf(mining).IsNil() { //https://groups.google.com/g/golang-nuts/c/wnH302gBa4I
 			go func() {
@@ -211,6 +236,26 @@ func (ff *Filters) subscribeToPendingTransactions(ctx context.Context, txPool tx
 	return nil
 }
 
+func (ff *Filters) subscribeToDroppedTransactions(ctx context.Context, txPool txpool.TxpoolClient) error {
+	subscription, err := txPool.OnDiscard(ctx, &txpool.OnDiscardRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := subscription.Recv()
+		if errors.Is(err, io.EOF) {
+			ff.logger.Debug("rpcdaemon: the subscription to dropped transactions channel was closed")
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		ff.OnDroppedTxs(event)
+	}
+	return nil
+}
+
 func (ff *Filters) subscribeToPendingBlocks(ctx context.Context, mining txpool.MiningClient) error {
 	subscription, err := mining.OnPendingBlock(ctx, &txpool.OnPendingBlockRequest{}, grpc.WaitForReady(true))
 	if err != nil {
@@ -367,6 +412,23 @@ func (ff *Filters) UnsubscribePendingTxs(id PendingTxsSubID) bool {
 	return true
 }
 
+func (ff *Filters) SubscribeDroppedTxs(size int) (<-chan []*DroppedTx, DroppedTxsSubID) {
+	id := DroppedTxsSubID(generateSubscriptionID())
+	sub := newChanSub[[]*DroppedTx](size)
+	ff.droppedTxsSubs.Put(id, sub)
+	return sub.ch, id
+}
+
+func (ff *Filters) UnsubscribeDroppedTxs(id DroppedTxsSubID) bool {
+	ch, ok := ff.droppedTxsSubs.Get(id)
+	if !ok {
+		return false
+	}
+	ch.Close()
+	_, ok = ff.droppedTxsSubs.Delete(id)
+	return ok
+}
+
 func (ff *Filters) SubscribeLogs(size int, crit filters.FilterCriteria) (<-chan *types.Log, LogsSubID) {
 	sub := newChanSub[*types.Log](size)
 	id, f := ff.logsSubs.insertLogsFilter(sub)
@@ -541,6 +603,31 @@ func (ff *Filters) OnNewTx(reply *txpool.OnAddReply) {
 	})
 }
 
+// DroppedTx is a transaction discarded from the txpool, together with the reason of discarding
+type DroppedTx struct {
+	Hash   libcommon.Hash    `json:"hash"`
+	From   libcommon.Address `json:"from"`
+	Nonce  hexutil.Uint64    `json:"nonce"`
+	Reason string            `json:"reason"`
+}
+
+// OnDroppedTxs is called when transactions are evicted or replaced in the txpool
+func (ff *Filters) OnDroppedTxs(reply *txpool.OnDiscardReply) {
+	txs := make([]*DroppedTx, len(reply.Txs))
+	for i, tx := range reply.Txs {
+		txs[i] = &DroppedTx{
+			Hash:   gointerfaces.ConvertH256ToHash(tx.Hash),
+			From:   gointerfaces.ConvertH160toAddress(tx.Sender),
+			Nonce:  hexutil.Uint64(tx.Nonce),
+			Reason: txpoolcfg.DiscardReason(tx.Reason).String(),
+		}
+	}
+	ff.droppedTxsSubs.Range(func(k DroppedTxsSubID, v Sub[[]*DroppedTx]) error {
+		v.Send(txs)
+		return nil
+	})
+}
+
 // OnNewLogs is called when there is a new log
 func (ff *Filters) OnNewLogs(reply *remote.SubscribeLogsReply) {
 	ff.logsSubs.distributeLog(reply)
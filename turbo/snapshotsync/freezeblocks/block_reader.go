@@ -30,6 +30,9 @@ import (
 
 var ErrSpanNotFound = errors.New("span not found")
 
+// ErrSnapshotNotDownloaded - block is older than snapshots of some type which node has, see snapcfg.DownloadPolicy
+var ErrSnapshotNotDownloaded = errors.New("snapshot not downloaded")
+
 type RemoteBlockReader struct {
 	client remote.ETHBACKENDClient
 }
@@ -469,7 +472,7 @@ func (r *BlockReader) BodyWithTransactions(ctx context.Context, tx kv.Getter, ha
 	var buf []byte
 	seg, ok := view.BodiesSegment(blockHeight)
 	if !ok {
-		return nil, nil
+		return nil, r.notDownloaded(view, snaptype.Bodies, blockHeight)
 	}
 	body, baseTxnID, txsAmount, buf, err = r.bodyFromSnapshot(blockHeight, seg, buf)
 	if err != nil {
@@ -480,7 +483,7 @@ func (r *BlockReader) BodyWithTransactions(ctx context.Context, tx kv.Getter, ha
 	}
 	txnSeg, ok := view.TxsSegment(blockHeight)
 	if !ok {
-		return nil, nil
+		return nil, r.notDownloaded(view, snaptype.Transactions, blockHeight)
 	}
 	txs, senders, err := r.txsFromSnapshot(baseTxnID, txsAmount, txnSeg, buf)
 	if err != nil {
@@ -517,7 +520,7 @@ func (r *BlockReader) Body(ctx context.Context, tx kv.Getter, hash common.Hash,
 
 	seg, ok := view.BodiesSegment(blockHeight)
 	if !ok {
-		return
+		return nil, 0, r.notDownloaded(view, snaptype.Bodies, blockHeight)
 	}
 	body, _, txAmount, _, err = r.bodyFromSnapshot(blockHeight, seg, nil)
 	if err != nil {
@@ -582,7 +585,7 @@ func (r *BlockReader) blockWithSenders(ctx context.Context, tx kv.Getter, hash c
 	var txsAmount uint32
 	bodySeg, ok := view.BodiesSegment(blockHeight)
 	if !ok {
-		return
+		return nil, nil, r.notDownloaded(view, snaptype.Bodies, blockHeight)
 	}
 	b, baseTxnId, txsAmount, buf, err = r.bodyFromSnapshot(blockHeight, bodySeg, buf)
 	if err != nil {
@@ -592,7 +595,7 @@ func (r *BlockReader) blockWithSenders(ctx context.Context, tx kv.Getter, hash c
 		return
 	}
 	if txsAmount == 0 {
-		block = types.NewBlockFromStorage(hash, h, nil, b.Uncles, b.Withdrawals)
+		block = types.NewBlockFromStorage(hash, h, nil, b.Uncles, b.Withdrawals, b.Requests)
 		if len(senders) != block.Transactions().Len() {
 			return block, senders, nil // no senders is fine - will recover them on the fly
 		}
@@ -602,7 +605,7 @@ func (r *BlockReader) blockWithSenders(ctx context.Context, tx kv.Getter, hash c
 
 	txnSeg, ok := view.TxsSegment(blockHeight)
 	if !ok {
-		return
+		return nil, nil, r.notDownloaded(view, snaptype.Transactions, blockHeight)
 	}
 	var txs []types.Transaction
 	txs, senders, err = r.txsFromSnapshot(baseTxnId, txsAmount, txnSeg, buf)
@@ -612,7 +615,7 @@ func (r *BlockReader) blockWithSenders(ctx context.Context, tx kv.Getter, hash c
 	if !ok {
 		return
 	}
-	block = types.NewBlockFromStorage(hash, h, txs, b.Uncles, b.Withdrawals)
+	block = types.NewBlockFromStorage(hash, h, txs, b.Uncles, b.Withdrawals, b.Requests)
 	if len(senders) != block.Transactions().Len() {
 		return block, senders, nil // no senders is fine - will recover them on the fly
 	}
@@ -620,6 +623,16 @@ func (r *BlockReader) blockWithSenders(ctx context.Context, tx kv.Getter, hash c
 	return block, senders, nil
 }
 
+// notDownloaded - error if block is in range which has no segments of given type, nil if block is just not found
+func (r *BlockReader) notDownloaded(view *View, t snaptype.Type, blockNum uint64) error {
+	for _, missing := range view.MissingRanges(t) {
+		if blockNum >= missing.from && blockNum < missing.to {
+			return fmt.Errorf("%w: %s of block %d, missing range %d-%d", ErrSnapshotNotDownloaded, t, blockNum, missing.from, missing.to)
+		}
+	}
+	return nil
+}
+
 func (r *BlockReader) headerFromSnapshot(blockHeight uint64, sn *Segment, buf []byte) (*types.Header, []byte, error) {
 	index := sn.Index()
 
@@ -698,6 +711,7 @@ func (r *BlockReader) bodyFromSnapshot(blockHeight uint64, sn *Segment, buf []by
 	body := new(types.Body)
 	body.Uncles = b.Uncles
 	body.Withdrawals = b.Withdrawals
+	body.Requests = b.Requests
 	var txsAmount uint32
 	if b.TxAmount >= 2 {
 		txsAmount = b.TxAmount - 2
@@ -867,7 +881,7 @@ func (r *BlockReader) TxnByIdxInBlock(ctx context.Context, tx kv.Getter, blockNu
 	defer view.Close()
 	seg, ok := view.BodiesSegment(blockNum)
 	if !ok {
-		return
+		return nil, r.notDownloaded(view, snaptype.Bodies, blockNum)
 	}
 
 	var b *types.BodyForStorage
@@ -886,7 +900,7 @@ func (r *BlockReader) TxnByIdxInBlock(ctx context.Context, tx kv.Getter, blockNu
 
 	txnSeg, ok := view.TxsSegment(blockNum)
 	if !ok {
-		return
+		return nil, r.notDownloaded(view, snaptype.Transactions, blockNum)
 	}
 	// +1 because block has system-txn in the beginning of block
 	return r.txnByID(b.BaseTxId+1+uint64(txIdxInBlock), txnSeg, nil)
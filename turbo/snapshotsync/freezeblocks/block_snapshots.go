@@ -312,10 +312,17 @@ func (s *RoSnapshots) BlocksAvailable() uint64 {
 func (s *RoSnapshots) LogStat(label string) {
 	var m runtime.MemStats
 	dbg.ReadMemStats(&m)
-	s.logger.Info(fmt.Sprintf("[snapshots:%s] Blocks Stat", label),
+	args := []interface{}{
 		"blocks", fmt.Sprintf("%dk", (s.BlocksAvailable()+1)/1000),
 		"indices", fmt.Sprintf("%dk", (s.IndicesMax()+1)/1000),
-		"alloc", common2.ByteCount(m.Alloc), "sys", common2.ByteCount(m.Sys))
+		"alloc", common2.ByteCount(m.Alloc), "sys", common2.ByteCount(m.Sys),
+	}
+	for _, t := range s.Types() {
+		if missing := s.MissingRanges(t); len(missing) > 0 {
+			args = append(args, "missing_"+t.String(), missing)
+		}
+	}
+	s.logger.Info(fmt.Sprintf("[snapshots:%s] Blocks Stat", label), args...)
 }
 
 func (s *RoSnapshots) EnsureExpectedBlocksAreAvailable(cfg *snapcfg.Cfg) error {
@@ -588,6 +595,12 @@ func (s *RoSnapshots) Ranges() []Range {
 	return view.Ranges()
 }
 
+func (s *RoSnapshots) MissingRanges(t snaptype.Type) Ranges {
+	view := s.View()
+	defer view.Close()
+	return view.MissingRanges(t)
+}
+
 func (s *RoSnapshots) OptimisticalyReopenFolder()           { _ = s.ReopenFolder() }
 func (s *RoSnapshots) OptimisticalyReopenWithDB(db kv.RoDB) { _ = s.ReopenWithDB(db) }
 func (s *RoSnapshots) ReopenFolder() error {
@@ -595,7 +608,7 @@ func (s *RoSnapshots) ReopenFolder() error {
 }
 
 func (s *RoSnapshots) ReopenSegments(types []snaptype.Type) error {
-	files, _, err := typedSegments(s.dir, s.segmentsMin.Load(), types)
+	files, _, err := typedSegments(s.dir, s.segmentsMin.Load(), types, s.cfg.DownloadPolicy)
 
 	if err != nil {
 		return err
@@ -765,27 +778,27 @@ func (s *RoSnapshots) AddSnapshotsToSilkworm(silkwormInstance *silkworm.Silkworm
 	return nil
 }
 
-func buildIdx(ctx context.Context, sn snaptype.FileInfo, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
+func buildIdx(ctx context.Context, sn snaptype.FileInfo, chainConfig *chain.Config, tmpDir string, idxCfg IndexCfg, p *background.Progress, lvl log.Lvl, logger log.Logger) error {
 	//log.Info("[snapshots] build idx", "file", sn.Name())
 	switch sn.Type.Enum() {
 	case snaptype.Enums.Headers:
-		if err := HeadersIdx(ctx, sn, tmpDir, p, lvl, logger); err != nil {
+		if err := HeadersIdx(ctx, sn, tmpDir, idxCfg, p, lvl, logger); err != nil {
 			return err
 		}
 	case snaptype.Enums.Bodies:
-		if err := BodiesIdx(ctx, sn, tmpDir, p, lvl, logger); err != nil {
+		if err := BodiesIdx(ctx, sn, tmpDir, idxCfg, p, lvl, logger); err != nil {
 			return err
 		}
 	case snaptype.Enums.Transactions:
-		if err := TransactionsIdx(ctx, chainConfig, sn, tmpDir, p, lvl, logger); err != nil {
+		if err := TransactionsIdx(ctx, chainConfig, sn, tmpDir, idxCfg, p, lvl, logger); err != nil {
 			return fmt.Errorf("TransactionsIdx: %s", err)
 		}
 	case snaptype.Enums.BorEvents:
-		if err := BorEventsIdx(ctx, sn, tmpDir, p, lvl, logger); err != nil {
+		if err := BorEventsIdx(ctx, sn, tmpDir, idxCfg, p, lvl, logger); err != nil {
 			return err
 		}
 	case snaptype.Enums.BorSpans:
-		if err := BorSpansIdx(ctx, sn, tmpDir, p, lvl, logger); err != nil {
+		if err := BorSpansIdx(ctx, sn, tmpDir, idxCfg, p, lvl, logger); err != nil {
 			return err
 		}
 	}
@@ -838,7 +851,7 @@ func buildMissedIndices(logPrefix string, ctx context.Context, dirs datadir.Dirs
 				ps.Add(p)
 				defer notifySegmentIndexingFinished(info.Name())
 				defer ps.Delete(p)
-				if err := buildIdx(gCtx, info, chainConfig, tmpDir, p, log.LvlInfo, logger); err != nil {
+				if err := buildIdx(gCtx, info, chainConfig, tmpDir, NewIndexCfg(snapshots.Cfg()), p, log.LvlInfo, logger); err != nil {
 					return fmt.Errorf("%s: %w", info.Name(), err)
 				}
 				return nil
@@ -902,19 +915,23 @@ func noGaps(in []snaptype.FileInfo, from uint64) (out []snaptype.FileInfo, missi
 	return out, missingSnapshots
 }
 
-func typeOfSegmentsMustExist(dir string, in []snaptype.FileInfo, types []snaptype.Type) (res []snaptype.FileInfo) {
+// typeOfSegmentsMustExist - all types of segments must exist for given range, except types skipped by download policy
+func typeOfSegmentsMustExist(dir string, in []snaptype.FileInfo, types []snaptype.Type, policy snapcfg.DownloadPolicy) (res []snaptype.FileInfo) {
 MainLoop:
 	for _, f := range in {
 		if f.From == f.To {
 			continue
 		}
 		for _, t := range types {
+			if f.To <= policy.From(t.Enum()) {
+				continue
+			}
 			p := filepath.Join(dir, snaptype.SegmentFileName(f.Version, f.From, f.To, t.Enum()))
 			if !dir2.FileExist(p) {
 				continue MainLoop
 			}
-			res = append(res, f)
 		}
+		res = append(res, f)
 	}
 	return res
 }
@@ -1020,12 +1037,30 @@ func SegmentsCaplin(dir string, minBlock uint64) (res []snaptype.FileInfo, missi
 }
 
 func Segments(dir string, minBlock uint64) (res []snaptype.FileInfo, missingSnapshots []Range, err error) {
-	return typedSegments(dir, minBlock, snaptype.BlockSnapshotTypes)
+	return typedSegments(dir, minBlock, snaptype.BlockSnapshotTypes, nil)
 }
 
-func typedSegments(dir string, minBlock uint64, types []snaptype.Type) (res []snaptype.FileInfo, missingSnapshots []Range, err error) {
+// policyStart - first block of segments of given type, if they start later than minBlock because of download policy:
+// it's start of the file which contains first block of the policy (files are not aligned with policy)
+func policyStart(in []snaptype.FileInfo, minBlock, policyFrom uint64) uint64 {
+	if policyFrom <= minBlock {
+		return minBlock
+	}
+	for _, f := range in {
+		if f.To <= policyFrom {
+			continue
+		}
+		if f.From <= policyFrom {
+			return cmp.Max(f.From, minBlock)
+		}
+		break
+	}
+	return policyFrom
+}
+
+func typedSegments(dir string, minBlock uint64, types []snaptype.Type, policy snapcfg.DownloadPolicy) (res []snaptype.FileInfo, missingSnapshots []Range, err error) {
 	segmentsTypeCheck := func(dir string, in []snaptype.FileInfo) (res []snaptype.FileInfo) {
-		return typeOfSegmentsMustExist(dir, in, types)
+		return typeOfSegmentsMustExist(dir, in, types, policy)
 	}
 
 	list, err := snaptype.Segments(dir)
@@ -1044,7 +1079,15 @@ func typedSegments(dir string, minBlock uint64, types []snaptype.Type) (res []sn
 				}
 				l = append(l, f)
 			}
-			l, m = noGaps(noOverlaps(segmentsTypeCheck(dir, l)), minBlock)
+			l = noOverlaps(segmentsTypeCheck(dir, l))
+			from := minBlock
+			if _, ok := policy[segType.Enum()]; ok {
+				// older files of this type are skipped by download policy, but if they exist - use them
+				if _, m = noGaps(l, minBlock); len(m) > 0 {
+					from = policyStart(l, minBlock, policy.From(segType.Enum()))
+				}
+			}
+			l, m = noGaps(l, from)
 			if len(m) > 0 {
 				lst := m[len(m)-1]
 				log.Debug("[snapshots] see gap", "type", segType, "from", lst.from)
@@ -1220,7 +1263,7 @@ func (br *BlockRetire) retireBlocks(ctx context.Context, minBlockNum uint64, max
 		}
 		logger.Log(lvl, "[snapshots] Retire Blocks", "range", fmt.Sprintf("%dk-%dk", blockFrom/1000, blockTo/1000))
 		// in future we will do it in background
-		if err := DumpBlocks(ctx, blockFrom, blockTo, br.chainConfig, tmpDir, snapshots.Dir(), db, workers, lvl, logger, blockReader); err != nil {
+		if err := DumpBlocks(ctx, blockFrom, blockTo, br.chainConfig, tmpDir, snapshots.Dir(), snapshots.Cfg().Codecs, NewIndexCfg(snapshots.Cfg()), db, workers, lvl, logger, blockReader); err != nil {
 			return ok, fmt.Errorf("DumpBlocks: %w", err)
 		}
 
@@ -1395,11 +1438,11 @@ func (br *BlockRetire) buildMissedIndicesIfNeed(ctx context.Context, logPrefix s
 	return nil
 }
 
-func DumpBlocks(ctx context.Context, blockFrom, blockTo uint64, chainConfig *chain.Config, tmpDir, snapDir string, chainDB kv.RoDB, workers int, lvl log.Lvl, logger log.Logger, blockReader services.FullBlockReader) error {
+func DumpBlocks(ctx context.Context, blockFrom, blockTo uint64, chainConfig *chain.Config, tmpDir, snapDir string, codecs ethconfig.SnapshotCodecs, idxCfg IndexCfg, chainDB kv.RoDB, workers int, lvl log.Lvl, logger log.Logger, blockReader services.FullBlockReader) error {
 
 	firstTxNum := blockReader.FirstTxnNumNotInSnapshots()
 	for i := blockFrom; i < blockTo; i = chooseSegmentEnd(i, blockTo, chainConfig) {
-		lastTxNum, err := dumpBlocksRange(ctx, i, chooseSegmentEnd(i, blockTo, chainConfig), tmpDir, snapDir, firstTxNum, chainDB, chainConfig, workers, lvl, logger)
+		lastTxNum, err := dumpBlocksRange(ctx, i, chooseSegmentEnd(i, blockTo, chainConfig), tmpDir, snapDir, codecs, idxCfg, firstTxNum, chainDB, chainConfig, workers, lvl, logger)
 		if err != nil {
 			return err
 		}
@@ -1408,22 +1451,22 @@ func DumpBlocks(ctx context.Context, blockFrom, blockTo uint64, chainConfig *cha
 	return nil
 }
 
-func dumpBlocksRange(ctx context.Context, blockFrom, blockTo uint64, tmpDir, snapDir string, firstTxNum uint64, chainDB kv.RoDB, chainConfig *chain.Config, workers int, lvl log.Lvl, logger log.Logger) (lastTxNum uint64, err error) {
+func dumpBlocksRange(ctx context.Context, blockFrom, blockTo uint64, tmpDir, snapDir string, codecs ethconfig.SnapshotCodecs, idxCfg IndexCfg, firstTxNum uint64, chainDB kv.RoDB, chainConfig *chain.Config, workers int, lvl log.Lvl, logger log.Logger) (lastTxNum uint64, err error) {
 	logEvery := time.NewTicker(20 * time.Second)
 	defer logEvery.Stop()
 
 	if _, err = dumpRange(ctx, snaptype.Headers.FileInfo(snapDir, blockFrom, blockTo),
-		DumpHeaders, nil, chainDB, chainConfig, tmpDir, workers, lvl, logger); err != nil {
+		DumpHeaders, nil, chainDB, chainConfig, tmpDir, codecs, idxCfg, workers, lvl, logger); err != nil {
 		return 0, err
 	}
 
 	if lastTxNum, err = dumpRange(ctx, snaptype.Bodies.FileInfo(snapDir, blockFrom, blockTo),
-		DumpBodies, func(context.Context) uint64 { return firstTxNum }, chainDB, chainConfig, tmpDir, workers, lvl, logger); err != nil {
+		DumpBodies, func(context.Context) uint64 { return firstTxNum }, chainDB, chainConfig, tmpDir, codecs, idxCfg, workers, lvl, logger); err != nil {
 		return lastTxNum, err
 	}
 
 	if _, err = dumpRange(ctx, snaptype.Transactions.FileInfo(snapDir, blockFrom, blockTo),
-		DumpTxs, func(context.Context) uint64 { return firstTxNum }, chainDB, chainConfig, tmpDir, workers, lvl, logger); err != nil {
+		DumpTxs, func(context.Context) uint64 { return firstTxNum }, chainDB, chainConfig, tmpDir, codecs, idxCfg, workers, lvl, logger); err != nil {
 		return lastTxNum, err
 	}
 
@@ -1433,7 +1476,7 @@ func dumpBlocksRange(ctx context.Context, blockFrom, blockTo uint64, tmpDir, sna
 type firstKeyGetter func(ctx context.Context) uint64
 type dumpFunc func(ctx context.Context, db kv.RoDB, chainConfig *chain.Config, blockFrom, blockTo uint64, firstKey firstKeyGetter, collecter func(v []byte) error, workers int, lvl log.Lvl, logger log.Logger) (uint64, error)
 
-func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstKey firstKeyGetter, chainDB kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
+func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstKey firstKeyGetter, chainDB kv.RoDB, chainConfig *chain.Config, tmpDir string, codecs ethconfig.SnapshotCodecs, idxCfg IndexCfg, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
 	var lastKeyValue uint64
 
 	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.String(), f.Path, tmpDir, seg.MinPatternScore, workers, log.LvlTrace, logger)
@@ -1442,6 +1485,7 @@ func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstK
 		return lastKeyValue, err
 	}
 	defer sn.Close()
+	sn.SetCodec(codecs.Codec(f.Type.Enum()))
 
 	lastKeyValue, err = dumper(ctx, chainDB, chainConfig, f.From, f.To, firstKey, func(v []byte) error {
 		return sn.AddWord(v)
@@ -1460,7 +1504,7 @@ func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstK
 
 	p := &background.Progress{}
 
-	if err := buildIdx(ctx, f, chainConfig, tmpDir, p, lvl, logger); err != nil {
+	if err := buildIdx(ctx, f, chainConfig, tmpDir, idxCfg, p, lvl, logger); err != nil {
 		return lastKeyValue, err
 	}
 
@@ -1874,7 +1918,7 @@ func txsAmountBasedOnBodiesSnapshots(bodiesSegment *seg.Decompressor, len uint64
 	return
 }
 
-func TransactionsIdx(ctx context.Context, chainConfig *chain.Config, sn snaptype.FileInfo, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+func TransactionsIdx(ctx context.Context, chainConfig *chain.Config, sn snaptype.FileInfo, tmpDir string, idxCfg IndexCfg, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("index panic: at=%s, %v, %s", sn.Name(), rec, dbg.Stack())
@@ -1908,7 +1952,7 @@ func TransactionsIdx(ctx context.Context, chainConfig *chain.Config, sn snaptype
 		p.Total.Store(uint64(d.Count() * 2))
 	}
 
-	txnHashIdx, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+	txnHashIdx, err := recsplit.NewRecSplit(idxCfg.apply(recsplit.RecSplitArgs{
 		KeyCount: d.Count(),
 
 		Enums:              true,
@@ -1919,12 +1963,12 @@ func TransactionsIdx(ctx context.Context, chainConfig *chain.Config, sn snaptype
 		TmpDir:     tmpDir,
 		IndexFile:  filepath.Join(sn.Dir(), snaptype.Transactions.IdxFileName(sn.Version, sn.From, sn.To)),
 		BaseDataID: firstTxID,
-	}, logger)
+	}), logger)
 	if err != nil {
 		return err
 	}
 
-	txnHash2BlockNumIdx, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+	txnHash2BlockNumIdx, err := recsplit.NewRecSplit(idxCfg.apply(recsplit.RecSplitArgs{
 		KeyCount:   d.Count(),
 		Enums:      false,
 		BucketSize: 2000,
@@ -1932,7 +1976,7 @@ func TransactionsIdx(ctx context.Context, chainConfig *chain.Config, sn snaptype
 		TmpDir:     tmpDir,
 		IndexFile:  filepath.Join(sn.Dir(), sn.Type.IdxFileName(sn.Version, sn.From, sn.To, snaptype.Indexes.TxnHash2BlockNum)),
 		BaseDataID: firstBlockNum,
-	}, logger)
+	}), logger)
 	if err != nil {
 		return err
 	}
@@ -2034,11 +2078,11 @@ RETRY:
 }
 
 // HeadersIdx - headerHash -> offset (analog of kv.HeaderNumber)
-func HeadersIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+func HeadersIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, idxCfg IndexCfg, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 	hasher := crypto.NewKeccakState()
 	defer cryptopool.ReturnToPoolKeccak256(hasher)
 	var h common2.Hash
-	if err := Idx(ctx, info, info.From, tmpDir, log.LvlDebug, p, func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error {
+	if err := Idx(ctx, info, info.From, tmpDir, idxCfg, log.LvlDebug, p, func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error {
 		if p != nil {
 			p.Processed.Add(1)
 		}
@@ -2057,10 +2101,10 @@ func HeadersIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, p *b
 	return nil
 }
 
-func BodiesIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+func BodiesIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, idxCfg IndexCfg, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
 	num := make([]byte, 8)
 
-	if err := Idx(ctx, info, info.From, tmpDir, log.LvlDebug, p, func(idx *recsplit.RecSplit, i, offset uint64, _ []byte) error {
+	if err := Idx(ctx, info, info.From, tmpDir, idxCfg, log.LvlDebug, p, func(idx *recsplit.RecSplit, i, offset uint64, _ []byte) error {
 		if p != nil {
 			p.Processed.Add(1)
 		}
@@ -2076,7 +2120,7 @@ func BodiesIdx(ctx context.Context, info snaptype.FileInfo, tmpDir string, p *ba
 }
 
 // Idx - iterate over segment and building .idx file
-func Idx(ctx context.Context, info snaptype.FileInfo, firstDataID uint64, tmpDir string, lvl log.Lvl, p *background.Progress, walker func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error, logger log.Logger) (err error) {
+func Idx(ctx context.Context, info snaptype.FileInfo, firstDataID uint64, tmpDir string, idxCfg IndexCfg, lvl log.Lvl, p *background.Progress, walker func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error, logger log.Logger) (err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("index panic: at=%s, %v, %s", info.Name(), rec, dbg.Stack())
@@ -2097,7 +2141,7 @@ func Idx(ctx context.Context, info snaptype.FileInfo, firstDataID uint64, tmpDir
 		p.Total.Store(uint64(d.Count()))
 	}
 
-	rs, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+	rs, err := recsplit.NewRecSplit(idxCfg.apply(recsplit.RecSplitArgs{
 		KeyCount:   d.Count(),
 		Enums:      true,
 		BucketSize: 2000,
@@ -2105,7 +2149,7 @@ func Idx(ctx context.Context, info snaptype.FileInfo, firstDataID uint64, tmpDir
 		TmpDir:     tmpDir,
 		IndexFile:  filepath.Join(info.Dir(), info.Type.IdxFileName(info.Version, info.From, info.To)),
 		BaseDataID: firstDataID,
-	}, logger)
+	}), logger)
 	if err != nil {
 		return err
 	}
@@ -2259,12 +2303,12 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, snapTypes []
 		for _, t := range snapTypes {
 			f := t.FileInfo(snapDir, r.from, r.to)
 
-			if err := m.merge(ctx, toMerge[t.Enum()], f.Path, logEvery); err != nil {
+			if err := m.merge(ctx, toMerge[t.Enum()], f.Path, snapshots.Cfg().Codecs.Codec(t.Enum()), logEvery); err != nil {
 				return fmt.Errorf("mergeByAppendSegments: %w", err)
 			}
 			if doIndex {
 				p := &background.Progress{}
-				if err := buildIdx(ctx, f, m.chainConfig, m.tmpDir, p, m.lvl, m.logger); err != nil {
+				if err := buildIdx(ctx, f, m.chainConfig, m.tmpDir, NewIndexCfg(snapshots.Cfg()), p, m.lvl, m.logger); err != nil {
 					return err
 				}
 			}
@@ -2297,7 +2341,7 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, snapTypes []
 	return nil
 }
 
-func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string, logEvery *time.Ticker) error {
+func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string, codec seg.Codec, logEvery *time.Ticker) error {
 	var word = make([]byte, 0, 4096)
 	var expectedTotal int
 	cList := make([]*seg.Decompressor, len(toMerge))
@@ -2316,6 +2360,7 @@ func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string,
 		return err
 	}
 	defer f.Close()
+	f.SetCodec(codec)
 	if m.noFsync {
 		f.DisableFsync()
 	}
@@ -2418,6 +2463,26 @@ func (v *View) Ranges() (ranges []Range) {
 	return ranges
 }
 
+// MissingRanges - block ranges up to BlocksAvailable, which have no segments of given type.
+// For example: older bodies and transactions skipped by snapcfg.DownloadPolicy
+func (v *View) MissingRanges(t snaptype.Type) (missing Ranges) {
+	available := v.s.BlocksAvailable()
+	if available == 0 {
+		return nil
+	}
+	prevTo := v.s.segmentsMin.Load()
+	for _, seg := range v.Segments(t) {
+		if seg.from > prevTo {
+			missing = append(missing, Range{prevTo, seg.from})
+		}
+		prevTo = cmp.Max(prevTo, seg.to)
+	}
+	if prevTo <= available {
+		missing = append(missing, Range{prevTo, available + 1})
+	}
+	return missing
+}
+
 func (v *View) HeadersSegment(blockNum uint64) (*Segment, bool) {
 	return v.Segment(snaptype.Headers, blockNum)
 }
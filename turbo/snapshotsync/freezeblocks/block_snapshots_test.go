@@ -11,6 +11,7 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/chain/networkname"
 	"github.com/ledgerwatch/erigon-lib/chain/snapcfg"
+	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
 	"github.com/ledgerwatch/erigon-lib/recsplit"
 	"github.com/ledgerwatch/erigon-lib/seg"
@@ -205,6 +206,41 @@ func TestMergeSnapshots(t *testing.T) {
 	require.Equal(10, a)
 }
 
+func TestMergeSnapshotsCodec(t *testing.T) {
+	logger := log.New()
+	dir, require := t.TempDir(), require.New(t)
+	_, err := ethconfig.ParseSnapshotCodecs("headers=lz4")
+	require.Error(err)
+	_, err = ethconfig.ParseSnapshotCodecs("receipts=zstd")
+	require.Error(err)
+	codecs, err := ethconfig.ParseSnapshotCodecs("headers=zstd, bodies=patterns")
+	require.NoError(err)
+	require.Equal("headers=zstd,bodies=patterns", codecs.String())
+
+	for i := uint64(0); i < 10; i++ {
+		for _, snT := range snaptype.BlockSnapshotTypes {
+			createTestSegmentFile(t, i*10_000, (i+1)*10_000, snT.Enum(), dir, 1, logger)
+		}
+	}
+	s := NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true, Codecs: codecs}, dir, 0, logger)
+	defer s.Close()
+	require.NoError(s.ReopenFolder())
+	merger := NewMerger(dir, 1, log.LvlInfo, nil, params.MainnetChainConfig, logger)
+	merger.DisableFsync()
+	ranges := merger.FindMergeRanges(s.Ranges(), s.SegmentsMax())
+	require.True(len(ranges) > 0)
+	require.NoError(merger.Merge(context.Background(), s, snaptype.BlockSnapshotTypes, ranges, s.Dir(), false, nil, nil))
+
+	for _, snT := range snaptype.BlockSnapshotTypes {
+		d, err := seg.NewDecompressor(filepath.Join(dir, snaptype.SegmentFileName(snT.Versions().Current, 0, 100_000, snT.Enum())))
+		require.NoError(err)
+		defer d.Close()
+		require.Equal(10, d.Count())
+		require.Equal(codecs.Codec(snT.Enum()), d.Codec())
+	}
+	require.Equal(seg.CodecZstd, codecs.Codec(snaptype.Enums.Headers))
+}
+
 func TestRemoveOverlaps(t *testing.T) {
 	logger := log.New()
 	dir, require := t.TempDir(), require.New(t)
@@ -360,6 +396,41 @@ func TestOpenAllSnapshot(t *testing.T) {
 	}
 }
 
+func TestOpenSnapshotsWithDownloadPolicy(t *testing.T) {
+	logger := log.New()
+	dir, require := t.TempDir(), require.New(t)
+	createFile := func(from, to uint64, name snaptype.Type) {
+		createTestSegmentFile(t, from, to, name.Enum(), dir, 1, logger)
+	}
+	createFile(0, 500_000, snaptype.Headers)
+	createFile(500_000, 1_000_000, snaptype.Headers)
+	createFile(500_000, 1_000_000, snaptype.Bodies)
+	createFile(500_000, 1_000_000, snaptype.Transactions)
+
+	// without policy older headers are useless
+	s := NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true}, dir, 0, logger)
+	defer s.Close()
+	require.NoError(s.ReopenFolder())
+	require.Equal(0, len(s.Ranges()))
+
+	policy, err := snapcfg.ParseDownloadPolicy("bodies=600000,transactions=600000")
+	require.NoError(err)
+	s = NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true, DownloadPolicy: policy}, dir, 0, logger)
+	defer s.Close()
+	require.NoError(s.ReopenFolder())
+	require.Equal([]Range{{0, 500_000}, {500_000, 1_000_000}}, s.Ranges())
+	require.Equal(uint64(1_000_000-1), s.BlocksAvailable())
+	require.Equal(Ranges{{0, 500_000}}, s.MissingRanges(snaptype.Bodies))
+	require.Equal(Ranges{{0, 500_000}}, s.MissingRanges(snaptype.Transactions))
+	require.Nil(s.MissingRanges(snaptype.Headers))
+
+	r := NewBlockReader(s, nil)
+	_, _, err = r.Body(context.Background(), nil, common.Hash{}, 10)
+	require.ErrorIs(err, ErrSnapshotNotDownloaded)
+	_, err = r.TxnByIdxInBlock(context.Background(), nil, 499_999, 0)
+	require.ErrorIs(err, ErrSnapshotNotDownloaded)
+}
+
 func TestParseCompressedFileName(t *testing.T) {
 	require := require.New(t)
 	fs := fstest.MapFS{
@@ -0,0 +1,25 @@
+package freezeblocks
+
+import (
+	"github.com/ledgerwatch/erigon-lib/recsplit"
+
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+)
+
+// IndexCfg - how .idx files of block snapshots are built, see recsplit.RecSplitArgs
+type IndexCfg struct {
+	Workers    int  // goroutines splitting buckets of one index, 0 or 1 - single-threaded
+	Checkpoint bool // save progress of index building, and continue from it after restart
+}
+
+// NewIndexCfg - IndexCfg of snapshots config, see RoSnapshots.Cfg
+func NewIndexCfg(cfg ethconfig.BlocksFreezing) IndexCfg {
+	return IndexCfg{Workers: cfg.IndexWorkers, Checkpoint: cfg.IndexCheckpoint}
+}
+
+// apply - applies IndexCfg to arguments of index builders
+func (c IndexCfg) apply(args recsplit.RecSplitArgs) recsplit.RecSplitArgs {
+	args.Workers = c.Workers
+	args.Checkpoint = c.Checkpoint
+	return args
+}
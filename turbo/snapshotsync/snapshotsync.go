@@ -3,6 +3,7 @@ package snapshotsync
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
@@ -94,7 +95,14 @@ func WaitForDownloader(ctx context.Context, logPrefix string, histV3, blobs bool
 
 	// send all hashes to the Downloader service
 	snapCfg := snapcfg.KnownCfg(cc.ChainName)
-	preverifiedBlockSnapshots := snapCfg.Preverified
+	downloadPolicy := blockReader.FreezingCfg().DownloadPolicy
+	if !histV3 && len(downloadPolicy) > 0 {
+		return fmt.Errorf("[%s] snapshots download policy %q requires history v3: without it all blocks are re-executed from genesis", logPrefix, downloadPolicy)
+	}
+	preverifiedBlockSnapshots := downloadPolicy.Filter(snapCfg.Preverified)
+	if len(downloadPolicy) > 0 {
+		log.Info(fmt.Sprintf("[%s] Download policy", logPrefix), "policy", downloadPolicy.String(), "files", len(preverifiedBlockSnapshots), "skipped", len(snapCfg.Preverified)-len(preverifiedBlockSnapshots))
+	}
 	downloadRequest := make([]services.DownloadRequest, 0, len(preverifiedBlockSnapshots))
 
 	// build all download requests
@@ -195,6 +203,9 @@ func WaitForDownloader(ctx context.Context, logPrefix string, histV3, blobs bool
 	if err := agg.OpenFolder(); err != nil {
 		return err
 	}
+	if err := checkDownloadPolicy(logPrefix, downloadPolicy, agg.EndTxNumMinimax(), blockReader); err != nil {
+		return err
+	}
 
 	// ProhibitNewDownloads implies - so only make the download request once,
 	//
@@ -226,6 +237,40 @@ func WaitForDownloader(ctx context.Context, logPrefix string, histV3, blobs bool
 	return nil
 }
 
+// checkDownloadPolicy - blocks above the downloaded state are executed, so the download policy must not skip them:
+// its first block must be at or below the state tip
+func checkDownloadPolicy(logPrefix string, policy snapcfg.DownloadPolicy, stateTxNum uint64, blockReader services.FullBlockReader) error {
+	first := policy.FirstFullBlock()
+	if first == 0 {
+		return nil
+	}
+
+	errFound := errors.New("found")
+	var stateBlock uint64
+	var inState bool
+	err := blockReader.IterateFrozenBodies(func(blockNum, baseTxNum, txAmount uint64) error {
+		if baseTxNum+txAmount <= stateTxNum {
+			return nil
+		}
+		// first block which is not fully in the state - execution starts from it
+		stateBlock, inState = blockNum, baseTxNum <= stateTxNum
+		return errFound
+	})
+	if err == nil {
+		return nil // state has all downloaded blocks
+	}
+	if !errors.Is(err, errFound) {
+		return err
+	}
+	if !inState {
+		return fmt.Errorf("[%s] snapshots download policy %q: downloaded state ends below block %d, the first downloaded body", logPrefix, policy, stateBlock)
+	}
+	if first > stateBlock {
+		return fmt.Errorf("[%s] snapshots download policy %q: first block %d is above the downloaded state, blocks are executed from %d", logPrefix, policy, first, stateBlock)
+	}
+	return nil
+}
+
 func logStats(ctx context.Context, stats *proto_downloader.StatsReply, startTime time.Time, stagesIdsList []string, logPrefix string, logReason string) {
 	var m runtime.MemStats
 
@@ -0,0 +1,40 @@
+package snapshotsync
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/chain/snapcfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+// frozenBodies - blocks from `from`, each of them has 2 system txs and 1 transaction
+type frozenBodies struct {
+	services.FullBlockReader
+	from, to uint64
+}
+
+func (b frozenBodies) IterateFrozenBodies(f func(blockNum, baseTxNum, txAmount uint64) error) error {
+	for blockNum := b.from; blockNum < b.to; blockNum++ {
+		if err := f(blockNum, blockNum*3, 3); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCheckDownloadPolicy(t *testing.T) {
+	policy, err := snapcfg.ParseDownloadPolicy("bodies=500,transactions=600")
+	require.NoError(t, err)
+
+	require.NoError(t, checkDownloadPolicy("", nil, 0, frozenBodies{from: 0, to: 1000}))
+	// state ends in the middle of block 700, it's executed from there
+	require.NoError(t, checkDownloadPolicy("", policy, 700*3+1, frozenBodies{from: 500, to: 1000}))
+	require.NoError(t, checkDownloadPolicy("", policy, 600*3, frozenBodies{from: 500, to: 1000}))
+	// state has all downloaded blocks
+	require.NoError(t, checkDownloadPolicy("", policy, 1000*3, frozenBodies{from: 500, to: 1000}))
+
+	require.ErrorContains(t, checkDownloadPolicy("", policy, 599*3, frozenBodies{from: 500, to: 1000}), "first block 600 is above the downloaded state")
+	require.ErrorContains(t, checkDownloadPolicy("", policy, 400*3, frozenBodies{from: 500, to: 1000}), "downloaded state ends below block 500")
+}
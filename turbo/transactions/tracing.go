@@ -71,6 +71,8 @@ func ComputeTxEnv(ctx context.Context, engine consensus.EngineReader, block *typ
 		}
 
 		TxContext := core.NewEVMTxContext(msg)
+		TxContext.TxHash, TxContext.Txn = txn.Hash(), txn
+		TxContext.BlockHash, TxContext.TxIndex = block.Hash(), txIndex
 		return msg, blockContext, TxContext, statedb, reader, nil
 	}
 	vmenv := vm.NewEVM(blockContext, evmtypes.TxContext{}, statedb, cfg, vm.Config{})
@@ -103,6 +105,8 @@ func ComputeTxEnv(ctx context.Context, engine consensus.EngineReader, block *typ
 
 		TxContext := core.NewEVMTxContext(msg)
 		if idx == txIndex {
+			TxContext.TxHash, TxContext.Txn = txn.Hash(), txn
+			TxContext.BlockHash, TxContext.TxIndex = block.Hash(), txIndex
 			return msg, blockContext, TxContext, statedb, reader, nil
 		}
 		vmenv.Reset(TxContext, statedb)
@@ -179,6 +183,8 @@ func AssembleTracer(
 			cfg = *config.TracerConfig
 		}
 		tracer, err := tracers.New(*config.Tracer, &tracers.Context{
+			BlockHash:         txCtx.BlockHash,
+			TxIndex:           txCtx.TxIndex,
 			TxHash:            txCtx.TxHash,
 			Txn:               txCtx.Txn,
 			CumulativeGasUsed: txCtx.CumulativeGasUsed,
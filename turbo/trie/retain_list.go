@@ -239,6 +239,92 @@ func (pr *DefaultProofRetainer) ProofResult() (*accounts.AccProofResult, error)
 	return result, nil
 }
 
+// RangeProofRetainer retains all trie nodes on the paths to the keys added to it. Unlike
+// DefaultProofRetainer it works with hashed keys, and is used to prove ranges of keys (snap
+// protocol): nodes on the paths to the first and the last key of a range prove that the trie
+// has no other keys between them. It also remembers the storage roots of accounts on these
+// paths, because storage roots are not stored with the accounts in the db.
+type RangeProofRetainer struct {
+	rl     *RetainList
+	proofs map[string]*proofElement
+}
+
+func NewRangeProofRetainer(rl *RetainList) *RangeProofRetainer {
+	return &RangeProofRetainer{
+		rl:     rl,
+		proofs: map[string]*proofElement{},
+	}
+}
+
+// AddKey adds a key in KEY encoding to the RetainList: an account key (32 bytes) or a storage
+// key (account key, incarnation and storage key - 72 bytes), and returns the nibble encoded key.
+// Keys must be added before Load of the FlatDBTrieLoader.
+func (pr *RangeProofRetainer) AddKey(key []byte) []byte {
+	return pr.rl.AddKey(key)
+}
+
+// AddHex adds a key or the path of a trie node in HEX encoding (without terminator)
+func (pr *RangeProofRetainer) AddHex(hex []byte) {
+	pr.rl.AddHex(hex)
+	pr.rl.AddMarker(false)
+}
+
+// ProofElement retains the node at any prefix of the added keys.
+func (pr *RangeProofRetainer) ProofElement(prefix []byte) *proofElement {
+	if !pr.rl.Retain(prefix) {
+		return nil
+	}
+	pe := &proofElement{
+		hexKey: libcommon.CopyBytes(prefix),
+	}
+	// Nodes are produced bottom-up, so the parent replaces a node with the same prefix
+	pr.proofs[string(prefix)] = pe
+	return pe
+}
+
+// Proof returns the nodes on the paths to the given keys, root first and each node once.
+// All keys must belong to one trie (accounts or storage of one account): the root of
+// the trie is 64 nibbles above the key.
+func (pr *RangeProofRetainer) Proof(hexKeys ...[]byte) [][]byte {
+	var proof [][]byte
+	seen := map[string]struct{}{}
+	for _, hexKey := range hexKeys {
+		for i := len(hexKey) - 2*length.Hash; i <= len(hexKey); i++ {
+			pe, ok := pr.proofs[string(hexKey[:i])]
+			if !ok || pe.proof.Len() == 0 {
+				continue
+			}
+			if _, ok := seen[string(hexKey[:i])]; ok {
+				continue
+			}
+			seen[string(hexKey[:i])] = struct{}{}
+			proof = append(proof, libcommon.CopyBytes(pe.proof.Bytes()))
+		}
+	}
+	return proof
+}
+
+// Node returns RLP of the node with given path, nil if there is no such node or its path
+// was not added.
+func (pr *RangeProofRetainer) Node(hexPath []byte) []byte {
+	pe, ok := pr.proofs[string(hexPath)]
+	if !ok || pe.proof.Len() == 0 {
+		return nil
+	}
+	return libcommon.CopyBytes(pe.proof.Bytes())
+}
+
+// StorageRoot returns the storage root of the account with given key (64 nibbles), if
+// the key was added.
+func (pr *RangeProofRetainer) StorageRoot(accHexKey []byte) (libcommon.Hash, bool) {
+	for i := 0; i <= len(accHexKey); i++ {
+		if pe, ok := pr.proofs[string(accHexKey[:i])]; ok && bytes.Equal(pe.storageRootKey, accHexKey) {
+			return pe.storageRoot, true
+		}
+	}
+	return libcommon.Hash{}, false
+}
+
 // proofElement represent a node or leaf in the trie and its
 // corresponding RLP encoding.  We store the elements individually when
 // aggregating as multiple keys (in particular storage keys) may need to
@@ -127,7 +127,7 @@ type RootHashAggregator struct {
 	accData        GenStructStepAccountData
 
 	// Used to construct an Account proof while calculating the tree root.
-	proofRetainer *DefaultProofRetainer
+	proofRetainer ProofRetainer
 	cutoff        bool
 }
 
@@ -342,7 +342,7 @@ func (l *FlatDBTrieLoader[T]) logProgress(accountKey, ihK []byte) {
 	log.Info(fmt.Sprintf("[%s] Calculating Merkle root", l.logPrefix), "current key", k)
 }
 
-func (r *RootHashAggregator) SetProofRetainer(pr *DefaultProofRetainer) {
+func (r *RootHashAggregator) SetProofRetainer(pr ProofRetainer) {
 	r.proofRetainer = pr
 }
 